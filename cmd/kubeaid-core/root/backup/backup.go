@@ -11,9 +11,13 @@ import (
 // PersistentPreRun, so subcommands run without any parsed cluster config.
 var BackupCmd = &cobra.Command{
 	Use:   "backup",
-	Short: "Inspect backups of a KubeAid managed K8s cluster",
+	Short: "Inspect, create and restore backups of a KubeAid managed K8s cluster",
 }
 
 func init() {
+	BackupCmd.AddCommand(CreateCmd)
+	BackupCmd.AddCommand(ListCmd)
+	BackupCmd.AddCommand(RestoreCmd)
 	BackupCmd.AddCommand(StatusCmd)
+	BackupCmd.AddCommand(VerifyCmd)
 }
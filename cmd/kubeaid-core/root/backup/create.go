@@ -0,0 +1,80 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+)
+
+var CreateCmd = &cobra.Command{
+	Use: "create [NAME]",
+
+	Short: "Create a Velero backup of the cluster, and wait for it to complete",
+
+	Long: `Create a Velero backup of the cluster pointed to by your current kubeconfig, and follow it
+until Velero is done with it. The name defaults to kubeaid-<timestamp>.`,
+
+	Args: cobra.MaximumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		assert.Assert(ctx, backupTTL >= 0,
+			fmt.Sprintf("invalid --%s value %s: can't be negative", constants.FlagNameTTL, backupTTL),
+		)
+
+		createBackupArgs := core.CreateBackupArgs{
+			Options: kubernetes.VeleroBackupOptions{
+				IncludedNamespaces: includeNamespaces,
+				ExcludedNamespaces: excludeNamespaces,
+				LabelSelector:      selector,
+				TTL:                backupTTL,
+			},
+		}
+		if len(args) > 0 {
+			createBackupArgs.Name = args[0]
+		}
+
+		core.CreateBackup(ctx, createBackupArgs)
+	},
+}
+
+var (
+	includeNamespaces,
+	excludeNamespaces []string
+
+	selector string
+
+	backupTTL time.Duration
+)
+
+func init() {
+	CreateCmd.Flags().
+		StringSliceVar(&includeNamespaces, constants.FlagNameIncludeNamespaces, nil,
+			"Namespaces to back up. Omit to back up every namespace",
+		)
+
+	CreateCmd.Flags().
+		StringSliceVar(&excludeNamespaces, constants.FlagNameExcludeNamespaces, nil,
+			"Namespaces not to back up",
+		)
+
+	CreateCmd.Flags().
+		StringVarP(&selector, constants.FlagNameSelector, "l", "",
+			"Only back up resources matching this label selector, e.g. app=postgres,tier!=cache",
+		)
+
+	CreateCmd.Flags().
+		DurationVar(&backupTTL, constants.FlagNameTTL, 0,
+			"How long Velero keeps the backup, e.g. 720h. Omit for Velero's default (30 days)",
+		)
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+)
+
+var ListCmd = &cobra.Command{
+	Use: "list",
+
+	Short: "List the Velero backups of the cluster",
+
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		assert.Assert(ctx, slices.Contains(core.BackupListSortKeys, sortBy),
+			fmt.Sprintf("invalid --%s value %q: must be one of %s",
+				constants.FlagNameSortBy, sortBy, strings.Join(core.BackupListSortKeys, ", ")),
+		)
+
+		assert.Assert(ctx, limit >= 0,
+			fmt.Sprintf("invalid --%s value %d: can't be negative", constants.FlagNameLimit, limit),
+		)
+
+		assert.Assert(ctx, page >= 1,
+			fmt.Sprintf("invalid --%s value %d: must be at least 1", constants.FlagNamePage, page),
+		)
+
+		core.ListBackups(ctx, core.ListBackupsArgs{
+			SortBy: sortBy,
+			Limit:  limit,
+			Page:   page,
+		})
+	},
+}
+
+var (
+	sortBy string
+
+	limit,
+	page int
+)
+
+func init() {
+	ListCmd.Flags().
+		StringVar(&sortBy, constants.FlagNameSortBy, core.BackupListSortByStarted,
+			`Sort by "started" (newest first), "expires" (soonest first), "name" or "status"`,
+		)
+
+	ListCmd.Flags().
+		IntVar(&limit, constants.FlagNameLimit, 20,
+			"How many backups to show per page. 0 shows them all",
+		)
+
+	ListCmd.Flags().
+		IntVar(&page, constants.FlagNamePage, 1,
+			"Which page of backups to show",
+		)
+}
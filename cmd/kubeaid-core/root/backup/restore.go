@@ -0,0 +1,58 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+)
+
+var RestoreCmd = &cobra.Command{
+	Use: "restore BACKUP",
+
+	Short: "Restore a Velero backup into the cluster, and follow it until done",
+
+	Long: `Restore the given Velero backup (see 'backup list') into the cluster pointed to by your
+current kubeconfig. Follows the restore's phase and warnings until Velero is done with it, then
+prints its warnings and errors in full.`,
+
+	Args: cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		options := kubernetes.VeleroRestoreOptions{
+			NamespaceMapping: namespaceMapping,
+		}
+
+		// Left to Velero's default, unless explicitly toggled.
+		if cmd.Flags().Changed(constants.FlagNameRestorePVs) {
+			options.RestorePVs = &restorePVs
+		}
+
+		core.RestoreBackup(cmd.Context(), core.RestoreBackupArgs{
+			BackupName: args[0],
+			Options:    options,
+		})
+	},
+}
+
+var (
+	namespaceMapping map[string]string
+
+	restorePVs bool
+)
+
+func init() {
+	RestoreCmd.Flags().
+		StringToStringVar(&namespaceMapping, constants.FlagNameNamespaceMapping, nil,
+			"Restore namespaces under other names, e.g. prod=prod-restored,db=db-restored",
+		)
+
+	RestoreCmd.Flags().
+		BoolVar(&restorePVs, constants.FlagNameRestorePVs, true,
+			"Whether to restore PersistentVolumes from their snapshots. When not set, Velero's default applies, which is restoring them",
+		)
+}
@@ -5,6 +5,11 @@ package backup
 
 import (
 	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -24,20 +29,64 @@ var StatusCmd = &cobra.Command{
 		ctx := cmd.Context()
 
 		assert.Assert(ctx,
-			outputFormat == "" || outputFormat == "json",
-			fmt.Sprintf("invalid --%s value %q: only \"json\" is supported",
-				constants.FlagNameOutput, outputFormat),
+			(outputFormat == "") || slices.Contains(core.BackupStatusOutputFormats, outputFormat),
+			fmt.Sprintf("invalid --%s value %q: must be one of %s",
+				constants.FlagNameOutput, outputFormat, strings.Join(core.BackupStatusOutputFormats, ", ")),
 		)
 
-		core.BackupStatus(ctx, outputFormat)
+		assert.Assert(ctx,
+			(failOn == "") || (failOn == core.BackupStatusFailOnWarning) || (failOn == core.BackupStatusFailOnError),
+			fmt.Sprintf("invalid --%s value %q: must be %q or %q",
+				constants.FlagNameFailOn, failOn, core.BackupStatusFailOnWarning, core.BackupStatusFailOnError),
+		)
+
+		parsedMaxAge := make(map[string]time.Duration, len(maxAge))
+		for operator, value := range maxAge {
+			duration, err := time.ParseDuration(value)
+			assert.AssertErrNil(ctx, err, fmt.Sprintf("Invalid --%s duration", constants.FlagNameMaxAge),
+				slog.String("operator", operator),
+			)
+			assert.Assert(ctx, duration > 0,
+				fmt.Sprintf("--%s duration of operator %q must be positive", constants.FlagNameMaxAge, operator),
+			)
+
+			parsedMaxAge[operator] = duration
+		}
+
+		exitCode := core.BackupStatus(ctx, core.BackupStatusArgs{
+			OutputFormat: outputFormat,
+			FailOn:       failOn,
+			MaxAge:       parsedMaxAge,
+		})
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
 	},
 }
 
-var outputFormat string
+var (
+	outputFormat,
+	failOn string
+
+	maxAge map[string]string
+)
 
 func init() {
 	StatusCmd.Flags().
 		StringVarP(&outputFormat, constants.FlagNameOutput, "o", "",
-			`Output format. Only "json" is supported; omit for human-readable output`,
+			`Output format. One of "json", "yaml", "prometheus" (textfile collector format) or "junit";`+
+				` omit for human-readable output`,
+		)
+
+	StatusCmd.Flags().
+		StringVar(&failOn, constants.FlagNameFailOn, "",
+			`Exit non-zero when a backup status is at least this severe : "error" (exceeds_rpo or`+
+				` no_backup) or "warning" (anything not healthy). Omit to always exit 0 once reported`,
+		)
+
+	StatusCmd.Flags().
+		StringToStringVar(&maxAge, constants.FlagNameMaxAge, nil,
+			"Stricter RPO per operator than backup-exporter's, e.g. velero=24h,cnpg=6h : a healthy"+
+				" backup whose latest backup is older gets reported as exceeds_rpo",
 		)
 }
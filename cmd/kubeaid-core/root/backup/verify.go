@@ -0,0 +1,97 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package backup
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+)
+
+var VerifyCmd = &cobra.Command{
+	Use: "verify [BACKUP]",
+
+	Short: "Test-restore a namespace from a Velero backup into a scratch namespace, and check the outcome",
+
+	Long: `Restore drill : restores the given namespace, from the given Velero backup (the latest
+Completed or PartiallyFailed one, when omitted), into a scratch namespace of the cluster pointed to
+by your current kubeconfig. Then checks that every backed up ConfigMap, Secret, Service,
+ServiceAccount, PVC, Deployment, StatefulSet, DaemonSet, CronJob and Ingress came back, and that
+the restored PVCs bind like their sources. The scratch namespace gets deleted afterwards.
+
+Exits non-zero when a check failed.`,
+
+	Args: cobra.MaximumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		assert.Assert(ctx,
+			(outputFormat == "") || slices.Contains(core.BackupStatusOutputFormats, outputFormat),
+			fmt.Sprintf("invalid --%s value %q: must be one of %s",
+				constants.FlagNameOutput, outputFormat, strings.Join(core.BackupStatusOutputFormats, ", ")),
+		)
+
+		assert.Assert(ctx, verifyNamespace != "", fmt.Sprintf("--%s is required", constants.FlagNameNamespace))
+
+		assert.Assert(ctx, verifyTimeout > 0,
+			fmt.Sprintf("invalid --%s value %s: must be positive", constants.FlagNameTimeout, verifyTimeout),
+		)
+
+		var backupName string
+		if len(args) > 0 {
+			backupName = args[0]
+		}
+
+		exitCode := core.BackupVerify(ctx, core.BackupVerifyArgs{
+			BackupName:   backupName,
+			Namespace:    verifyNamespace,
+			OutputFormat: outputFormat,
+			Timeout:      verifyTimeout,
+			Keep:         keepScratchNamespace,
+		})
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	},
+}
+
+var (
+	verifyNamespace string
+
+	verifyTimeout time.Duration
+
+	keepScratchNamespace bool
+)
+
+func init() {
+	VerifyCmd.Flags().
+		StringVar(&verifyNamespace, constants.FlagNameNamespace, "",
+			"Backed up namespace to test-restore",
+		)
+
+	VerifyCmd.Flags().
+		StringVarP(&outputFormat, constants.FlagNameOutput, "o", "",
+			`Output format. One of "json", "yaml", "prometheus" (textfile collector format) or "junit";`+
+				` omit for human-readable output`,
+		)
+
+	VerifyCmd.Flags().
+		DurationVar(&verifyTimeout, constants.FlagNameTimeout, 30*time.Minute,
+			"How long the restore, and the restored PVCs binding, may take",
+		)
+
+	VerifyCmd.Flags().
+		BoolVar(&keepScratchNamespace, constants.FlagNameKeep, false,
+			"Keep the scratch namespace and the Velero Restore around afterwards, for inspection",
+		)
+}
@@ -73,6 +73,7 @@ var BootstrapCmd = &cobra.Command{
 				IsPartOfDisasterRecovery: false,
 			},
 			SkipClusterctlMove: skipClusterctlMove,
+			Resume:             resumeBootstrap,
 		})
 
 		// Last, not at fetch time: bootstrap runs for many minutes and
@@ -88,7 +89,8 @@ var BootstrapCmd = &cobra.Command{
 var obmondoPaths *obmondo.WrittenPaths
 
 var skipMonitoringSetup,
-	skipClusterctlMove bool
+	skipClusterctlMove,
+	resumeBootstrap bool
 
 var bootstrapToken,
 	obmondoCertname,
@@ -167,7 +169,7 @@ func fetchObmondoConfig(ctx context.Context, cmd *cobra.Command) *obmondo.Writte
 		assert.AssertErrNil(ctx, err, "Failed resolving where to write the cluster configuration")
 	}
 
-	written, err := obmondo.Write(globals.ConfigsDirectory, config)
+	written, err := obmondo.Write(ctx, globals.ConfigsDirectory, config)
 	assert.AssertErrNil(ctx, err, "Failed writing the cluster configuration")
 
 	obmondo.LogPaths(ctx, written)
@@ -190,6 +192,12 @@ func init() {
 			"Skip executing the 'clusterctl move' command",
 		)
 
+	BootstrapCmd.PersistentFlags().
+		BoolVar(
+			&resumeBootstrap, constants.FlagNameResume, false,
+			"Continue from the last completed phase, recorded in the cluster's bootstrap checkpoint",
+		)
+
 	// Defaulted from the environment so the token can be supplied without
 	// landing in argv, which is world-readable via ps on a shared machine.
 	BootstrapCmd.PersistentFlags().
@@ -5,6 +5,8 @@ package cluster
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 
@@ -13,9 +15,14 @@ import (
 	"github.com/Obmondo/kubeaid-cli/cmd/kubeaid-core/root/cluster/delete"
 	clusterSync "github.com/Obmondo/kubeaid-cli/cmd/kubeaid-core/root/cluster/sync"
 	"github.com/Obmondo/kubeaid-cli/cmd/kubeaid-core/root/cluster/upgrade"
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
 	configSetup "github.com/Obmondo/kubeaid-cli/pkg/config/setup"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/globals"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
 )
 
 var ClusterCmd = &cobra.Command{
@@ -23,13 +30,55 @@ var ClusterCmd = &cobra.Command{
 	Short: "Manage the lifecycle of a KubeAid managed K8s cluster",
 
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		assert.Assert(ctx,
+			(outputFormat == "") || (outputFormat == outputFormatEvents) ||
+				(((cmd == PlanCmd) || (cmd == StatusCmd)) && (outputFormat == outputFormatJSON)),
+			fmt.Sprintf("invalid --%s value %q", constants.FlagNameOutput, outputFormat),
+		)
+
+		// Before anything else, so the event stream covers the whole run.
+		setupEventStream(ctx)
+
 		if preparedByCommand(cmd) {
 			return
 		}
-		prepareClusterCommand(cmd.Context())
+		prepareClusterCommand(ctx)
 	},
 }
 
+// Accepted --output values.
+const (
+	outputFormatEvents = "events"
+	outputFormatJSON   = "json"
+)
+
+// setupEventStream adds the JSON event stream's sinks, requested via --output=events and / or
+// --events-file.
+func setupEventStream(ctx context.Context) {
+	events.SetClusterName(globals.ClusterName)
+
+	if outputFormat == outputFormatEvents {
+		stdout := os.Stdout
+
+		// The event stream owns stdout, so consumers can parse it line by line : everything else
+		// meant for the terminal (error logs, live tables, prompts) moves to stderr.
+		os.Stdout = os.Stderr //nolint:reassign
+		logger.CreateLogger(globals.IsDebugModeEnabled, []io.Writer{globals.LogFile, os.Stderr})
+
+		events.AddSink(stdout)
+	}
+
+	if eventsFilePath != "" {
+		eventsFile, err := os.OpenFile(eventsFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		assert.AssertErrNil(ctx, err, "Failed opening events file", slog.String("path", eventsFilePath))
+
+		events.AddSink(eventsFile)
+		cobra.OnFinalize(func() { _ = eventsFile.Close() })
+	}
+}
+
 // preparedByCommand reports whether cmd, or an ancestor of it, prepares itself
 // and so must not be prepared here first.
 //
@@ -65,6 +114,8 @@ func prepareClusterCommand(ctx context.Context) {
 	}
 	cobra.OnFinalize(cleanup)
 
+	events.SetClusterName(config.ParsedGeneralConfig.Cluster.Name)
+
 	// Initialize temp directory.
 	if err := utils.InitTempDir(ctx); err != nil {
 		slog.ErrorContext(ctx, "Failed initializing temp dir", slog.String("error", err.Error()))
@@ -72,14 +123,22 @@ func prepareClusterCommand(ctx context.Context) {
 	}
 }
 
-var managementClusterName string
+var (
+	managementClusterName string
+
+	outputFormat,
+	eventsFilePath string
+)
 
 func init() {
 	// Subcommands.
 	ClusterCmd.AddCommand(BootstrapCmd)
 	ClusterCmd.AddCommand(TestCmd)
+	ClusterCmd.AddCommand(PlanCmd)
+	ClusterCmd.AddCommand(StatusCmd)
 	ClusterCmd.AddCommand(upgrade.UpgradeCmd)
 	ClusterCmd.AddCommand(clusterSync.SyncCmd)
+	ClusterCmd.AddCommand(SyncKeycloakCmd)
 	ClusterCmd.AddCommand(delete.DeleteCmd)
 	ClusterCmd.AddCommand(RecoverCmd)
 
@@ -97,4 +156,15 @@ func init() {
 			"Name of the local K3D management cluster. When omitted, defaults to "+
 				constants.ManagementClusterNamePrefix+"<cluster-name> (from general.yaml)",
 		)
+
+	ClusterCmd.PersistentFlags().
+		StringVarP(&outputFormat, constants.FlagNameOutput, "o", "",
+			`Output format. "events" streams newline-delimited JSON events to stdout, for dashboards`+
+				` and CI (see docs/events.md); omit for the terminal UI`,
+		)
+
+	ClusterCmd.PersistentFlags().
+		StringVar(&eventsFilePath, constants.FlagNameEventsFile, "",
+			"Append the JSON event stream to this file, keeping the terminal UI",
+		)
 }
@@ -0,0 +1,40 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+)
+
+var PlanCmd = &cobra.Command{
+	Use: "plan",
+
+	Short: "Show the kubeaid-config changes a bootstrap / upgrade / sync would push, without committing anything",
+
+	Args: cobra.NoArgs,
+
+	// --output (shared by every cluster subcommand) additionally accepts "json" here : just the
+	// summary, without the per-file diff.
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		core.PlanCluster(ctx, core.PlanClusterArgs{
+			SkipMonitoringSetup: skipMonitoringSetup,
+			OutputFormat:        outputFormat,
+		})
+	},
+}
+
+func init() {
+	// Flags.
+
+	PlanCmd.Flags().
+		BoolVar(
+			&skipMonitoringSetup, constants.FlagNameSkipMonitoringSetup, false,
+			"Skip rendering the KubePrometheus manifests",
+		)
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+)
+
+var StatusCmd = &cobra.Command{
+	Use: "status",
+
+	Short: "Show a read-only health overview of the main cluster, exiting non-zero when it's unhealthy",
+
+	Args: cobra.NoArgs,
+
+	// --output (shared by every cluster subcommand) additionally accepts "json" here.
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		exitCode := core.ClusterStatus(ctx, outputFormat)
+		if exitCode == core.ClusterStatusExitHealthy {
+			return
+		}
+
+		// Exit only once the config setup's cleanup (registered by ClusterCmd's PersistentPreRun,
+		// and so finalized before this) has run.
+		cobra.OnFinalize(func() { os.Exit(exitCode) })
+	},
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/core"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
@@ -22,10 +23,10 @@ var SyncCmd = &cobra.Command{
 	Args: cobra.NoArgs,
 
 	// GitOps driven : no desired-state flags. Everything (kubelet tuning, helm releases,
-	// addons, hosts) is read from general.yaml; version changes are 'cluster upgrade's job.
-	// One upfront confirmation gates the whole run (--yes skips it, for unattended runs);
-	// disruptive reconciles (kubelet flags need a rolling per-node procedure) ask again
-	// separately.
+	// addons, hosts, machine sizing) is read from general.yaml; version changes are
+	// 'cluster upgrade's job. One upfront confirmation gates the whole run (--yes skips it, for
+	// unattended runs); disruptive reconciles (kubelet flags need a rolling per-node procedure,
+	// a new instance type replaces the machines) ask again separately.
 	Run: func(cmd *cobra.Command, args []string) {
 		switch globals.CloudProviderName {
 		case constants.CloudProviderBareMetal:
@@ -34,9 +35,26 @@ var SyncCmd = &cobra.Command{
 				Yes:            yes,
 			})
 
+		case constants.CloudProviderAWS, constants.CloudProviderAzure, constants.CloudProviderHetzner:
+			// Managed control planes (EKS / AKS) have no machine templates of ours to rotate : the
+			// cloud provider resizes the node pools when the capi-cluster values change.
+			assert.Assert(cmd.Context(), !config.ManagedControlPlaneEnabled(),
+				"`cluster sync` doesn't apply to EKS / AKS clusters : edit argocd-apps/values-capi-cluster.yaml "+
+					"in your kubeaid-config repo and let ArgoCD sync",
+			)
+
+			assert.Assert(cmd.Context(), !config.ControlPlaneInHetznerBareMetal(),
+				"`cluster sync` doesn't apply to Hetzner bare-metal clusters : their machine sizing is the hardware's",
+			)
+
+			core.SyncClusterUsingCAPI(cmd.Context(), core.SyncCAPIClusterArgs{
+				SkipPRWorkflow: skipPRWorkflow,
+				Yes:            yes,
+			})
+
 		default:
 			assert.Assert(cmd.Context(), false, fmt.Sprintf(
-				"'cluster sync' is only needed for the Bare Metal (KubeOne) provider - on %s, merged kubeaid-config changes get reconciled by ArgoCD",
+				"'cluster sync' isn't supported for the %s provider. Recreate the dev environment instead",
 				globals.CloudProviderName,
 			))
 		}
@@ -0,0 +1,22 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+)
+
+var SyncKeycloakCmd = &cobra.Command{
+	Use: "sync-keycloak",
+
+	Short: "Converge the managed Keycloak realm's users and groups onto cluster.keycloak in general.yaml",
+
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		core.SyncKeycloakDirectory(cmd.Context())
+	},
+}
@@ -17,4 +17,5 @@ var ConfigFilesDirectory string
 func init() {
 	// Subcommands.
 	ConfigCmd.AddCommand(GenerateCmd)
+	ConfigCmd.AddCommand(ValidateCmd)
 }
@@ -71,7 +71,7 @@ first, review the output, then bootstrap.`,
 		// starting fresh replaces the cluster name, and a replaced name moves
 		// the files. Printing the directory we picked would send the operator
 		// to the config they chose to leave alone.
-		written, err := prompt.ConfigFromPrompt(globals.ConfigsDirectory, clusterName)
+		written, err := prompt.ConfigFromPrompt(ctx, globals.ConfigsDirectory, clusterName)
 		if err != nil {
 			assert.AssertErrNil(ctx, err, "Interactive config generation failed")
 		}
@@ -0,0 +1,65 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	configSetup "github.com/Obmondo/kubeaid-cli/pkg/config/setup"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+	"github.com/Obmondo/kubeaid-cli/pkg/globals"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+)
+
+var ValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate general.yaml and secrets.yaml, and optionally the Helm values rendered from them",
+	Long: `Parses and validates general.yaml and secrets.yaml, the same way every cluster
+command does before touching anything.
+
+With --render, it also renders the kubeaid-config files into a scratch directory,
+and validates each Helm values file against the values.schema.json of the KubeAid
+Helm chart it's meant for, followed by a dry render of that chart. The KubeAid
+repository gets cloned for that, at forks.kubeaidFork.version. Nothing gets pushed.`,
+
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		// Exits, when the config files are invalid.
+		cleanup, err := configSetup.Prepare(ctx)
+		if err != nil {
+			cleanup()
+		}
+		assert.AssertErrNil(ctx, err, "Failed preparing config files")
+		cobra.OnFinalize(cleanup)
+
+		slog.InfoContext(ctx, "Config files are valid", slog.String("path", globals.ConfigsDirectory))
+
+		if !render {
+			return
+		}
+
+		err = utils.InitTempDir(ctx)
+		assert.AssertErrNil(ctx, err, "Failed initializing temp dir")
+
+		core.ValidateRenderedHelmValues(ctx)
+
+		slog.InfoContext(ctx, "Rendered Helm values are valid")
+	},
+}
+
+var render bool
+
+func init() {
+	ValidateCmd.Flags().
+		BoolVar(&render, constants.FlagNameRender, false,
+			"Also render the kubeaid-config files, and validate the Helm values against the KubeAid Helm charts",
+		)
+}
@@ -16,7 +16,9 @@ import (
 	"github.com/Obmondo/kubeaid-cli/cmd/kubeaid-core/root/cluster"
 	"github.com/Obmondo/kubeaid-cli/cmd/kubeaid-core/root/config"
 	"github.com/Obmondo/kubeaid-cli/cmd/kubeaid-core/root/devenv"
+	"github.com/Obmondo/kubeaid-cli/cmd/kubeaid-core/root/secrets"
 	"github.com/Obmondo/kubeaid-cli/cmd/kubeaid-core/root/version"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
@@ -49,6 +51,11 @@ var RootCmd = &cobra.Command{
 		globals.LogFilePath = logFilePath
 
 		logger.CreateLogger(globals.IsDebugModeEnabled, []io.Writer{logFile, os.Stdout})
+
+		// Load the answers file upfront, so a malformed one fails the run before anything
+		// gets provisioned.
+		answers.Parsed, err = answers.Load(globals.AnswersFilePath, globals.IsNonInteractiveModeEnabled)
+		assert.AssertErrNil(cmd.Context(), err, "Failed loading answers file")
 	},
 
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -66,6 +73,7 @@ func init() {
 	RootCmd.AddCommand(devenv.DevenvCmd)
 	RootCmd.AddCommand(backup.BackupCmd)
 	RootCmd.AddCommand(cluster.ClusterCmd)
+	RootCmd.AddCommand(secrets.SecretsCmd)
 	RootCmd.AddCommand(version.VersionCommand)
 
 	// Flags.
@@ -73,6 +81,22 @@ func init() {
 	RootCmd.PersistentFlags().
 		BoolVar(&globals.IsDebugModeEnabled, constants.FlagNameDebug, false, "Generate debug logs")
 
+	RootCmd.PersistentFlags().
+		BoolVar(&globals.IsNonInteractiveModeEnabled,
+			constants.FlagNameNonInteractive,
+			false,
+			"Never prompt : take every decision from --"+constants.FlagNameAnswersFile+
+				", and fail when one's missing (for CI pipelines)",
+		)
+
+	RootCmd.PersistentFlags().
+		StringVar(&globals.AnswersFilePath,
+			constants.FlagNameAnswersFile,
+			"",
+			"Path to a YAML file with answers to the approval prompts (see docs/non-interactive.md)",
+		)
+	RootCmd.MarkPersistentFlagFilename(constants.FlagNameAnswersFile, "yaml", "yml")
+
 	RootCmd.PersistentFlags().
 		StringVar(&globals.ConfigsDirectory,
 			constants.FlagNameConfigsDirectory,
@@ -0,0 +1,27 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+)
+
+var DiffCmd = &cobra.Command{
+	Use: "diff",
+
+	Short: "Show which fields of each out of date Sealed Secret changed, without revealing their values",
+
+	Long: `For every Sealed Secret file a bootstrap / sync would re-seal, show which of its fields got
+added (+), changed (~) or removed (-). Only field names get printed, never values.
+
+Works offline, against the local kubeaid-config clone and the cached sealing certificate.`,
+
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		core.DiffSealedSecrets(cmd.Context())
+	},
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+)
+
+var ListCmd = &cobra.Command{
+	Use: "list",
+
+	Short: "List every Sealed Secret file in kubeaid-config, and whether its hash header is current, stale or missing",
+
+	Long: `List every Sealed Secret file of the cluster in kubeaid-config, with its status :
+
+  current   A bootstrap / sync would leave it untouched
+  stale     Its plaintext changed, or the sealing key got rotated, since it was sealed
+  missing   It has no kubeaid-sha256 hash header
+  new       kubeaid-cli renders it, but it isn't in kubeaid-config yet
+  manual    kubeaid-cli doesn't render it
+  unknown   kubeaid-cli renders it from a value only the cluster or the cloud provider has, so can't
+            tell offline
+
+Works offline, against the local kubeaid-config clone and the cached sealing certificate.`,
+
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		core.ListSealedSecrets(cmd.Context())
+	},
+}
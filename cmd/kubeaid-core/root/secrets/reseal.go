@@ -0,0 +1,41 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+)
+
+var ResealCmd = &cobra.Command{
+	Use: "reseal",
+
+	Short: "Re-render and re-seal the out of date Sealed Secrets in the local kubeaid-config clone",
+
+	Long: `Re-render and re-seal every Sealed Secret file a bootstrap / sync would re-seal, or just the
+one given by --only. The changes are left in the local kubeaid-config clone, to review, commit and
+push from there.
+
+Works offline, with the cached sealing certificate.`,
+
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		core.ResealSealedSecrets(cmd.Context(), only)
+	},
+}
+
+var only string
+
+func init() {
+	// Flags.
+
+	ResealCmd.Flags().
+		StringVar(&only, constants.FlagNameOnly, "",
+			"Only re-seal this Sealed Secret file, given by its path relative to the cluster directory,"+
+				" like sealed-secrets/argocd/kubeaid-config.yaml",
+		)
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/core"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+)
+
+var RotateSealingKeyCmd = &cobra.Command{
+	Use: "rotate-sealing-key",
+
+	Short: "Rotate the Sealed Secrets sealing key, re-sealing every Sealed Secret in kubeaid-config",
+
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		assert.Assert(ctx, syncTimeout > 0,
+			fmt.Sprintf("invalid --%s value %s: must be positive", constants.FlagNameSyncTimeout, syncTimeout),
+		)
+
+		core.RotateSealingKey(ctx, core.RotateSealingKeyArgs{
+			SkipPRWorkflow: skipPRWorkflow,
+			SyncTimeout:    syncTimeout,
+		})
+	},
+}
+
+var (
+	skipPRWorkflow bool
+	syncTimeout    time.Duration
+)
+
+func init() {
+	// Flags.
+
+	RotateSealingKeyCmd.Flags().
+		BoolVar(&skipPRWorkflow, constants.FlagNameSkipPRWorkflow, false,
+			"Skip the PR workflow and let KubeAid Bootstrap Script push changes directly to the default branch",
+		)
+
+	RotateSealingKeyCmd.Flags().
+		DurationVar(&syncTimeout, constants.FlagNameSyncTimeout, 30*time.Minute,
+			"How long to wait, once the re-sealed Sealed Secrets are merged, for ArgoCD to sync them."+
+				" The old keys are only retired after that",
+		)
+}
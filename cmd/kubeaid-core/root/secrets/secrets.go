@@ -0,0 +1,46 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package secrets
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	configSetup "github.com/Obmondo/kubeaid-cli/pkg/config/setup"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+)
+
+var SecretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Manage the Sealed Secrets of a KubeAid managed K8s cluster",
+
+	// Same preparation as the cluster group : secrets subcommands work against the cluster's
+	// config and its KubeAid Config repository.
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		cleanup, err := configSetup.Prepare(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed preparing config files", slog.String("error", err.Error()))
+			cleanup()
+			os.Exit(1)
+		}
+		cobra.OnFinalize(cleanup)
+
+		// Initialize temp directory.
+		if err := utils.InitTempDir(ctx); err != nil {
+			slog.ErrorContext(ctx, "Failed initializing temp dir", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	SecretsCmd.AddCommand(ListCmd)
+	SecretsCmd.AddCommand(DiffCmd)
+	SecretsCmd.AddCommand(ResealCmd)
+	SecretsCmd.AddCommand(RotateSealingKeyCmd)
+}
@@ -6,6 +6,7 @@ package plan
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/storageplanner/storageplan"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor"
 )
@@ -15,12 +16,24 @@ var ExecuteCommand = &cobra.Command{
 
 	// execute applies the storage plan: it scans and prints the plan (the same
 	// read-only dry run as bare `plan`), then partitions the disks accordingly.
+	// A preflight refuses disks carrying data first, unless their WWN is passed
+	// via --allow-wipe-wwn.
 	Run: func(cmd *cobra.Command, _ []string) {
 		ctx := cmd.Context()
 
 		commandExecutor := commandexecutor.NewLocalCommandExecutor(false)
 
 		storagePlan := generateAndPrintStoragePlan(ctx, commandExecutor)
-		storageplan.Execute(ctx, storagePlan, commandExecutor)
+		storageplan.Execute(ctx, storagePlan, commandExecutor, allowedWipeWWNs)
 	},
 }
+
+var allowedWipeWWNs []string
+
+func init() {
+	ExecuteCommand.Flags().
+		StringArrayVar(&allowedWipeWWNs, constants.FlagNameAllowWipeWWN, nil,
+			"WWN of a disk to wipe, when it carries a filesystem, LVM, mdraid or foreign ZFS pool"+
+				" signature the storage plan executor didn't put there. Repeatable",
+		)
+}
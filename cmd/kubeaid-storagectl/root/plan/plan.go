@@ -5,6 +5,7 @@ package plan
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/spf13/cobra"
@@ -27,7 +28,20 @@ var PlanCommand = &cobra.Command{
 	},
 }
 
-var osSize, zfsPoolSize int
+var (
+	osSize,
+	zfsPoolSize,
+	zfsPoolDisks int
+
+	zfsPoolType string
+
+	zfsDatasets []string
+
+	excludeCeph bool
+
+	includedDisks,
+	excludedDisks []string
+)
 
 // generateAndPrintStoragePlan scans the server's disks (via commandExecutor),
 // builds the storage plan and pretty-prints it. It performs NO disk mutation:
@@ -38,7 +52,9 @@ func generateAndPrintStoragePlan(
 	ctx context.Context,
 	commandExecutor commandexecutor.CommandExecutor,
 ) *storageplan.StoragePlan {
-	storagePlan, err := storageplanner.GenerateStoragePlan(ctx, "", commandExecutor, osSize, zfsPoolSize)
+	storagePlan, err := storageplanner.GenerateStoragePlan(ctx, "",
+		commandExecutor, osSize, zfsPoolSize, getStorageLayout(ctx), getDiskSelection(),
+	)
 	assert.AssertErrNil(ctx, err, "Failed generating storage-plan")
 
 	slog.InfoContext(ctx, "Generated storage plan:")
@@ -47,9 +63,31 @@ func generateAndPrintStoragePlan(
 	return storagePlan
 }
 
+// getStorageLayout returns the storage layout declared by the flags.
+func getStorageLayout(ctx context.Context) storageplan.StorageLayout {
+	layout := storageplan.StorageLayout{
+		ZFSPoolType:  zfsPoolType,
+		ZFSPoolDisks: zfsPoolDisks,
+		ExcludeCEPH:  excludeCeph,
+	}
+	for _, zfsDataset := range zfsDatasets {
+		dataset, err := storageplan.ParseZFSDataset(zfsDataset)
+		assert.AssertErrNil(ctx, err, fmt.Sprintf("Invalid --%s value", constants.FlagNameZFSDataset))
+
+		layout.ZFSDatasets = append(layout.ZFSDatasets, dataset)
+	}
+	return layout
+}
+
+// getDiskSelection returns the disk selection declared by the flags.
+func getDiskSelection() storageplan.DiskSelection {
+	return storageplan.DiskSelection{Include: includedDisks, Exclude: excludedDisks}
+}
+
 func init() {
 	// Subcommands.
 	PlanCommand.AddCommand(ExecuteCommand)
+	PlanCommand.AddCommand(VerifyCommand)
 
 	// Flags.
 
@@ -57,5 +95,42 @@ func init() {
 		IntVar(&osSize, constants.FlagNameOSSize, constants.OSDefaultSize, "OS size (in GB)")
 
 	PlanCommand.PersistentFlags().
-		IntVar(&zfsPoolSize, constants.FlagNameZFSPoolSize, constants.ZFSPoolDefaultSize, "ZFS pool size (in GB)")
+		IntVar(&zfsPoolSize, constants.FlagNameZFSPoolSize, constants.ZFSPoolDefaultSize,
+			"ZFS pool size (in GB), allocated on each disk the ZFS pool spans",
+		)
+
+	PlanCommand.PersistentFlags().
+		StringVar(&zfsPoolType, constants.FlagNameZFSPoolType, constants.ZFSPoolTypeMirror,
+			`ZFS pool type. One of "mirror", "raidz1", "raidz2" or "single"`,
+		)
+
+	PlanCommand.PersistentFlags().
+		IntVar(&zfsPoolDisks, constants.FlagNameZFSPoolDisks, 0,
+			"How many disks the ZFS pool spans. 0 means the minimum its type needs"+
+				" (2 for mirror, 3 for raidz1, 4 for raidz2, 1 for single)",
+		)
+
+	PlanCommand.PersistentFlags().
+		StringArrayVar(&zfsDatasets, constants.FlagNameZFSDataset, nil,
+			"ZFS dataset to create, as <name>:<mountpoint>[:<property>=<value>,...], with compression,"+
+				" recordsize and quota as properties, e.g. containerd:/var/lib/containerd:compression=zstd,quota=100G."+
+				" Repeatable. Omit for the containerd, pod-logs and pod-ephemeral-volumes datasets",
+		)
+
+	PlanCommand.PersistentFlags().
+		BoolVar(&excludeCeph, constants.FlagNameExcludeCeph, false,
+			"Leave the disk space left over after the OS volume and the ZFS pool unallocated,"+
+				" instead of handing it to Rook Ceph",
+		)
+
+	PlanCommand.PersistentFlags().
+		StringArrayVar(&includedDisks, constants.FlagNameIncludeDisk, nil,
+			"Disk (by name, WWN or serial) to consider, even when it's of a type the storage planner"+
+				" doesn't recognise. Repeatable. When given, no other disk gets considered",
+		)
+
+	PlanCommand.PersistentFlags().
+		StringArrayVar(&excludedDisks, constants.FlagNameExcludeDisk, nil,
+			"Disk (by name, WWN or serial) to leave alone. Repeatable",
+		)
 }
@@ -0,0 +1,140 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package plan
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	kubeonessh "k8c.io/kubeone/pkg/ssh"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/globals"
+	"github.com/Obmondo/kubeaid-cli/pkg/storageplanner"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/sshkey"
+)
+
+var VerifyCommand = &cobra.Command{
+	Use: "verify",
+
+	Short: "Compare the server's live storage against its storage plan, exiting non-zero on drift",
+
+	Long: `Read-only : regenerates the storage plan (for the same --os-size, --zfs-pool-size and layout
+flags 'plan execute' got run with), then compares the server's live storage against it. Reports
+missing partitions, a degraded / faulted ZFS pool or vdev, disks which aren't (or shouldn't be) in
+the ZFS pool, ZFS datasets which are missing or mounted elsewhere, Rook Ceph partitions holding
+something else, and disks whose WWN changed since 'plan execute' (which records the executed
+storage plan at ` + constants.StoragePlanRecordPath + `).
+
+Verifies the local server, or the one given by --host, over SSH.
+
+Exits 1 when anything drifted, and 2 when nothing drifted but something couldn't be looked at.`,
+
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, _ []string) {
+		ctx := cmd.Context()
+
+		assert.Assert(ctx,
+			(verifyOutputFormat == "") || slices.Contains(storageplanner.VerifyOutputFormats, verifyOutputFormat),
+			fmt.Sprintf("invalid --%s value %q: must be one of %s",
+				constants.FlagNameOutput, verifyOutputFormat, strings.Join(storageplanner.VerifyOutputFormats, ", ")),
+		)
+
+		// Keep stdout for the report alone, so it stays machine-readable.
+		logger.CreateLogger(globals.IsDebugModeEnabled, []io.Writer{io.Discard, os.Stderr})
+
+		layout := getStorageLayout(ctx)
+
+		server := "localhost"
+		commandExecutor := commandexecutor.NewLocalCommandExecutor(false)
+		if verifyHost != "" {
+			server = verifyHost
+
+			var privateKey []byte
+			if verifySSHPrivateKeyPath != "" {
+				var err error
+				privateKey, err = os.ReadFile(verifySSHPrivateKeyPath)
+				assert.AssertErrNil(ctx, err, "Failed reading SSH private key")
+
+				// kubeone can't parse passphrase protected keys : ask for the passphrase.
+				privateKey, _, err = sshkey.UnencryptedPEM(verifySSHPrivateKeyPath, privateKey)
+				assert.AssertErrNil(ctx, err, "Failed to parse SSH private key")
+			}
+
+			connection, err := kubeonessh.NewConnection(kubeonessh.NewConnector(ctx), kubeonessh.Opts{
+				Context:     ctx,
+				Hostname:    verifyHost,
+				Port:        verifySSHPort,
+				Username:    verifySSHUser,
+				AgentSocket: os.Getenv(constants.EnvNameSSHAuthSock),
+				PrivateKey:  privateKey,
+				Timeout:     10 * time.Second,
+			})
+			assert.AssertErrNil(ctx, err, fmt.Sprintf("Failed SSHing into %s", verifyHost))
+			defer connection.Close()
+
+			commandExecutor = commandexecutor.NewSSHCommandExecutor(connection)
+		}
+
+		report := storageplanner.VerifyStoragePlan(ctx, server, commandExecutor,
+			osSize, zfsPoolSize, layout, getDiskSelection(),
+		)
+
+		output, err := storageplanner.RenderVerifyReport(report, verifyOutputFormat)
+		assert.AssertErrNil(ctx, err, "Failed rendering storage verify report")
+		fmt.Print(output) //nolint:forbidigo // operator-facing terminal output
+
+		if exitCode := report.ExitCode(); exitCode != storageplanner.VerifyExitOK {
+			// Close the SSH connection first : os.Exit skips the deferred Close.
+			cobra.OnFinalize(func() { os.Exit(exitCode) })
+		}
+	},
+}
+
+var (
+	verifyOutputFormat string
+
+	verifyHost,
+	verifySSHUser,
+	verifySSHPrivateKeyPath string
+
+	verifySSHPort int
+)
+
+func init() {
+	VerifyCommand.Flags().
+		StringVarP(&verifyOutputFormat, constants.FlagNameOutput, "o", "",
+			`Output format. "json", or omit for human-readable output`,
+		)
+
+	VerifyCommand.Flags().
+		StringVar(&verifyHost, constants.FlagNameHost, "",
+			"Verify this remote server, over SSH, instead of the local one",
+		)
+
+	VerifyCommand.Flags().
+		StringVar(&verifySSHUser, constants.FlagNameSSHUser, "root",
+			"SSH user, for --"+constants.FlagNameHost,
+		)
+
+	VerifyCommand.Flags().
+		IntVar(&verifySSHPort, constants.FlagNameSSHPort, 22,
+			"SSH port, for --"+constants.FlagNameHost,
+		)
+
+	VerifyCommand.Flags().
+		StringVar(&verifySSHPrivateKeyPath, constants.FlagNameSSHPrivateKey, "",
+			"SSH private key file, for --"+constants.FlagNameHost+". Omit to authenticate via the SSH agent."+
+				" A passphrase protected one's passphrase gets asked for, or read from $"+constants.EnvNameSSHKeyPassphrase,
+		)
+}
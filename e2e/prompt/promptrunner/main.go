@@ -7,6 +7,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -28,7 +29,7 @@ func main() {
 
 	// The result carries where it actually wrote; the e2e tests drive this
 	// through a PTY and match on prompt text, so nothing is printed for it.
-	if _, err := prompt.ConfigFromPrompt(os.Args[1], clusterName); err != nil {
+	if _, err := prompt.ConfigFromPrompt(context.Background(), os.Args[1], clusterName); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
@@ -16,8 +16,13 @@ import (
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
 )
 
+// AWSMachineTemplateUpdates lists the AWSMachineTemplate fields to change. Empty (zero) fields
+// are left as they are.
 type AWSMachineTemplateUpdates struct {
 	AMIID string
+
+	InstanceType   string
+	RootVolumeSize uint32 // (in GiB).
 }
 
 func (*AWS) UpdateMachineTemplate(ctx context.Context,
@@ -30,6 +35,11 @@ func (*AWS) UpdateMachineTemplate(ctx context.Context,
 		return fmt.Errorf("wrong type of MachineTemplateUpdates object passed")
 	}
 
+	// Nothing to update.
+	if parsedUpdates == (AWSMachineTemplateUpdates{}) {
+		return nil
+	}
+
 	awsMachineTemplate := &capaV1Beta2.AWSMachineTemplate{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      name,
@@ -45,7 +55,7 @@ func (*AWS) UpdateMachineTemplate(ctx context.Context,
 	}
 	slog.InfoContext(ctx, "Deleted the current AWSMachineTemplate")
 
-	awsMachineTemplate.Spec.Template.Spec.AMI.ID = &parsedUpdates.AMIID
+	applyAWSMachineTemplateUpdates(&awsMachineTemplate.Spec.Template.Spec, parsedUpdates)
 	awsMachineTemplate.ResourceVersion = ""
 
 	if err := clusterClient.Create(ctx, awsMachineTemplate, &client.CreateOptions{}); err != nil {
@@ -55,6 +65,23 @@ func (*AWS) UpdateMachineTemplate(ctx context.Context,
 	return nil
 }
 
+func applyAWSMachineTemplateUpdates(spec *capaV1Beta2.AWSMachineSpec, updates AWSMachineTemplateUpdates) {
+	if len(updates.AMIID) > 0 {
+		spec.AMI.ID = &updates.AMIID
+	}
+
+	if len(updates.InstanceType) > 0 {
+		spec.InstanceType = updates.InstanceType
+	}
+
+	if updates.RootVolumeSize > 0 {
+		if spec.RootVolume == nil {
+			spec.RootVolume = &capaV1Beta2.Volume{}
+		}
+		spec.RootVolume.Size = int64(updates.RootVolumeSize)
+	}
+}
+
 func (*AWS) UpdateCapiClusterValuesFile(ctx context.Context, path string, updates any) error {
 	parsedUpdates, ok := updates.(AWSMachineTemplateUpdates)
 	if !ok {
@@ -50,6 +50,9 @@ func TestUpdateMachineTemplate(t *testing.T) {
 		wantErr   bool
 		errMsg    string
 		wantAMIID string
+
+		wantInstanceType   string
+		wantRootVolumeSize int64
 	}{
 		{
 			name: "wrong updates type returns error",
@@ -86,6 +89,23 @@ func TestUpdateMachineTemplate(t *testing.T) {
 			updates:   AWSMachineTemplateUpdates{AMIID: "ami-new-456"},
 			wantAMIID: "ami-new-456",
 		},
+		{
+			name: "resize keeps the AMI",
+			setup: func(_ *testing.T) *fakeclient.ClientBuilder {
+				return fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(existingTemplate.DeepCopy())
+			},
+			updates:            AWSMachineTemplateUpdates{InstanceType: "t3.xlarge", RootVolumeSize: 80},
+			wantAMIID:          oldAMI,
+			wantInstanceType:   "t3.xlarge",
+			wantRootVolumeSize: 80,
+		},
+		{
+			name: "empty updates are a no-op",
+			setup: func(_ *testing.T) *fakeclient.ClientBuilder {
+				return fakeclient.NewClientBuilder().WithScheme(scheme)
+			},
+			updates: AWSMachineTemplateUpdates{},
+		},
 	}
 
 	for _, tc := range tests {
@@ -106,12 +126,21 @@ func TestUpdateMachineTemplate(t *testing.T) {
 			}
 			require.NoError(t, err)
 
+			if tc.wantAMIID == "" {
+				return
+			}
+
 			recreated := &capaV1Beta2.AWSMachineTemplate{}
 			require.NoError(t, cl.Get(context.Background(),
 				types.NamespacedName{Name: "my-template", Namespace: "capi-cluster"},
 				recreated))
 			require.NotNil(t, recreated.Spec.Template.Spec.AMI.ID)
 			assert.Equal(t, tc.wantAMIID, *recreated.Spec.Template.Spec.AMI.ID)
+			if tc.wantInstanceType != "" {
+				assert.Equal(t, tc.wantInstanceType, recreated.Spec.Template.Spec.InstanceType)
+				require.NotNil(t, recreated.Spec.Template.Spec.RootVolume)
+				assert.Equal(t, tc.wantRootVolumeSize, recreated.Spec.Template.Spec.RootVolume.Size)
+			}
 		})
 	}
 }
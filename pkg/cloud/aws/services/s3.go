@@ -33,8 +33,14 @@ type S3API interface {
 
 var getDownloadedStorageBucketContentsDir = utils.GetDownloadedStorageBucketContentsDir
 
-// Creates S3 Bucket.
+// Creates S3 Bucket, in the AWS region from the general config.
 func CreateS3Bucket(ctx context.Context, s3Client S3API, name string) error {
+	return CreateS3BucketInRegion(ctx, s3Client, name, config.ParsedGeneralConfig.Cloud.AWS.Region)
+}
+
+// Creates S3 Bucket in the given region.
+// Also used against S3-compatible object storages (Hetzner Object Storage, MinIO, Ceph RGW).
+func CreateS3BucketInRegion(ctx context.Context, s3Client S3API, name, region string) error {
 	ctx = logger.AppendSlogAttributesToCtx(ctx, []slog.Attr{
 		slog.String("s3Bucket", name),
 	})
@@ -42,11 +48,9 @@ func CreateS3Bucket(ctx context.Context, s3Client S3API, name string) error {
 	createBucketInput := &s3.CreateBucketInput{
 		Bucket: aws.String(name),
 	}
-	if config.ParsedGeneralConfig.Cloud.AWS.Region != "us-east-1" {
+	if region != "us-east-1" {
 		createBucketInput.CreateBucketConfiguration = &s3Types.CreateBucketConfiguration{
-			LocationConstraint: s3Types.BucketLocationConstraint(
-				config.ParsedGeneralConfig.Cloud.AWS.Region,
-			),
+			LocationConstraint: s3Types.BucketLocationConstraint(region),
 		}
 	}
 	_, err := s3Client.CreateBucket(ctx, createBucketInput)
@@ -13,12 +13,16 @@ import (
 	capzV1Beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/Obmondo/kubeaid-cli/pkg/config"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
 )
 
+// AzureMachineTemplateUpdates lists the AzureMachineTemplate fields to change. Empty (zero)
+// fields are left as they are.
 type AzureMachineTemplateUpdates struct {
 	NewImageOffer string
+
+	VMSize     string
+	DiskSizeGB uint32
 }
 
 func (*Azure) UpdateMachineTemplate(ctx context.Context,
@@ -31,36 +35,35 @@ func (*Azure) UpdateMachineTemplate(ctx context.Context,
 		return fmt.Errorf("wrong type of MachineTemplateUpdates object passed")
 	}
 
-	// The user doesn't want to do an OS upgrade.
+	// Neither an OS upgrade nor a resize.
 	// So we don't need to do anything.
-	if len(parsedUpdates.NewImageOffer) == 0 {
+	if parsedUpdates == (AzureMachineTemplateUpdates{}) {
 		return nil
 	}
 
-	// Get the AzureMachineTemplate currently being referred by KubeadmControlPlane.
+	// Get the AzureMachineTemplate currently being referred by the KubeadmControlPlane /
+	// MachineDeployment.
 	azureMachineTemplate := &capzV1Beta1.AzureMachineTemplate{
 		ObjectMeta: v1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-control-plane", config.ParsedGeneralConfig.Cluster.Name),
+			Name:      name,
 			Namespace: kubernetes.GetCapiClusterNamespace(),
 		},
 	}
 	err := kubernetes.GetKubernetesResource(ctx, clusterClient, azureMachineTemplate)
 	if err != nil {
-		return fmt.Errorf("retrieving the current AzureMachineTemplate resource used by the KubeadmControlPlane resource: %w", err)
+		return fmt.Errorf("retrieving the current AzureMachineTemplate: %w", err)
 	}
 
 	// Delete that AzureMachineTemplate.
 	err = clusterClient.Delete(ctx, azureMachineTemplate, &client.DeleteOptions{})
 	if err != nil {
-		return fmt.Errorf("deleting the current AzureMachineTemplate resource being used by the KubeadmControlPlane resource: %w", err)
+		return fmt.Errorf("deleting the current AzureMachineTemplate: %w", err)
 	}
-	slog.InfoContext(ctx,
-		"Deleted the current azureMachineTemplate resource being used by the KubeadmControlPlane resource",
-	)
+	slog.InfoContext(ctx, "Deleted the current AzureMachineTemplate", slog.String("name", name))
 
 	// Recreate the updated AzureMachineTemplate.
 
-	azureMachineTemplate.Spec.Template.Spec.Image.Marketplace.Offer = parsedUpdates.NewImageOffer
+	applyAzureMachineTemplateUpdates(&azureMachineTemplate.Spec.Template.Spec, parsedUpdates)
 	azureMachineTemplate.ResourceVersion = ""
 
 	err = clusterClient.Create(ctx, azureMachineTemplate, &client.CreateOptions{})
@@ -71,6 +74,23 @@ func (*Azure) UpdateMachineTemplate(ctx context.Context,
 	return nil
 }
 
+func applyAzureMachineTemplateUpdates(spec *capzV1Beta1.AzureMachineSpec,
+	updates AzureMachineTemplateUpdates,
+) {
+	if len(updates.NewImageOffer) > 0 && spec.Image != nil && spec.Image.Marketplace != nil {
+		spec.Image.Marketplace.Offer = updates.NewImageOffer
+	}
+
+	if len(updates.VMSize) > 0 {
+		spec.VMSize = updates.VMSize
+	}
+
+	if updates.DiskSizeGB > 0 {
+		diskSizeGB := int32(updates.DiskSizeGB) //nolint:gosec // G115: OS disks are sized in hundreds of GiB.
+		spec.OSDisk.DiskSizeGB = &diskSizeGB
+	}
+}
+
 func (a *Azure) UpdateCapiClusterValuesFile(ctx context.Context, path string, updates any) error {
 	parsedUpdates, ok := updates.(AzureMachineTemplateUpdates)
 	if !ok {
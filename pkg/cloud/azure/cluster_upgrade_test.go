@@ -111,6 +111,8 @@ func TestUpdateMachineTemplate(t *testing.T) {
 		wantErr   bool
 		errMsg    string
 		wantOffer string
+		wantSize  string
+		wantDisk  int32
 		noOp      bool
 	}{
 		{
@@ -120,7 +122,15 @@ func TestUpdateMachineTemplate(t *testing.T) {
 			wantOffer: "new-offer",
 		},
 		{
-			name:    "empty NewImageOffer is a no-op",
+			name:      "recreates template with new VM size and OS disk size",
+			updates:   AzureMachineTemplateUpdates{VMSize: "Standard_D4s_v3", DiskSizeGB: 200},
+			objects:   []client.Object{existingTemplate.DeepCopy()},
+			wantOffer: "old-offer",
+			wantSize:  "Standard_D4s_v3",
+			wantDisk:  200,
+		},
+		{
+			name:    "empty updates are a no-op",
 			updates: AzureMachineTemplateUpdates{NewImageOffer: ""},
 			objects: []client.Object{existingTemplate.DeepCopy()},
 			noOp:    true,
@@ -157,7 +167,9 @@ func TestUpdateMachineTemplate(t *testing.T) {
 			fakeClient := builder.Build()
 
 			a := &Azure{}
-			err := a.UpdateMachineTemplate(context.Background(), fakeClient, "", tc.updates)
+			err := a.UpdateMachineTemplate(context.Background(),
+				fakeClient, fmt.Sprintf("%s-control-plane", clusterName), tc.updates,
+			)
 			if tc.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tc.errMsg)
@@ -176,6 +188,11 @@ func TestUpdateMachineTemplate(t *testing.T) {
 			}
 			require.NoError(t, fakeClient.Get(context.Background(), key, recreated))
 			assert.Equal(t, tc.wantOffer, recreated.Spec.Template.Spec.Image.Marketplace.Offer)
+			if tc.wantSize != "" {
+				assert.Equal(t, tc.wantSize, recreated.Spec.Template.Spec.VMSize)
+				require.NotNil(t, recreated.Spec.Template.Spec.OSDisk.DiskSizeGB)
+				assert.Equal(t, tc.wantDisk, *recreated.Spec.Template.Spec.OSDisk.DiskSizeGB)
+			}
 		})
 	}
 }
@@ -27,8 +27,12 @@ type (
 		HetznerBareMetalMachineTemplateUpdates
 	}
 
+	// Empty fields are left as they are.
 	HCloudMachineTemplateUpdates struct {
 		NewImageName string
+
+		// HCloud server type, like cpx31. The root volume size comes with it.
+		MachineType string
 	}
 
 	HetznerBareMetalMachineTemplateUpdates struct {
@@ -95,6 +99,10 @@ func updateHCloudMachineTemplate(ctx context.Context,
 	name string,
 	updates HCloudMachineTemplateUpdates,
 ) error {
+	if updates == (HCloudMachineTemplateUpdates{}) {
+		return nil
+	}
+
 	hcloudMachineTemplate := &caphV1Beta1.HCloudMachineTemplate{
 		ObjectMeta: metaV1.ObjectMeta{
 			Name:      name,
@@ -110,7 +118,12 @@ func updateHCloudMachineTemplate(ctx context.Context,
 	}
 	slog.InfoContext(ctx, "Deleted the current HCloudMachineTemplate")
 
-	hcloudMachineTemplate.Spec.Template.Spec.ImageName = updates.NewImageName
+	if len(updates.NewImageName) > 0 {
+		hcloudMachineTemplate.Spec.Template.Spec.ImageName = updates.NewImageName
+	}
+	if len(updates.MachineType) > 0 {
+		hcloudMachineTemplate.Spec.Template.Spec.Type = caphV1Beta1.HCloudMachineType(updates.MachineType)
+	}
 	hcloudMachineTemplate.ResourceVersion = ""
 
 	if err := clusterClient.Create(ctx, hcloudMachineTemplate, &client.CreateOptions{}); err != nil {
@@ -125,6 +138,10 @@ func updateHetznerBareMetalMachineTemplate(ctx context.Context,
 	name string,
 	updates HetznerBareMetalMachineTemplateUpdates,
 ) error {
+	if len(updates.NewImagePath) == 0 {
+		return nil
+	}
+
 	hetznerBareMetalMachineTemplate := &caphV1Beta1.HetznerBareMetalMachineTemplate{
 		ObjectMeta: metaV1.ObjectMeta{
 			Name:      name,
@@ -0,0 +1,56 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/dns"
+)
+
+// upsertDNSRecords points every fqdn in fqdns to ip, through the DNS provider configured in
+// cluster.dnsRecords. Reports false, when there's none : then the operator creates the records.
+func upsertDNSRecords(ctx context.Context, fqdns []string, ip string) (bool, error) {
+	provider, err := dns.FromConfig()
+	if err != nil {
+		return false, fmt.Errorf("setting up DNS provider: %w", err)
+	}
+	if provider == nil {
+		return false, nil
+	}
+
+	cluster := config.ParsedGeneralConfig.Cluster
+	for _, fqdn := range fqdns {
+		if err := dns.UpsertA(ctx, provider, cluster.Name, fqdn, ip, cluster.DNSRecords.TTL); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// DeleteDNSRecords deletes the A records KubeAid CLI created for this cluster, through the DNS
+// provider configured in cluster.dnsRecords. Records it didn't create are left alone. No-op when
+// cluster.dnsRecords isn't set.
+func DeleteDNSRecords(ctx context.Context) error {
+	provider, err := dns.FromConfig()
+	if (err != nil) || (provider == nil) {
+		return err
+	}
+
+	fqdns := append(ingressLBFQDNs(), coturnFloatingIPFQDNs()...)
+	if hcloudControlPlane := config.ParsedGeneralConfig.Cloud.Hetzner.ControlPlane.HCloud; hcloudControlPlane != nil &&
+		hcloudControlPlane.LoadBalancer.Endpoint != "" {
+		fqdns = append(fqdns, hcloudControlPlane.LoadBalancer.Endpoint)
+	}
+
+	clusterName := config.ParsedGeneralConfig.Cluster.Name
+	for _, fqdn := range fqdns {
+		if err := dns.DeleteA(ctx, provider, clusterName, fqdn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -47,6 +47,9 @@ const dnsTotalTimeout = 10 * time.Minute
 // dnsTotalTimeout passes (the wait fails closed — interactive bootstrap
 // can't loop forever on a missing record).
 //
+// With cluster.dnsRecords set, the A records get created through that
+// DNS provider first, so the wait only confirms they propagated.
+//
 // No skip option: bypassing the wait would just push the failure into
 // a later stage (cert-manager's first ACME HTTP-01, NetBird OIDC
 // callback, etc.) where the symptom is harder to diagnose. Better to
@@ -58,6 +61,11 @@ func WaitForDNSResolution(ctx context.Context, fqdns []string, expectedIP string
 		return nil
 	}
 
+	managed, err := upsertDNSRecords(ctx, fqdns, expectedIP)
+	if err != nil {
+		return fmt.Errorf("creating DNS records: %w", err)
+	}
+
 	// Pause the bar's spinner so its 100ms auto-render goroutine can't
 	// \r-overwrite our table rows (the spinner anchors at col 0 of the
 	// cursor's current line; without pausing, ticks scribble "⠋ [16s]"
@@ -77,7 +85,11 @@ func WaitForDNSResolution(ctx context.Context, fqdns []string, expectedIP string
 	resolver := net.DefaultResolver
 
 	fmt.Println()
-	fmt.Println("Add the A records shown below to your DNS provider — bootstrap continues automatically once they all resolve.")
+	if managed {
+		fmt.Println("Created the A records shown below in your DNS provider — bootstrap continues automatically once they all resolve.")
+	} else {
+		fmt.Println("Add the A records shown below to your DNS provider — bootstrap continues automatically once they all resolve.")
+	}
 	fmt.Println()
 
 	maxAttempts := int(dnsTotalTimeout / dnsPollInterval)
@@ -14,10 +14,19 @@ import (
 	"github.com/hetznercloud/hcloud-go/hcloud"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/cloud"
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/aws/services"
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/objectstorage"
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 )
 
+var (
+	newObjectStorageS3Client = func() (services.S3API, error) {
+		return objectstorage.NewS3ClientFromConfig()
+	}
+	setupObjectStorageDisasterRecovery = objectstorage.SetupDisasterRecovery
+)
+
 type serverTypeClient interface {
 	GetByName(ctx context.Context, name string) (*hcloud.ServerType, *hcloud.Response, error)
 }
@@ -176,6 +185,14 @@ func newRobotFailoverRestyClient(robotUser, robotPassword string) *resty.Client
 		SetRetryCount(0)
 }
 
-func (*Hetzner) SetupDisasterRecovery(_ context.Context) error {
-	return fmt.Errorf("setup disaster recovery is not implemented for Hetzner")
+// SetupDisasterRecovery sets up the provisioned cluster for Disaster Recovery.
+// Hetzner has no object storage API KubeAid CLI could provision buckets through. So the
+// backups go to the S3-compatible object storage (Hetzner Object Storage, MinIO, Ceph RGW)
+// configured in cloud.disasterRecovery.s3.
+func (*Hetzner) SetupDisasterRecovery(ctx context.Context) error {
+	s3Client, err := newObjectStorageS3Client()
+	if err != nil {
+		return fmt.Errorf("creating object storage client: %w", err)
+	}
+	return setupObjectStorageDisasterRecovery(ctx, s3Client)
 }
@@ -5,33 +5,68 @@ package hetzner
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/aws/services"
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/aws/services/fake"
 )
 
+// Mutates newObjectStorageS3Client, setupObjectStorageDisasterRecovery — sequential only.
 func TestSetupDisasterRecovery(t *testing.T) {
-	t.Parallel()
-
 	tests := []struct {
 		name       string
+		clientErr  error
+		setupErr   error
 		wantErrMsg string
 	}{
 		{
-			name:       "always returns not implemented error",
-			wantErrMsg: "not implemented",
+			name:       "returns error when object storage client can't be created",
+			clientErr:  errors.New("no S3-compatible object storage configured"),
+			wantErrMsg: "creating object storage client",
+		},
+		{
+			name:       "propagates bucket setup error",
+			setupErr:   errors.New("creating Velero backup bucket: access denied"),
+			wantErrMsg: "access denied",
+		},
+		{
+			name: "succeeds when the object storage setup succeeds",
 		},
 	}
 
+	origNewClient := newObjectStorageS3Client
+	origSetup := setupObjectStorageDisasterRecovery
+	t.Cleanup(func() {
+		newObjectStorageS3Client = origNewClient
+		setupObjectStorageDisasterRecovery = origSetup
+	})
+
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+			s3Client := &fake.S3API{}
+			newObjectStorageS3Client = func() (services.S3API, error) {
+				return s3Client, tc.clientErr
+			}
+
+			var gotClient services.S3API
+			setupObjectStorageDisasterRecovery = func(_ context.Context, client services.S3API) error {
+				gotClient = client
+				return tc.setupErr
+			}
 
 			h := &Hetzner{}
 			err := h.SetupDisasterRecovery(context.Background())
-			require.Error(t, err)
-			assert.Contains(t, err.Error(), tc.wantErrMsg)
+			if tc.wantErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Same(t, s3Client, gotClient)
 		})
 	}
 }
@@ -43,6 +43,9 @@ const (
 // the next retry succeed instead of leaving the apps unhealthy
 // while the operator scrambles to figure out which IP to point at.
 //
+// With cluster.dnsRecords set, WaitForDNSResolution creates the
+// records itself, so there's nothing for the operator to do.
+//
 // No-op when no FQDNs are configured (workload clusters, or VPN
 // clusters with no Keycloak/NetBird DNS set).
 func WaitForIngressLBDNS(ctx context.Context, clusterClient client.Client) error {
@@ -305,7 +305,8 @@ func (h *Hetzner) preCreateControlPlaneLB(ctx context.Context, network *hcloud.N
 // waitForControlPlaneDNS pauses bootstrap until the operator has
 // added the A record for the control-plane LB hostname — without
 // it, kubeadm's TLS handshake against the configured endpoint fails
-// at first-boot.
+// at first-boot. With cluster.dnsRecords set, WaitForDNSResolution
+// creates the record itself.
 //
 // Scope is intentionally JUST the control-plane hostname.
 // keycloak.dns, netbird.dns, stun.dns, turn.dns all point at a
@@ -51,6 +51,7 @@ func (h *Hetzner) GenerateStoragePlans(ctx context.Context, hetznerConfig *confi
 				privateKey,
 				hetznerConfig.BareMetal.InstallImage.VG0.Size,
 				hetznerConfig.BareMetal.ZFS.Size,
+				config.StorageLayout(hetznerConfig.ControlPlane.BareMetal.ZFS),
 			)
 			if err != nil {
 				return fmt.Errorf("control-plane server %s: %w", host.ServerID, err)
@@ -82,12 +83,15 @@ func (h *Hetzner) GenerateStoragePlans(ctx context.Context, hetznerConfig *confi
 
 			// Same VG0.Size vs RootVolumeSize rationale as the
 			// control-plane branch above — see that comment for the
-			// "prompt over-reports Ceph" failure mode this avoids.
+			// "prompt over-reports Ceph" failure mode this avoids. The
+			// layout comes from the node group's own zfs block though :
+			// that's what the chart hands its nodes' storagectl.
 			sp, err := h.generateStoragePlan(nodeCtx,
 				host,
 				privateKey,
 				hetznerConfig.BareMetal.InstallImage.VG0.Size,
 				hetznerConfig.BareMetal.ZFS.Size,
+				config.StorageLayout(nodeGroup.ZFS),
 			)
 			if err != nil {
 				return fmt.Errorf("node-group %s server %s: %w", nodeGroup.Name, host.ServerID, err)
@@ -284,6 +288,7 @@ func (h *Hetzner) generateStoragePlan(ctx context.Context,
 	privateKey string,
 	osSize,
 	zfsPoolSize int,
+	layout storageplan.StorageLayout,
 ) (*storageplan.StoragePlan, error) {
 	address, err := h.getHetznerBareMetalServerIP(host.ServerID)
 	if err != nil {
@@ -311,6 +316,8 @@ func (h *Hetzner) generateStoragePlan(ctx context.Context,
 		commandExecutor,
 		osSize,
 		zfsPoolSize,
+		layout,
+		config.DiskSelection(host),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("generating storage plan: %w", err)
@@ -0,0 +1,108 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+// Package objectstorage talks to the S3-compatible object storage (Hetzner Object Storage,
+// MinIO, Ceph RGW) configured in cloud.disasterRecovery.s3. Hetzner and Bare Metal clusters
+// keep their Velero and Sealed Secrets backups there, the way AWS clusters keep them in S3.
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	argoCDV1Alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/aws/services"
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+)
+
+var syncArgoCDApp = kubernetes.SyncArgoCDApp
+
+// NewS3Client returns an S3 client pointed at the given S3-compatible object storage,
+// authenticating with the given static access key pair.
+func NewS3Client(storageConfig *config.S3CompatibleStorageConfig,
+	credentials *config.ObjectStorageCredentials,
+) *s3.Client {
+	return s3.New(s3.Options{
+		BaseEndpoint: aws.String(storageConfig.Endpoint),
+		Region:       storageConfig.Region,
+		UsePathStyle: storageConfig.ForcePathStyle,
+		Credentials: aws.NewCredentialsCache(aws.CredentialsProviderFunc(
+			func(context.Context) (aws.Credentials, error) {
+				return aws.Credentials{
+					AccessKeyID:     credentials.AccessKeyID,
+					SecretAccessKey: credentials.SecretAccessKey,
+					Source:          "kubeaid-cli secrets.yaml",
+				}, nil
+			},
+		)),
+
+		// S3-compatible implementations don't all understand the CRC checksums newer AWS SDK
+		// versions attach to every request by default.
+		RequestChecksumCalculation: aws.RequestChecksumCalculationWhenRequired,
+		ResponseChecksumValidation: aws.ResponseChecksumValidationWhenRequired,
+	})
+}
+
+// NewS3ClientFromConfig returns an S3 client for the object storage configured in the parsed
+// general and secrets config.
+func NewS3ClientFromConfig() (*s3.Client, error) {
+	disasterRecoveryConfig := config.ParsedGeneralConfig.Cloud.DisasterRecovery
+	if (disasterRecoveryConfig == nil) || (disasterRecoveryConfig.S3 == nil) {
+		return nil, errors.New("no S3-compatible object storage configured in cloud.disasterRecovery.s3")
+	}
+
+	credentials := config.ParsedSecretsConfig.ObjectStorage
+	if credentials == nil {
+		return nil, errors.New("no object storage credentials provided in secrets.yaml (objectStorage)")
+	}
+
+	return NewS3Client(disasterRecoveryConfig.S3, credentials), nil
+}
+
+// SetupDisasterRecovery creates the Velero and Sealed Secrets backup buckets in the
+// S3-compatible object storage, and then syncs the ArgoCD Apps which ship backups there.
+// Creating a bucket which already exists and is owned by us, is a no-op.
+func SetupDisasterRecovery(ctx context.Context, s3Client services.S3API) error {
+	disasterRecoveryConfig := config.ParsedGeneralConfig.Cloud.DisasterRecovery
+	if (disasterRecoveryConfig == nil) || (disasterRecoveryConfig.S3 == nil) {
+		return errors.New("no S3-compatible disaster-recovery config provided")
+	}
+
+	slog.InfoContext(ctx, "Setting up Disaster Recovery",
+		slog.String("endpoint", disasterRecoveryConfig.S3.Endpoint),
+	)
+
+	region := disasterRecoveryConfig.S3.Region
+
+	if err := services.CreateS3BucketInRegion(ctx,
+		s3Client, disasterRecoveryConfig.SealedSecretsBackupsBucketName, region,
+	); err != nil {
+		return fmt.Errorf("creating sealed-secrets backup bucket: %w", err)
+	}
+
+	if err := services.CreateS3BucketInRegion(ctx,
+		s3Client, disasterRecoveryConfig.VeleroBackupsBucketName, region,
+	); err != nil {
+		return fmt.Errorf("creating Velero backup bucket: %w", err)
+	}
+
+	argocdAppsToBeSynced := []string{
+		"k8s-configs",
+		constants.ArgoCDAppVelero,
+		"sealed-secrets",
+	}
+	for _, argoCDApp := range argocdAppsToBeSynced {
+		if err := syncArgoCDApp(ctx, argoCDApp, []*argoCDV1Alpha1.SyncOperationResource{}); err != nil {
+			return fmt.Errorf("syncing ArgoCD app %s: %w", argoCDApp, err)
+		}
+	}
+
+	return nil
+}
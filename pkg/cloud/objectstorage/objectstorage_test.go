@@ -0,0 +1,185 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package objectstorage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	argoCDV1Alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3Types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/aws/services/fake"
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+)
+
+// MinIO and Ceph RGW only understand path-style addressing, so the bucket must end up in the
+// URL path, and the request must be signed with the configured access key.
+func TestNewS3ClientPathStyle(t *testing.T) {
+	var gotPath, gotAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuthorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	s3Client := NewS3Client(
+		&config.S3CompatibleStorageConfig{
+			Endpoint:       server.URL,
+			Region:         "us-east-1",
+			ForcePathStyle: true,
+		},
+		&config.ObjectStorageCredentials{AccessKeyID: "minio-access", SecretAccessKey: "minio-secret"},
+	)
+
+	_, err := s3Client.HeadBucket(context.Background(), &s3.HeadBucketInput{
+		Bucket: aws.String("velero-backups"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/velero-backups", gotPath)
+	assert.Contains(t, gotAuthorization, "Credential=minio-access/")
+}
+
+// Mutates config.ParsedGeneralConfig, config.ParsedSecretsConfig — sequential only.
+func TestNewS3ClientFromConfig(t *testing.T) {
+	s3Config := &config.S3CompatibleStorageConfig{
+		Endpoint: "https://fsn1.your-objectstorage.com",
+		Region:   "fsn1",
+	}
+
+	tests := []struct {
+		name     string
+		drConfig *config.DisasterRecoveryConfig
+		creds    *config.ObjectStorageCredentials
+		errMsg   string
+	}{
+		{
+			name:     "returns error when no s3 block",
+			drConfig: &config.DisasterRecoveryConfig{},
+			creds:    &config.ObjectStorageCredentials{AccessKeyID: "a", SecretAccessKey: "s"},
+			errMsg:   "no S3-compatible object storage configured",
+		},
+		{
+			name:     "returns error when no credentials",
+			drConfig: &config.DisasterRecoveryConfig{S3: s3Config},
+			errMsg:   "no object storage credentials provided",
+		},
+		{
+			name:     "succeeds when fully configured",
+			drConfig: &config.DisasterRecoveryConfig{S3: s3Config},
+			creds:    &config.ObjectStorageCredentials{AccessKeyID: "a", SecretAccessKey: "s"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			savedGeneralConfig := config.ParsedGeneralConfig
+			savedSecretsConfig := config.ParsedSecretsConfig
+			t.Cleanup(func() {
+				config.ParsedGeneralConfig = savedGeneralConfig
+				config.ParsedSecretsConfig = savedSecretsConfig
+			})
+
+			config.ParsedGeneralConfig = &config.GeneralConfig{
+				Cloud: config.CloudConfig{DisasterRecovery: tc.drConfig},
+			}
+			config.ParsedSecretsConfig = &config.SecretsConfig{ObjectStorage: tc.creds}
+
+			s3Client, err := NewS3ClientFromConfig()
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "fsn1", s3Client.Options().Region)
+		})
+	}
+}
+
+// Mutates syncArgoCDApp, config.ParsedGeneralConfig — sequential only.
+func TestSetupDisasterRecovery(t *testing.T) {
+	drConfig := &config.DisasterRecoveryConfig{
+		VeleroBackupsBucketName:        "velero-bucket",
+		SealedSecretsBackupsBucketName: "ss-bucket",
+		S3: &config.S3CompatibleStorageConfig{
+			Endpoint: "http://minio:9000",
+			Region:   "us-east-1",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		drConfig   *config.DisasterRecoveryConfig
+		s3Client   *fake.S3API
+		syncErr    error
+		wantSynced []string
+		errMsg     string
+	}{
+		{
+			name:     "returns error when no s3 block",
+			drConfig: &config.DisasterRecoveryConfig{VeleroBackupsBucketName: "velero-bucket"},
+			s3Client: &fake.S3API{},
+			errMsg:   "no S3-compatible disaster-recovery config provided",
+		},
+		{
+			name:     "returns error when bucket creation fails",
+			drConfig: drConfig,
+			s3Client: &fake.S3API{CreateBucketErr: fmt.Errorf("access denied")},
+			errMsg:   "creating sealed-secrets backup bucket",
+		},
+		{
+			name:     "returns error when SyncArgoCDApp fails",
+			drConfig: drConfig,
+			s3Client: &fake.S3API{CreateBucketErr: &s3Types.BucketAlreadyOwnedByYou{}},
+			syncErr:  fmt.Errorf("sync timeout"),
+			errMsg:   "syncing ArgoCD app k8s-configs",
+		},
+		{
+			name:       "succeeds when the buckets already exist",
+			drConfig:   drConfig,
+			s3Client:   &fake.S3API{CreateBucketErr: &s3Types.BucketAlreadyOwnedByYou{}},
+			wantSynced: []string{"k8s-configs", "velero", "sealed-secrets"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			savedSync := syncArgoCDApp
+			savedCfg := config.ParsedGeneralConfig
+			t.Cleanup(func() {
+				syncArgoCDApp = savedSync
+				config.ParsedGeneralConfig = savedCfg
+			})
+
+			config.ParsedGeneralConfig = &config.GeneralConfig{
+				Cloud: config.CloudConfig{DisasterRecovery: tc.drConfig},
+			}
+
+			var synced []string
+			syncArgoCDApp = func(_ context.Context, name string, _ []*argoCDV1Alpha1.SyncOperationResource) error {
+				synced = append(synced, name)
+				return tc.syncErr
+			}
+
+			err := SetupDisasterRecovery(context.Background(), tc.s3Client)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantSynced, synced)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "strings"
+
+// The ACME DNS-01 solver and cluster.dnsRecords can manage records in the
+// same DNS provider. Rather than having the operator repeat the nameserver,
+// TSIG key and credentials, the solver's fall back to cluster.dnsRecords'
+// ones — the way cluster.dnsRecords' Cloudflare API token falls back to
+// the solver's. Both take the parsed config as arguments, so parser
+// validation can call them before ParsedGeneralConfig is set.
+
+// ACMEDNS01RFC2136 returns the nameserver and TSIG key the rfc2136 DNS-01
+// solver sends its updates to : cluster.acmeDNS01.rfc2136, or else
+// cluster.dnsRecords.rfc2136's. Nil when neither is set.
+func ACMEDNS01RFC2136(cluster ClusterConfig) *ACMERFC2136Config {
+	if (cluster.ACMEDNS01 != nil) && (cluster.ACMEDNS01.RFC2136 != nil) {
+		return cluster.ACMEDNS01.RFC2136
+	}
+
+	if (cluster.DNSRecords == nil) || (cluster.DNSRecords.RFC2136 == nil) {
+		return nil
+	}
+	recordsRFC2136 := cluster.DNSRecords.RFC2136
+	return &ACMERFC2136Config{
+		Nameserver:    recordsRFC2136.Nameserver,
+		TSIGKeyName:   recordsRFC2136.TSIGKeyName,
+		TSIGAlgorithm: recordsRFC2136.TSIGAlgorithm,
+	}
+}
+
+// ACMEDNS01Credentials returns secrets.yaml's acme block, with each
+// credential it leaves blank falling back to the one cluster.dnsRecords
+// uses for the same DNS provider :
+//
+//   - acme.cloudflareApiToken to dns.cloudflareApiToken.
+//
+//   - acme.hetzner to hetzner.apiToken, the Cloud API token.
+//
+//   - acme.rfc2136 to dns.tsigSecret, when the solver signs with the same
+//     TSIG key as cluster.dnsRecords.
+//
+// Never nil.
+func ACMEDNS01Credentials(cluster ClusterConfig, secretsConfig *SecretsConfig) *ACMECredentials {
+	acmeCreds := ACMECredentials{}
+	if secretsConfig.ACME != nil {
+		acmeCreds = *secretsConfig.ACME
+	}
+
+	dnsCreds := secretsConfig.DNS
+	if dnsCreds == nil {
+		dnsCreds = &DNSCredentials{}
+	}
+
+	if acmeCreds.CloudflareAPIToken == "" {
+		acmeCreds.CloudflareAPIToken = dnsCreds.CloudflareAPIToken
+	}
+
+	if (acmeCreds.Hetzner == nil) && (secretsConfig.Hetzner != nil) && (secretsConfig.Hetzner.APIToken != "") {
+		acmeCreds.Hetzner = &ACMEHetznerCredentials{APIToken: secretsConfig.Hetzner.APIToken}
+	}
+
+	if (acmeCreds.RFC2136 == nil) && (dnsCreds.TSIGSecret != "") && sharesDNSRecordsTSIGKey(cluster) {
+		acmeCreds.RFC2136 = &ACMERFC2136Credentials{TSIGSecret: dnsCreds.TSIGSecret}
+	}
+
+	return &acmeCreds
+}
+
+// sharesDNSRecordsTSIGKey reports whether the rfc2136 DNS-01 solver signs
+// its updates with cluster.dnsRecords.rfc2136's TSIG key.
+func sharesDNSRecordsTSIGKey(cluster ClusterConfig) bool {
+	if (cluster.DNSRecords == nil) || (cluster.DNSRecords.RFC2136 == nil) {
+		return false
+	}
+
+	acmeRFC2136 := ACMEDNS01RFC2136(cluster)
+	if acmeRFC2136 == nil {
+		return false
+	}
+
+	normalize := func(keyName string) string {
+		return strings.TrimSuffix(strings.ToLower(keyName), ".")
+	}
+	return normalize(acmeRFC2136.TSIGKeyName) == normalize(cluster.DNSRecords.RFC2136.TSIGKeyName)
+}
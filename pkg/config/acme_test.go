@@ -0,0 +1,94 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+)
+
+// TestACMEDNS01FallsBackToDNSRecords verifies the ACME DNS-01 solver reuses
+// cluster.dnsRecords' nameserver, TSIG key and credentials for whatever its
+// own config leaves blank, and only hands it dns.tsigSecret for the TSIG
+// key that secret belongs to.
+func TestACMEDNS01FallsBackToDNSRecords(t *testing.T) {
+	dnsRecords := &config.DNSRecordsConfig{
+		Provider: "rfc2136",
+		RFC2136: &config.RFC2136Config{
+			Nameserver:    "ns1.acme.com",
+			Zone:          "acme.com",
+			TSIGKeyName:   "kubeaid",
+			TSIGAlgorithm: "hmac-sha512",
+		},
+	}
+	secretsConfig := &config.SecretsConfig{
+		DNS:     &config.DNSCredentials{CloudflareAPIToken: "cf-token", TSIGSecret: "c2VjcmV0"},
+		Hetzner: &config.HetznerCredentials{APIToken: "hcloud"},
+	}
+
+	t.Run("blank solver settings reuse cluster.dnsRecords'", func(t *testing.T) {
+		cluster := config.ClusterConfig{
+			ACMEDNS01:  &config.ACMEDNS01Config{Provider: "rfc2136"},
+			DNSRecords: dnsRecords,
+		}
+
+		assert.Equal(t, &config.ACMERFC2136Config{
+			Nameserver:    "ns1.acme.com",
+			TSIGKeyName:   "kubeaid",
+			TSIGAlgorithm: "hmac-sha512",
+		}, config.ACMEDNS01RFC2136(cluster))
+
+		acmeCreds := config.ACMEDNS01Credentials(cluster, secretsConfig)
+		assert.Equal(t, "cf-token", acmeCreds.CloudflareAPIToken)
+		require.NotNil(t, acmeCreds.Hetzner)
+		assert.Equal(t, "hcloud", acmeCreds.Hetzner.APIToken)
+		require.NotNil(t, acmeCreds.RFC2136)
+		assert.Equal(t, "c2VjcmV0", acmeCreds.RFC2136.TSIGSecret)
+	})
+
+	t.Run("solver settings take precedence", func(t *testing.T) {
+		acmeRFC2136 := &config.ACMERFC2136Config{Nameserver: "ns2.acme.com", TSIGKeyName: "KubeAid."}
+		cluster := config.ClusterConfig{
+			ACMEDNS01:  &config.ACMEDNS01Config{Provider: "rfc2136", RFC2136: acmeRFC2136},
+			DNSRecords: dnsRecords,
+		}
+		assert.Same(t, acmeRFC2136, config.ACMEDNS01RFC2136(cluster))
+
+		acmeCreds := config.ACMEDNS01Credentials(cluster, &config.SecretsConfig{
+			ACME: &config.ACMECredentials{CloudflareAPIToken: "acme-token"},
+			DNS:  secretsConfig.DNS,
+		})
+		assert.Equal(t, "acme-token", acmeCreds.CloudflareAPIToken)
+
+		// Key names compare case insensitively, with or without the root.
+		require.NotNil(t, acmeCreds.RFC2136)
+		assert.Equal(t, "c2VjcmV0", acmeCreds.RFC2136.TSIGSecret)
+	})
+
+	t.Run("dns.tsigSecret isn't handed to another TSIG key", func(t *testing.T) {
+		cluster := config.ClusterConfig{
+			ACMEDNS01: &config.ACMEDNS01Config{
+				Provider: "rfc2136",
+				RFC2136:  &config.ACMERFC2136Config{Nameserver: "ns1.acme.com", TSIGKeyName: "cert-manager."},
+			},
+			DNSRecords: dnsRecords,
+		}
+		assert.Nil(t, config.ACMEDNS01Credentials(cluster, secretsConfig).RFC2136)
+	})
+
+	t.Run("nothing to fall back to", func(t *testing.T) {
+		cluster := config.ClusterConfig{ACMEDNS01: &config.ACMEDNS01Config{Provider: "rfc2136"}}
+		assert.Nil(t, config.ACMEDNS01RFC2136(cluster))
+
+		acmeCreds := config.ACMEDNS01Credentials(cluster, &config.SecretsConfig{})
+		require.NotNil(t, acmeCreds)
+		assert.Empty(t, acmeCreds.CloudflareAPIToken)
+		assert.Nil(t, acmeCreds.Hetzner)
+		assert.Nil(t, acmeCreds.RFC2136)
+	})
+}
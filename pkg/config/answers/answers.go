@@ -0,0 +1,200 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+// Package answers holds the operator's decisions for every point where a lifecycle command
+// would otherwise stop and ask : given via --answers-file, they let `cluster bootstrap`,
+// `cluster upgrade` and `cluster sync` run from a CI pipeline.
+//
+// With --non-interactive, an approval point the answers file says nothing about fails the run
+// right away, naming the missing decision, instead of waiting on a terminal that isn't there.
+// Without it, answered decisions still skip their prompt and the rest get asked as usual.
+package answers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
+)
+
+// Decision names an approval point, by its key in the answers file.
+type Decision string
+
+const (
+	// DecisionSSHPrivateKeyPath : path of the SSH private key, for a key pair general.yaml
+	// names no privateKeyFilePath for.
+	DecisionSSHPrivateKeyPath Decision = "sshPrivateKeyPath"
+
+	// DecisionApproveStoragePlans : whether to go ahead with the computed Hetzner bare-metal
+	// storage plans.
+	DecisionApproveStoragePlans Decision = "approveStoragePlans"
+
+	// DecisionRemoveBlockingPDBs : whether to temporarily remove the PodDisruptionBudgets which
+	// would deadlock a single-node upgrade's drain.
+	DecisionRemoveBlockingPDBs Decision = "removeBlockingPDBs"
+
+	// DecisionLockdown : whether to apply the host firewall lockdown, when general.yaml leaves
+	// cluster.lockdown unset.
+	DecisionLockdown Decision = "lockdown"
+
+	// DecisionNetBirdOperatorToken : what to do when the NetBird operator's API-key Secret is
+	// missing - wait for it to appear, or defer the NetBird setup (and lockdown).
+	DecisionNetBirdOperatorToken Decision = "netbirdOperatorToken"
+
+	// DecisionSyncCluster : whether to go ahead with `cluster sync`. Same as passing --yes.
+	DecisionSyncCluster Decision = "syncCluster"
+
+	// DecisionReconcileKubeletFlags : whether `cluster sync` rolls the nodes whose kubelet
+	// flags drifted from general.yaml.
+	DecisionReconcileKubeletFlags Decision = "reconcileKubeletFlags"
+
+	// DecisionRotateMachines : whether `cluster sync` replaces the Cluster API machines whose
+	// instance type / root volume drifted from general.yaml.
+	DecisionRotateMachines Decision = "rotateMachines"
+)
+
+// Accepted values of DecisionNetBirdOperatorToken. Pasting the token isn't one : unattended
+// runs supply it via secrets.yaml (netbird.apiKey).
+const (
+	NetBirdOperatorTokenWait  = "wait"
+	NetBirdOperatorTokenDefer = "defer"
+)
+
+// ErrMissingDecision is returned, wrapped, when a non-interactive run reaches an approval
+// point the answers file has no decision for.
+var ErrMissingDecision = errors.New("missing decision in answers file")
+
+// Answers is the answers file's schema. Unset decisions are left for the operator.
+type Answers struct {
+	SSHPrivateKeyPath     string `yaml:"sshPrivateKeyPath"`
+	ApproveStoragePlans   *bool  `yaml:"approveStoragePlans"`
+	RemoveBlockingPDBs    *bool  `yaml:"removeBlockingPDBs"`
+	Lockdown              *bool  `yaml:"lockdown"`
+	NetBirdOperatorToken  string `yaml:"netbirdOperatorToken"`
+	SyncCluster           *bool  `yaml:"syncCluster"`
+	ReconcileKubeletFlags *bool  `yaml:"reconcileKubeletFlags"`
+	RotateMachines        *bool  `yaml:"rotateMachines"`
+
+	nonInteractive bool
+}
+
+// Parsed holds this run's answers, loaded once in cmd/kubeaid-core/root/root.go. Nil means an
+// interactive run without an answers file : every approval point asks the operator.
+var Parsed *Answers
+
+// Load reads the answers file at path (if any), for a run which is non-interactive or not.
+// Unknown keys are rejected, so a typo surfaces here rather than as a missing decision an hour
+// into a bootstrap.
+func Load(path string, nonInteractive bool) (*Answers, error) {
+	answers := &Answers{}
+
+	if path != "" {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading answers file: %w", err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(contents))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(answers); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parsing answers file %s: %w", path, err)
+		}
+
+		validNetBirdOperatorTokenValues := []string{"", NetBirdOperatorTokenWait, NetBirdOperatorTokenDefer}
+		if !slices.Contains(validNetBirdOperatorTokenValues, answers.NetBirdOperatorToken) {
+			return nil, fmt.Errorf("answers file %s: %s must be %q or %q, not %q",
+				path, DecisionNetBirdOperatorToken,
+				NetBirdOperatorTokenWait, NetBirdOperatorTokenDefer, answers.NetBirdOperatorToken,
+			)
+		}
+	}
+
+	answers.nonInteractive = nonInteractive
+	return answers, nil
+}
+
+// NonInteractive reports whether this run must never prompt.
+func (a *Answers) NonInteractive() bool {
+	return (a != nil) && a.nonInteractive
+}
+
+// Confirm resolves a yes / no approval point.
+//
+// answered=false (with a nil error) means the answers file has no say on it and the run is
+// interactive : the caller should ask the operator. A non-interactive run gets an
+// ErrMissingDecision instead.
+func (a *Answers) Confirm(decision Decision) (proceed, answered bool, err error) {
+	var value *bool
+	if a != nil {
+		switch decision {
+		case DecisionApproveStoragePlans:
+			value = a.ApproveStoragePlans
+
+		case DecisionRemoveBlockingPDBs:
+			value = a.RemoveBlockingPDBs
+
+		case DecisionLockdown:
+			value = a.Lockdown
+
+		case DecisionSyncCluster:
+			value = a.SyncCluster
+
+		case DecisionReconcileKubeletFlags:
+			value = a.ReconcileKubeletFlags
+
+		case DecisionRotateMachines:
+			value = a.RotateMachines
+
+		default:
+			return false, false, fmt.Errorf("%s isn't a yes / no decision", decision)
+		}
+	}
+
+	if value != nil {
+		return *value, true, nil
+	}
+	return false, false, a.missing(decision)
+}
+
+// Choose resolves an approval point whose answer is a string, the same way Confirm does.
+func (a *Answers) Choose(decision Decision) (choice string, answered bool, err error) {
+	if a != nil {
+		switch decision {
+		case DecisionSSHPrivateKeyPath:
+			choice = a.SSHPrivateKeyPath
+
+		case DecisionNetBirdOperatorToken:
+			choice = a.NetBirdOperatorToken
+
+		default:
+			return "", false, fmt.Errorf("%s isn't a choice", decision)
+		}
+	}
+
+	if choice != "" {
+		return choice, true, nil
+	}
+	return "", false, a.missing(decision)
+}
+
+// missing returns the error for an unanswered decision : nil for interactive runs, which just
+// ask. The prompt blocks the run, so that gets reported on the JSON event stream.
+func (a *Answers) missing(decision Decision) error {
+	if !a.NonInteractive() {
+		events.Emit(events.Event{
+			Type:     events.TypeWaitingForApproval,
+			Message:  "Waiting for the operator's decision",
+			Approval: &events.Approval{Decision: string(decision)},
+		})
+		return nil
+	}
+	return fmt.Errorf("%w : running non-interactively, so set %s in the answers file (--answers-file)",
+		ErrMissingDecision, decision,
+	)
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package answers
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
+)
+
+func writeAnswersFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		wantErr  string
+	}{
+		{
+			name: "all decisions",
+			contents: `sshPrivateKeyPath: ~/.ssh/id_ed25519
+approveStoragePlans: true
+removeBlockingPDBs: false
+lockdown: true
+netbirdOperatorToken: defer
+syncCluster: true
+reconcileKubeletFlags: false
+rotateMachines: true
+`,
+		},
+		{name: "empty file"},
+		{
+			name:     "unknown key",
+			contents: "lockDown: true\n",
+			wantErr:  "field lockDown not found",
+		},
+		{
+			name:     "invalid NetBird operator token choice",
+			contents: "netbirdOperatorToken: paste\n",
+			wantErr:  `netbirdOperatorToken must be "wait" or "defer", not "paste"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load(writeAnswersFile(t, tc.contents), true)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := Load(filepath.Join(t.TempDir(), "answers.yaml"), true)
+		require.Error(t, err)
+	})
+}
+
+// Mutates the events package's sinks — sequential only.
+func TestConfirm(t *testing.T) {
+	var stream bytes.Buffer
+	t.Cleanup(events.AddSink(&stream))
+
+	answers, err := Load(writeAnswersFile(t, "lockdown: false\n"), true)
+	require.NoError(t, err)
+
+	proceed, answered, err := answers.Confirm(DecisionLockdown)
+	require.NoError(t, err)
+	assert.True(t, answered)
+	assert.False(t, proceed)
+
+	_, answered, err = answers.Confirm(DecisionRemoveBlockingPDBs)
+	require.ErrorIs(t, err, ErrMissingDecision)
+	assert.ErrorContains(t, err, "set removeBlockingPDBs in the answers file")
+	assert.False(t, answered)
+
+	// Interactive runs, with or without an answers file, leave unanswered decisions to the
+	// operator.
+	interactive, err := Load(writeAnswersFile(t, "lockdown: true\n"), false)
+	require.NoError(t, err)
+
+	for _, answers := range []*Answers{interactive, nil} {
+		stream.Reset()
+
+		_, answered, err = answers.Confirm(DecisionRemoveBlockingPDBs)
+		require.NoError(t, err)
+		assert.False(t, answered)
+
+		// The run now blocks on the operator.
+		var event events.Event
+		require.NoError(t, json.Unmarshal(stream.Bytes(), &event))
+		assert.Equal(t, events.TypeWaitingForApproval, event.Type)
+		assert.Equal(t, &events.Approval{Decision: string(DecisionRemoveBlockingPDBs)}, event.Approval)
+	}
+
+	proceed, answered, err = interactive.Confirm(DecisionLockdown)
+	require.NoError(t, err)
+	assert.True(t, answered)
+	assert.True(t, proceed)
+}
+
+func TestChoose(t *testing.T) {
+	answers, err := Load(writeAnswersFile(t, "netbirdOperatorToken: wait\n"), true)
+	require.NoError(t, err)
+
+	choice, answered, err := answers.Choose(DecisionNetBirdOperatorToken)
+	require.NoError(t, err)
+	assert.True(t, answered)
+	assert.Equal(t, NetBirdOperatorTokenWait, choice)
+
+	_, _, err = answers.Choose(DecisionSSHPrivateKeyPath)
+	require.ErrorIs(t, err, ErrMissingDecision)
+
+	_, _, err = answers.Choose(DecisionLockdown)
+	require.Error(t, err)
+}
@@ -35,11 +35,22 @@ const generalFileName = "general.yaml"
 
 // For returns where clusterName's config lives.
 func For(clusterName string) (string, error) {
+	root, err := Root(clusterName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, configsSubdir), nil
+}
+
+// Root returns clusterName's root directory, which its config directory sits
+// under. Per-cluster state that must stay out of the working directory, but
+// isn't config, lives here.
+func Root(clusterName string) (string, error) {
 	base, err := os.UserConfigDir()
 	if err != nil {
 		return "", fmt.Errorf("locating the user config directory: %w", err)
 	}
-	return filepath.Join(base, dirName, clusterName, configsSubdir), nil
+	return filepath.Join(base, dirName, clusterName), nil
 }
 
 // List returns the names of every cluster with a config on disk, sorted.
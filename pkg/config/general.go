@@ -66,11 +66,28 @@ type (
 		// set" and falls back to kubeaid-cli's own version, same as
 		// omitting the parent block.
 		Version string `yaml:"version"`
+
+		// ExcludeCeph leaves the disk space left over after the OS volume
+		// and the ZFS pool unallocated on every bare-metal node, instead
+		// of handing it to Rook Ceph — rendered into the chart as
+		// `global.kubeaidStoragectl.excludeCeph`, for the node's
+		// `kubeaid-storagectl plan execute --exclude-ceph`. Rook Ceph then
+		// doesn't get deployed either.
+		ExcludeCeph bool `yaml:"excludeCeph"`
+
+		// AllowWipeWWNs lists the WWNs of bare-metal disks the node's
+		// `kubeaid-storagectl plan execute` may wipe, when its preflight
+		// finds a filesystem, LVM, mdraid or foreign ZFS pool signature on
+		// them. Any other disk carrying data makes the storage plan
+		// execution fail, instead of partitioning it. Rendered into the
+		// chart as `global.kubeaidStoragectl.allowWipeWWNs`.
+		AllowWipeWWNs []string `yaml:"allowWipeWWNs" validate:"dive,notblank"`
 	}
 
 	// Git specific details, used by KubeAid CLI,
 	// to clone repositories from and push changes to the Git server.
-	// We enforce the user to use SSH, for authenticating to the Git server.
+	// KubeAid CLI authenticates over SSH, unless the KubeAid Config repository URL is an HTTP(S)
+	// one : then it authenticates over HTTPS (see https).
 	GitConfig struct {
 		CABundlePath string `yaml:"caBundlePath"`
 		CABundle     []byte
@@ -87,6 +104,52 @@ type (
 		// Additional SSH known hosts.
 		// Merged with known hosts of common Git repo hosting providers (like Azure DevOps, GitLab etc.)
 		KnownHosts []string `yaml:"knownHosts"`
+
+		// HTTPS authentication, used when the KubeAid Config repository URL is an HTTP(S) one :
+		// for CI runners and networks which only allow HTTPS egress. Unless a GitHub App or the
+		// git credential helper is configured here, KubeAid CLI authenticates with the access token
+		// in secrets.yaml (git.token), or else the KUBEAID_CLI_GIT_TOKEN environment variable. And
+		// anonymously, when there's neither.
+		// The credentials are only ever sent to the KubeAid Config repository's host.
+		HTTPS *GitHTTPSConfig `yaml:"https"`
+
+		// Forge (GitHub, GitLab or Gitea) hosting the KubeAid Config repository.
+		// When an API token is provided in secrets.yaml (git.forgeToken), KubeAid CLI opens the
+		// pull request for you, and polls the forge until it gets merged, instead of waiting for
+		// you to press ENTER. Only needed when the forge can't be detected from the repository
+		// host (github.com, gitlab.com, or a hostname containing 'gitlab' / 'gitea').
+		Forge *ForgeConfig `yaml:"forge"`
+	}
+
+	GitHTTPSConfig struct {
+		// Delegate to the git credential helper configured for the KubeAid Config repository's
+		// host (git config credential.helper), like git-credential-manager or
+		// gh auth git-credential : KubeAid CLI runs `git credential fill` to get the credentials.
+		UseCredentialHelper bool `yaml:"useCredentialHelper"`
+
+		// Authenticate as a GitHub App installation. Its installation access tokens get minted
+		// using the GitHub App's private key (secrets.yaml : git.githubAppPrivateKey), and
+		// refreshed before they expire. git.forge.apiURL is honoured, for GitHub Enterprise.
+		GitHubApp *GitHubAppConfig `yaml:"githubApp"`
+	}
+
+	GitHubAppConfig struct {
+		// GitHub App ID.
+		AppID int64 `yaml:"appID" validate:"required,gt=0"`
+
+		// ID of the GitHub App's installation, on the organization or user owning the KubeAid
+		// Config repository.
+		InstallationID int64 `yaml:"installationID" validate:"required,gt=0"`
+	}
+
+	ForgeConfig struct {
+		// Forge type.
+		Type string `yaml:"type" validate:"omitempty,oneof=github gitlab gitea"`
+
+		// Base URL of the forge's REST API, when it doesn't live at the default location :
+		// https://api.github.com for github.com, https://<host>/api/v3 for GitHub Enterprise,
+		// https://<host>/api/v4 for GitLab and https://<host>/api/v1 for Gitea.
+		APIURL string `yaml:"apiURL" validate:"omitempty,url"`
 	}
 
 	// KubeAid and KubeAid Config repository specific details.
@@ -111,7 +174,7 @@ type (
 
 	// KubeAid Config repository specific details.
 	KubeaidConfigForkConfig struct {
-		// KubeAid Config repository SSH URL.
+		// KubeAid Config repository SSH or HTTP(S) URL.
 		URL       string `yaml:"url" validate:"required"`
 		ParsedURL *urlprotocol.Parsed
 
@@ -156,9 +219,17 @@ type (
 		// the mesh — Let's Encrypt can never reach them over HTTP, but
 		// proves ownership via a TXT record on the public zone instead.
 		// Requires cluster.acmeEmail plus the provider credential in
-		// secrets.yaml (acme.cloudflareApiToken).
+		// secrets.yaml's acme block.
 		ACMEDNS01 *ACMEDNS01Config `yaml:"acmeDNS01"`
 
+		// DNSRecords lets KubeAid CLI create the A records of the
+		// cluster's public endpoints (control-plane LB hostname,
+		// keycloak.dns, netbird.dns, netbird.stunDNS / turnDNS) in your
+		// DNS provider itself, and delete them on `cluster delete`.
+		// Bootstrap then only waits for the records to propagate.
+		// When not set, bootstrap waits for you to create them.
+		DNSRecords *DNSRecordsConfig `yaml:"dnsRecords"`
+
 		// Configuration options for the Kubernetes API server.
 		APIServer APIServerConfig `yaml:"apiServer"`
 
@@ -196,16 +267,171 @@ type (
 	}
 
 	// ACMEDNS01Config selects and scopes the ClusterIssuer's DNS-01
-	// solver. Only Cloudflare is wired today (the chart's solver list
-	// also knows route53; extend Provider's oneof when kubeaid-cli
-	// grows the matching credential plumbing).
+	// solver.
 	ACMEDNS01Config struct {
-		Provider string `yaml:"provider" default:"cloudflare" validate:"oneof=cloudflare"`
+		// Provider is one of :
+		//
+		//   cloudflare : authenticates with secrets.yaml's
+		//   acme.cloudflareApiToken (or dns.cloudflareApiToken).
+		//
+		//   route53 : AWS Route53. Configured in route53, authenticates
+		//   with secrets.yaml's acme.route53.
+		//
+		//   azuredns : Azure DNS. Configured in azureDNS, authenticates
+		//   with secrets.yaml's acme.azureDNS.
+		//
+		//   hetzner : Hetzner DNS, through a cert-manager webhook you
+		//   install beforehand (see hetzner). Configured in hetzner,
+		//   authenticates with secrets.yaml's acme.hetzner (or
+		//   hetzner.apiToken).
+		//
+		//   rfc2136 : any nameserver accepting TSIG signed dynamic
+		//   updates (BIND, Knot, PowerDNS). Configured in rfc2136 (or
+		//   cluster.dnsRecords.rfc2136), authenticates with secrets.yaml's
+		//   acme.rfc2136 (or dns.tsigSecret, for the same TSIG key).
+		Provider string `yaml:"provider" default:"cloudflare" validate:"oneof=cloudflare route53 azuredns hetzner rfc2136"`
 
 		// DNSZones limits which zones this solver answers challenges
 		// for (cert-manager's selector.dnsZones). Empty matches every
 		// DNS-01 order — fine when this is the only solver.
 		DNSZones []string `yaml:"dnsZones"`
+
+		// Route53 is required when provider is route53.
+		Route53 *ACMERoute53Config `yaml:"route53"`
+
+		// AzureDNS is required when provider is azuredns.
+		AzureDNS *ACMEAzureDNSConfig `yaml:"azureDNS"`
+
+		// Hetzner is required when provider is hetzner.
+		Hetzner *ACMEHetznerConfig `yaml:"hetzner"`
+
+		// RFC2136 is required when provider is rfc2136, unless
+		// cluster.dnsRecords.rfc2136 already points to the nameserver.
+		RFC2136 *ACMERFC2136Config `yaml:"rfc2136"`
+	}
+
+	// ACMERoute53Config is the non-secret part of cert-manager's
+	// Route53 DNS-01 solver. The IAM user's access key pair goes in
+	// secrets.yaml's acme.route53.
+	ACMERoute53Config struct {
+		// Region of the Route53 API endpoint (e.g. us-east-1).
+		Region string `yaml:"region" validate:"notblank"`
+
+		// HostedZoneID pins the hosted zone the TXT records go in.
+		// When omitted, cert-manager looks it up, which needs
+		// route53:ListHostedZonesByName.
+		HostedZoneID string `yaml:"hostedZoneID"`
+
+		// Role is the ARN of an IAM role to assume, when the access key
+		// pair can't manage the hosted zone itself.
+		Role string `yaml:"role"`
+	}
+
+	// ACMEAzureDNSConfig is the non-secret part of cert-manager's
+	// Azure DNS DNS-01 solver. The service principal's client secret
+	// goes in secrets.yaml's acme.azureDNS.
+	ACMEAzureDNSConfig struct {
+		SubscriptionID    string `yaml:"subscriptionID"    validate:"notblank"`
+		ResourceGroupName string `yaml:"resourceGroupName" validate:"notblank"`
+
+		// HostedZoneName pins the DNS zone the TXT records go in.
+		// When omitted, cert-manager derives it from the challenged
+		// domain.
+		HostedZoneName string `yaml:"hostedZoneName"`
+
+		// TenantID and ClientID identify the service principal, which
+		// needs the DNS Zone Contributor role on the zone.
+		TenantID string `yaml:"tenantID" validate:"notblank"`
+		ClientID string `yaml:"clientID" validate:"notblank"`
+
+		Environment string `yaml:"environment" default:"AzurePublicCloud" validate:"oneof=AzurePublicCloud AzureChinaCloud AzureGermanCloud AzureUSGovernmentCloud"`
+	}
+
+	// ACMEHetznerConfig points the DNS-01 solver to a cert-manager
+	// webhook for Hetzner DNS. KubeAid CLI doesn't install the webhook :
+	// install it in the cert-manager namespace beforehand, with RBAC to
+	// read the cert-manager/hetzner-dns-api-token Secret (the API token,
+	// under the api-key key). The solver passes it the secretName,
+	// zoneName and apiUrl config. The API token goes in secrets.yaml's
+	// acme.hetzner, or else hetzner.apiToken is used.
+	ACMEHetznerConfig struct {
+		// GroupName the webhook got installed with (its groupName Helm
+		// value).
+		GroupName string `yaml:"groupName" validate:"notblank"`
+
+		// SolverName the webhook registers.
+		SolverName string `yaml:"solverName" default:"hetzner" validate:"notblank"`
+
+		// ZoneName is the Hetzner DNS zone the TXT records go in
+		// (e.g. example.com).
+		ZoneName string `yaml:"zoneName" validate:"notblank"`
+
+		// APIURL of the Hetzner API managing the zone : the Cloud API,
+		// which cluster.dnsRecords uses too. Hetzner DNS zones not yet
+		// migrated to the Cloud Console are still served by the legacy
+		// DNS API (https://dns.hetzner.com/api/v1).
+		APIURL string `yaml:"apiURL" default:"https://api.hetzner.cloud/v1" validate:"url"`
+	}
+
+	// ACMERFC2136Config points the DNS-01 solver to the nameserver
+	// accepting dynamic updates for the challenged zones. The TSIG
+	// secret goes in secrets.yaml's acme.rfc2136, or else dns.tsigSecret
+	// is used, when the TSIG key is cluster.dnsRecords.rfc2136's.
+	ACMERFC2136Config struct {
+		// Nameserver is the primary nameserver's host:port (port 53
+		// when omitted). It must be reachable from the cluster.
+		Nameserver string `yaml:"nameserver" validate:"notblank"`
+
+		// TSIGKeyName is the name of the TSIG key the updates get
+		// signed with.
+		TSIGKeyName string `yaml:"tsigKeyName" validate:"notblank"`
+
+		// TSIGAlgorithm the TSIG key uses.
+		TSIGAlgorithm string `yaml:"tsigAlgorithm" default:"hmac-sha256" validate:"oneof=hmac-sha256 hmac-sha512"`
+	}
+
+	// DNSRecordsConfig selects the DNS provider KubeAid CLI manages
+	// the cluster's A records in. Each record gets claimed for the
+	// cluster by a TXT record at _kubeaid-cli.<fqdn>. Records
+	// KubeAid CLI didn't create are never modified or deleted.
+	DNSRecordsConfig struct {
+		// Provider is one of :
+		//
+		//   cloudflare : authenticates with secrets.yaml's
+		//   dns.cloudflareApiToken (or acme.cloudflareApiToken), which
+		//   needs Zone:Read + DNS:Edit on the zones.
+		//
+		//   hetzner : Hetzner DNS, through the Cloud API. Authenticates
+		//   with secrets.yaml's hetzner.apiToken.
+		//
+		//   rfc2136 : any nameserver accepting TSIG signed dynamic
+		//   updates (BIND, Knot, PowerDNS). Configured in rfc2136.
+		Provider string `yaml:"provider" validate:"notblank,oneof=cloudflare hetzner rfc2136"`
+
+		// TTL (in seconds) of the created records.
+		TTL int `yaml:"ttl" default:"300" validate:"gte=60"`
+
+		// RFC2136 is required when provider is rfc2136.
+		RFC2136 *RFC2136Config `yaml:"rfc2136"`
+	}
+
+	// RFC2136Config points to the nameserver accepting dynamic updates
+	// for the zone the cluster's FQDNs live in.
+	RFC2136Config struct {
+		// Nameserver is the primary nameserver's host:port (port 53
+		// when omitted).
+		Nameserver string `yaml:"nameserver" validate:"notblank"`
+
+		// Zone every managed FQDN belongs to (e.g. example.com).
+		Zone string `yaml:"zone" validate:"notblank"`
+
+		// TSIGKeyName is the name of the TSIG key the updates get
+		// signed with. Its secret goes in secrets.yaml's
+		// dns.tsigSecret.
+		TSIGKeyName string `yaml:"tsigKeyName" validate:"notblank"`
+
+		// TSIGAlgorithm the TSIG key uses.
+		TSIGAlgorithm string `yaml:"tsigAlgorithm" default:"hmac-sha256" validate:"oneof=hmac-sha256 hmac-sha512"`
 	}
 
 	// SecurityConfig selects the optional security ArgoCD Apps. Both
@@ -251,7 +477,7 @@ type (
 	// NetBird's SSO IdP. The parser derives the Realm from DNS when unset
 	// and validates the combination against cluster.type. The admin
 	// password is generated by kubeaid-cli at bootstrap and never lives in
-	// this struct or in secrets.yaml; only Mode/DNS/Realm are user-facing.
+	// this struct or in secrets.yaml.
 	KeycloakConfig struct {
 		// Mode is "managed" (kubeaid-cli installs Keycloak via the
 		// keycloakx Helm chart on this cluster — VPN clusters only)
@@ -273,6 +499,50 @@ type (
 		//   keycloak.foo.co.uk     → "foo"
 		// Set this explicitly to override the derivation.
 		Realm string `yaml:"realm"`
+
+		// Users are the engineers kubeaid-cli keeps in the realm, so
+		// they can be onboarded and offboarded through a kubeaid-config
+		// PR. Missing users are created and drifted profiles updated;
+		// credentials are left to the admin console. Managed mode only.
+		Users []KeycloakUserConfig `yaml:"users" validate:"dive"`
+
+		// Groups are the realm's top-level groups. Users' memberships of
+		// these groups follow their users[].groups, and each group's
+		// clusterRoles are bound to it through rendered
+		// ClusterRoleBindings — which the NetBird clusterProxy honours,
+		// once NetBird's JWT group sync imports the group. In external
+		// mode the groups aren't created, only bound.
+		Groups []KeycloakGroupConfig `yaml:"groups" validate:"dive"`
+
+		// Prune deletes the realm's users and groups kubeaid-cli created
+		// which are no longer listed above, turning the lists into the
+		// realm's source of truth. Users and groups created by other
+		// means (the admin console, NetBird, LDAP / Kerberos federation,
+		// service accounts) are never deleted.
+		Prune bool `yaml:"prune"`
+	}
+
+	// KeycloakUserConfig declares a user in the Keycloak realm.
+	KeycloakUserConfig struct {
+		Username string `yaml:"username" validate:"required,notblank"`
+
+		Email     string `yaml:"email" validate:"omitempty,email"`
+		FirstName string `yaml:"firstName"`
+		LastName  string `yaml:"lastName"`
+
+		// Groups are the names of the cluster.keycloak.groups the user is
+		// a member of.
+		Groups []string `yaml:"groups"`
+	}
+
+	// KeycloakGroupConfig declares a top-level group in the Keycloak
+	// realm.
+	KeycloakGroupConfig struct {
+		Name string `yaml:"name" validate:"required,notblank"`
+
+		// ClusterRoles are the ClusterRoles (like cluster-admin, edit or
+		// view) bound to the group's members, cluster-wide.
+		ClusterRoles []string `yaml:"clusterRoles" validate:"dive,notblank"`
 	}
 
 	// NetBirdConfig describes this cluster's relationship to the NetBird
@@ -411,6 +681,26 @@ type (
 	DisasterRecoveryConfig struct {
 		VeleroBackupsBucketName        string `yaml:"veleroBackupsBucketName"`
 		SealedSecretsBackupsBucketName string `yaml:"sealedSecretsBackupsBucketName"`
+
+		// S3 points the backups at an S3-compatible object storage (Hetzner Object Storage,
+		// MinIO, Ceph RGW). Required on Hetzner and Bare Metal clusters, which have no native
+		// object storage KubeAid CLI can provision. Must be left unset on AWS and Azure.
+		// The access key pair comes from secrets.yaml (objectStorage).
+		S3 *S3CompatibleStorageConfig `yaml:"s3"`
+	}
+
+	S3CompatibleStorageConfig struct {
+		// Endpoint URL of the object storage, e.g. https://fsn1.your-objectstorage.com or
+		// http://minio.example.com:9000.
+		Endpoint string `yaml:"endpoint" validate:"required,url"`
+
+		// Region the buckets get created in. Most S3-compatible implementations ignore it,
+		// but the request signature still needs one.
+		Region string `yaml:"region" default:"us-east-1" validate:"notblank"`
+
+		// Address buckets as <endpoint>/<bucket> instead of <bucket>.<endpoint>.
+		// Needed for MinIO and Ceph RGW, unless they're set up with wildcard DNS.
+		ForcePathStyle bool `yaml:"forcePathStyle"`
 	}
 
 	SSHKeyPairConfig struct {
@@ -433,11 +723,20 @@ type (
 		// private keys never need to be exported.
 		UseSSHAgent bool `yaml:"useSSHAgent"`
 
+		// PrivateKey is the hydrated private key, OpenSSH PEM encoded. Passphrase protected keys
+		// get unlocked (see pkg/utils/sshkey) and stored without the passphrase, so every SSH
+		// consumer can use it as is.
+		//
 		//nolint:gosec // This struct intentionally stores hydrated SSH key material.
 		PrivateKey,
 
 		PublicKey,
 		Fingerprint string
+
+		// PassphraseProtected is true when the private key file is passphrase protected. KubeOne
+		// reads the private key file itself, so it gets pointed at the in-process SSH agent
+		// serving the unlocked key instead.
+		PassphraseProtected bool
 	}
 
 	KubePrometheusConfig struct {
@@ -637,9 +936,10 @@ type (
 		Firewall FirewallConfig `yaml:"firewall"`
 
 		// ZFS specific configuration.
-		// Every node runs a ZFS pool, named primary. We carve out storage for container images, pod
-		// logs and pod ephemeral volumes from that ZFS pool, as required.
-		// The ZFS pool has RAIDZ-1 enabled, which means it can survive single disk failure.
+		// Every node runs a ZFS pool, named primary. We carve out datasets for container images, pod
+		// logs and pod ephemeral volumes (or the ones you declare) from that ZFS pool, as required.
+		// By default, the ZFS pool is a mirror across 2 disks, which means it can survive single
+		// disk failure.
 		ZFS ZFSConfig `yaml:"zfs" validate:"required"`
 
 		// Details about the VSwitch which'll be used to connect the Hetzner Bare Metal servers with
@@ -793,9 +1093,10 @@ type (
 		BareMetalHosts []*HetznerBareMetalHost `yaml:"bareMetalHosts" validate:"required,gt=0"`
 
 		// ZFS specific configuration.
-		// Every node runs a ZFS pool, named primary. We carve out storage for container images, pod
-		// logs and pod ephemeral volumes from that ZFS pool, as required.
-		// The ZFS pool has RAIDZ-1 enabled, which means it can survive single disk failure.
+		// Every node runs a ZFS pool, named primary. We carve out datasets for container images, pod
+		// logs and pod ephemeral volumes (or the ones you declare) from that ZFS pool, as required.
+		// By default, the ZFS pool is a mirror across 2 disks, which means it can survive single
+		// disk failure.
 		ZFS ZFSConfig `yaml:"zfs" validate:"required"`
 
 		StoragePlan storagetypes.StoragePlan `yaml:"-"`
@@ -805,15 +1106,37 @@ type (
 		ServerID  string `yaml:"serverID"  validate:"notblank"`
 		PrivateIP string `yaml:"privateIP" validate:"ipv4"`
 		WWNs      []string
+
+		// Disks overrides which of the server's disks the storage planner considers, when its
+		// choice is wrong : include lists the only disks to consider (even ones of a type it
+		// doesn't recognise), and exclude the ones to leave alone. Disks are referred to by name
+		// (like sda), WWN or serial.
+		Disks *storagetypes.DiskSelection `yaml:"disks,omitempty"`
 	}
 
 	ZFSConfig struct {
-		// ZFS pool size (in GB), on each node in the corresponding node-group.
+		// ZFS pool size (in GB), allocated on each disk the ZFS pool spans, on each node in the
+		// corresponding node-group.
 		// Must be >= 200 GB : reserving 100 GB for container images, 50 GB for pod logs and 50 GB for
 		// pod ephemeral volumes.
 		// On top of that, if you want x GB of node-local storage for your workloads (like Redis),
-		// the ZFS pool size will be (200 + 2x) GB, keeping in mind that RAIDZ-1 is enabled.
+		// the ZFS pool size will be (200 + x) GB for a mirror : each disk holds a full copy.
 		Size int `yaml:"size" validate:"required,gt=200" default:"220"`
+
+		// PoolType is the shape of the ZFS pool : mirror (the default), raidz1 / raidz2 (surviving
+		// 1 / 2 disk failures, across at least 3 / 4 disks), or single (a single disk, with no
+		// redundancy).
+		PoolType string `yaml:"poolType" validate:"omitempty,oneof=mirror raidz1 raidz2 single" default:"mirror"`
+
+		// Disks is how many disks the ZFS pool spans. Omit for the minimum its type needs : 2 for a
+		// mirror, 3 for raidz1, 4 for raidz2 and 1 for single.
+		Disks int `yaml:"disks,omitempty" validate:"gte=0"`
+
+		// Datasets get created in the ZFS pool, each with its mountpoint and optional compression,
+		// recordSize and quota. Omit for the containerd (/var/lib/containerd), pod-logs
+		// (/var/log/pods) and pod-ephemeral-volumes (/var/lib/kubelet/pods) ones.
+		// Declaring your own replaces them, so include those too, unless the node does without.
+		Datasets []storagetypes.ZFSDataset `yaml:"datasets,omitempty" validate:"omitempty,dive"`
 	}
 )
 
@@ -7,12 +7,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"os"
 
-	"github.com/google/renameio"
 	"gopkg.in/yaml.v3"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/sops"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/randval"
@@ -33,6 +32,10 @@ import (
 // existing comments and key ordering survive. Only ADDS missing
 // keys; never removes or rewrites existing values.
 //
+// A SOPS encrypted secrets.yaml gets decrypted in memory, and
+// re-encrypted for the same recipients when written back, so the
+// plaintext never touches disk.
+//
 // On any change, the in-memory ParsedSecretsConfig is refreshed
 // from the mutated YAML so callers downstream see the freshly-
 // generated values.
@@ -48,7 +51,7 @@ func FillMissingSecrets(ctx context.Context) error {
 	}
 
 	secretsPath := globals.SecretsConfigFilePath()
-	raw, err := os.ReadFile(secretsPath)
+	raw, err := sops.ReadFile(ctx, secretsPath)
 	if err != nil {
 		return fmt.Errorf("reading secrets.yaml: %w", err)
 	}
@@ -88,7 +91,7 @@ func FillMissingSecrets(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("marshalling secrets.yaml: %w", err)
 	}
-	if err := renameio.WriteFile(secretsPath, out, 0o600); err != nil {
+	if err := sops.WriteFile(ctx, secretsPath, out, 0o600); err != nil {
 		return fmt.Errorf("writing secrets.yaml: %w", err)
 	}
 
@@ -151,5 +151,53 @@ func validateKeycloakConfig() error {
 		}
 	}
 
+	return validateKeycloakDirectory(cfg)
+}
+
+// validateKeycloakDirectory checks the cluster.keycloak users and groups:
+//
+//   - usernames and group names are unique, and group names are
+//     top-level (no "/")
+//   - every group a user is a member of is declared
+//   - users and prune need mode=managed — kubeaid-cli only holds admin
+//     credentials for a Keycloak it installed itself. Groups are
+//     allowed in external mode, for their ClusterRoleBindings.
+func validateKeycloakDirectory(cfg *config.KeycloakConfig) error {
+	if cfg.Mode == constants.KeycloakModeExternal && (len(cfg.Users) > 0 || cfg.Prune) {
+		return errors.New(
+			"cluster.keycloak.users and cluster.keycloak.prune require cluster.keycloak.mode=managed — kubeaid-cli has no admin access to an external Keycloak",
+		)
+	}
+
+	groups := make(map[string]bool, len(cfg.Groups))
+	for _, group := range cfg.Groups {
+		if groups[group.Name] {
+			return fmt.Errorf("cluster.keycloak.groups: group %q is declared more than once", group.Name)
+		}
+		if strings.Contains(group.Name, "/") {
+			return fmt.Errorf(
+				"cluster.keycloak.groups: group %q must be a top-level group — subgroups aren't supported",
+				group.Name,
+			)
+		}
+		groups[group.Name] = true
+	}
+
+	usernames := make(map[string]bool, len(cfg.Users))
+	for _, user := range cfg.Users {
+		if usernames[user.Username] {
+			return fmt.Errorf("cluster.keycloak.users: user %q is declared more than once", user.Username)
+		}
+		usernames[user.Username] = true
+
+		for _, group := range user.Groups {
+			if !groups[group] {
+				return fmt.Errorf(
+					"cluster.keycloak.users: user %q is a member of group %q, which isn't declared in cluster.keycloak.groups",
+					user.Username, group,
+				)
+			}
+		}
+	}
 	return nil
 }
@@ -337,3 +337,106 @@ func TestValidateKeycloakConfig(t *testing.T) {
 		})
 	})
 }
+
+func TestValidateKeycloakDirectory(t *testing.T) {
+	t.Parallel()
+
+	groups := []config.KeycloakGroupConfig{
+		{Name: "platform-admins", ClusterRoles: []string{"cluster-admin"}},
+		{Name: "developers", ClusterRoles: []string{"view"}},
+	}
+
+	tests := []struct {
+		name    string
+		cfg     config.KeycloakConfig
+		wantErr string
+	}{
+		{
+			name: "users and groups in managed mode pass",
+			cfg: config.KeycloakConfig{
+				Mode:   constants.KeycloakModeManaged,
+				Groups: groups,
+				Users: []config.KeycloakUserConfig{
+					{Username: "alice", Groups: []string{"platform-admins", "developers"}},
+					{Username: "bob", Groups: []string{"developers"}},
+				},
+				Prune: true,
+			},
+		},
+		{
+			name: "groups alone in external mode pass",
+			cfg: config.KeycloakConfig{
+				Mode:   constants.KeycloakModeExternal,
+				Groups: groups,
+			},
+		},
+		{
+			name: "users in external mode are rejected",
+			cfg: config.KeycloakConfig{
+				Mode:  constants.KeycloakModeExternal,
+				Users: []config.KeycloakUserConfig{{Username: "alice"}},
+			},
+			wantErr: "require cluster.keycloak.mode=managed",
+		},
+		{
+			name: "prune in external mode is rejected",
+			cfg: config.KeycloakConfig{
+				Mode:  constants.KeycloakModeExternal,
+				Prune: true,
+			},
+			wantErr: "require cluster.keycloak.mode=managed",
+		},
+		{
+			name: "duplicate group",
+			cfg: config.KeycloakConfig{
+				Mode:   constants.KeycloakModeManaged,
+				Groups: append(groups, config.KeycloakGroupConfig{Name: "developers"}),
+			},
+			wantErr: `group "developers" is declared more than once`,
+		},
+		{
+			name: "subgroup",
+			cfg: config.KeycloakConfig{
+				Mode:   constants.KeycloakModeManaged,
+				Groups: []config.KeycloakGroupConfig{{Name: "engineering/sre"}},
+			},
+			wantErr: "must be a top-level group",
+		},
+		{
+			name: "duplicate user",
+			cfg: config.KeycloakConfig{
+				Mode: constants.KeycloakModeManaged,
+				Users: []config.KeycloakUserConfig{
+					{Username: "alice"},
+					{Username: "alice"},
+				},
+			},
+			wantErr: `user "alice" is declared more than once`,
+		},
+		{
+			name: "membership of an undeclared group",
+			cfg: config.KeycloakConfig{
+				Mode:   constants.KeycloakModeManaged,
+				Groups: groups,
+				Users: []config.KeycloakUserConfig{
+					{Username: "alice", Groups: []string{"sre"}},
+				},
+			},
+			wantErr: `member of group "sre", which isn't declared`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateKeycloakDirectory(&tc.cfg)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/Obmondo/kubeaid-cli/pkg/cloud/azure"
 	"github.com/Obmondo/kubeaid-cli/pkg/cloud/hetzner"
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/sops"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
@@ -107,7 +108,8 @@ func ParseConfigFiles(ctx context.Context, configsDirectory string) {
 	// Read contents of the secrets config file into ParsedSecretsConfig.
 	// This needs to be done before reading the general config.
 	{
-		secretsConfigFileContents, err := os.ReadFile(globals.SecretsConfigFilePath())
+		// Transparently decrypted, when SOPS encrypted.
+		secretsConfigFileContents, err := sops.ReadFile(ctx, globals.SecretsConfigFilePath())
 		assert.AssertErrNil(ctx, err, "Failed reading secrets config file")
 
 		err = yaml.Unmarshal([]byte(secretsConfigFileContents), config.ParsedSecretsConfig)
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config/clusterdir"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/sops"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
 )
@@ -60,6 +61,10 @@ func UsingDefaultConfigsDirectory() bool {
 
 // resolveFromStdin reads YAML from stdinReader and writes it as general.yaml to a temp directory.
 // An empty secrets.yaml is also created so ParseConfigFiles doesn't fail.
+//
+// A SOPS encrypted secrets.yaml document is decrypted right away, so a missing key fails here
+// rather than midway through parsing. But it's written encrypted : ParseConfigFiles decrypts it
+// again in memory, and the plaintext never lands in the temp directory.
 func resolveFromStdin(ctx context.Context) error {
 	slog.InfoContext(ctx, "Reading config from stdin")
 
@@ -97,6 +102,12 @@ func resolveFromStdin(ctx context.Context) error {
 		secretsContent = docs[1]
 	}
 
+	if sops.IsEncrypted(secretsContent) {
+		if _, err := sops.Decrypt(ctx, secretsContent); err != nil {
+			return fmt.Errorf("decrypting secrets.yaml: %w", err)
+		}
+	}
+
 	//nolint:gosec // tmpDir is created by os.MkdirTemp, and the file name is a fixed constant.
 	if err := os.WriteFile(path.Join(tmpDir, "secrets.yaml"), secretsContent, 0o600); err != nil {
 		return fmt.Errorf("writing secrets.yaml: %w", err)
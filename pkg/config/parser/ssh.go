@@ -20,11 +20,13 @@ import (
 	"golang.org/x/term"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/sshkey"
 )
 
 func hydrateSSHKeyPairConfigs() {
@@ -106,7 +108,8 @@ func hydrateSSHKeyPairConfigs() {
 //  1. UseSSHAgent=false (default): read PrivateKeyFilePath as an
 //     OpenSSH private key, parse it, derive PublicKey + Fingerprint
 //     from the parsed key. PrivateKey is the raw bytes (used by the
-//     Hetzner NAT-gateway SSH client when no agent is available).
+//     Hetzner NAT-gateway SSH client when no agent is available) —
+//     unlocked first, when the key is passphrase protected.
 //
 //  2. UseSSHAgent=true: dial SSH_AUTH_SOCK and ask the agent for
 //     its loaded identities. The private key stays in the agent
@@ -169,18 +172,30 @@ const defaultSSHPrivateKeyPath = "~/.ssh/id_ed25519"
 // unfinished one, and the answer is on the operator's own machine. Failing
 // would tell someone holding the key that they cannot proceed.
 //
-// Unattended runs cannot answer, so those still fail, with a message naming
-// every way out rather than the "no such file" that an empty path used to
-// produce.
+// The answers file can give the path instead, and has to when running
+// non-interactively. Other unattended runs cannot answer, so those still fail,
+// with a message naming every way out rather than the "no such file" that an
+// empty path used to produce.
 func askForSSHPrivateKeyPath(ctx context.Context) string {
+	path, answered, err := answers.Parsed.Choose(answers.DecisionSSHPrivateKeyPath)
+	assert.AssertErrNil(ctx, err,
+		"No SSH private key file path set and useSSHAgent is false: set privateKeyFilePath in general.yaml, enable useSSHAgent, or give the path in the answers file",
+	)
+	if answered {
+		assert.AssertErrNil(ctx, validateSSHPrivateKeyAtPath(path),
+			"Invalid SSH private key file path in the answers file",
+		)
+		return path
+	}
+
 	assert.Assert(
 		ctx,
 		stdinIsTerminal(),
 		"No SSH private key file path set, useSSHAgent is false, and there is no terminal to ask on: set privateKeyFilePath in general.yaml, enable useSSHAgent, or re-run with an install token that delivers the key",
 	)
 
-	path := defaultSSHPrivateKeyPath
-	err := promptSSHPrivateKeyPath(&path)
+	path = defaultSSHPrivateKeyPath
+	err = promptSSHPrivateKeyPath(&path)
 	assert.AssertErrNil(ctx, err, "Failed asking for the SSH private key file path")
 
 	return path
@@ -202,8 +217,8 @@ func runSSHPrivateKeyPathForm(path *string) error {
 }
 
 // validateSSHPrivateKeyAtPath checks the answer names a key this run can
-// actually use. Encrypted keys pass: the passphrase is supplied later, the
-// same allowance pkg/config/prompt makes.
+// actually use. Encrypted keys pass: the passphrase is supplied later (see
+// pkg/utils/sshkey), the same allowance pkg/config/prompt makes.
 func validateSSHPrivateKeyAtPath(path string) error {
 	if strings.TrimSpace(path) == "" {
 		return fmt.Errorf("a path is required")
@@ -254,8 +269,6 @@ func hydrateSSHKeyPairFromFile(sshKeyPairConfig *config.SSHKeyPairConfig) {
 	privateKey, err := os.ReadFile(privateKeyFilePath)
 	assert.AssertErrNil(ctx, err, "Failed reading SSH private key file")
 
-	sshKeyPairConfig.PrivateKey = strings.TrimSpace(string(privateKey))
-
 	// Ensure that the serialization format is OpenSSH.
 	block, _ := pem.Decode(privateKey)
 	assert.Assert(ctx,
@@ -263,8 +276,16 @@ func hydrateSSHKeyPairFromFile(sshKeyPairConfig *config.SSHKeyPairConfig) {
 		"Serialization format for SSH private key isn't OpenSSH",
 	)
 
-	// Parse the SSH private key.
-	parsedPrivateKey, err := ssh.ParseRawPrivateKey(privateKey)
+	// Parse the SSH private key, asking for its passphrase when it's protected by one. The same
+	// key file is often used for several key pairs (git and bare-metal SSH, for example) : it's
+	// only asked for once.
+	unencryptedPrivateKey, passphraseProtected, err := sshkey.UnencryptedPEM(privateKeyFilePath, privateKey)
+	assert.AssertErrNil(ctx, err, "Failed to parse SSH private key")
+
+	sshKeyPairConfig.PrivateKey = strings.TrimSpace(string(unencryptedPrivateKey))
+	sshKeyPairConfig.PassphraseProtected = passphraseProtected
+
+	parsedPrivateKey, _, err := sshkey.Unlock(privateKeyFilePath, privateKey)
 	assert.AssertErrNil(ctx, err, "Failed to parse SSH private key")
 
 	// Get the public key and fingerprint,
@@ -16,6 +16,7 @@ import (
 	"golang.org/x/crypto/ssh"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
 )
 
 // sshTestKeyPair generates an ed25519 key and returns its OpenSSH private
@@ -155,6 +156,43 @@ func TestHydrateSSHKeyPairFromFileDoesNotAskWhenAPathIsSet(t *testing.T) {
 	})
 }
 
+// TestHydrateSSHKeyPairFromFileTakesThePathFromTheAnswersFile covers
+// non-interactive runs: the answers file answers the question, and there is no
+// terminal to fall back to.
+// Mutates answers.Parsed — sequential only.
+func TestHydrateSSHKeyPairFromFileTakesThePathFromTheAnswersFile(t *testing.T) {
+	privatePEM, _, fingerprint := sshTestKeyPair(t)
+	keyPath := sshTestTempFile(t, "id_ed25519", privatePEM)
+
+	restoreTerminal := stdinIsTerminal
+	restorePrompt := promptSSHPrivateKeyPath
+	restoreAnswers := answers.Parsed
+	t.Cleanup(func() {
+		stdinIsTerminal = restoreTerminal
+		promptSSHPrivateKeyPath = restorePrompt
+		answers.Parsed = restoreAnswers
+	})
+
+	stdinIsTerminal = func() bool { return false }
+	promptSSHPrivateKeyPath = func(_ *string) error {
+		t.Fatal("must not ask when running non-interactively")
+		return nil
+	}
+
+	parsedAnswers, err := answers.Load(
+		sshTestTempFile(t, "answers.yaml", []byte("sshPrivateKeyPath: "+keyPath+"\n")),
+		true,
+	)
+	require.NoError(t, err)
+	answers.Parsed = parsedAnswers
+
+	sshKeyPairConfig := &config.SSHKeyPairConfig{}
+	hydrateSSHKeyPairFromFile(sshKeyPairConfig)
+
+	assert.Equal(t, keyPath, sshKeyPairConfig.PrivateKeyFilePath)
+	assert.Equal(t, fingerprint, sshKeyPairConfig.Fingerprint)
+}
+
 // TestValidateSSHPrivateKeyAtPath keeps a wrong answer inside the prompt,
 // where it can be corrected, rather than accepting it and failing on the next
 // line. Tilde expansion is covered here too: it is the form most keys are
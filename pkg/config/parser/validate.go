@@ -5,6 +5,7 @@ package parser
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -30,7 +31,9 @@ import (
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
 	"github.com/Obmondo/kubeaid-cli/pkg/config/validate"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/dns"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
+	"github.com/Obmondo/kubeaid-cli/pkg/repository/forge"
 	repourl "github.com/Obmondo/kubeaid-cli/pkg/repository/url"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
 )
@@ -106,7 +109,15 @@ func validateConfigFields(
 		func() error { return validateAdditionalUsers(generalConfig.Cluster.AdditionalUsers) },
 		func() error { return validateKnownHostsEntries(ctx, generalConfig.Git.KnownHosts) },
 		func() error { return validateObmondoMonitoring(generalConfig.Obmondo, stat) },
-		func() error { return validateACMEDNS01(generalConfig.Cluster, secretsConfig.ACME) },
+		func() error { return validateACMEDNS01(generalConfig.Cluster, secretsConfig) },
+		func() error { return validateDNSRecords(generalConfig.Cluster, secretsConfig, cloudProviderName) },
+		func() error { return validateDisasterRecovery(generalConfig.Cloud, secretsConfig.ObjectStorage) },
+		func() error {
+			return validateGitForge(generalConfig.Git, generalConfig.Forks.KubeaidConfigFork.ParsedURL, secretsConfig.Git)
+		},
+		func() error {
+			return validateGitHTTPS(generalConfig.Git, generalConfig.Forks.KubeaidConfigFork.URL, secretsConfig.Git)
+		},
 	}
 
 	for _, validator := range validators {
@@ -120,13 +131,17 @@ func validateConfigFields(
 
 // validateACMEDNS01 enforces the cross-field requirements of the
 // DNS-01 ClusterIssuer: the block needs an ACME account email to
-// register with, and the Cloudflare API token the solver authenticates
-// with (sealed into cert-manager/cloudflare-api-token). Validated at
-// parse time so a half-configured issuer fails here instead of as a
-// CertificateRequest stuck Pending on the cluster. Provider values are
-// constrained by the struct's oneof tag.
-func validateACMEDNS01(cluster config.ClusterConfig, acmeCreds *config.ACMECredentials) error {
-	if cluster.ACMEDNS01 == nil {
+// register with, the provider's block in cluster.acmeDNS01 (all but
+// cloudflare), and the credential the solver authenticates with
+// (sealed into the cert-manager namespace). The settings and
+// credentials shared with cluster.dnsRecords fall back to its ones
+// (see config.ACMEDNS01Credentials). Validated at parse time so a
+// half-configured issuer fails here instead of as a CertificateRequest
+// stuck Pending on the cluster. Provider values are constrained by the
+// struct's oneof tag, and the fields of each block by their own tags.
+func validateACMEDNS01(cluster config.ClusterConfig, secretsConfig *config.SecretsConfig) error {
+	dns01 := cluster.ACMEDNS01
+	if dns01 == nil {
 		return nil
 	}
 
@@ -136,12 +151,206 @@ func validateACMEDNS01(cluster config.ClusterConfig, acmeCreds *config.ACMECrede
 		)
 	}
 
-	if acmeCreds == nil || acmeCreds.CloudflareAPIToken == "" {
+	acmeCreds := config.ACMEDNS01Credentials(cluster, secretsConfig)
+
+	switch dns01.Provider {
+	case constants.ACMEDNS01ProviderCloudflare:
+		if acmeCreds.CloudflareAPIToken == "" {
+			return errors.New(
+				"secrets.yaml: acme.cloudflareApiToken (or dns.cloudflareApiToken) is required when cluster.acmeDNS01 is set — the DNS-01 solver creates TXT challenge records with it (needs Zone:Read + DNS:Edit on the solved zones)",
+			)
+		}
+
+	case constants.ACMEDNS01ProviderRoute53:
+		if dns01.Route53 == nil {
+			return errors.New("cluster.acmeDNS01.route53 is required when cluster.acmeDNS01.provider is route53")
+		}
+		if acmeCreds.Route53 == nil {
+			return errors.New(
+				"secrets.yaml: acme.route53 is required when cluster.acmeDNS01.provider is route53 — the access key pair of an IAM user allowed to change the hosted zone's records",
+			)
+		}
+
+	case constants.ACMEDNS01ProviderAzureDNS:
+		if dns01.AzureDNS == nil {
+			return errors.New("cluster.acmeDNS01.azureDNS is required when cluster.acmeDNS01.provider is azuredns")
+		}
+		if acmeCreds.AzureDNS == nil {
+			return errors.New(
+				"secrets.yaml: acme.azureDNS is required when cluster.acmeDNS01.provider is azuredns — the client secret of the service principal in cluster.acmeDNS01.azureDNS.clientID",
+			)
+		}
+
+	case constants.ACMEDNS01ProviderHetzner:
+		if dns01.Hetzner == nil {
+			return errors.New(
+				"cluster.acmeDNS01.hetzner is required when cluster.acmeDNS01.provider is hetzner. " +
+					"KubeAid CLI doesn't install the cert-manager webhook solving DNS-01 challenges through Hetzner DNS : " +
+					"install one in the cert-manager namespace first, allowed to read the cert-manager/hetzner-dns-api-token Secret, " +
+					"then set cluster.acmeDNS01.hetzner.groupName / solverName to the ones it got installed with",
+			)
+		}
+		if acmeCreds.Hetzner == nil {
+			return errors.New(
+				"secrets.yaml: acme.hetzner (or hetzner.apiToken) is required when cluster.acmeDNS01.provider is hetzner — the Hetzner API token the webhook authenticates with",
+			)
+		}
+
+	case constants.ACMEDNS01ProviderRFC2136:
+		if config.ACMEDNS01RFC2136(cluster) == nil {
+			return errors.New(
+				"cluster.acmeDNS01.rfc2136 (or cluster.dnsRecords.rfc2136) is required when cluster.acmeDNS01.provider is rfc2136",
+			)
+		}
+		if acmeCreds.RFC2136 == nil {
+			return errors.New(
+				"secrets.yaml: acme.rfc2136 is required when cluster.acmeDNS01.provider is rfc2136 — the base64 encoded secret of cluster.acmeDNS01.rfc2136.tsigKeyName (dns.tsigSecret gets used, for cluster.dnsRecords.rfc2136's TSIG key)",
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateDNSRecords enforces the cross-field requirements of
+// cluster.dnsRecords : KubeAid CLI only creates the records of Hetzner
+// clusters (the only ones it waits for DNS on), and each provider needs
+// its credential in secrets.yaml. Validated at parse time, so a missing
+// credential doesn't surface only once the control-plane LB exists.
+func validateDNSRecords(cluster config.ClusterConfig, secretsConfig *config.SecretsConfig, cloudProviderName string) error {
+	recordsConfig := cluster.DNSRecords
+	if recordsConfig == nil {
+		return nil
+	}
+
+	if cloudProviderName != constants.CloudProviderHetzner {
+		return errors.New("cluster.dnsRecords is only supported on Hetzner clusters")
+	}
+
+	dnsCreds := secretsConfig.DNS
+	if dnsCreds == nil {
+		dnsCreds = &config.DNSCredentials{}
+	}
+
+	switch recordsConfig.Provider {
+	case dns.ProviderCloudflare:
+		if (dnsCreds.CloudflareAPIToken == "") &&
+			((secretsConfig.ACME == nil) || (secretsConfig.ACME.CloudflareAPIToken == "")) {
+			return errors.New(
+				"secrets.yaml: dns.cloudflareApiToken (or acme.cloudflareApiToken) is required when cluster.dnsRecords.provider is cloudflare — it needs Zone:Read + DNS:Edit on the zones",
+			)
+		}
+
+	case dns.ProviderHetzner:
+		if (secretsConfig.Hetzner == nil) || (secretsConfig.Hetzner.APIToken == "") {
+			return errors.New(
+				"secrets.yaml: hetzner.apiToken is required when cluster.dnsRecords.provider is hetzner",
+			)
+		}
+
+	case dns.ProviderRFC2136:
+		if recordsConfig.RFC2136 == nil {
+			return errors.New("cluster.dnsRecords.rfc2136 is required when cluster.dnsRecords.provider is rfc2136")
+		}
+		if dnsCreds.TSIGSecret == "" {
+			return errors.New(
+				"secrets.yaml: dns.tsigSecret is required when cluster.dnsRecords.provider is rfc2136 — the base64 encoded secret of cluster.dnsRecords.rfc2136.tsigKeyName",
+			)
+		}
+		if _, err := base64.StdEncoding.DecodeString(dnsCreds.TSIGSecret); err != nil {
+			return fmt.Errorf("secrets.yaml: dns.tsigSecret must be base64 encoded: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateDisasterRecovery enforces where cloud.disasterRecovery.s3 belongs : AWS and Azure
+// back up to their native object storage (S3 / Blob Storage), while Hetzner and Bare Metal
+// have none KubeAid CLI can provision, so there the S3-compatible endpoint and its access
+// key pair (secrets.yaml: objectStorage) are mandatory.
+func validateDisasterRecovery(cloud config.CloudConfig, objectStorageCreds *config.ObjectStorageCredentials) error {
+	if cloud.DisasterRecovery == nil {
+		return nil
+	}
+
+	s3CompatibleOnly := (cloud.Hetzner != nil) || (cloud.BareMetal != nil)
+	switch {
+	case !s3CompatibleOnly && (cloud.DisasterRecovery.S3 != nil):
+		return errors.New(
+			"cloud.disasterRecovery.s3 is only supported on Hetzner and Bare Metal — AWS and Azure clusters back up to the cloud provider's native object storage",
+		)
+
+	case s3CompatibleOnly && (cloud.DisasterRecovery.S3 == nil):
+		return errors.New(
+			"cloud.disasterRecovery.s3 is required on Hetzner and Bare Metal — point it to an S3-compatible object storage (Hetzner Object Storage, MinIO, Ceph RGW)",
+		)
+
+	case s3CompatibleOnly && (objectStorageCreds == nil):
+		return errors.New(
+			"secrets.yaml: objectStorage is required when cloud.disasterRecovery.s3 is set — Velero, the Sealed Secrets backuper and KubeAid CLI authenticate to the object storage with it",
+		)
+	}
+
+	return nil
+}
+
+// validateGitForge makes sure that, when a forge API token is provided, KubeAid CLI knows which
+// forge it belongs to. Otherwise the PR workflow would silently fall back to the manual
+// press-ENTER flow, at the first PR.
+func validateGitForge(gitConfig config.GitConfig,
+	kubeaidConfigURL *repourl.Parsed,
+	gitCreds *config.GitCredentials,
+) error {
+	if (gitCreds == nil) || (gitCreds.ForgeToken == "") || (kubeaidConfigURL == nil) {
+		return nil
+	}
+
+	if (gitConfig.Forge != nil) && (gitConfig.Forge.Type != "") {
+		return nil
+	}
+
+	if _, err := forge.DetectType(kubeaidConfigURL); err != nil {
+		return fmt.Errorf("secrets.yaml: git.forgeToken is set, but %w", err)
+	}
+	return nil
+}
+
+// validateGitHTTPS enforces the cross-field requirements of git.https : it only applies to an
+// HTTP(S) KubeAid Config repository URL, takes a single auth flavour, and a GitHub App needs its
+// private key in secrets.yaml.
+func validateGitHTTPS(gitConfig config.GitConfig,
+	kubeaidConfigURL string,
+	gitCreds *config.GitCredentials,
+) error {
+	httpsConfig := gitConfig.HTTPS
+	if httpsConfig == nil {
+		return nil
+	}
+
+	if !repourl.UsingHTTPBasedProtocol(kubeaidConfigURL) {
 		return errors.New(
-			"secrets.yaml: acme.cloudflareApiToken is required when cluster.acmeDNS01 is set — the DNS-01 solver creates TXT challenge records with it (needs Zone:Read + DNS:Edit on the solved zones)",
+			"git.https is set, but the KubeAid Config repository URL isn't an HTTP(S) one : drop git.https, or use an HTTPS URL",
 		)
 	}
 
+	if httpsConfig.GitHubApp == nil {
+		return nil
+	}
+
+	if httpsConfig.UseCredentialHelper {
+		return errors.New("git.https : set either useCredentialHelper or githubApp, not both")
+	}
+
+	if (gitConfig.Forge != nil) && (gitConfig.Forge.Type != "") && (gitConfig.Forge.Type != forge.TypeGitHub) {
+		return fmt.Errorf("git.https.githubApp is set, but git.forge.type is %s", gitConfig.Forge.Type)
+	}
+
+	if (gitCreds == nil) || (gitCreds.GitHubAppPrivateKey == "") {
+		return errors.New(
+			"secrets.yaml: git.githubAppPrivateKey is required when git.https.githubApp is set",
+		)
+	}
 	return nil
 }
 
@@ -757,10 +966,59 @@ func validateHetznerBareMetalConfig() error {
 		return errors.New("hetzner bare metal specific control-plane details not provided")
 	}
 
+	if err := validateZFSConfig("cloud.hetzner.bareMetal.zfs", hetznerConfig.BareMetal.ZFS); err != nil {
+		return err
+	}
+	if config.ControlPlaneInHetznerBareMetal() {
+		err := validateZFSConfig("cloud.hetzner.controlPlane.bareMetal.zfs", hetznerConfig.ControlPlane.BareMetal.ZFS)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, hetznerBaremetalNodeGroup := range hetznerConfig.NodeGroups.BareMetal {
 		if err := validateNodeGroup(&hetznerBaremetalNodeGroup.NodeGroup); err != nil {
 			return err
 		}
+
+		err := validateZFSConfig(
+			fmt.Sprintf("cloud.hetzner.nodeGroups.bareMetal[%s].zfs", hetznerBaremetalNodeGroup.Name),
+			hetznerBaremetalNodeGroup.ZFS,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := validateDiskSelections(hetznerBaremetalNodeGroup.BareMetalHosts); err != nil {
+			return err
+		}
+	}
+
+	if config.ControlPlaneInHetznerBareMetal() {
+		if err := validateDiskSelections(hetznerConfig.ControlPlane.BareMetal.BareMetalHosts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDiskSelections checks the disk selections the given bare-metal hosts declare : no disk
+// can be both included and excluded.
+func validateDiskSelections(hosts []*config.HetznerBareMetalHost) error {
+	for _, host := range hosts {
+		if err := config.DiskSelection(host).Validate(); err != nil {
+			return fmt.Errorf("bare-metal host %s: disks: %w", host.ServerID, err)
+		}
+	}
+	return nil
+}
+
+// validateZFSConfig checks the storage layout the ZFS config at the given path declares, beyond
+// what its struct tags can : disk counts against the pool type, and the datasets' names,
+// mountpoints and properties.
+func validateZFSConfig(path string, zfs config.ZFSConfig) error {
+	if _, err := config.StorageLayout(zfs).Normalized(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
 	}
 	return nil
 }
@@ -4,7 +4,10 @@
 package config
 
 import (
+	"slices"
+
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/storagetypes"
 )
 
 // The predicates below answer "what shape is this cluster" from the parsed
@@ -119,6 +122,19 @@ func ManagedKeycloakEnabled() bool {
 	return cluster.Keycloak.Mode == constants.KeycloakModeManaged
 }
 
+// KeycloakClusterRoleBindingsEnabled reports whether any of the
+// cluster.keycloak.groups is bound to ClusterRoles, so the ClusterRoleBindings
+// get rendered. Nil-safe.
+func KeycloakClusterRoleBindingsEnabled() bool {
+	keycloak := ParsedGeneralConfig.Cluster.Keycloak
+	if keycloak == nil {
+		return false
+	}
+	return slices.ContainsFunc(keycloak.Groups, func(group KeycloakGroupConfig) bool {
+		return len(group.ClusterRoles) > 0
+	})
+}
+
 // Returns whether we're using Hetzner Bare Metal.
 func UsingHetznerBareMetal() bool {
 	if ParsedGeneralConfig.Cloud.Hetzner == nil {
@@ -171,10 +187,37 @@ func HetznerBareMetalWorkerNodeCount() int {
 // constants.RookCephMinNodes), so below the threshold we skip rendering and
 // syncing it entirely rather than leave a permanently-unhealthy cluster.
 func RookCephEnabled() bool {
-	return UsingHetznerBareMetal() &&
+	return UsingHetznerBareMetal() && !CephExcluded() &&
 		(HetznerBareMetalWorkerNodeCount() >= constants.RookCephMinNodes)
 }
 
+// CephExcluded reports whether the operator opted out of handing the bare-metal nodes' leftover
+// disk space to Rook Ceph (kubeaidStoragectl.excludeCeph).
+func CephExcluded() bool {
+	storagectl := ParsedGeneralConfig.KubeaidStoragectl
+	return (storagectl != nil) && storagectl.ExcludeCeph
+}
+
+// StorageLayout returns the storage layout, a node group with the given ZFS config gets its
+// bare-metal nodes' disks laid out as.
+func StorageLayout(zfs ZFSConfig) storagetypes.StorageLayout {
+	return storagetypes.StorageLayout{
+		ZFSPoolType:  zfs.PoolType,
+		ZFSPoolDisks: zfs.Disks,
+		ZFSDatasets:  zfs.Datasets,
+		ExcludeCEPH:  CephExcluded(),
+	}
+}
+
+// DiskSelection returns the disk selection the storage planner applies to the given bare-metal
+// host's disks : none, unless the host declares one.
+func DiskSelection(host *HetznerBareMetalHost) storagetypes.DiskSelection {
+	if host.Disks == nil {
+		return storagetypes.DiskSelection{}
+	}
+	return *host.Disks
+}
+
 // ObmondoIntegrationEnabled reports whether this cluster pushes to Obmondo:
 // monitoring was asked for, and the mTLS material to authenticate with is on
 // disk.
@@ -212,3 +255,15 @@ func VulnerabilityScanningEnabled() bool {
 func RuntimeDetectionEnabled() bool {
 	return ParsedGeneralConfig.Cluster.Security.RuntimeDetection
 }
+
+// S3CompatibleDisasterRecoveryEnabled reports whether the Velero and Sealed Secrets
+// backups go to an S3-compatible object storage (cloud.disasterRecovery.s3) : a Hetzner or
+// Bare Metal cluster with a disaster-recovery block. AWS and Azure back up into their own
+// native object storage instead.
+func S3CompatibleDisasterRecoveryEnabled() bool {
+	disasterRecovery := ParsedGeneralConfig.Cloud.DisasterRecovery
+	if disasterRecovery == nil || disasterRecovery.S3 == nil {
+		return false
+	}
+	return (ParsedGeneralConfig.Cloud.Hetzner != nil) || (ParsedGeneralConfig.Cloud.BareMetal != nil)
+}
@@ -86,7 +86,7 @@ const (
 	maxHetznerVLANID = MaxHetznerVLANID
 )
 
-// nonEmpty / clusterName / sshGitURL / ipv4 / cidrv4 / hetznerVLANID /
+// nonEmpty / clusterName / gitURL / ipv4 / cidrv4 / hetznerVLANID /
 // exported field validators, kept so every huh Validate(...) call site in
 // this package is unchanged. See that package for the validation rules —
 // the Obmondo API imports it directly to enforce the same rules in the
@@ -94,7 +94,7 @@ const (
 var (
 	nonEmpty      = NonEmpty
 	clusterName   = ClusterName
-	sshGitURL     = SSHGitURL
+	gitURL        = GitURL
 	ipv4          = IPv4
 	cidrv4        = CIDRv4
 	hetznerVLANID = HetznerVLANID
@@ -107,7 +107,7 @@ func populateGitKnownHosts(cfg *PromptedConfig) {
 		if err != nil || host == "" {
 			// HTTPS URL, empty, or unparseable — skip silently.
 			// HTTPS doesn't need known_hosts; unparseable inputs
-			// would have been caught by sshGitURL upstream.
+			// would have been caught by gitURL upstream.
 			continue
 		}
 		if commonGitHosts[host] {
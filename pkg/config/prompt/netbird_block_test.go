@@ -67,7 +67,7 @@ func TestRenderWorkloadOmitsNetBirdWhenNotJoining(t *testing.T) {
 	}
 
 	dir := t.TempDir()
-	require.NoError(t, writeConfigFiles(dir, cfg))
+	require.NoError(t, writeConfigFiles(t.Context(), dir, cfg))
 
 	body, err := os.ReadFile(filepath.Join(dir, "general.yaml"))
 	require.NoError(t, err)
@@ -5,6 +5,7 @@ package prompt
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
@@ -17,7 +18,9 @@ import (
 
 	configpkg "github.com/Obmondo/kubeaid-cli/pkg/config"
 	"github.com/Obmondo/kubeaid-cli/pkg/config/clusterdir"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/sops"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	repourl "github.com/Obmondo/kubeaid-cli/pkg/repository/url"
 )
 
 var (
@@ -57,6 +60,7 @@ func askSaveInterruptedConfig(configsDirectory string) (bool, error) {
 // Non-abort errors fall through unchanged — caller's slog.Error
 // chain still applies for those.
 func exitCleanlyOnAbort(
+	ctx context.Context,
 	errPtr *error,
 	configsDirectory string,
 	pickedClusterName string,
@@ -97,7 +101,7 @@ func exitCleanlyOnAbort(
 		fmt.Fprintf(os.Stderr, "  Cancelled - failed creating %s: %v\n", directory, err)
 		os.Exit(1)
 	}
-	if err := writeConfigFiles(directory, cfg); err != nil {
+	if err := writeConfigFiles(ctx, directory, cfg); err != nil {
 		fmt.Fprintf(os.Stderr, "  Cancelled - failed saving partial config: %v\n", err)
 		os.Exit(1)
 	}
@@ -162,7 +166,7 @@ func resolveWriteTarget(configsDirectory, pickedClusterName, finalClusterName st
 //     Step 4 — Git/SSH (deploy key, config repo, optional Git SSH key)
 //   - Phase 3: Print summary; "Looks good?" confirm. Loop back to Phase 2 on No.
 //   - Phase 4: Collect optional Obmondo support details after the summary is accepted.
-func ConfigFromPrompt(configsDirectory, clusterName string) (result PromptResult, returnErr error) {
+func ConfigFromPrompt(ctx context.Context, configsDirectory, clusterName string) (result PromptResult, returnErr error) {
 	detected := autoDetect()
 	cfg := defaultPromptedConfig(detected)
 	cfg.ConfigsDirectory = configsDirectory
@@ -188,9 +192,9 @@ func ConfigFromPrompt(configsDirectory, clusterName string) (result PromptResult
 	// instead of the deeply-wrapped 'Failed preparing config files
 	// error=interactive config setup failed: collecting cluster
 	// basics: user aborted' chain that bubbles up through Prepare.
-	defer exitCleanlyOnAbort(&returnErr, configsDirectory, clusterName, cfg, state)
+	defer exitCleanlyOnAbort(ctx, &returnErr, configsDirectory, clusterName, cfg, state)
 
-	if err := session.loadExistingConfigIfRequested(); err != nil {
+	if err := session.loadExistingConfigIfRequested(ctx); err != nil {
 		return PromptResult{}, err
 	}
 	if err := session.pickK8sProfileIfNeeded(); err != nil {
@@ -220,7 +224,7 @@ func ConfigFromPrompt(configsDirectory, clusterName string) (result PromptResult
 			"creating configs directory %s: %w", target.ConfigsDirectory, err)
 	}
 
-	if err := writeConfigFiles(target.ConfigsDirectory, cfg); err != nil {
+	if err := writeConfigFiles(ctx, target.ConfigsDirectory, cfg); err != nil {
 		return PromptResult{}, fmt.Errorf("writing config files: %w", err)
 	}
 	if err := removePromptState(target.ConfigsDirectory); err != nil {
@@ -255,7 +259,7 @@ type promptSession struct {
 	state            *promptState
 }
 
-func (s *promptSession) loadExistingConfigIfRequested() error {
+func (s *promptSession) loadExistingConfigIfRequested(ctx context.Context) error {
 	if !ExistingConfigPresent(s.configsDirectory) {
 		return nil
 	}
@@ -278,7 +282,7 @@ func (s *promptSession) loadExistingConfigIfRequested() error {
 	if err != nil {
 		return fmt.Errorf("loading prompt state: %w", err)
 	}
-	loadedConfig, err := loadExistingPromptedConfigIfPresent(s.configsDirectory, s.cfg)
+	loadedConfig, err := loadExistingPromptedConfigIfPresent(ctx, s.configsDirectory, s.cfg)
 	if err != nil {
 		return fmt.Errorf("loading existing config: %w", err)
 	}
@@ -1031,24 +1035,28 @@ func runGitSSHForm(cfg *PromptedConfig, detected *autoDetectedConfig) error {
 		Title("KubeAid Config fork URL:").
 		// Not "the key collected below" — that field is skipped when the
 		// config already supplies one, and it never appears for an agent.
-		Description("SSH form — uses your yubikey via SSH agent, or your configured SSH key.").
+		Description("SSH form uses your yubikey via SSH agent, or your configured SSH key. "+
+			"HTTPS uses git.token in secrets.yaml or $"+constants.EnvNameGitToken+" (see git.https).").
 		Value(&cfg.KubeaidConfigForkURL).
-		Validate(sshGitURL))
+		Validate(gitURL))
 
-	groups := []*huh.Group{
+	if err := huh.NewForm(
 		huh.NewGroup(fields...).Title("Git / SSH").Description("Step 4/4"),
+	).Run(); err != nil {
+		return err
 	}
-	if plan.gitKey {
-		groups = append(groups, huh.NewGroup(
+
+	// The push key only matters for an SSH-form fork URL : over HTTPS,
+	// kubeaid-cli pushes with the access token.
+	if plan.gitKey && !repourl.UsingHTTPBasedProtocol(strings.TrimSpace(cfg.KubeaidConfigForkURL)) {
+		if err := huh.NewForm(huh.NewGroup(
 			huh.NewInput().
 				Title("Your SSH private key (with write access to kubeaid-config — used by kubeaid-cli to push):").
 				Value(&cfg.SSHKeyPath).
 				Validate(validateSSHKeyPath),
-		))
-	}
-
-	if err := huh.NewForm(groups...).Run(); err != nil {
-		return err
+		)).Run(); err != nil {
+			return err
+		}
 	}
 
 	// Auto-populate git.knownHosts for self-hosted forge URLs whose
@@ -1124,7 +1132,9 @@ func runConfirm() (bool, error) {
 }
 
 // writeConfigFiles renders the config templates with prompted values and writes them to disk.
-func writeConfigFiles(configsDirectory string, cfg *PromptedConfig) error {
+// secrets.yaml gets SOPS encrypted, when the one it replaces was, or a .sops.yaml creation rule
+// covers it.
+func writeConfigFiles(ctx context.Context, configsDirectory string, cfg *PromptedConfig) error {
 	general, secrets, err := Render(cfg)
 	if err != nil {
 		return err
@@ -1136,7 +1146,7 @@ func writeConfigFiles(configsDirectory string, cfg *PromptedConfig) error {
 	}
 
 	secretsPath := path.Join(configsDirectory, "secrets.yaml")
-	if err := writeFile(secretsPath, secrets, 0o600); err != nil {
+	if err := sops.WriteFile(ctx, secretsPath, secrets, 0o600); err != nil {
 		return fmt.Errorf("writing secrets config: %w", err)
 	}
 
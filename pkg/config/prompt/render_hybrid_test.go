@@ -80,7 +80,7 @@ func TestRenderHetznerHybridVPN(t *testing.T) {
 	}
 
 	dir := t.TempDir()
-	require.NoError(t, writeConfigFiles(dir, cfg))
+	require.NoError(t, writeConfigFiles(t.Context(), dir, cfg))
 
 	body, err := os.ReadFile(filepath.Join(dir, "general.yaml"))
 	require.NoError(t, err)
@@ -78,7 +78,7 @@ func TestRenderSecretsQuotesYAMLMetacharacters(t *testing.T) {
 				HetznerRobotPassword: tc.password,
 			}
 			dir := t.TempDir()
-			require.NoError(t, writeConfigFiles(dir, cfg))
+			require.NoError(t, writeConfigFiles(t.Context(), dir, cfg))
 
 			body, err := os.ReadFile(filepath.Join(dir, "secrets.yaml"))
 			require.NoError(t, err)
@@ -123,7 +123,7 @@ func TestRenderSecretsPureBareMetalEmitsAPIToken(t *testing.T) {
 		HetznerRobotPassword: "p",
 	}
 	dir := t.TempDir()
-	require.NoError(t, writeConfigFiles(dir, cfg))
+	require.NoError(t, writeConfigFiles(t.Context(), dir, cfg))
 
 	body, err := os.ReadFile(filepath.Join(dir, "secrets.yaml"))
 	require.NoError(t, err)
@@ -174,7 +174,7 @@ func TestRenderSecretsOmitsRobotWhenIncomplete(t *testing.T) {
 				HetznerRobotPassword: tc.password,
 			}
 			dir := t.TempDir()
-			require.NoError(t, writeConfigFiles(dir, cfg))
+			require.NoError(t, writeConfigFiles(t.Context(), dir, cfg))
 
 			body, err := os.ReadFile(filepath.Join(dir, "secrets.yaml"))
 			require.NoError(t, err)
@@ -201,7 +201,7 @@ func TestRenderSecretsNetBirdAPIKey(t *testing.T) {
 			NetBirdAPIKey:   "nbp_tok#en",
 		}
 		dir := t.TempDir()
-		require.NoError(t, writeConfigFiles(dir, cfg))
+		require.NoError(t, writeConfigFiles(t.Context(), dir, cfg))
 
 		body, err := os.ReadFile(filepath.Join(dir, "secrets.yaml"))
 		require.NoError(t, err)
@@ -220,7 +220,7 @@ func TestRenderSecretsNetBirdAPIKey(t *testing.T) {
 			HetznerAPIToken: "fake-api-token",
 		}
 		dir := t.TempDir()
-		require.NoError(t, writeConfigFiles(dir, cfg))
+		require.NoError(t, writeConfigFiles(t.Context(), dir, cfg))
 
 		body, err := os.ReadFile(filepath.Join(dir, "secrets.yaml"))
 		require.NoError(t, err)
@@ -240,7 +240,7 @@ func TestRenderObmondoSupportConfig(t *testing.T) {
 	}
 
 	dir := t.TempDir()
-	require.NoError(t, writeConfigFiles(dir, cfg))
+	require.NoError(t, writeConfigFiles(t.Context(), dir, cfg))
 
 	generalBody, err := os.ReadFile(filepath.Join(dir, "general.yaml"))
 	require.NoError(t, err)
@@ -4,6 +4,7 @@
 package prompt
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -15,6 +16,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/sops"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/render"
 )
@@ -106,7 +108,7 @@ func promptLoadExistingConfig(configsDirectory string) (bool, error) {
 	return loadExisting, nil
 }
 
-func loadExistingPromptedConfig(configsDirectory string, cfg *PromptedConfig) error {
+func loadExistingPromptedConfig(ctx context.Context, configsDirectory string, cfg *PromptedConfig) error {
 	if cfg == nil {
 		return errors.New("prompted config is nil")
 	}
@@ -128,7 +130,7 @@ func loadExistingPromptedConfig(configsDirectory string, cfg *PromptedConfig) er
 	}
 
 	secretsLoaded := false
-	if data, err := os.ReadFile(secretsPath); err == nil {
+	if data, err := sops.ReadFile(ctx, secretsPath); err == nil {
 		secretsLoaded = true
 		var secrets config.SecretsConfig
 		if err := yaml.Unmarshal(data, &secrets); err != nil {
@@ -146,8 +148,8 @@ func loadExistingPromptedConfig(configsDirectory string, cfg *PromptedConfig) er
 	return nil
 }
 
-func loadExistingPromptedConfigIfPresent(configsDirectory string, cfg *PromptedConfig) (bool, error) {
-	if err := loadExistingPromptedConfig(configsDirectory, cfg); err != nil {
+func loadExistingPromptedConfigIfPresent(ctx context.Context, configsDirectory string, cfg *PromptedConfig) (bool, error) {
+	if err := loadExistingPromptedConfig(ctx, configsDirectory, cfg); err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			return false, nil
 		}
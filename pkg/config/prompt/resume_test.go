@@ -137,7 +137,7 @@ func TestLoadExistingPromptedConfig(t *testing.T) {
 			copyTestdataConfig(t, dir, tc.fixtureDir, "secrets.yaml")
 
 			cfg := &PromptedConfig{}
-			require.NoError(t, loadExistingPromptedConfig(dir, cfg))
+			require.NoError(t, loadExistingPromptedConfig(t.Context(), dir, cfg))
 
 			assert.Equal(t, tc.want, cfg)
 		})
@@ -174,10 +174,10 @@ func TestLoadExistingPromptedConfigHetznerBareMetalTopology(t *testing.T) {
 		"1234571": "198.51.100.11",
 	}
 
-	require.NoError(t, writeConfigFiles(dir, want))
+	require.NoError(t, writeConfigFiles(t.Context(), dir, want))
 
 	got := &PromptedConfig{}
-	require.NoError(t, loadExistingPromptedConfig(dir, got))
+	require.NoError(t, loadExistingPromptedConfig(t.Context(), dir, got))
 
 	assert.Equal(t, constants.CloudProviderHetzner, got.CloudProvider)
 	assert.Equal(t, constants.HetznerModeBareMetal, got.HetznerMode)
@@ -223,10 +223,10 @@ func TestLoadExistingPromptedConfigHetznerHybridTopology(t *testing.T) {
 		"1234571": "198.51.100.11",
 	}
 
-	require.NoError(t, writeConfigFiles(dir, want))
+	require.NoError(t, writeConfigFiles(t.Context(), dir, want))
 
 	got := &PromptedConfig{}
-	require.NoError(t, loadExistingPromptedConfig(dir, got))
+	require.NoError(t, loadExistingPromptedConfig(t.Context(), dir, got))
 
 	assert.Equal(t, constants.CloudProviderHetzner, got.CloudProvider)
 	assert.Equal(t, constants.HetznerModeHybrid, got.HetznerMode)
@@ -269,10 +269,10 @@ func TestLoadExistingPromptedConfigHetznerHCloudWorkerPools(t *testing.T) {
 		{Name: "batch-arm", MachineType: "cax41", MinSize: "1", MaxSize: "4", CPU: "16", Memory: "32"},
 	}
 
-	require.NoError(t, writeConfigFiles(dir, want))
+	require.NoError(t, writeConfigFiles(t.Context(), dir, want))
 
 	got := &PromptedConfig{}
-	require.NoError(t, loadExistingPromptedConfig(dir, got))
+	require.NoError(t, loadExistingPromptedConfig(t.Context(), dir, got))
 
 	assert.Equal(t, constants.HetznerModeHCloud, got.HetznerMode)
 	assert.Equal(t, want.HetznerNodeGroups, got.HetznerNodeGroups,
@@ -328,7 +328,7 @@ func TestLoadExistingPromptedConfigOverridesThePreFilledClusterName(t *testing.T
 	))
 
 	cfg := &PromptedConfig{ClusterName: "demo-02"}
-	require.NoError(t, loadExistingPromptedConfig(dir, cfg))
+	require.NoError(t, loadExistingPromptedConfig(t.Context(), dir, cfg))
 
 	assert.Equal(t, "old-cluster", cfg.ClusterName)
 }
@@ -81,6 +81,23 @@ func SSHGitURL(s string) error {
 	return nil
 }
 
+// GitURL validates that s is a non-empty Git URL : SSH form (scp-like
+// git@host:path or ssh://…), or an HTTP(S) URL naming a host and a
+// repository path.
+func GitURL(s string) error {
+	if err := NonEmpty(s); err != nil {
+		return err
+	}
+	s = strings.TrimSpace(s)
+	if !repourl.UsingHTTPBasedProtocol(s) {
+		return nil
+	}
+	if u, err := url.Parse(s); err != nil || u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return errors.New("must be an HTTP(S) URL of a repository (e.g. https://github.com/org/repo.git)")
+	}
+	return nil
+}
+
 // IPv4 validates that s is a non-empty IPv4 address.
 func IPv4(s string) error {
 	if err := NonEmpty(s); err != nil {
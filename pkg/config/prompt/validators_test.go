@@ -101,6 +101,33 @@ func TestSSHGitURL(t *testing.T) {
 	}
 }
 
+func TestGitURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr bool
+	}{
+		{name: "scp-like form passes", in: "git@github.com:acme/repo.git", wantErr: false},
+		{name: "ssh:// form passes", in: "ssh://git@github.com/acme/repo.git", wantErr: false},
+		{name: "https passes", in: "https://github.com/acme/repo.git", wantErr: false},
+		{name: "http passes", in: "http://gitea.local:3000/acme/repo.git", wantErr: false},
+		{name: "https without a host fails", in: "https:///acme/repo.git", wantErr: true},
+		{name: "https without a repository path fails", in: "https://github.com/", wantErr: true},
+		{name: "empty fails", in: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := GitURL(tc.in)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestIPv4(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -33,7 +33,7 @@ func TestWriteConfigFilesMatchesRender(t *testing.T) {
 	require.NoError(t, err)
 
 	dir := t.TempDir()
-	require.NoError(t, writeConfigFiles(dir, cfg))
+	require.NoError(t, writeConfigFiles(t.Context(), dir, cfg))
 
 	gotGeneral, err := os.ReadFile(filepath.Join(dir, "general.yaml"))
 	require.NoError(t, err)
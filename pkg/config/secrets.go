@@ -11,18 +11,121 @@ type (
 		Keycloak *KeycloakCredentials `yaml:"keycloak"`
 		NetBird  *NetBirdCredentials  `yaml:"netbird"`
 		ACME     *ACMECredentials     `yaml:"acme"`
+		DNS      *DNSCredentials      `yaml:"dns"`
+
+		ObjectStorage *ObjectStorageCredentials `yaml:"objectStorage"`
+		Git           *GitCredentials           `yaml:"git"`
+	}
+
+	GitCredentials struct {
+		// API token of the forge hosting the KubeAid Config repository, used to open pull
+		// requests and poll their merge status : a GitHub fine-grained PAT (Pull requests: write),
+		// a GitLab PAT / project access token (api scope) or a Gitea access token
+		// (write:repository).
+		//
+		//nolint:gosec // This struct intentionally models a user-provided API token.
+		ForgeToken string `yaml:"forgeToken"`
+
+		// Access token KubeAid CLI authenticates to the Git server with, over HTTPS : a GitHub /
+		// GitLab / Gitea personal access token, or a GitLab project access token, allowed to read
+		// and write the KubeAid Config repository. Only used when the KubeAid Config repository
+		// URL is an HTTP(S) one.
+		//
+		//nolint:gosec // This struct intentionally models a user-provided access token.
+		Token string `yaml:"token"`
+
+		// Username sent along with the access token (x-access-token, when blank). Any non-empty
+		// value works for GitHub and GitLab tokens; Gitea and Bitbucket want the token owner's
+		// username.
+		Username string `yaml:"username"`
+
+		// PEM encoded private key of the GitHub App configured in git.https.githubApp.
+		//
+		//nolint:gosec // This struct intentionally models a user-provided private key.
+		GitHubAppPrivateKey string `yaml:"githubAppPrivateKey"`
+	}
+
+	// ObjectStorageCredentials is the access key pair for the S3-compatible object storage
+	// configured in cloud.disasterRecovery.s3. Sealed into the Velero and Sealed Secrets
+	// backuper credential Secrets, and used by KubeAid CLI itself to create the buckets and
+	// download the backups during a disaster recovery.
+	ObjectStorageCredentials struct {
+		AccessKeyID string `yaml:"accessKeyID" validate:"notblank"`
+		//nolint:gosec // This struct intentionally models user-provided object storage credentials.
+		SecretAccessKey string `yaml:"secretAccessKey" validate:"notblank"`
 	}
 
 	// ACMECredentials carries the DNS-provider secrets the cert-manager
 	// ClusterIssuer's DNS-01 solver authenticates with. Only needed when
-	// cluster.acmeDNS01 is set.
+	// cluster.acmeDNS01 is set, and the provider's isn't already in
+	// secrets.yaml for cluster.dnsRecords.
 	ACMECredentials struct {
 		// CloudflareAPIToken is a Cloudflare API token with Zone:Read +
 		// DNS:Edit on the zones the solver manages (the TXT challenge
-		// records). Sealed into the cert-manager/cloudflare-api-token
-		// Secret the ClusterIssuer references.
+		// records). Falls back to dns.cloudflareApiToken, when blank.
+		// Sealed into the cert-manager/cloudflare-api-token Secret the
+		// ClusterIssuer references.
 		//nolint:gosec // This struct intentionally models a user-provided API token.
 		CloudflareAPIToken string `yaml:"cloudflareApiToken"`
+
+		// Route53 is the access key pair of an IAM user allowed to
+		// change the hosted zone's records. Sealed into the
+		// cert-manager/route53-credentials Secret.
+		Route53 *ACMERoute53Credentials `yaml:"route53"`
+
+		// AzureDNS is the client secret of the service principal named
+		// in cluster.acmeDNS01.azureDNS. Sealed into the
+		// cert-manager/azuredns-credentials Secret.
+		AzureDNS *ACMEAzureDNSCredentials `yaml:"azureDNS"`
+
+		// Hetzner is the Hetzner API token the webhook authenticates
+		// with. Falls back to hetzner.apiToken, when not set. Sealed into
+		// the cert-manager/hetzner-dns-api-token Secret.
+		Hetzner *ACMEHetznerCredentials `yaml:"hetzner"`
+
+		// RFC2136 is the secret of the TSIG key named in
+		// cluster.acmeDNS01.rfc2136.tsigKeyName. Falls back to
+		// dns.tsigSecret, when not set and the key is
+		// cluster.dnsRecords.rfc2136's. Sealed into the
+		// cert-manager/rfc2136-tsig-secret Secret.
+		RFC2136 *ACMERFC2136Credentials `yaml:"rfc2136"`
+	}
+
+	ACMERoute53Credentials struct {
+		AccessKeyID     string `yaml:"accessKeyID"     validate:"notblank"`
+		SecretAccessKey string `yaml:"secretAccessKey" validate:"notblank"`
+	}
+
+	ACMEAzureDNSCredentials struct {
+		//nolint:gosec // This struct intentionally models user-provided Azure credentials.
+		ClientSecret string `yaml:"clientSecret" validate:"notblank"`
+	}
+
+	ACMEHetznerCredentials struct {
+		//nolint:gosec // This struct intentionally models a user-provided API token.
+		APIToken string `yaml:"apiToken" validate:"notblank"`
+	}
+
+	ACMERFC2136Credentials struct {
+		// TSIGSecret is the base64 encoded secret of the TSIG key.
+		//nolint:gosec // This struct intentionally models a user-provided TSIG key.
+		TSIGSecret string `yaml:"tsigSecret" validate:"notblank,base64"`
+	}
+
+	// DNSCredentials carries the secrets of the DNS provider
+	// KubeAid CLI manages the cluster's A records in
+	// (cluster.dnsRecords). Hetzner DNS uses hetzner.apiToken instead.
+	DNSCredentials struct {
+		// CloudflareAPIToken is a Cloudflare API token with Zone:Read +
+		// DNS:Edit on the zones the cluster's FQDNs live in. Falls back
+		// to acme.cloudflareApiToken, when blank.
+		//nolint:gosec // This struct intentionally models a user-provided API token.
+		CloudflareAPIToken string `yaml:"cloudflareApiToken"`
+
+		// TSIGSecret is the base64 encoded secret of the TSIG key named
+		// in cluster.dnsRecords.rfc2136.tsigKeyName.
+		//nolint:gosec // This struct intentionally models a user-provided TSIG key.
+		TSIGSecret string `yaml:"tsigSecret"`
 	}
 
 	// KeycloakCredentials carries Keycloak-related secrets — admin
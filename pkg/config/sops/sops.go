@@ -0,0 +1,323 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sops reads and writes SOPS encrypted config files (secrets.yaml), so a per-cluster
+// config directory can be committed to git.
+//
+// It shells out to the sops CLI, which is only required once an encrypted file is met. Age keys
+// are picked up the way sops itself picks them up (SOPS_AGE_KEY_FILE, SOPS_AGE_KEY, or
+// ~/.config/sops/age/keys.txt), and PGP keys through the local gpg-agent. Plaintext is piped
+// through sops' stdin / stdout, and so never touches disk.
+package sops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/renameio"
+	"gopkg.in/yaml.v3"
+)
+
+// binary is the sops CLI executable.
+const binary = "sops"
+
+// configFileName is sops' own config file, whose creation_rules decide which files get
+// encrypted, and for whom.
+const configFileName = ".sops.yaml"
+
+// metadata is the part of the top-level `sops` key of an encrypted file, needed to re-encrypt
+// it for the same recipients, the same way.
+type metadata struct {
+	Age []struct {
+		Recipient string `yaml:"recipient"`
+	} `yaml:"age"`
+
+	PGP []struct {
+		Fingerprint string `yaml:"fp"`
+	} `yaml:"pgp"`
+
+	// Any other key kind (KMS, GCP KMS, Azure Key Vault, HashiCorp Vault) or key groups, which
+	// can't be re-encrypted for from the command line.
+	KMS       []any `yaml:"kms"`
+	GCPKMS    []any `yaml:"gcp_kms"`
+	AzureKV   []any `yaml:"azure_kv"`
+	HCVault   []any `yaml:"hc_vault"`
+	KeyGroups []any `yaml:"key_groups"`
+
+	MAC string `yaml:"mac"`
+
+	EncryptedRegex    string `yaml:"encrypted_regex"`
+	UnencryptedRegex  string `yaml:"unencrypted_regex"`
+	EncryptedSuffix   string `yaml:"encrypted_suffix"`
+	UnencryptedSuffix string `yaml:"unencrypted_suffix"`
+}
+
+// readMetadata returns the sops metadata of data, and whether data is SOPS encrypted at all :
+// a YAML mapping with a top-level `sops` mapping carrying a MAC.
+func readMetadata(data []byte) (*metadata, bool) {
+	var document struct {
+		SOPS *metadata `yaml:"sops"`
+	}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, false
+	}
+
+	if (document.SOPS == nil) || (document.SOPS.MAC == "") {
+		return nil, false
+	}
+	return document.SOPS, true
+}
+
+// IsEncrypted reports whether data is a SOPS encrypted YAML document.
+func IsEncrypted(data []byte) bool {
+	_, encrypted := readMetadata(data)
+	return encrypted
+}
+
+// Decrypt returns the plaintext of the SOPS encrypted YAML document data.
+func Decrypt(ctx context.Context, data []byte) ([]byte, error) {
+	plaintext, err := run(ctx, data,
+		"--decrypt", "--input-type", "yaml", "--output-type", "yaml", "/dev/stdin",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypting with sops: %w", err)
+	}
+	return plaintext, nil
+}
+
+// ReadFile reads the YAML file at filePath, decrypting it when it's SOPS encrypted.
+func ReadFile(ctx context.Context, filePath string) ([]byte, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+
+	plaintext, err := Decrypt(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypting %s: %w", filePath, err)
+	}
+	return plaintext, nil
+}
+
+/*
+WriteFile atomically writes the YAML document plaintext to filePath. It gets SOPS encrypted first
+when either :
+
+	(1) the file it replaces is SOPS encrypted : then, for the same age / PGP recipients, the same
+	    way (encrypted / unencrypted regex or suffix).
+
+	(2) a creation rule in the nearest .sops.yaml (looked up from filePath's directory upwards)
+	    covers filePath.
+
+Otherwise, plaintext is written as is.
+*/
+func WriteFile(ctx context.Context, filePath string, plaintext []byte, perm fs.FileMode) error {
+	args, encrypt, err := encryptArgs(filePath)
+	if err != nil {
+		return err
+	}
+
+	data := plaintext
+	if encrypt {
+		data, err = run(ctx, plaintext, args...)
+		if err != nil {
+			return fmt.Errorf("failed encrypting %s with sops: %w", filePath, err)
+		}
+	}
+
+	if err := renameio.WriteFile(filePath, data, perm); err != nil {
+		return fmt.Errorf("failed writing %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// encryptArgs returns the sops arguments encrypting a YAML document from stdin, the way WriteFile
+// needs the file at filePath encrypted. And whether it needs to be encrypted at all.
+func encryptArgs(filePath string) ([]string, bool, error) {
+	args := []string{
+		"--encrypt", "--input-type", "yaml", "--output-type", "yaml",
+		// Creation rules get matched against this path, rather than /dev/stdin.
+		"--filename-override", filePath,
+	}
+
+	// (1) Re-encrypt an encrypted file for the same recipients.
+	existing, err := os.ReadFile(filePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, false, fmt.Errorf("failed reading %s: %w", filePath, err)
+	}
+	if existingMetadata, encrypted := readMetadata(existing); encrypted {
+		reencryptArgs, err := existingMetadata.reencryptArgs()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed re-encrypting %s: %w", filePath, err)
+		}
+		return append(append(args, reencryptArgs...), "/dev/stdin"), true, nil
+	}
+
+	// (2) Encrypt as a .sops.yaml creation rule says.
+	configFilePath, err := findConfigFile(filepath.Dir(filePath))
+	if err != nil {
+		return nil, false, err
+	}
+	if configFilePath == "" {
+		return nil, false, nil
+	}
+
+	covered, err := creationRuleCovers(configFilePath, filePath)
+	if err != nil {
+		return nil, false, err
+	}
+	if !covered {
+		return nil, false, nil
+	}
+	return append(args, "--config", configFilePath, "/dev/stdin"), true, nil
+}
+
+// reencryptArgs returns the sops arguments encrypting for the recipients m was encrypted for, the
+// way it was encrypted.
+func (m *metadata) reencryptArgs() ([]string, error) {
+	if (len(m.KMS) > 0) || (len(m.GCPKMS) > 0) || (len(m.AzureKV) > 0) || (len(m.HCVault) > 0) ||
+		(len(m.KeyGroups) > 0) {
+		return nil, errors.New(
+			"only age and PGP recipients are supported, not cloud KMS / Vault keys or key groups",
+		)
+	}
+
+	var args []string
+
+	ageRecipients := make([]string, 0, len(m.Age))
+	for _, age := range m.Age {
+		ageRecipients = append(ageRecipients, age.Recipient)
+	}
+	if len(ageRecipients) > 0 {
+		args = append(args, "--age", strings.Join(ageRecipients, ","))
+	}
+
+	pgpFingerprints := make([]string, 0, len(m.PGP))
+	for _, pgp := range m.PGP {
+		pgpFingerprints = append(pgpFingerprints, pgp.Fingerprint)
+	}
+	if len(pgpFingerprints) > 0 {
+		args = append(args, "--pgp", strings.Join(pgpFingerprints, ","))
+	}
+
+	if len(args) == 0 {
+		return nil, errors.New("no age or PGP recipient found in its sops metadata")
+	}
+
+	for _, option := range [][2]string{
+		{"--encrypted-regex", m.EncryptedRegex},
+		{"--unencrypted-regex", m.UnencryptedRegex},
+		{"--encrypted-suffix", m.EncryptedSuffix},
+		{"--unencrypted-suffix", m.UnencryptedSuffix},
+	} {
+		if option[1] != "" {
+			args = append(args, option[0], option[1])
+		}
+	}
+
+	return args, nil
+}
+
+// findConfigFile returns the path of the nearest .sops.yaml, looked up from directory upwards.
+// "" when there's none.
+func findConfigFile(directory string) (string, error) {
+	directory, err := filepath.Abs(directory)
+	if err != nil {
+		return "", fmt.Errorf("failed resolving %s: %w", directory, err)
+	}
+
+	for {
+		configFilePath := filepath.Join(directory, configFileName)
+
+		_, err := os.Stat(configFilePath)
+		switch {
+		case err == nil:
+			return configFilePath, nil
+
+		case !errors.Is(err, fs.ErrNotExist):
+			return "", fmt.Errorf("failed checking %s: %w", configFilePath, err)
+		}
+
+		parent := filepath.Dir(directory)
+		if parent == directory {
+			return "", nil
+		}
+		directory = parent
+	}
+}
+
+// creationRuleCovers reports whether a creation rule in the .sops.yaml at configFilePath applies
+// to filePath : one without a path_regex, or whose path_regex matches filePath relative to the
+// .sops.yaml's directory (or, like sops, as is).
+func creationRuleCovers(configFilePath, filePath string) (bool, error) {
+	data, err := os.ReadFile(configFilePath)
+	if err != nil {
+		return false, fmt.Errorf("failed reading %s: %w", configFilePath, err)
+	}
+
+	var config struct {
+		CreationRules []struct {
+			PathRegex string `yaml:"path_regex"`
+		} `yaml:"creation_rules"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return false, fmt.Errorf("failed parsing %s: %w", configFilePath, err)
+	}
+
+	candidates := []string{filePath}
+	if absoluteFilePath, err := filepath.Abs(filePath); err == nil {
+		if relativeFilePath, err := filepath.Rel(filepath.Dir(configFilePath), absoluteFilePath); err == nil {
+			candidates = append(candidates, filepath.ToSlash(relativeFilePath))
+		}
+	}
+
+	for _, rule := range config.CreationRules {
+		if rule.PathRegex == "" {
+			return true, nil
+		}
+
+		pathRegex, err := regexp.Compile(rule.PathRegex)
+		if err != nil {
+			return false, fmt.Errorf("failed compiling path_regex %q in %s: %w", rule.PathRegex, configFilePath, err)
+		}
+		for _, candidate := range candidates {
+			if pathRegex.MatchString(candidate) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// run runs sops with args, piping stdin to it, and returns what it prints to stdout.
+func run(ctx context.Context, stdin []byte, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath(binary); err != nil {
+		return nil, fmt.Errorf("%s isn't installed, but is required for SOPS encrypted config files: %w",
+			binary, err,
+		)
+	}
+
+	cmd := exec.CommandContext(ctx, binary, args...) //nolint:gosec // G204: args are built here, from sops metadata and paths.
+	cmd.Stdin = bytes.NewReader(stdin)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w (sops stderr: %s)", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
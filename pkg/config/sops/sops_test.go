@@ -0,0 +1,169 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package sops
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const encryptedSecretsYAML = `hetzner:
+  apiToken: ENC[AES256_GCM,data:Zm9v,iv:YmFy,tag:YmF6,type:str]
+sops:
+  age:
+    - recipient: age1examplerecipient0
+      enc: |
+        -----BEGIN AGE ENCRYPTED FILE-----
+        -----END AGE ENCRYPTED FILE-----
+    - recipient: age1examplerecipient1
+  pgp:
+    - fp: 85D77543B3D624B63CEA9E6DBC17301B491B3F21
+  encrypted_regex: ^(apiToken|password)$
+  lastmodified: "2026-10-17T10:00:00Z"
+  mac: ENC[AES256_GCM,data:bWFj,iv:aXY=,tag:dGFn,type:str]
+  version: 3.10.2
+`
+
+func TestIsEncrypted(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		data     string
+		expected bool
+	}{
+		{"encrypted", encryptedSecretsYAML, true},
+		{"plaintext", "hetzner:\n  apiToken: foo\n", false},
+		{"a sops key, without a MAC", "sops:\n  age: []\n", false},
+		{"empty", "", false},
+		{"not YAML", "{{ not yaml", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, IsEncrypted([]byte(tc.data)))
+		})
+	}
+}
+
+func TestReencryptArgs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("age and PGP recipients, and the encrypted regex", func(t *testing.T) {
+		t.Parallel()
+
+		m, encrypted := readMetadata([]byte(encryptedSecretsYAML))
+		require.True(t, encrypted)
+
+		args, err := m.reencryptArgs()
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"--age", "age1examplerecipient0,age1examplerecipient1",
+			"--pgp", "85D77543B3D624B63CEA9E6DBC17301B491B3F21",
+			"--encrypted-regex", "^(apiToken|password)$",
+		}, args)
+	})
+
+	t.Run("cloud KMS keys aren't supported", func(t *testing.T) {
+		t.Parallel()
+
+		m, encrypted := readMetadata([]byte("sops:\n  kms:\n    - arn: arn:aws:kms:eu-west-1:1:key/x\n  mac: x\n"))
+		require.True(t, encrypted)
+
+		_, err := m.reencryptArgs()
+		require.Error(t, err)
+	})
+
+	t.Run("no recipients", func(t *testing.T) {
+		t.Parallel()
+
+		m, encrypted := readMetadata([]byte("sops:\n  mac: x\n"))
+		require.True(t, encrypted)
+
+		_, err := m.reencryptArgs()
+		require.Error(t, err)
+	})
+}
+
+func TestEncryptArgs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("an encrypted file gets re-encrypted for the same recipients", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "secrets.yaml")
+		require.NoError(t, os.WriteFile(filePath, []byte(encryptedSecretsYAML), 0o600))
+
+		args, encrypt, err := encryptArgs(filePath)
+		require.NoError(t, err)
+		assert.True(t, encrypt)
+		assert.Contains(t, args, "age1examplerecipient0,age1examplerecipient1")
+		assert.Equal(t, "/dev/stdin", args[len(args)-1])
+	})
+
+	t.Run("a file covered by a creation rule in a parent directory's .sops.yaml", func(t *testing.T) {
+		t.Parallel()
+
+		root := t.TempDir()
+		configFilePath := filepath.Join(root, ".sops.yaml")
+		require.NoError(t, os.WriteFile(configFilePath, []byte(
+			"creation_rules:\n  - path_regex: configs/secrets\\.yaml$\n    age: age1examplerecipient0\n",
+		), 0o600))
+
+		configsDirectory := filepath.Join(root, "demo", "configs")
+		require.NoError(t, os.MkdirAll(configsDirectory, 0o700))
+
+		args, encrypt, err := encryptArgs(filepath.Join(configsDirectory, "secrets.yaml"))
+		require.NoError(t, err)
+		assert.True(t, encrypt)
+		assert.Equal(t, []string{"--config", configFilePath, "/dev/stdin"}, args[len(args)-3:])
+
+		// general.yaml isn't covered.
+		_, encrypt, err = encryptArgs(filepath.Join(configsDirectory, "general.yaml"))
+		require.NoError(t, err)
+		assert.False(t, encrypt)
+	})
+
+	t.Run("a creation rule without a path_regex covers every file", func(t *testing.T) {
+		t.Parallel()
+
+		root := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(root, ".sops.yaml"), []byte(
+			"creation_rules:\n  - age: age1examplerecipient0\n",
+		), 0o600))
+
+		_, encrypt, err := encryptArgs(filepath.Join(root, "secrets.yaml"))
+		require.NoError(t, err)
+		assert.True(t, encrypt)
+	})
+}
+
+func TestWriteFilePlaintext(t *testing.T) {
+	t.Parallel()
+
+	// Neither an encrypted file to replace, nor a .sops.yaml (up to the filesystem root, in a
+	// fresh temp directory) : written as is, without needing sops.
+	filePath := filepath.Join(t.TempDir(), "secrets.yaml")
+	if configFilePath, err := findConfigFile(filepath.Dir(filePath)); (err != nil) || (configFilePath != "") {
+		t.Skipf("a %s above the temp directory decides encryption : %q", configFileName, configFilePath)
+	}
+
+	plaintext := []byte("hetzner:\n  apiToken: foo\n")
+	require.NoError(t, WriteFile(context.Background(), filePath, plaintext, 0o600))
+
+	written, err := ReadFile(context.Background(), filePath)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, written)
+
+	info, err := os.Stat(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
@@ -15,6 +15,16 @@ const (
 	EnvNameSSHAuthSock   = "SSH_AUTH_SOCK"
 	EnvNameSSHKnownHosts = "SSH_KNOWN_HOSTS"
 
+	// EnvNameSSHKeyPassphrase and EnvNameSSHKeyPassphraseFD supply the passphrase of
+	// passphrase protected SSH private keys, for unattended runs : directly, or as the number of
+	// a file descriptor to read it from.
+	EnvNameSSHKeyPassphrase   = "KUBEAID_CLI_SSH_KEY_PASSPHRASE"
+	EnvNameSSHKeyPassphraseFD = "KUBEAID_CLI_SSH_KEY_PASSPHRASE_FD"
+
+	// EnvNameSSHKeyAgentSocket points KubeOne at the in-process SSH agent serving the unlocked
+	// passphrase protected SSH private keys. Referred to by the KubeOne manifest template.
+	EnvNameSSHKeyAgentSocket = "KUBEAID_CLI_SSH_KEY_AGENT_SOCK"
+
 	EnvNameAWSAccessKey            = "AWS_ACCESS_KEY_ID"
 	EnvNameAWSSecretKey            = "AWS_SECRET_ACCESS_KEY"
 	EnvNameAWSSessionToken         = "AWS_SESSION_TOKEN"
@@ -26,14 +36,50 @@ const (
 	EnvNameRobotPassword = "ROBOT_PASSWORD"
 
 	EnvNameKubeconfig = "KUBECONFIG"
+
+	// EnvNameGitToken supplies the access token KubeAid CLI authenticates to the Git server with,
+	// over HTTPS, when secrets.yaml doesn't (git.token).
+	EnvNameGitToken = "KUBEAID_CLI_GIT_TOKEN"
 )
 
 // CLI flags.
 const (
 	FlagNameDebug = "debug"
 
+	// FlagNameNonInteractive makes every approval point take its decision from the answers
+	// file (FlagNameAnswersFile), failing the run when one's missing, instead of prompting.
+	FlagNameNonInteractive = "non-interactive"
+	FlagNameAnswersFile    = "answers-file"
+
 	FlagNameOutput = "output"
 
+	// FlagNameFailOn and FlagNameMaxAge turn `backup status` into a gate : the least severe
+	// backup status that makes it exit non-zero, and per-operator RPOs stricter than
+	// backup-exporter's.
+	FlagNameFailOn = "fail-on"
+	FlagNameMaxAge = "max-age"
+
+	// Flags of `backup create`, `backup list` and `backup restore`, named like the velero CLI's.
+	FlagNameIncludeNamespaces = "include-namespaces"
+	FlagNameExcludeNamespaces = "exclude-namespaces"
+	FlagNameSelector          = "selector"
+	FlagNameTTL               = "ttl"
+	FlagNameSortBy            = "sort-by"
+	FlagNameLimit             = "limit"
+	FlagNamePage              = "page"
+	FlagNameNamespaceMapping  = "namespace-mapping"
+	FlagNameRestorePVs        = "restore-pvs"
+
+	// Flags of `backup verify` : the namespace to restore drill, how long the drill may take, and
+	// whether to keep its scratch namespace around afterwards.
+	FlagNameNamespace = "namespace"
+	FlagNameTimeout   = "timeout"
+	FlagNameKeep      = "keep"
+
+	// FlagNameEventsFile names a file, the JSON event stream of a long-running cluster command
+	// gets appended to. Same events as --output=events, without taking over stdout.
+	FlagNameEventsFile = "events-file"
+
 	FlagNameKubeAidVersion = "kubeaid-version"
 
 	FlagNameManagementClusterName = "management-cluster-name"
@@ -55,11 +101,21 @@ const (
 	// both are given.
 	FlagNameClusterName = "cluster-name"
 
+	// FlagNameRender makes `config validate` also render the kubeaid-config files and validate
+	// the Helm values among them, against the KubeAid Helm charts.
+	FlagNameRender = "render"
+
 	FlagNameSkipMonitoringSetup = "skip-monitoring-setup"
 	FlagNameSkipPRWorkflow      = "skip-pr-workflow"
 	FlagNameSkipClusterctlMove  = "skip-clusterctl-move"
+	FlagNameResume              = "resume"
+	FlagNameSyncTimeout         = "sync-timeout"
 	FlagNameYes                 = "yes"
 
+	// FlagNameOnly narrows `secrets reseal` down to a single Sealed Secret file, given by its
+	// path relative to the cluster directory in kubeaid-config.
+	FlagNameOnly = "only"
+
 	// FlagNameToken takes the short-lived bootstrap token the Obmondo
 	// portal's add-cluster flow issues, and fetches that cluster's rendered
 	// general.yaml and secrets.yaml instead of running `config generate`.
@@ -100,6 +156,29 @@ const (
 
 	FlagNameOSSize      = "os-size"
 	FlagNameZFSPoolSize = "zfs-pool-size"
+
+	// Flags of `kubeaid-storagectl plan`, declaring the storage layout. See
+	// storagetypes.StorageLayout.
+	FlagNameZFSPoolType  = "zfs-pool-type"
+	FlagNameZFSPoolDisks = "zfs-pool-disks"
+	FlagNameZFSDataset   = "zfs-dataset"
+	FlagNameExcludeCeph  = "exclude-ceph"
+
+	// Flags of `kubeaid-storagectl plan`, overriding which disks the storage planner considers.
+	// See storagetypes.DiskSelection.
+	FlagNameIncludeDisk = "include-disk"
+	FlagNameExcludeDisk = "exclude-disk"
+
+	// Flags of `kubeaid-storagectl plan verify`, for verifying a remote host over SSH, instead of
+	// the local one.
+	FlagNameHost          = "host"
+	FlagNameSSHUser       = "ssh-user"
+	FlagNameSSHPort       = "ssh-port"
+	FlagNameSSHPrivateKey = "ssh-private-key"
+
+	// FlagNameAllowWipeWWN lets `kubeaid-storagectl plan execute` wipe the disk with the given
+	// WWN, when the preflight finds data on it.
+	FlagNameAllowWipeWWN = "allow-wipe-wwn"
 )
 
 // Kube API server CLI flags.
@@ -117,12 +196,27 @@ const (
 	CloudProviderLocal     = "local"
 )
 
+// DNS providers the cert-manager ClusterIssuer's DNS-01 solver supports.
+const (
+	ACMEDNS01ProviderCloudflare = "cloudflare"
+	ACMEDNS01ProviderRoute53    = "route53"
+	ACMEDNS01ProviderAzureDNS   = "azuredns"
+	ACMEDNS01ProviderHetzner    = "hetzner"
+	ACMEDNS01ProviderRFC2136    = "rfc2136"
+)
+
 // Disk types.
 const (
 	DiskTypeHDD  = "HDD"
 	DiskTypeSSD  = "SSD"
 	DiskTypeNVMe = "NVMe"
 
+	// DiskTypeSASSSD is a SAS attached SSD. A SAS attached HDD is a DiskTypeHDD.
+	DiskTypeSASSSD = "SAS SSD"
+
+	// DiskTypeVirtio is a paravirtualized disk (virtio-blk or virtio-scsi), of a virtual machine.
+	DiskTypeVirtio = "virtio"
+
 	DiskTypeUnknown = "Unknown"
 )
 
@@ -130,6 +224,11 @@ const HighSpeedNICThreshold = 5000 // GBPS.
 
 const OSDefaultSize = 50 // GB.
 
+// StoragePlanRecordPath is where `kubeaid-storagectl plan execute` records the storage plan it
+// executed (as JSON) on the node, for `kubeaid-storagectl plan verify` to compare the node
+// against later : like which disk (by WWN) got which partitions.
+const StoragePlanRecordPath = "/var/lib/kubeaid-storagectl/storage-plan.json"
+
 // ZFS.
 const (
 	ZFSPoolDefaultSize = (ZFSVolumeSizeContainerImages + ZFSVolumeSizePodLogs + ZFSVolumeSizePodEphemeralVolumes) + 20 // = 220 GB.
@@ -139,6 +238,17 @@ const (
 	ZFSVolumeSizePodEphemeralVolumes = 50
 )
 
+// ZFSPoolName is the name of the ZFS pool the storage plan executor creates on a bare-metal node.
+const ZFSPoolName = "primary"
+
+// ZFS pool types : the vdev the ZFS pool gets created as.
+const (
+	ZFSPoolTypeMirror = "mirror"
+	ZFSPoolTypeRAIDZ1 = "raidz1"
+	ZFSPoolTypeRAIDZ2 = "raidz2"
+	ZFSPoolTypeSingle = "single"
+)
+
 const CEPHNodeMinSize = 50 // GB.
 
 // RookCephMinNodes is the minimum number of Hetzner bare-metal worker nodes
@@ -157,6 +267,14 @@ var (
 
 	OutputLogsDirectory = path.Join(OutputsDirectory, "logs")
 
+	// OutputBootstrapCheckpointsDirectory holds a checkpoint file per cluster, recording the
+	// bootstrap phases completed so far, for `cluster bootstrap --resume`.
+	OutputBootstrapCheckpointsDirectory = path.Join(OutputsDirectory, "checkpoints")
+
+	// OutputSealedSecretsDirectory holds, per cluster, the sealed-secrets controller certificate
+	// and the fields of what got sealed with it last, for the offline `secrets` commands.
+	OutputSealedSecretsDirectory = path.Join(OutputsDirectory, "sealed-secrets")
+
 	OutputPathKnownHostsFile = path.Join(TempDirectory, "known_hosts")
 
 	OutputPathManagementClusterK3DConfig = path.Join(OutputsDirectory, "k3d.config.yaml")
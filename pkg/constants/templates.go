@@ -41,6 +41,15 @@ var (
 	KubeaidDeployKeySecretTemplateName = "sealed-secrets/argocd/repo-kubeaid.yaml.tmpl"
 )
 
+// Sealed Secret templates shared by several cloud providers' template sets.
+const (
+	// The credentials Cluster API's infrastructure provider authenticates with.
+	CAPIClusterCloudCredentialsSecretTemplateName = "sealed-secrets/capi-cluster/cloud-credentials.yaml.tmpl"
+
+	// The object storage credentials of the Sealed Secrets Backuper.
+	SealedSecretsBackuperSecretTemplateName = "sealed-secrets/sealed-secrets/backup-sealed-secrets-pod-env.yaml.tmpl"
+)
+
 // Common template names (for clusters being provisioned in any of the supported cloud providers).
 var (
 	CommonCloudSpecificNonSecretTemplateNames = []string{
@@ -90,7 +99,7 @@ var (
 
 	AWSSpecificSecretTemplateNames = []string{
 		// For Cluster API.
-		"sealed-secrets/capi-cluster/cloud-credentials.yaml.tmpl",
+		CAPIClusterCloudCredentialsSecretTemplateName,
 	}
 
 	AWSDisasterRecoverySpecificNonSecretTemplateNames = []string{
@@ -166,7 +175,7 @@ var (
 	// AWS and Hetzner providers seal their credentials into.
 	AzureAKSSpecificSecretTemplateNames = []string{
 		// For Cluster API.
-		"sealed-secrets/capi-cluster/cloud-credentials.yaml.tmpl",
+		CAPIClusterCloudCredentialsSecretTemplateName,
 	}
 
 	AzureDisasterRecoverySpecificNonSecretTemplateNames = []string{
@@ -180,7 +189,30 @@ var (
 
 	AzureDisasterRecoverySpecificSecretTemplateNames = []string{
 		// For Sealed Secrets Backuper.
-		"sealed-secrets/sealed-secrets/backup-sealed-secrets-pod-env.yaml.tmpl",
+		SealedSecretsBackuperSecretTemplateName,
+	}
+)
+
+// S3-compatible object storage (Hetzner Object Storage, MinIO, Ceph RGW) disaster recovery
+// specific template names. Used by Hetzner and Bare Metal clusters.
+var (
+	S3CompatibleDisasterRecoverySpecificNonSecretTemplateNames = []string{
+		// For Velero.
+		"argocd-apps/templates/velero.yaml.tmpl",
+		"argocd-apps/values-velero.yaml.tmpl",
+
+		// For K8sConfigs.
+		"argocd-apps/templates/k8s-configs.yaml.tmpl",
+		"k8s-configs/sealed-secrets.namespace.yaml.tmpl",
+		"k8s-configs/velero.namespace.yaml.tmpl",
+	}
+
+	S3CompatibleDisasterRecoverySpecificSecretTemplateNames = []string{
+		// For Sealed Secrets Backuper.
+		SealedSecretsBackuperSecretTemplateName,
+
+		// For Velero.
+		"sealed-secrets/velero/cloud-credentials.yaml.tmpl",
 	}
 )
 
@@ -191,7 +223,7 @@ var (
 		"sealed-secrets/kube-system/cloud-credentials.yaml.tmpl",
 
 		// For Cluster API.
-		"sealed-secrets/capi-cluster/cloud-credentials.yaml.tmpl",
+		CAPIClusterCloudCredentialsSecretTemplateName,
 	}
 
 	// HCloudCCMNonSecretTemplateNames are the ccm-hcloud ArgoCD App + values
@@ -336,12 +368,27 @@ var NetBirdOperatorTemplateNames = []string{
 	"argocd-apps/values-netbird-operator.yaml.tmpl",
 }
 
-// CertManagerCloudflareAPITokenSecretTemplateName seals secrets.yaml's
-// acme.cloudflareApiToken into the cert-manager/cloudflare-api-token
-// Secret the DNS-01 ClusterIssuer's solver references. Only registered
-// when cluster.acmeDNS01 is set (parser validation guarantees the
-// token is present by then).
-const CertManagerCloudflareAPITokenSecretTemplateName = "sealed-secrets/cert-manager/cloudflare-api-token.yaml.tmpl"
+// CertManagerDNS01SecretTemplateNames maps each cluster.acmeDNS01.provider
+// to the template sealing its secrets.yaml acme credential into the
+// cert-manager namespace, where the DNS-01 ClusterIssuer's solver
+// references it. Only registered when cluster.acmeDNS01 is set (parser
+// validation guarantees the credential is present by then).
+var CertManagerDNS01SecretTemplateNames = map[string]string{
+	ACMEDNS01ProviderCloudflare: "sealed-secrets/cert-manager/cloudflare-api-token.yaml.tmpl",
+	ACMEDNS01ProviderRoute53:    "sealed-secrets/cert-manager/route53-credentials.yaml.tmpl",
+	ACMEDNS01ProviderAzureDNS:   "sealed-secrets/cert-manager/azuredns-credentials.yaml.tmpl",
+	ACMEDNS01ProviderHetzner:    "sealed-secrets/cert-manager/hetzner-dns-api-token.yaml.tmpl",
+	ACMEDNS01ProviderRFC2136:    "sealed-secrets/cert-manager/rfc2136-tsig-secret.yaml.tmpl",
+}
+
+// CertManagerClusterIssuerTemplateNames render the Let's Encrypt
+// ClusterIssuer as is, through the k8s-configs App, when its DNS-01
+// solver is one the KubeAid cert-manager chart's issuer values can't
+// express (any but cloudflare).
+var CertManagerClusterIssuerTemplateNames = []string{
+	"argocd-apps/templates/k8s-configs.yaml.tmpl",
+	"k8s-configs/letsencrypt-prod.clusterissuer.yaml.tmpl",
+}
 
 // NetBirdOperatorAPIKeySecretTemplateName seals secrets.yaml's
 // netbird.apiKey (a Mgmt service-user access token) into the
@@ -402,10 +449,14 @@ var KeycloakManagedSecretTemplateNames = []string{
 //     own TURN auth. Password matches the netbird Secret's
 //     turnServerPassword so Mgmt's hand-back to clients lines up.
 var NetBirdSecretTemplateNames = []string{
-	"sealed-secrets/netbird/netbird.yaml.tmpl",
+	NetBirdSecretTemplateName,
 	"sealed-secrets/netbird/netbird-turn-credentials.yaml.tmpl",
 }
 
+// NetBirdSecretTemplateName is the netbird SealedSecret. Its postgresDSN is
+// CNPG generated, so only readable from the cluster.
+const NetBirdSecretTemplateName = "sealed-secrets/netbird/netbird.yaml.tmpl"
+
 // Obmondo customer specific template names.
 var (
 	// For KubeAid Agent. Included whenever obmondo.monitoring is true.
@@ -0,0 +1,398 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	veleroV1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"github.com/vmware-tanzu/velero/pkg/util/results"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+)
+
+// Accepted `backup list --sort-by` values.
+const (
+	// Newest first.
+	BackupListSortByStarted = "started"
+
+	// Soonest to expire first.
+	BackupListSortByExpires = "expires"
+
+	BackupListSortByName   = "name"
+	BackupListSortByStatus = "status"
+)
+
+// BackupListSortKeys are the --sort-by values `backup list` accepts.
+var BackupListSortKeys = []string{
+	BackupListSortByStarted, BackupListSortByExpires, BackupListSortByName, BackupListSortByStatus,
+}
+
+// veleroNameTimestampLayout suffixes generated Velero Backup / Restore names, like the velero
+// CLI does.
+const veleroNameTimestampLayout = "20060102150405"
+
+// veleroProgressStep is the share of items (in percent) a Backup / Restore needs to progress by,
+// before it's reported again.
+const veleroProgressStep = 10
+
+type CreateBackupArgs struct {
+	// Name of the Velero Backup. Generated when empty.
+	Name string
+
+	Options kubernetes.VeleroBackupOptions
+}
+
+// CreateBackup creates a Velero Backup in the cluster pointed to by the current kubeconfig, and
+// follows it until Velero is done with it.
+func CreateBackup(ctx context.Context, args CreateBackupArgs) {
+	clusterClient, err := kubernetes.CreateVeleroClient(ctx)
+	assert.AssertErrNil(ctx, err, "Failed constructing cluster client from your kubeconfig")
+
+	name := args.Name
+	if name == "" {
+		name = "kubeaid-" + time.Now().UTC().Format(veleroNameTimestampLayout)
+	}
+
+	bar := progress.New("Creating Velero backup " + name)
+	ctx = progress.WithBar(ctx, bar)
+	defer bar.Finish()
+
+	err = kubernetes.CreateBackup(ctx, name, clusterClient, args.Options)
+	assert.AssertErrNil(ctx, err, "Failed creating Velero backup")
+
+	var reporter veleroProgressReporter
+	backup, err := kubernetes.WaitForVeleroBackup(ctx, clusterClient, name,
+		func(backup *veleroV1.Backup) {
+			itemsDone, itemsTotal := 0, 0
+			if backup.Status.Progress != nil {
+				itemsDone, itemsTotal = backup.Status.Progress.ItemsBackedUp, backup.Status.Progress.TotalItems
+			}
+
+			for _, message := range reporter.update(string(backup.Status.Phase),
+				itemsDone, itemsTotal, backup.Status.Warnings, backup.Status.Errors,
+			) {
+				bar.Substep(message)
+			}
+		},
+	)
+	assert.AssertErrNil(ctx, err, "Velero backup didn't complete")
+
+	if backup.Status.Phase == veleroV1.BackupPhasePartiallyFailed {
+		slog.WarnContext(ctx, "Velero backup partially failed",
+			slog.String("backup-name", name),
+			slog.Int("errors", backup.Status.Errors),
+			slog.Int("warnings", backup.Status.Warnings),
+		)
+		return
+	}
+
+	slog.InfoContext(ctx, "Created Velero backup",
+		slog.String("backup-name", name),
+		slog.Int("warnings", backup.Status.Warnings),
+	)
+}
+
+type ListBackupsArgs struct {
+	// One of BackupListSortKeys.
+	SortBy string
+
+	// Limit is how many Backups get printed per page. 0 prints them all.
+	Limit,
+
+	// Page is the 1-based page to print.
+	Page int
+}
+
+// ListBackups prints the Velero Backups in the cluster pointed to by the current kubeconfig.
+func ListBackups(ctx context.Context, args ListBackupsArgs) {
+	clusterClient, err := kubernetes.CreateVeleroClient(ctx)
+	assert.AssertErrNil(ctx, err, "Failed constructing cluster client from your kubeconfig")
+
+	backups, err := kubernetes.ListVeleroBackups(ctx, clusterClient)
+	assert.AssertErrNil(ctx, err, "Failed listing Velero backups")
+
+	sortVeleroBackups(backups, args.SortBy)
+
+	//nolint:forbidigo // operator-facing terminal output
+	fmt.Print(renderVeleroBackups(backups, args.Limit, args.Page, time.Now()))
+}
+
+type RestoreBackupArgs struct {
+	BackupName string
+
+	Options kubernetes.VeleroRestoreOptions
+}
+
+// RestoreBackup restores the given Velero Backup into the cluster pointed to by the current
+// kubeconfig, following the Restore until Velero is done with it. Its warnings and errors get
+// printed in full.
+func RestoreBackup(ctx context.Context, args RestoreBackupArgs) {
+	clusterClient, err := kubernetes.CreateVeleroClient(ctx)
+	assert.AssertErrNil(ctx, err, "Failed constructing cluster client from your kubeconfig")
+
+	backup, err := kubernetes.GetVeleroBackup(ctx, clusterClient, args.BackupName)
+	assert.AssertErrNil(ctx, err, "Failed getting Velero backup")
+
+	assert.Assert(ctx,
+		(backup.Status.Phase == veleroV1.BackupPhaseCompleted) ||
+			(backup.Status.Phase == veleroV1.BackupPhasePartiallyFailed),
+		fmt.Sprintf("Velero backup %s is %s : only a Completed or PartiallyFailed one can be restored",
+			backup.Name, backup.Status.Phase,
+		),
+	)
+
+	name := backup.Name + "-" + time.Now().UTC().Format(veleroNameTimestampLayout)
+
+	bar := progress.New("Restoring Velero backup " + backup.Name)
+	ctx = progress.WithBar(ctx, bar)
+	defer bar.Finish()
+
+	_, err = kubernetes.CreateVeleroRestore(ctx, clusterClient, name, backup.Name, args.Options)
+	assert.AssertErrNil(ctx, err, "Failed creating Velero restore")
+
+	var reporter veleroProgressReporter
+	restore, err := kubernetes.WaitForVeleroRestore(ctx, clusterClient, name,
+		func(restore *veleroV1.Restore) {
+			itemsDone, itemsTotal := 0, 0
+			if restore.Status.Progress != nil {
+				itemsDone, itemsTotal = restore.Status.Progress.ItemsRestored, restore.Status.Progress.TotalItems
+			}
+
+			for _, message := range reporter.update(string(restore.Status.Phase),
+				itemsDone, itemsTotal, restore.Status.Warnings, restore.Status.Errors,
+			) {
+				bar.Substep(message)
+			}
+		},
+	)
+	assert.AssertErrNil(ctx, err, "Velero restore didn't complete")
+
+	if (restore.Status.Warnings > 0) || (restore.Status.Errors > 0) {
+		warnings, errs, err := kubernetes.GetVeleroRestoreResults(ctx, clusterClient, restore)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed fetching Velero restore warnings and errors",
+				slog.String("error", err.Error()),
+			)
+		} else {
+			//nolint:forbidigo // operator-facing terminal output
+			fmt.Print(renderVeleroRestoreResults("Warnings", warnings) + renderVeleroRestoreResults("Errors", errs))
+		}
+	}
+
+	if restore.Status.Phase == veleroV1.RestorePhasePartiallyFailed {
+		slog.WarnContext(ctx, "Velero restore partially failed",
+			slog.String("restore-name", name),
+			slog.Int("errors", restore.Status.Errors),
+			slog.Int("warnings", restore.Status.Warnings),
+		)
+		return
+	}
+
+	slog.InfoContext(ctx, "Restored Velero backup",
+		slog.String("restore-name", name),
+		slog.Int("warnings", restore.Status.Warnings),
+	)
+}
+
+// veleroProgressReporter turns the polled status of a Velero Backup / Restore into progress
+// messages : one whenever its phase changes, it progresses by another veleroProgressStep percent
+// of its items, or it hits new warnings / errors.
+type veleroProgressReporter struct {
+	phase string
+
+	percent,
+	warnings,
+	errors int
+}
+
+func (r *veleroProgressReporter) update(phase string, itemsDone, itemsTotal, warnings, errors int) []string {
+	var messages []string
+
+	if (phase != "") && (phase != r.phase) {
+		messages = append(messages, "Phase : "+phase)
+		r.phase = phase
+	}
+
+	if itemsTotal > 0 {
+		percent := (itemsDone * 100 / itemsTotal) / veleroProgressStep * veleroProgressStep
+		if percent > r.percent {
+			messages = append(messages, fmt.Sprintf("%d / %d items (%d%%)", itemsDone, itemsTotal, percent))
+			r.percent = percent
+		}
+	}
+
+	if (warnings > r.warnings) || (errors > r.errors) {
+		messages = append(messages, fmt.Sprintf("%d warning(s), %d error(s) so far", warnings, errors))
+		r.warnings, r.errors = warnings, errors
+	}
+
+	return messages
+}
+
+// sortVeleroBackups sorts backups by one of BackupListSortKeys, falling back to newest first.
+func sortVeleroBackups(backups []veleroV1.Backup, sortBy string) {
+	started := func(backup veleroV1.Backup) time.Time {
+		if backup.Status.StartTimestamp == nil {
+			return backup.CreationTimestamp.Time
+		}
+		return backup.Status.StartTimestamp.Time
+	}
+	newestFirst := func(i, j int) bool {
+		if !started(backups[i]).Equal(started(backups[j])) {
+			return started(backups[i]).After(started(backups[j]))
+		}
+		return backups[i].Name < backups[j].Name
+	}
+
+	switch sortBy {
+	case BackupListSortByName:
+		sort.SliceStable(backups, func(i, j int) bool { return backups[i].Name < backups[j].Name })
+
+	case BackupListSortByStatus:
+		sort.SliceStable(backups, func(i, j int) bool {
+			if backups[i].Status.Phase != backups[j].Status.Phase {
+				return backups[i].Status.Phase < backups[j].Status.Phase
+			}
+			return newestFirst(i, j)
+		})
+
+	case BackupListSortByExpires:
+		// Backups which never expire go last.
+		expires := func(backup veleroV1.Backup) (time.Time, bool) {
+			if backup.Status.Expiration == nil {
+				return time.Time{}, false
+			}
+			return backup.Status.Expiration.Time, true
+		}
+		sort.SliceStable(backups, func(i, j int) bool {
+			expiresI, okI := expires(backups[i])
+			expiresJ, okJ := expires(backups[j])
+			if okI != okJ {
+				return okI
+			}
+			if !expiresI.Equal(expiresJ) {
+				return expiresI.Before(expiresJ)
+			}
+			return newestFirst(i, j)
+		})
+
+	default:
+		sort.SliceStable(backups, newestFirst)
+	}
+}
+
+// renderVeleroBackups lays out the given page of (already sorted) backups as a table, followed by
+// a pagination footer when they don't all fit in one.
+func renderVeleroBackups(backups []veleroV1.Backup, limit, page int, now time.Time) string {
+	if len(backups) == 0 {
+		return "No Velero backups found.\n"
+	}
+
+	pageBackups, pages := paginate(backups, limit, page)
+
+	var b strings.Builder
+
+	w := tabwriter.NewWriter(&b, tabwriterMinWidth, tabwriterTabWidth, tabwriterPadding, ' ', 0)
+
+	// Writes to a strings.Builder-backed tabwriter never fail.
+	_, _ = fmt.Fprintln(w, "NAME\tSTATUS\tERRORS\tWARNINGS\tSTARTED\tEXPIRES")
+	for _, backup := range pageBackups {
+		phase := string(backup.Status.Phase)
+		if phase == "" {
+			phase = string(veleroV1.BackupPhaseNew)
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\n",
+			backup.Name, phase, backup.Status.Errors, backup.Status.Warnings,
+			formatVeleroTimestamp(backup.Status.StartTimestamp, now, " ago"),
+			formatVeleroTimestamp(backup.Status.Expiration, now, ""),
+		)
+	}
+
+	// Flush only fails when the underlying writer does, and a strings.Builder never does.
+	_ = w.Flush()
+
+	if pages > 1 {
+		fmt.Fprintf(&b, "\nPage %d of %d (%d backups)", min(page, pages), pages, len(backups))
+		if page < pages {
+			fmt.Fprintf(&b, " : --page %d for more", page+1)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// paginate returns the 1-based page of items, limit per page, and the number of pages. A limit
+// of 0 puts everything in a single page.
+func paginate[T any](items []T, limit, page int) ([]T, int) {
+	if (limit <= 0) || (len(items) == 0) {
+		return items, 1
+	}
+
+	pages := (len(items) + limit - 1) / limit
+
+	start := (page - 1) * limit
+	if start >= len(items) {
+		return nil, pages
+	}
+	return items[start:min(start+limit, len(items))], pages
+}
+
+// formatVeleroTimestamp renders how far timestamp is from now, like "3d 4h", with suffix when
+// it's in the past, or "in " prefixed when it's in the future. "-" when unset.
+func formatVeleroTimestamp(timestamp *metaV1.Time, now time.Time, suffix string) string {
+	if (timestamp == nil) || timestamp.IsZero() {
+		return "-"
+	}
+
+	if timestamp.After(now) {
+		return "in " + formatAge(timestamp.Sub(now))
+	}
+	return formatAge(now.Sub(timestamp.Time)) + suffix
+}
+
+// renderVeleroRestoreResults renders the warnings or errors (as given by kind) of a Velero
+// Restore. "" when there are none.
+func renderVeleroRestoreResults(kind string, result results.Result) string {
+	if (len(result.Velero) == 0) && (len(result.Cluster) == 0) && (len(result.Namespaces) == 0) {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s :\n", kind)
+
+	writeMessages := func(scope string, messages []string) {
+		if len(messages) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "  %s :\n", scope)
+		for _, message := range messages {
+			fmt.Fprintf(&b, "    - %s\n", message)
+		}
+	}
+
+	writeMessages("Velero", result.Velero)
+	writeMessages("Cluster", result.Cluster)
+
+	namespaces := make([]string, 0, len(result.Namespaces))
+	for namespace := range result.Namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		writeMessages("Namespace "+namespace, result.Namespaces[namespace])
+	}
+	return b.String()
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 	"k8s.io/kubectl/pkg/util/podutils"
+	"sigs.k8s.io/yaml"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
@@ -45,8 +46,29 @@ const (
 	backupStatusUnknown        = "unknown"
 )
 
-// outputFormatJSON is the only accepted --output value.
-const outputFormatJSON = "json"
+// Accepted --output values, besides "" for the human-readable report.
+const (
+	outputFormatJSON       = "json"
+	outputFormatYAML       = "yaml"
+	outputFormatPrometheus = "prometheus"
+	outputFormatJUnit      = "junit"
+)
+
+// BackupStatusOutputFormats are the --output values `backup status` accepts.
+var BackupStatusOutputFormats = []string{
+	outputFormatJSON, outputFormatYAML, outputFormatPrometheus, outputFormatJUnit,
+}
+
+// Accepted --fail-on values : the least severe backup status, that makes `backup status` exit
+// non-zero.
+const (
+	BackupStatusFailOnWarning = "warning"
+	BackupStatusFailOnError   = "error"
+)
+
+// backupStatusExitThresholdBreached is the exit code, when a backup status reaches the --fail-on
+// threshold.
+const backupStatusExitThresholdBreached = 1
 
 // Resource-table column padding, matching kubectl's own printer
 // (k8s.io/cli-runtime/pkg/printers.GetNewTabWriter).
@@ -118,12 +140,26 @@ type backupResponse struct {
 	OperatorErrors []backupOperatorError `json:"operator_errors,omitempty"`
 }
 
-// BackupStatus fetches backup health from backup-exporter's JSON API and prints it.
-// outputFormat "json" dumps the raw API response verbatim; any other value (including "")
-// renders the human-readable report. Exits non-zero only when the fetch or decode itself
-// fails - reported backup statuses (healthy/exceeds_rpo/no_backup/...) never affect the exit
-// code.
-func BackupStatus(ctx context.Context, outputFormat string) {
+type BackupStatusArgs struct {
+	// OutputFormat is either "" (the human-readable report), or one of BackupStatusOutputFormats.
+	OutputFormat string
+
+	// FailOn is either "" (never exit non-zero because of reported backup statuses),
+	// BackupStatusFailOnWarning or BackupStatusFailOnError.
+	FailOn string
+
+	// MaxAge maps an operator to a stricter RPO than the exporter's MaxIntervalSeconds : a
+	// healthy backup stream of that operator, whose latest backup is older, gets reported as
+	// exceeds_rpo.
+	MaxAge map[string]time.Duration
+}
+
+// BackupStatus fetches backup health from backup-exporter's JSON API and prints it, in the
+// requested output format. "json" dumps the raw API response verbatim, unless a MaxAge override
+// changed it. Exits non-zero when the fetch or decode itself fails. Otherwise, returns the exit
+// code the command should exit with : non-zero only when a reported backup status reaches the
+// FailOn threshold.
+func BackupStatus(ctx context.Context, args BackupStatusArgs) int {
 	// The current kubeconfig, exactly like kubectl: a status command must not
 	// depend on the KubeOne artifact, whose endpoint is only reachable through
 	// the provisioning SSH tunnel.
@@ -138,17 +174,47 @@ func BackupStatus(ctx context.Context, outputFormat string) {
 	body, err := fetchBackupStatus(ctx, clientset, restConfig)
 	assert.AssertErrNil(ctx, err, "Failed fetching backup status from backup-exporter")
 
-	if outputFormat == outputFormatJSON {
-		_, err := os.Stdout.Write(body) //nolint:forbidigo // verbatim API passthrough, not a log line
-		assert.AssertErrNil(ctx, err, "Failed writing backup status JSON to stdout")
-		return
-	}
-
 	var response backupResponse
 	err = json.Unmarshal(body, &response)
 	assert.AssertErrNil(ctx, err, "Failed decoding backup-exporter response")
 
-	fmt.Print(renderBackupStatus(response, time.Now())) //nolint:forbidigo // operator-facing terminal output
+	now := time.Now()
+
+	if len(args.MaxAge) > 0 {
+		applyMaxAgeOverrides(&response, args.MaxAge, now)
+
+		body, err = json.Marshal(response)
+		assert.AssertErrNil(ctx, err, "Failed JSON encoding backup status")
+	}
+
+	switch args.OutputFormat {
+	case outputFormatJSON:
+		_, err := os.Stdout.Write(body) //nolint:forbidigo // verbatim API passthrough, not a log line
+		assert.AssertErrNil(ctx, err, "Failed writing backup status JSON to stdout")
+
+	case outputFormatYAML:
+		output, err := yaml.JSONToYAML(body)
+		assert.AssertErrNil(ctx, err, "Failed converting backup status to YAML")
+
+		fmt.Print(string(output)) //nolint:forbidigo // operator-facing terminal output
+
+	case outputFormatPrometheus:
+		fmt.Print(renderBackupStatusPrometheus(response, now)) //nolint:forbidigo // operator-facing terminal output
+
+	case outputFormatJUnit:
+		output, err := renderBackupStatusJUnit(response, now)
+		assert.AssertErrNil(ctx, err, "Failed rendering backup status as JUnit XML")
+
+		fmt.Print(output) //nolint:forbidigo // operator-facing terminal output
+
+	default:
+		fmt.Print(renderBackupStatus(response, now)) //nolint:forbidigo // operator-facing terminal output
+	}
+
+	if backupStatusBreachesThreshold(response, args.FailOn) {
+		return backupStatusExitThresholdBreached
+	}
+	return 0
 }
 
 // findBackupExporterService locates backup-exporter's Service by label, looking in
@@ -0,0 +1,237 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// applyMaxAgeOverrides enforces the per-operator maxAge RPOs on response, wherever they're
+// stricter than the exporter's own : a healthy stream whose latest backup (as of now) is older
+// than its operator's maxAge becomes exceeds_rpo, and MaxIntervalSeconds reports the RPO it was
+// judged against. Streams in any other status keep it, exceeds_rpo never being an improvement.
+func applyMaxAgeOverrides(response *backupResponse, maxAge map[string]time.Duration, now time.Time) {
+	collectedAt := collectedAtByOperator(response.Collectors)
+
+	for i := range response.Resources {
+		r := &response.Resources[i]
+
+		override, ok := maxAge[r.Operator]
+		if !ok {
+			continue
+		}
+
+		overrideSeconds := override.Seconds()
+		if (r.MaxIntervalSeconds != nil) && (*r.MaxIntervalSeconds <= overrideSeconds) {
+			continue
+		}
+		r.MaxIntervalSeconds = &overrideSeconds
+
+		if r.Status != backupStatusHealthy {
+			continue
+		}
+
+		// The no-backup sentinel (a reported age of exactly zero) is already no_backup, never
+		// healthy, so isn't special-cased here.
+		if age := adjustedAge(r.LatestBackupAgeSeconds, collectedAt[r.Operator], now); (age != nil) && (*age > override) {
+			r.Status = backupStatusExceedsRPO
+		}
+	}
+}
+
+// backupStatusBreachesThreshold reports whether response has anything at least as severe as the
+// failOn threshold (see statusSeverity) : with BackupStatusFailOnError, a resource which is
+// exceeds_rpo or worse; with BackupStatusFailOnWarning, any resource which isn't healthy, or any
+// operator error. Never, with no threshold.
+func backupStatusBreachesThreshold(response backupResponse, failOn string) bool {
+	var thresholdRank int
+	switch failOn {
+	case BackupStatusFailOnError:
+		thresholdRank = severityRank(backupStatusExceedsRPO)
+
+	case BackupStatusFailOnWarning:
+		if len(response.OperatorErrors) > 0 {
+			return true
+		}
+		thresholdRank = severityRank(backupStatusHealthy) - 1
+
+	default:
+		return false
+	}
+
+	for _, r := range response.Resources {
+		if severityRank(r.Status) <= thresholdRank {
+			return true
+		}
+	}
+	return false
+}
+
+// Metric names of the Prometheus exposition, `backup status -o prometheus` prints.
+const (
+	backupMetricLatestAge = "kubeaid_backup_latest_age_seconds"
+	backupMetricOldestAge = "kubeaid_backup_oldest_age_seconds"
+	backupMetricStatus    = "kubeaid_backup_status"
+)
+
+// renderBackupStatusPrometheus renders response in the Prometheus text exposition format, for
+// node-exporter's textfile collector : the latest and oldest backup age of every backup stream
+// (as of now), and its status as a 1-valued gauge labeled with it. An age is omitted when there's
+// none to report, rather than exposed as a misleadingly fresh 0.
+func renderBackupStatusPrometheus(response backupResponse, now time.Time) string {
+	resources := make([]backupResource, len(response.Resources))
+	copy(resources, response.Resources)
+	sortResources(resources)
+
+	collectedAt := collectedAtByOperator(response.Collectors)
+
+	var b strings.Builder
+
+	writeAgeGauge := func(name, help string, ageSeconds func(r backupResource) *float64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+
+		for _, r := range resources {
+			seconds := ageSeconds(r)
+			if (seconds == nil) || (*seconds == 0) {
+				continue
+			}
+
+			age := adjustedAge(seconds, collectedAt[r.Operator], now)
+			if age == nil {
+				continue
+			}
+			fmt.Fprintf(&b, "%s{%s} %g\n", name, backupMetricLabels(r), age.Seconds())
+		}
+	}
+
+	writeAgeGauge(backupMetricLatestAge, "Age of the newest backup of a backup stream.",
+		func(r backupResource) *float64 { return r.LatestBackupAgeSeconds },
+	)
+	writeAgeGauge(backupMetricOldestAge, "Age of the oldest backup of a backup stream.",
+		func(r backupResource) *float64 { return r.OldestBackupAgeSeconds },
+	)
+
+	fmt.Fprintf(&b, "# HELP %s Status of a backup stream, as reported by backup-exporter.\n", backupMetricStatus)
+	fmt.Fprintf(&b, "# TYPE %s gauge\n", backupMetricStatus)
+	for _, r := range resources {
+		fmt.Fprintf(&b, "%s{%s,status=\"%s\"} 1\n",
+			backupMetricStatus, backupMetricLabels(r), prometheusLabelValueEscaper.Replace(r.Status),
+		)
+	}
+
+	return b.String()
+}
+
+// backupMetricLabels renders the labels identifying a backup stream.
+func backupMetricLabels(r backupResource) string {
+	labels := [][2]string{
+		{"operator", r.Operator},
+		{"namespace", r.Namespace},
+		{"resource", r.ResourceName},
+		{"resource_type", r.ResourceType},
+		{"stream", r.Stream},
+		{"method", resourceMethod(r)},
+	}
+
+	rendered := make([]string, 0, len(labels))
+	for _, label := range labels {
+		rendered = append(rendered, fmt.Sprintf(`%s="%s"`, label[0], prometheusLabelValueEscaper.Replace(label[1])))
+	}
+	return strings.Join(rendered, ",")
+}
+
+// prometheusLabelValueEscaper escapes what the text exposition format requires escaped in a
+// label value.
+var prometheusLabelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+}
+
+// renderBackupStatusJUnit renders response as a JUnit XML report, for CI systems to show : a
+// testcase per backup stream and per operator error. A stream which is exceeds_rpo or worse
+// fails; one the collector couldn't evaluate (collector_error / unknown) and an operator error
+// are errors instead.
+func renderBackupStatusJUnit(response backupResponse, now time.Time) (string, error) {
+	resources := make([]backupResource, len(response.Resources))
+	copy(resources, response.Resources)
+	sortResources(resources)
+
+	collectedAt := collectedAtByOperator(response.Collectors)
+
+	suite := junitTestSuite{
+		Name:      "backup-status",
+		Timestamp: now.UTC().Format(time.RFC3339),
+	}
+
+	for _, operatorError := range response.OperatorErrors {
+		suite.Errors++
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			ClassName: operatorError.Operator,
+			Name:      "collector",
+			Error: &junitProblem{
+				Type:    backupStatusCollectorError,
+				Message: operatorError.Type,
+			},
+		})
+	}
+
+	for _, r := range resources {
+		testCase := junitTestCase{
+			ClassName: r.Operator + "." + r.Namespace,
+			Name:      fmt.Sprintf("%s/%s (%s)", r.ResourceType, r.ResourceName, r.Stream),
+		}
+
+		problem := &junitProblem{
+			Type:    r.Status,
+			Message: fmt.Sprintf("%s, latest backup : %s", formatStatus(r), formatLatestAge(r, collectedAt[r.Operator], now)),
+		}
+		switch rank := severityRank(r.Status); {
+		case rank == severityRank(backupStatusHealthy):
+
+		case rank <= severityRank(backupStatusExceedsRPO):
+			suite.Failures++
+			testCase.Failure = problem
+
+		default:
+			suite.Errors++
+			testCase.Error = problem
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Tests = len(suite.TestCases)
+
+	output, err := xml.MarshalIndent(junitTestSuites{TestSuites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed XML encoding JUnit report: %w", err)
+	}
+	return xml.Header + string(output) + "\n", nil
+}
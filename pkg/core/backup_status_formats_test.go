@@ -0,0 +1,241 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyMaxAgeOverrides(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 7, 20, 12, 0, 0, 0, time.UTC)
+	collectors := []backupCollector{
+		{Operator: "velero", CollectedAt: timePtr(now)},
+		{Operator: "cnpg", CollectedAt: timePtr(now)},
+	}
+
+	testCases := []struct {
+		name                   string
+		resource               backupResource
+		expectedStatus         string
+		expectedMaxIntervalSec *float64
+	}{
+		{
+			name: "healthy stream older than the override exceeds the RPO",
+			resource: backupResource{
+				Operator: "velero", Status: backupStatusHealthy,
+				LatestBackupAgeSeconds: floatPtr(30 * 3600), MaxIntervalSeconds: floatPtr(48 * 3600),
+			},
+			expectedStatus:         backupStatusExceedsRPO,
+			expectedMaxIntervalSec: floatPtr(24 * 3600),
+		},
+		{
+			name: "healthy stream within the override stays healthy",
+			resource: backupResource{
+				Operator: "velero", Status: backupStatusHealthy,
+				LatestBackupAgeSeconds: floatPtr(3600), MaxIntervalSeconds: floatPtr(48 * 3600),
+			},
+			expectedStatus:         backupStatusHealthy,
+			expectedMaxIntervalSec: floatPtr(24 * 3600),
+		},
+		{
+			name: "a laxer override than the exporter's is ignored",
+			resource: backupResource{
+				Operator: "velero", Status: backupStatusHealthy,
+				LatestBackupAgeSeconds: floatPtr(30 * 3600), MaxIntervalSeconds: floatPtr(12 * 3600),
+			},
+			expectedStatus:         backupStatusHealthy,
+			expectedMaxIntervalSec: floatPtr(12 * 3600),
+		},
+		{
+			name: "a stream in a worse status keeps it",
+			resource: backupResource{
+				Operator: "velero", Status: backupStatusNoBackup,
+				LatestBackupAgeSeconds: floatPtr(0),
+			},
+			expectedStatus:         backupStatusNoBackup,
+			expectedMaxIntervalSec: floatPtr(24 * 3600),
+		},
+		{
+			name: "an operator without an override is left alone",
+			resource: backupResource{
+				Operator: "cnpg", Status: backupStatusHealthy,
+				LatestBackupAgeSeconds: floatPtr(30 * 3600),
+			},
+			expectedStatus: backupStatusHealthy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			response := backupResponse{
+				Collectors: collectors,
+				Resources:  []backupResource{tc.resource},
+			}
+			applyMaxAgeOverrides(&response, map[string]time.Duration{"velero": 24 * time.Hour}, now)
+
+			assert.Equal(t, tc.expectedStatus, response.Resources[0].Status)
+			assert.Equal(t, tc.expectedMaxIntervalSec, response.Resources[0].MaxIntervalSeconds)
+		})
+	}
+}
+
+func TestBackupStatusBreachesThreshold(t *testing.T) {
+	t.Parallel()
+
+	withStatuses := func(statuses ...string) backupResponse {
+		response := backupResponse{}
+		for _, status := range statuses {
+			response.Resources = append(response.Resources, backupResource{Status: status})
+		}
+		return response
+	}
+	withOperatorError := backupResponse{
+		OperatorErrors: []backupOperatorError{{Operator: "velero", Type: "s3_list_failed"}},
+	}
+
+	testCases := []struct {
+		name     string
+		response backupResponse
+		failOn   string
+		expected bool
+	}{
+		{"no threshold never breaches", withStatuses(backupStatusNoBackup), "", false},
+
+		{"error: all healthy", withStatuses(backupStatusHealthy), BackupStatusFailOnError, false},
+		{"error: collector_error is only a warning", withStatuses(backupStatusCollectorError), BackupStatusFailOnError, false},
+		{"error: unknown is only a warning", withStatuses(backupStatusUnknown), BackupStatusFailOnError, false},
+		{"error: operator errors are only warnings", withOperatorError, BackupStatusFailOnError, false},
+		{"error: exceeds_rpo", withStatuses(backupStatusHealthy, backupStatusExceedsRPO), BackupStatusFailOnError, true},
+		{"error: no_backup", withStatuses(backupStatusNoBackup), BackupStatusFailOnError, true},
+		{"error: unrecognised status ranks worst", withStatuses("stale"), BackupStatusFailOnError, true},
+
+		{"warning: all healthy", withStatuses(backupStatusHealthy), BackupStatusFailOnWarning, false},
+		{"warning: collector_error", withStatuses(backupStatusCollectorError), BackupStatusFailOnWarning, true},
+		{"warning: unknown", withStatuses(backupStatusUnknown), BackupStatusFailOnWarning, true},
+		{"warning: exceeds_rpo", withStatuses(backupStatusExceedsRPO), BackupStatusFailOnWarning, true},
+		{"warning: operator error", withOperatorError, BackupStatusFailOnWarning, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, backupStatusBreachesThreshold(tc.response, tc.failOn))
+		})
+	}
+}
+
+func TestRenderBackupStatusPrometheus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 7, 20, 12, 0, 0, 0, time.UTC)
+	response := backupResponse{
+		Collectors: []backupCollector{
+			{Operator: "velero", CollectedAt: timePtr(now.Add(-10 * time.Second))},
+			{Operator: "cnpg", CollectedAt: timePtr(now)},
+		},
+		Resources: []backupResource{
+			{
+				Operator: "velero", Stream: "volume", Namespace: "demo", ResourceName: "uploads",
+				ResourceType: "pvc", Method: "PodVolumeBackup", Status: backupStatusHealthy,
+				LatestBackupAgeSeconds: floatPtr(3590), OldestBackupAgeSeconds: floatPtr(86390),
+			},
+			{
+				Operator: "cnpg", Stream: "logical", Namespace: "demo", ResourceName: "pgsql",
+				ResourceType: "cnpg_cluster", Status: backupStatusNoBackup,
+				LatestBackupAgeSeconds: floatPtr(0),
+			},
+		},
+	}
+
+	output := renderBackupStatusPrometheus(response, now)
+
+	veleroLabels := `operator="velero",namespace="demo",resource="uploads",resource_type="pvc",stream="volume",method="PodVolumeBackup"`
+	cnpgLabels := `operator="cnpg",namespace="demo",resource="pgsql",resource_type="cnpg_cluster",stream="logical",method="CronJob"`
+
+	assert.Contains(t, output, "# TYPE kubeaid_backup_latest_age_seconds gauge\n")
+	assert.Contains(t, output, "kubeaid_backup_latest_age_seconds{"+veleroLabels+"} 3600\n")
+	assert.Contains(t, output, "kubeaid_backup_oldest_age_seconds{"+veleroLabels+"} 86400\n")
+	assert.Contains(t, output, "kubeaid_backup_status{"+veleroLabels+`,status="healthy"} 1`+"\n")
+	assert.Contains(t, output, "kubeaid_backup_status{"+cnpgLabels+`,status="no_backup"} 1`+"\n")
+
+	// No age gets exposed for a stream without a backup.
+	assert.NotContains(t, output, "kubeaid_backup_latest_age_seconds{"+cnpgLabels)
+	assert.NotContains(t, output, "kubeaid_backup_oldest_age_seconds{"+cnpgLabels)
+}
+
+func TestPrometheusLabelValueEscaper(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `a\\b\"c\nd`, prometheusLabelValueEscaper.Replace("a\\b\"c\nd"))
+}
+
+func TestRenderBackupStatusJUnit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 7, 20, 12, 0, 0, 0, time.UTC)
+	response := backupResponse{
+		Collectors: []backupCollector{{Operator: "velero", CollectedAt: timePtr(now)}},
+		Resources: []backupResource{
+			{
+				Operator: "velero", Stream: "volume", Namespace: "demo", ResourceName: "uploads",
+				ResourceType: "pvc", Status: backupStatusHealthy, LatestBackupAgeSeconds: floatPtr(3600),
+			},
+			{
+				Operator: "velero", Stream: "volume", Namespace: "demo", ResourceName: "data",
+				ResourceType: "pvc", Status: backupStatusExceedsRPO, LatestBackupAgeSeconds: floatPtr(3 * 86400),
+			},
+			{
+				Operator: "velero", Stream: "volume", Namespace: "demo", ResourceName: "logs",
+				ResourceType: "pvc", Status: backupStatusCollectorError, ErrorType: "pvb_list_failed",
+			},
+		},
+		OperatorErrors: []backupOperatorError{{Operator: "cnpg", Type: "s3_list_failed"}},
+	}
+
+	output, err := renderBackupStatusJUnit(response, now)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(output, xml.Header))
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal([]byte(output), &report))
+	require.Len(t, report.TestSuites, 1)
+
+	suite := report.TestSuites[0]
+	assert.Equal(t, 4, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, 2, suite.Errors)
+
+	testCases := make(map[string]junitTestCase, len(suite.TestCases))
+	for _, testCase := range suite.TestCases {
+		testCases[testCase.ClassName+" "+testCase.Name] = testCase
+	}
+
+	assert.Equal(t, junitTestCase{
+		ClassName: "cnpg", Name: "collector",
+		Error: &junitProblem{Type: backupStatusCollectorError, Message: "s3_list_failed"},
+	}, testCases["cnpg collector"])
+
+	healthy := testCases["velero.demo pvc/uploads (volume)"]
+	assert.Nil(t, healthy.Failure)
+	assert.Nil(t, healthy.Error)
+
+	assert.Equal(t, &junitProblem{
+		Type: backupStatusExceedsRPO, Message: "exceeds_rpo, latest backup : 3d",
+	}, testCases["velero.demo pvc/data (volume)"].Failure)
+
+	assert.Equal(t, &junitProblem{
+		Type: backupStatusCollectorError, Message: "collector_error (pvb_list_failed), latest backup : -",
+	}, testCases["velero.demo pvc/logs (volume)"].Error)
+}
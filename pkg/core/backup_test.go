@@ -0,0 +1,183 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	veleroV1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"github.com/vmware-tanzu/velero/pkg/util/results"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVeleroProgressReporter(t *testing.T) {
+	t.Parallel()
+
+	var reporter veleroProgressReporter
+
+	assert.Equal(t, []string{"Phase : New"}, reporter.update("New", 0, 0, 0, 0))
+	assert.Equal(t, []string{"Phase : InProgress"}, reporter.update("InProgress", 5, 100, 0, 0))
+	assert.Equal(t, []string{"12 / 100 items (10%)"}, reporter.update("InProgress", 12, 100, 0, 0))
+
+	// Nothing new to report.
+	assert.Empty(t, reporter.update("InProgress", 19, 100, 0, 0))
+
+	assert.Equal(t,
+		[]string{"57 / 100 items (50%)", "2 warning(s), 0 error(s) so far"},
+		reporter.update("InProgress", 57, 100, 2, 0),
+	)
+	assert.Equal(t,
+		[]string{"Phase : Completed", "100 / 100 items (100%)"},
+		reporter.update("Completed", 100, 100, 2, 0),
+	)
+}
+
+func TestSortVeleroBackups(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	backup := func(name string, phase veleroV1.BackupPhase, startedAgo, expiresIn time.Duration) veleroV1.Backup {
+		b := veleroV1.Backup{
+			ObjectMeta: metaV1.ObjectMeta{Name: name},
+			Status: veleroV1.BackupStatus{
+				Phase:          phase,
+				StartTimestamp: &metaV1.Time{Time: now.Add(-startedAgo)},
+			},
+		}
+		if expiresIn != 0 {
+			b.Status.Expiration = &metaV1.Time{Time: now.Add(expiresIn)}
+		}
+		return b
+	}
+
+	tests := []struct {
+		sortBy string
+		want   []string
+	}{
+		{sortBy: BackupListSortByStarted, want: []string{"c", "a", "b"}},
+		{sortBy: BackupListSortByName, want: []string{"a", "b", "c"}},
+		{sortBy: BackupListSortByExpires, want: []string{"b", "a", "c"}},
+		{sortBy: BackupListSortByStatus, want: []string{"a", "b", "c"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.sortBy, func(t *testing.T) {
+			t.Parallel()
+
+			backups := []veleroV1.Backup{
+				backup("b", veleroV1.BackupPhaseCompleted, 3*time.Hour, time.Hour),
+				backup("c", veleroV1.BackupPhasePartiallyFailed, time.Hour, 0),
+				backup("a", veleroV1.BackupPhaseCompleted, 2*time.Hour, 2*time.Hour),
+			}
+			sortVeleroBackups(backups, tc.sortBy)
+
+			got := []string{}
+			for _, backup := range backups {
+				got = append(got, backup.Name)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRenderVeleroBackups(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	backups := []veleroV1.Backup{
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "daily-2"},
+			Status: veleroV1.BackupStatus{
+				Phase:          veleroV1.BackupPhaseCompleted,
+				StartTimestamp: &metaV1.Time{Time: now.Add(-2 * time.Hour)},
+				Expiration:     &metaV1.Time{Time: now.Add(72 * time.Hour)},
+			},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "daily-1"},
+			Status: veleroV1.BackupStatus{
+				Phase:          veleroV1.BackupPhasePartiallyFailed,
+				Errors:         1,
+				Warnings:       3,
+				StartTimestamp: &metaV1.Time{Time: now.Add(-26 * time.Hour)},
+			},
+		},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "manual"}},
+	}
+
+	assert.Equal(t,
+		"NAME      STATUS            ERRORS   WARNINGS   STARTED     EXPIRES\n"+
+			"daily-2   Completed         0        0          2h ago      in 3d\n"+
+			"daily-1   PartiallyFailed   1        3          1d 2h ago   -\n"+
+			"\nPage 1 of 2 (3 backups) : --page 2 for more\n",
+		renderVeleroBackups(backups, 2, 1, now),
+	)
+
+	// Not started yet.
+	assert.Equal(t,
+		"NAME     STATUS   ERRORS   WARNINGS   STARTED   EXPIRES\n"+
+			"manual   New      0        0          -         -\n"+
+			"\nPage 2 of 2 (3 backups)\n",
+		renderVeleroBackups(backups, 2, 2, now),
+	)
+
+	got := renderVeleroBackups(backups, 0, 1, now)
+	assert.NotContains(t, got, "Page")
+
+	assert.Equal(t, "No Velero backups found.\n", renderVeleroBackups(nil, 20, 1, now))
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name        string
+		limit, page int
+		want        []int
+		wantPages   int
+	}{
+		{name: "no limit", limit: 0, page: 1, want: items, wantPages: 1},
+		{name: "first page", limit: 2, page: 1, want: []int{1, 2}, wantPages: 3},
+		{name: "last, partial page", limit: 2, page: 3, want: []int{5}, wantPages: 3},
+		{name: "past the last page", limit: 2, page: 4, want: nil, wantPages: 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, pages := paginate(items, tc.limit, tc.page)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantPages, pages)
+		})
+	}
+}
+
+func TestRenderVeleroRestoreResults(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, renderVeleroRestoreResults("Warnings", results.Result{}))
+
+	got := renderVeleroRestoreResults("Warnings", results.Result{
+		Cluster: []string{"could not restore, CustomResourceDefinition already exists"},
+		Namespaces: map[string][]string{
+			"prod": {"could not restore, Service prod/api already exists"},
+			"db":   {"volume snapshot skipped"},
+		},
+	})
+	assert.Equal(t,
+		"Warnings :\n"+
+			"  Cluster :\n"+
+			"    - could not restore, CustomResourceDefinition already exists\n"+
+			"  Namespace db :\n"+
+			"    - volume snapshot skipped\n"+
+			"  Namespace prod :\n"+
+			"    - could not restore, Service prod/api already exists\n",
+		got,
+	)
+}
@@ -0,0 +1,706 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	veleroV1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+)
+
+// backupVerifyExitFailed is the exit code, when the restore drill failed.
+const backupVerifyExitFailed = 1
+
+// Names of the restore drill's checks, besides the per-kind and per-PVC ones.
+const (
+	backupVerifyCheckRestore = "restore"
+	backupVerifyCheckCleanup = "cleanup"
+	backupVerifyCheckData    = "data"
+)
+
+// backupVerifyCheckPVCPrefix prefixes the name of the binding check of a restored PVC.
+const backupVerifyCheckPVCPrefix = "pvc/"
+
+// backupVerifyCheckDataPrefix prefixes the name of the data restoration check of a file-system
+// backed up pod volume.
+const backupVerifyCheckDataPrefix = backupVerifyCheckData + "/"
+
+// backupVerifyKinds are the kinds whose objects `backup verify` expects back, in the scratch
+// namespace, as backed up : the ones a workload is declared with. Pods, ReplicaSets, Endpoints
+// and the like are left out, their controllers recreating them under other names.
+var backupVerifyKinds = []string{
+	"v1/ConfigMap",
+	"v1/Secret",
+	"v1/Service",
+	"v1/ServiceAccount",
+	"v1/PersistentVolumeClaim",
+	"apps/v1/Deployment",
+	"apps/v1/StatefulSet",
+	"apps/v1/DaemonSet",
+	"batch/v1/CronJob",
+}
+
+// backupVerifyExcludedResources are left out of the restore drill, since they'd make the scratch
+// namespace compete with the live one for its hostnames and certificates.
+var backupVerifyExcludedResources = []string{
+	"ingresses.networking.k8s.io",
+	"certificates.cert-manager.io",
+}
+
+// backupVerifyResourceModifierRules are the Velero resource modifier rules keeping the restored
+// workloads inert : controllers are scaled down to 0 replicas, DaemonSets made unschedulable and
+// Jobs / CronJobs suspended. The restored Pods are orphaned, and their containers swapped for a
+// pause container, so they only live for Velero to restore their file-system backed up volumes
+// into (through the restore-wait init container Velero adds) and to keep their PVCs bound.
+const backupVerifyResourceModifierRules = `version: v1
+resourceModifierRules:
+- conditions:
+    groupResource: deployments.apps
+  patches:
+  - operation: add
+    path: /spec/replicas
+    value: "0"
+- conditions:
+    groupResource: statefulsets.apps
+  patches:
+  - operation: add
+    path: /spec/replicas
+    value: "0"
+- conditions:
+    groupResource: replicasets.apps
+  patches:
+  - operation: add
+    path: /spec/replicas
+    value: "0"
+- conditions:
+    groupResource: daemonsets.apps
+  patches:
+  - operation: add
+    path: /spec/template/spec/nodeSelector
+    value: '{"kubeaid.io/restore-drill": "inert"}'
+- conditions:
+    groupResource: cronjobs.batch
+  patches:
+  - operation: add
+    path: /spec/suspend
+    value: "true"
+- conditions:
+    groupResource: jobs.batch
+  patches:
+  - operation: add
+    path: /spec/suspend
+    value: "true"
+- conditions:
+    groupResource: pods
+  patches:
+  - operation: add
+    path: /metadata/ownerReferences
+    value: "[]"
+  - operation: add
+    path: /spec/containers
+    value: '[{"name": "inert", "image": "registry.k8s.io/pause:3.10"}]'
+`
+
+// backupVerifyPVCKind is the backupVerifyKinds entry of PersistentVolumeClaims, whose binding gets
+// checked too.
+const backupVerifyPVCKind = "v1/PersistentVolumeClaim"
+
+// backupVerifyCleanupTimeout bounds waiting for the scratch namespace to be gone. Independent of
+// the drill's own timeout, so cleanup still happens once that ran out.
+const backupVerifyCleanupTimeout = 10 * time.Minute
+
+// backupVerifyPVCBindingPollInterval is how often restored PVCs get polled, while waiting for
+// them to bind.
+const backupVerifyPVCBindingPollInterval = 5 * time.Second
+
+// backupVerifyScratchNamespacePrefix prefixes the scratch namespace a backed up namespace gets
+// restored into.
+const backupVerifyScratchNamespacePrefix = "kubeaid-verify-"
+
+// Prometheus metric names, for `backup verify -o prometheus`.
+const (
+	backupVerifyMetricPassed       = "kubeaid_backup_verify_passed"
+	backupVerifyMetricCheckPassed  = "kubeaid_backup_verify_check_passed"
+	backupVerifyMetricTimestamp    = "kubeaid_backup_verify_timestamp_seconds"
+	backupVerifyMetricDurationSecs = "kubeaid_backup_verify_duration_seconds"
+)
+
+type BackupVerifyArgs struct {
+	// Name of the Velero Backup to verify. The latest restorable one when empty.
+	BackupName string
+
+	// Namespace whose backup gets test-restored.
+	Namespace string
+
+	// OutputFormat is either "" (the human-readable report), or one of BackupStatusOutputFormats.
+	OutputFormat string
+
+	// Timeout bounds the restore, and the restored PVCs binding.
+	Timeout time.Duration
+
+	// Keep leaves the scratch namespace and the Velero Restore around, for inspection.
+	Keep bool
+}
+
+// backupVerifyReport is the outcome of a restore drill.
+type backupVerifyReport struct {
+	Backup           string              `json:"backup"`
+	Namespace        string              `json:"namespace"`
+	ScratchNamespace string              `json:"scratch_namespace"`
+	StartedAt        time.Time           `json:"started_at"`
+	FinishedAt       time.Time           `json:"finished_at"`
+	Passed           bool                `json:"passed"`
+	Checks           []backupVerifyCheck `json:"checks"`
+}
+
+type backupVerifyCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+/*
+BackupVerify runs a restore drill, against the cluster pointed to by the current kubeconfig :
+
+	(1) Restores args.Namespace, from the given (or the latest restorable) Velero Backup, into a
+	    scratch namespace, PersistentVolumes included. The restored workloads are kept inert (see
+	    backupVerifyResourceModifierRules), and backupVerifyExcludedResources left out.
+
+	(2) Checks that every object of backupVerifyKinds in the backup came back, that every
+	    restored PVC binds like its source, and that every file-system backed up pod volume got
+	    its data restored.
+
+	(3) Deletes the scratch namespace, the PersistentVolumes restored into it, the Velero Restore
+	    and its resource modifier, unless args.Keep.
+
+Prints the report in the requested output format, and returns the exit code the command should
+exit with : non-zero when the drill failed.
+*/
+func BackupVerify(ctx context.Context, args BackupVerifyArgs) int {
+	clusterClient, err := kubernetes.CreateVeleroClient(ctx)
+	assert.AssertErrNil(ctx, err, "Failed constructing cluster client from your kubeconfig")
+
+	var backup *veleroV1.Backup
+	if args.BackupName != "" {
+		backup, err = kubernetes.GetVeleroBackup(ctx, clusterClient, args.BackupName)
+		assert.AssertErrNil(ctx, err, "Failed getting Velero backup")
+	} else {
+		backups, err := kubernetes.ListVeleroBackups(ctx, clusterClient)
+		assert.AssertErrNil(ctx, err, "Failed listing Velero backups")
+
+		backup = latestRestorableVeleroBackup(backups)
+		assert.AssertNotNil(ctx, backup, "No Completed or PartiallyFailed Velero backup found")
+	}
+	assert.Assert(ctx, veleroBackupRestorable(backup),
+		fmt.Sprintf("Velero backup %s is %s : only a Completed or PartiallyFailed one can be restored",
+			backup.Name, backup.Status.Phase,
+		),
+	)
+
+	resourceList, err := kubernetes.GetVeleroBackupResourceList(ctx, clusterClient, backup.Name)
+	assert.AssertErrNil(ctx, err, "Failed fetching Velero backup resource list")
+
+	backedUp := namespaceBackupItems(resourceList, args.Namespace)
+	assert.Assert(ctx, len(backedUp) > 0,
+		fmt.Sprintf("Velero backup %s has nothing of namespace %s", backup.Name, args.Namespace),
+	)
+
+	report := backupVerifyReport{
+		Backup:           backup.Name,
+		Namespace:        args.Namespace,
+		ScratchNamespace: scratchNamespaceName(args.Namespace, time.Now()),
+		StartedAt:        time.Now(),
+	}
+
+	bar := progress.New(fmt.Sprintf("Verifying Velero backup %s, with namespace %s", backup.Name, args.Namespace))
+	ctx = progress.WithBar(ctx, bar)
+
+	drillCtx, cancel := context.WithTimeout(ctx, args.Timeout)
+	report.Checks = runRestoreDrill(drillCtx, clusterClient, backup.Name, report.ScratchNamespace, args.Namespace, backedUp)
+	cancel()
+
+	if !args.Keep {
+		report.Checks = append(report.Checks,
+			cleanupRestoreDrill(ctx, clusterClient, report.ScratchNamespace),
+		)
+	} else {
+		bar.Substep("Kept namespace " + report.ScratchNamespace + " and Velero Restore " + report.ScratchNamespace)
+	}
+	bar.Finish()
+
+	report.FinishedAt = time.Now()
+	report.Passed = backupVerifyChecksPassed(report.Checks)
+
+	switch args.OutputFormat {
+	case outputFormatJSON:
+		output, err := json.Marshal(report)
+		assert.AssertErrNil(ctx, err, "Failed JSON encoding backup verify report")
+
+		fmt.Println(string(output)) //nolint:forbidigo // operator-facing terminal output
+
+	case outputFormatYAML:
+		body, err := json.Marshal(report)
+		assert.AssertErrNil(ctx, err, "Failed JSON encoding backup verify report")
+
+		output, err := yaml.JSONToYAML(body)
+		assert.AssertErrNil(ctx, err, "Failed converting backup verify report to YAML")
+
+		fmt.Print(string(output)) //nolint:forbidigo // operator-facing terminal output
+
+	case outputFormatPrometheus:
+		fmt.Print(renderBackupVerifyPrometheus(report)) //nolint:forbidigo // operator-facing terminal output
+
+	case outputFormatJUnit:
+		output, err := renderBackupVerifyJUnit(report)
+		assert.AssertErrNil(ctx, err, "Failed rendering backup verify report as JUnit XML")
+
+		fmt.Print(output) //nolint:forbidigo // operator-facing terminal output
+
+	default:
+		fmt.Print(renderBackupVerify(report)) //nolint:forbidigo // operator-facing terminal output
+	}
+
+	if !report.Passed {
+		return backupVerifyExitFailed
+	}
+	return 0
+}
+
+// runRestoreDrill restores namespace from the Velero Backup into scratchNamespace, and checks the
+// outcome against backedUp (see namespaceBackupItems). Failures become failed checks, not errors,
+// so the drill always gets reported and cleaned up.
+func runRestoreDrill(ctx context.Context,
+	clusterClient client.Client,
+	backupName, scratchNamespace, namespace string,
+	backedUp map[string][]string,
+) []backupVerifyCheck {
+	bar := progress.FromCtx(ctx)
+
+	// The resource modifier is named after the scratch namespace, like the Velero Restore.
+	err := kubernetes.CreateVeleroResourceModifier(ctx, clusterClient, scratchNamespace, backupVerifyResourceModifierRules)
+	if err != nil {
+		return []backupVerifyCheck{{Name: backupVerifyCheckRestore, Message: err.Error()}}
+	}
+
+	_, err = kubernetes.CreateVeleroRestore(ctx, clusterClient, scratchNamespace, backupName,
+		kubernetes.VeleroRestoreOptions{
+			IncludedNamespaces: []string{namespace},
+			NamespaceMapping:   map[string]string{namespace: scratchNamespace},
+			RestorePVs:         aws.Bool(true),
+			ExcludedResources:  backupVerifyExcludedResources,
+			ResourceModifier:   scratchNamespace,
+		},
+	)
+	if err != nil {
+		return []backupVerifyCheck{{Name: backupVerifyCheckRestore, Message: err.Error()}}
+	}
+
+	var reporter veleroProgressReporter
+	restore, err := kubernetes.WaitForVeleroRestore(ctx, clusterClient, scratchNamespace,
+		func(restore *veleroV1.Restore) {
+			itemsDone, itemsTotal := 0, 0
+			if restore.Status.Progress != nil {
+				itemsDone, itemsTotal = restore.Status.Progress.ItemsRestored, restore.Status.Progress.TotalItems
+			}
+
+			for _, message := range reporter.update(string(restore.Status.Phase),
+				itemsDone, itemsTotal, restore.Status.Warnings, restore.Status.Errors,
+			) {
+				bar.Substep(message)
+			}
+		},
+	)
+	if err != nil {
+		return []backupVerifyCheck{{Name: backupVerifyCheckRestore, Message: err.Error()}}
+	}
+
+	checks := []backupVerifyCheck{{
+		Name:   backupVerifyCheckRestore,
+		Passed: restore.Status.Phase == veleroV1.RestorePhaseCompleted,
+		Message: fmt.Sprintf("%s, %d warning(s), %d error(s)",
+			restore.Status.Phase, restore.Status.Warnings, restore.Status.Errors,
+		),
+	}}
+
+	// Compare what came back against what was backed up.
+
+	restored := map[string][]string{}
+	for _, kind := range sortedKeys(backedUp) {
+		names, err := kubernetes.ListObjectNames(ctx, clusterClient, parseVeleroResourceKey(kind), scratchNamespace)
+		if err != nil {
+			checks = append(checks, backupVerifyCheck{Name: kind, Message: err.Error()})
+			continue
+		}
+		restored[kind] = names
+	}
+	checks = append(checks, resourceCountChecks(backedUp, restored)...)
+	bar.Substep("Compared restored resources")
+
+	// Check the data of file-system backed up pod volumes got restored. Velero is done with those
+	// by the time the Restore is.
+
+	podVolumeRestorePhases, err := kubernetes.GetPodVolumeRestorePhases(ctx, clusterClient, scratchNamespace)
+	if err != nil {
+		checks = append(checks, backupVerifyCheck{Name: backupVerifyCheckData, Message: err.Error()})
+	} else {
+		checks = append(checks, podVolumeRestoreChecks(podVolumeRestorePhases)...)
+	}
+
+	// Compare PVC binding against the source namespace.
+
+	pvcNames := backedUp[backupVerifyPVCKind]
+	if len(pvcNames) == 0 {
+		return checks
+	}
+
+	sourcePhases, err := kubernetes.GetPVCPhases(ctx, clusterClient, namespace, pvcNames)
+	if err != nil {
+		return append(checks, backupVerifyCheck{Name: backupVerifyPVCKind, Message: err.Error()})
+	}
+
+	restoredPhases := waitForRestoredPVCsBinding(ctx, clusterClient, scratchNamespace, pvcNames, sourcePhases)
+	checks = append(checks, pvcBindingChecks(pvcNames, sourcePhases, restoredPhases)...)
+	bar.Substep("Compared restored PVC binding")
+
+	return checks
+}
+
+// waitForRestoredPVCsBinding polls the restored PVCs until each binds like its source did, or ctx
+// is done, returning their last seen phases.
+func waitForRestoredPVCsBinding(ctx context.Context,
+	clusterClient client.Client,
+	scratchNamespace string,
+	pvcNames []string,
+	sourcePhases map[string]coreV1.PersistentVolumeClaimPhase,
+) map[string]coreV1.PersistentVolumeClaimPhase {
+	restoredPhases := map[string]coreV1.PersistentVolumeClaimPhase{}
+	for {
+		phases, err := kubernetes.GetPVCPhases(ctx, clusterClient, scratchNamespace, pvcNames)
+		if err == nil {
+			restoredPhases = phases
+			if backupVerifyChecksPassed(pvcBindingChecks(pvcNames, sourcePhases, restoredPhases)) {
+				return restoredPhases
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return restoredPhases
+
+		case <-time.After(backupVerifyPVCBindingPollInterval):
+		}
+	}
+}
+
+// cleanupRestoreDrill deletes the scratch namespace, the PersistentVolumes restored into it, and the
+// Velero Restore and resource modifier named after it.
+func cleanupRestoreDrill(ctx context.Context, clusterClient client.Client, scratchNamespace string) backupVerifyCheck {
+	check := backupVerifyCheck{Name: backupVerifyCheckCleanup}
+
+	if err := kubernetes.DeleteVeleroRestore(ctx, clusterClient, scratchNamespace); err != nil {
+		check.Message = err.Error()
+		return check
+	}
+
+	if err := kubernetes.DeleteVeleroResourceModifier(ctx, clusterClient, scratchNamespace); err != nil {
+		check.Message = err.Error()
+		return check
+	}
+
+	// Otherwise, the ones with the Retain reclaim policy, and their disks, outlive the scratch
+	// namespace's claims.
+	reclaimed, err := kubernetes.ReclaimNamespacePersistentVolumes(ctx, clusterClient, scratchNamespace)
+	if err != nil {
+		check.Message = err.Error()
+		return check
+	}
+	if len(reclaimed) > 0 {
+		progress.FromCtx(ctx).Substep(
+			fmt.Sprintf("Set reclaim policy of %d restored PersistentVolume(s) to Delete", len(reclaimed)),
+		)
+	}
+
+	err = kubernetes.DeleteNamespaceAndWait(ctx, clusterClient, scratchNamespace, backupVerifyCleanupTimeout)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed cleaning up restore drill",
+			slog.String("namespace", scratchNamespace), slog.String("error", err.Error()),
+		)
+		check.Message = err.Error()
+		return check
+	}
+
+	progress.FromCtx(ctx).Substep("Deleted namespace " + scratchNamespace)
+
+	check.Passed = true
+	check.Message = "Deleted namespace and Velero Restore " + scratchNamespace
+	return check
+}
+
+// latestRestorableVeleroBackup returns the most recently started Velero Backup, out of the
+// restorable ones. nil when there's none.
+func latestRestorableVeleroBackup(backups []veleroV1.Backup) *veleroV1.Backup {
+	var latest *veleroV1.Backup
+	for i := range backups {
+		backup := &backups[i]
+		if !veleroBackupRestorable(backup) || (backup.Status.StartTimestamp == nil) {
+			continue
+		}
+		if (latest == nil) || backup.Status.StartTimestamp.After(latest.Status.StartTimestamp.Time) {
+			latest = backup
+		}
+	}
+	return latest
+}
+
+func veleroBackupRestorable(backup *veleroV1.Backup) bool {
+	return (backup.Status.Phase == veleroV1.BackupPhaseCompleted) ||
+		(backup.Status.Phase == veleroV1.BackupPhasePartiallyFailed)
+}
+
+// namespaceBackupItems picks, out of a Velero Backup's resource list (see
+// kubernetes.GetVeleroBackupResourceList), the names of namespace's objects of backupVerifyKinds,
+// keyed by kind.
+func namespaceBackupItems(resourceList map[string][]string, namespace string) map[string][]string {
+	items := map[string][]string{}
+	for _, kind := range backupVerifyKinds {
+		for _, entry := range resourceList[kind] {
+			if name, found := strings.CutPrefix(entry, namespace+"/"); found {
+				items[kind] = append(items[kind], name)
+			}
+		}
+	}
+	return items
+}
+
+// parseVeleroResourceKey parses a Velero resource list key, like apps/v1/Deployment.
+func parseVeleroResourceKey(key string) schema.GroupVersionKind {
+	index := strings.LastIndex(key, "/")
+
+	groupVersion, _ := schema.ParseGroupVersion(key[:index])
+	return groupVersion.WithKind(key[index+1:])
+}
+
+// scratchNamespaceName names the namespace namespace gets restored into, keeping within the 63
+// characters a namespace name can have.
+func scratchNamespaceName(namespace string, now time.Time) string {
+	const maxNamespaceNameLength = 63
+
+	suffix := "-" + now.UTC().Format(veleroNameTimestampLayout)
+
+	namespace = namespace[:min(len(namespace),
+		maxNamespaceNameLength-len(backupVerifyScratchNamespacePrefix)-len(suffix),
+	)]
+	return backupVerifyScratchNamespacePrefix + strings.TrimSuffix(namespace, "-") + suffix
+}
+
+// resourceCountChecks checks, per kind, that every backed up object got restored. Objects the
+// scratch namespace has besides, like its default ServiceAccount, don't count.
+func resourceCountChecks(backedUp, restored map[string][]string) []backupVerifyCheck {
+	checks := make([]backupVerifyCheck, 0, len(backedUp))
+	for _, kind := range sortedKeys(backedUp) {
+		restoredNames := map[string]bool{}
+		for _, name := range restored[kind] {
+			restoredNames[name] = true
+		}
+
+		var missing []string
+		for _, name := range backedUp[kind] {
+			if !restoredNames[name] {
+				missing = append(missing, name)
+			}
+		}
+
+		check := backupVerifyCheck{
+			Name:    kind,
+			Passed:  len(missing) == 0,
+			Message: fmt.Sprintf("%d / %d restored", len(backedUp[kind])-len(missing), len(backedUp[kind])),
+		}
+		if len(missing) > 0 {
+			check.Message += ", missing : " + strings.Join(missing, ", ")
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// podVolumeRestoreChecks checks that Velero restored the data of every file-system backed up pod
+// volume, given the phases of its PodVolumeRestores (see kubernetes.GetPodVolumeRestorePhases).
+func podVolumeRestoreChecks(phases map[string]veleroV1.PodVolumeRestorePhase) []backupVerifyCheck {
+	checks := make([]backupVerifyCheck, 0, len(phases))
+	for _, podVolume := range sortedKeys(phases) {
+		phase := phases[podVolume]
+		checks = append(checks, backupVerifyCheck{
+			Name:    backupVerifyCheckDataPrefix + podVolume,
+			Passed:  phase == veleroV1.PodVolumeRestorePhaseCompleted,
+			Message: string(phase),
+		})
+	}
+	return checks
+}
+
+// pvcBindingChecks checks that every restored PVC is Bound, or at least in the same phase as its
+// source ("" when gone).
+func pvcBindingChecks(names []string, sourcePhases, restoredPhases map[string]coreV1.PersistentVolumeClaimPhase) []backupVerifyCheck {
+	checks := make([]backupVerifyCheck, 0, len(names))
+	for _, name := range names {
+		check := backupVerifyCheck{Name: backupVerifyCheckPVCPrefix + name}
+
+		sourcePhase, restoredPhase := sourcePhases[name], restoredPhases[name]
+		switch {
+		case restoredPhase == "":
+			check.Message = "not restored"
+
+		case restoredPhase == coreV1.ClaimBound:
+			check.Passed = true
+			check.Message = string(coreV1.ClaimBound)
+
+		case (sourcePhase != "") && (sourcePhase != coreV1.ClaimBound) && (restoredPhase == sourcePhase):
+			check.Passed = true
+			check.Message = fmt.Sprintf("%s, like its source", restoredPhase)
+
+		default:
+			source := string(sourcePhase)
+			if source == "" {
+				source = "gone"
+			}
+			check.Message = fmt.Sprintf("%s, while its source is %s", restoredPhase, source)
+		}
+
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func backupVerifyChecksPassed(checks []backupVerifyCheck) bool {
+	for _, check := range checks {
+		if !check.Passed {
+			return false
+		}
+	}
+	return len(checks) > 0
+}
+
+// renderBackupVerify renders report as a CHECK / RESULT / DETAIL table, under a header naming
+// what got restored where, and over a PASSED / FAILED verdict.
+func renderBackupVerify(report backupVerifyReport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Restored namespace %s from Velero backup %s, into %s\n\n",
+		report.Namespace, report.Backup, report.ScratchNamespace,
+	)
+
+	w := tabwriter.NewWriter(&b, tabwriterMinWidth, tabwriterTabWidth, tabwriterPadding, ' ', 0)
+
+	// Writes to a strings.Builder-backed tabwriter never fail.
+	_, _ = fmt.Fprintln(w, "CHECK\tRESULT\tDETAIL")
+	passed := 0
+	for _, check := range report.Checks {
+		result := "FAIL"
+		if check.Passed {
+			result = "PASS"
+			passed++
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", check.Name, result, check.Message)
+	}
+
+	// Flush only fails when the underlying writer does, and a strings.Builder never does.
+	_ = w.Flush()
+
+	verdict := "FAILED"
+	if report.Passed {
+		verdict = "PASSED"
+	}
+	fmt.Fprintf(&b, "\n%s : %d / %d checks passed, in %s\n",
+		verdict, passed, len(report.Checks), formatAge(report.FinishedAt.Sub(report.StartedAt)),
+	)
+	return b.String()
+}
+
+// renderBackupVerifyPrometheus renders report in the Prometheus text exposition format, for
+// node-exporter's textfile collector : whether the drill passed, each check, and when it ran for
+// how long, all labeled with the verified namespace.
+func renderBackupVerifyPrometheus(report backupVerifyReport) string {
+	labels := fmt.Sprintf(`backup="%s",namespace="%s"`,
+		prometheusLabelValueEscaper.Replace(report.Backup),
+		prometheusLabelValueEscaper.Replace(report.Namespace),
+	)
+	boolGauge := func(value bool) int {
+		if value {
+			return 1
+		}
+		return 0
+	}
+
+	var b strings.Builder
+
+	writeHeader := func(name, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+	}
+
+	writeHeader(backupVerifyMetricPassed, "Whether the latest restore drill of a namespace passed.")
+	fmt.Fprintf(&b, "%s{%s} %d\n", backupVerifyMetricPassed, labels, boolGauge(report.Passed))
+
+	writeHeader(backupVerifyMetricCheckPassed, "Whether a check of the latest restore drill of a namespace passed.")
+	for _, check := range report.Checks {
+		fmt.Fprintf(&b, "%s{%s,check=\"%s\"} %d\n",
+			backupVerifyMetricCheckPassed, labels,
+			prometheusLabelValueEscaper.Replace(check.Name), boolGauge(check.Passed),
+		)
+	}
+
+	writeHeader(backupVerifyMetricTimestamp, "When the latest restore drill of a namespace finished.")
+	fmt.Fprintf(&b, "%s{%s} %d\n", backupVerifyMetricTimestamp, labels, report.FinishedAt.Unix())
+
+	writeHeader(backupVerifyMetricDurationSecs, "How long the latest restore drill of a namespace took.")
+	fmt.Fprintf(&b, "%s{%s} %g\n", backupVerifyMetricDurationSecs, labels,
+		report.FinishedAt.Sub(report.StartedAt).Seconds(),
+	)
+
+	return b.String()
+}
+
+// renderBackupVerifyJUnit renders report as a JUnit XML report, for CI systems to show : a
+// testcase per check, failing when it did.
+func renderBackupVerifyJUnit(report backupVerifyReport) (string, error) {
+	suite := junitTestSuite{
+		Name:      "backup-verify",
+		Timestamp: report.StartedAt.UTC().Format(time.RFC3339),
+	}
+
+	for _, check := range report.Checks {
+		testCase := junitTestCase{
+			ClassName: report.Backup + "." + report.Namespace,
+			Name:      check.Name,
+		}
+		if !check.Passed {
+			suite.Failures++
+			testCase.Failure = &junitProblem{Type: "failed", Message: check.Message}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Tests = len(suite.TestCases)
+
+	output, err := xml.MarshalIndent(junitTestSuites{TestSuites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed XML encoding JUnit report: %w", err)
+	}
+	return xml.Header + string(output) + "\n", nil
+}
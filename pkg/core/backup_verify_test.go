@@ -0,0 +1,257 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	veleroV1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+func TestLatestRestorableVeleroBackup(t *testing.T) {
+	t.Parallel()
+
+	at := func(hour int) *metaV1.Time {
+		return &metaV1.Time{Time: time.Date(2026, 5, 1, hour, 0, 0, 0, time.UTC)}
+	}
+	backup := func(name string, phase veleroV1.BackupPhase, startedAt *metaV1.Time) veleroV1.Backup {
+		return veleroV1.Backup{
+			ObjectMeta: metaV1.ObjectMeta{Name: name},
+			Status:     veleroV1.BackupStatus{Phase: phase, StartTimestamp: startedAt},
+		}
+	}
+
+	latest := latestRestorableVeleroBackup([]veleroV1.Backup{
+		backup("completed", veleroV1.BackupPhaseCompleted, at(1)),
+		backup("partially-failed", veleroV1.BackupPhasePartiallyFailed, at(2)),
+		backup("failed", veleroV1.BackupPhaseFailed, at(3)),
+		backup("in-progress", veleroV1.BackupPhaseInProgress, at(4)),
+	})
+	require.NotNil(t, latest)
+	assert.Equal(t, "partially-failed", latest.Name)
+
+	assert.Nil(t, latestRestorableVeleroBackup([]veleroV1.Backup{
+		backup("failed", veleroV1.BackupPhaseFailed, at(3)),
+	}))
+}
+
+func TestNamespaceBackupItems(t *testing.T) {
+	t.Parallel()
+
+	items := namespaceBackupItems(map[string][]string{
+		"apps/v1/Deployment":       {"shop/api", "shop/web", "other/api"},
+		"v1/PersistentVolumeClaim": {"shop/data"},
+		"v1/Pod":                   {"shop/api-7d9c5-x2x4k"},
+		"v1/PersistentVolume":      {"pvc-1234"},
+		"v1/ConfigMap":             {"shopping/config"},
+	}, "shop")
+
+	assert.Equal(t, map[string][]string{
+		"apps/v1/Deployment":       {"api", "web"},
+		"v1/PersistentVolumeClaim": {"data"},
+	}, items)
+}
+
+func TestParseVeleroResourceKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		parseVeleroResourceKey("apps/v1/Deployment"),
+	)
+	assert.Equal(t,
+		schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		parseVeleroResourceKey("v1/ConfigMap"),
+	)
+}
+
+func TestScratchNamespaceName(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "kubeaid-verify-shop-20260501103000", scratchNamespaceName("shop", now))
+
+	long := scratchNamespaceName(strings.Repeat("a", 40)+"-"+strings.Repeat("b", 22), now)
+	assert.Len(t, long, 63)
+	assert.Equal(t, "kubeaid-verify-"+strings.Repeat("a", 33)+"-20260501103000", long)
+}
+
+func TestResourceCountChecks(t *testing.T) {
+	t.Parallel()
+
+	checks := resourceCountChecks(
+		map[string][]string{
+			"v1/ServiceAccount":  {"api"},
+			"apps/v1/Deployment": {"api", "web"},
+		},
+		map[string][]string{
+			// The scratch namespace's own default ServiceAccount doesn't count.
+			"v1/ServiceAccount":  {"api", "default"},
+			"apps/v1/Deployment": {"api"},
+		},
+	)
+
+	assert.Equal(t, []backupVerifyCheck{
+		{Name: "apps/v1/Deployment", Message: "1 / 2 restored, missing : web"},
+		{Name: "v1/ServiceAccount", Passed: true, Message: "1 / 1 restored"},
+	}, checks)
+}
+
+func TestPVCBindingChecks(t *testing.T) {
+	t.Parallel()
+
+	checks := pvcBindingChecks(
+		[]string{"bound", "pending", "pending-like-source", "gone-source", "missing"},
+		map[string]coreV1.PersistentVolumeClaimPhase{
+			"bound":               coreV1.ClaimBound,
+			"pending":             coreV1.ClaimBound,
+			"pending-like-source": coreV1.ClaimPending,
+			"missing":             coreV1.ClaimBound,
+		},
+		map[string]coreV1.PersistentVolumeClaimPhase{
+			"bound":               coreV1.ClaimBound,
+			"pending":             coreV1.ClaimPending,
+			"pending-like-source": coreV1.ClaimPending,
+			"gone-source":         coreV1.ClaimPending,
+		},
+	)
+
+	assert.Equal(t, []backupVerifyCheck{
+		{Name: "pvc/bound", Passed: true, Message: "Bound"},
+		{Name: "pvc/pending", Message: "Pending, while its source is Bound"},
+		{Name: "pvc/pending-like-source", Passed: true, Message: "Pending, like its source"},
+		{Name: "pvc/gone-source", Message: "Pending, while its source is gone"},
+		{Name: "pvc/missing", Message: "not restored"},
+	}, checks)
+}
+
+func TestPodVolumeRestoreChecks(t *testing.T) {
+	t.Parallel()
+
+	checks := podVolumeRestoreChecks(map[string]veleroV1.PodVolumeRestorePhase{
+		"db-1/data": veleroV1.PodVolumeRestorePhaseFailed,
+		"db-0/data": veleroV1.PodVolumeRestorePhaseCompleted,
+	})
+
+	assert.Equal(t, []backupVerifyCheck{
+		{Name: "data/db-0/data", Passed: true, Message: "Completed"},
+		{Name: "data/db-1/data", Message: "Failed"},
+	}, checks)
+}
+
+func TestBackupVerifyResourceModifierRules(t *testing.T) {
+	t.Parallel()
+
+	var rules struct {
+		Version               string `json:"version"`
+		ResourceModifierRules []struct {
+			Conditions struct {
+				GroupResource string `json:"groupResource"`
+			} `json:"conditions"`
+			Patches []struct {
+				Operation string `json:"operation"`
+				Path      string `json:"path"`
+				Value     string `json:"value"`
+			} `json:"patches"`
+		} `json:"resourceModifierRules"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(backupVerifyResourceModifierRules), &rules))
+	assert.Equal(t, "v1", rules.Version)
+
+	patched := map[string]string{}
+	for _, rule := range rules.ResourceModifierRules {
+		for _, patch := range rule.Patches {
+			assert.Equal(t, "add", patch.Operation)
+			patched[rule.Conditions.GroupResource+patch.Path] = patch.Value
+
+			// JSON values have to be valid, for Velero to leave them unquoted.
+			if strings.HasPrefix(patch.Value, "{") || strings.HasPrefix(patch.Value, "[") {
+				assert.True(t, json.Valid([]byte(patch.Value)), patch.Value)
+			}
+		}
+	}
+	assert.Equal(t, "0", patched["deployments.apps/spec/replicas"])
+	assert.Equal(t, "0", patched["statefulsets.apps/spec/replicas"])
+	assert.Equal(t, "true", patched["cronjobs.batch/spec/suspend"])
+	assert.Equal(t, "[]", patched["pods/metadata/ownerReferences"])
+}
+
+func TestBackupVerifyChecksPassed(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, backupVerifyChecksPassed(nil))
+	assert.True(t, backupVerifyChecksPassed([]backupVerifyCheck{{Passed: true}, {Passed: true}}))
+	assert.False(t, backupVerifyChecksPassed([]backupVerifyCheck{{Passed: true}, {}}))
+}
+
+func newTestBackupVerifyReport() backupVerifyReport {
+	startedAt := time.Date(2026, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	return backupVerifyReport{
+		Backup:           "daily-20260501",
+		Namespace:        "shop",
+		ScratchNamespace: "kubeaid-verify-shop-20260501103000",
+		StartedAt:        startedAt,
+		FinishedAt:       startedAt.Add(90 * time.Second),
+		Checks: []backupVerifyCheck{
+			{Name: "restore", Passed: true, Message: "Completed, 0 warning(s), 0 error(s)"},
+			{Name: "pvc/data", Message: "Pending, while its source is Bound"},
+		},
+	}
+}
+
+func TestRenderBackupVerify(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"Restored namespace shop from Velero backup daily-20260501, into kubeaid-verify-shop-20260501103000\n"+
+			"\n"+
+			"CHECK      RESULT   DETAIL\n"+
+			"restore    PASS     Completed, 0 warning(s), 0 error(s)\n"+
+			"pvc/data   FAIL     Pending, while its source is Bound\n"+
+			"\n"+
+			"FAILED : 1 / 2 checks passed, in 1m\n",
+		renderBackupVerify(newTestBackupVerifyReport()),
+	)
+}
+
+func TestRenderBackupVerifyPrometheus(t *testing.T) {
+	t.Parallel()
+
+	output := renderBackupVerifyPrometheus(newTestBackupVerifyReport())
+
+	for _, line := range []string{
+		`kubeaid_backup_verify_passed{backup="daily-20260501",namespace="shop"} 0`,
+		`kubeaid_backup_verify_check_passed{backup="daily-20260501",namespace="shop",check="restore"} 1`,
+		`kubeaid_backup_verify_check_passed{backup="daily-20260501",namespace="shop",check="pvc/data"} 0`,
+		`kubeaid_backup_verify_timestamp_seconds{backup="daily-20260501",namespace="shop"} 1777631490`,
+		`kubeaid_backup_verify_duration_seconds{backup="daily-20260501",namespace="shop"} 90`,
+		"# TYPE kubeaid_backup_verify_passed gauge",
+	} {
+		assert.Contains(t, output, line+"\n")
+	}
+}
+
+func TestRenderBackupVerifyJUnit(t *testing.T) {
+	t.Parallel()
+
+	output, err := renderBackupVerifyJUnit(newTestBackupVerifyReport())
+	require.NoError(t, err)
+
+	assert.Contains(t, output, `<testsuite name="backup-verify" tests="2" failures="1" errors="0" timestamp="2026-05-01T10:30:00Z">`)
+	assert.Contains(t, output, `<testcase classname="daily-20260501.shop" name="restore"></testcase>`)
+	assert.Contains(t, output,
+		`<failure type="failed" message="Pending, while its source is Bound"></failure>`,
+	)
+}
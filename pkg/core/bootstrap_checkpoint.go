@@ -0,0 +1,406 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	coreV1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/aws"
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/globals"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	gitUtils "github.com/Obmondo/kubeaid-cli/pkg/utils/git"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+)
+
+// Bootstrap phases, as recorded in the checkpoint file. The values are persisted : never rename
+// one.
+const (
+	bootstrapPhaseInfrastructure    = "infrastructure"
+	bootstrapPhaseManagementCluster = "management-cluster"
+	bootstrapPhaseMainCluster       = "main-cluster"
+	bootstrapPhaseDisasterRecovery  = "disaster-recovery"
+	bootstrapPhaseArgoCDApps        = "argocd-apps"
+	bootstrapPhaseInitialBackups    = "initial-backups"
+)
+
+/*
+bootstrapCheckpoint records which phases of a cluster's bootstrap have completed, and the
+artifacts they produced, so `cluster bootstrap --resume` can continue from the last completed
+phase instead of starting over.
+
+It gets persisted after every completed phase, under outputs/checkpoints/<cluster>.json.
+*/
+type bootstrapCheckpoint struct {
+	Cluster         string                     `json:"cluster"`
+	CompletedPhases []bootstrapCheckpointPhase `json:"completedPhases"`
+	Artifacts       bootstrapArtifacts         `json:"artifacts"`
+
+	filePath string
+
+	// resuming stays true while a resumed run is still skipping completed phases. The first
+	// phase which doesn't get skipped ends the resume : every phase after it runs.
+	resuming bool
+}
+
+type bootstrapCheckpointPhase struct {
+	Name string `json:"name"`
+
+	// Title is the phase's section title in the progress UI, printed when resuming.
+	Title string `json:"title"`
+
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// bootstrapArtifacts is what completed phases produced, which later phases need.
+type bootstrapArtifacts struct {
+	ManagementClusterKubeconfig string `json:"managementClusterKubeconfig,omitempty"`
+	MainClusterKubeconfig       string `json:"mainClusterKubeconfig,omitempty"`
+
+	// Set by the Hetzner infrastructure phase. Restored into globals when that phase gets skipped,
+	// since the kubeaid-config templates render them.
+	ControlPlaneLBPrivateIP         string   `json:"controlPlaneLBPrivateIP,omitempty"`
+	ControlPlaneHostname            string   `json:"controlPlaneHostname,omitempty"`
+	ControlPlaneLBBootstrapPublicIP string   `json:"controlPlaneLBBootstrapPublicIP,omitempty"`
+	CoturnFloatingIPs               []string `json:"coturnFloatingIPs,omitempty"`
+
+	// KubeaidConfigCommits maps a cluster type (management / main) to the hash of the last
+	// kubeaid-config commit pushed while setting that cluster up.
+	KubeaidConfigCommits map[string]string `json:"kubeaidConfigCommits,omitempty"`
+}
+
+// Returns path to the checkpoint file of the given cluster.
+func bootstrapCheckpointFilePath(clusterName string) string {
+	return path.Join(constants.OutputBootstrapCheckpointsDirectory, clusterName+".json")
+}
+
+func newBootstrapCheckpoint(clusterName, filePath string) *bootstrapCheckpoint {
+	return &bootstrapCheckpoint{
+		Cluster:  clusterName,
+		filePath: filePath,
+	}
+}
+
+// loadBootstrapCheckpoint reads the checkpoint file at the given path. The returned error wraps
+// os.ErrNotExist when there's none.
+func loadBootstrapCheckpoint(filePath string) (*bootstrapCheckpoint, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading bootstrap checkpoint %s: %w", filePath, err)
+	}
+
+	checkpoint := &bootstrapCheckpoint{}
+	if err := json.Unmarshal(content, checkpoint); err != nil {
+		return nil, fmt.Errorf("parsing bootstrap checkpoint %s: %w", filePath, err)
+	}
+	checkpoint.filePath = filePath
+
+	return checkpoint, nil
+}
+
+// save writes the checkpoint to its file. The write goes through a temp file in the same
+// directory, renamed over the checkpoint file : a crash mid-write never leaves a truncated one
+// behind.
+func (c *bootstrapCheckpoint) save() error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling bootstrap checkpoint: %w", err)
+	}
+
+	if err := utils.CreateIntermediateDirsForFile(c.filePath); err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(c.filePath), ".checkpoint-*.json")
+	if err != nil {
+		return fmt.Errorf("creating temp file for bootstrap checkpoint: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(append(content, '\n')); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("writing bootstrap checkpoint: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("writing bootstrap checkpoint: %w", err)
+	}
+
+	if err := os.Rename(tempFile.Name(), c.filePath); err != nil {
+		return fmt.Errorf("replacing bootstrap checkpoint %s: %w", c.filePath, err)
+	}
+	return nil
+}
+
+// completed reports whether the given phase has been recorded as completed.
+func (c *bootstrapCheckpoint) completed(phase string) bool {
+	return slices.ContainsFunc(c.CompletedPhases, func(p bootstrapCheckpointPhase) bool {
+		return p.Name == phase
+	})
+}
+
+// lastCompletedPhase returns the most recently completed phase. False when no phase has
+// completed yet.
+func (c *bootstrapCheckpoint) lastCompletedPhase() (bootstrapCheckpointPhase, bool) {
+	if len(c.CompletedPhases) == 0 {
+		return bootstrapCheckpointPhase{}, false
+	}
+	return c.CompletedPhases[len(c.CompletedPhases)-1], true
+}
+
+// recordPhase marks the given phase as completed, captures the artifacts held in globals, and
+// persists the checkpoint.
+// A checkpoint failing to get persisted doesn't fail the bootstrap : only a later --resume is
+// affected, so it just gets warned about.
+func (c *bootstrapCheckpoint) recordPhase(ctx context.Context, phase, title string) {
+	if c == nil {
+		return
+	}
+
+	completedPhase := bootstrapCheckpointPhase{
+		Name:        phase,
+		Title:       title,
+		CompletedAt: time.Now().UTC(),
+	}
+
+	// A phase re-run by a resumed run keeps its place in the order.
+	index := slices.IndexFunc(c.CompletedPhases, func(p bootstrapCheckpointPhase) bool {
+		return p.Name == phase
+	})
+	if index >= 0 {
+		c.CompletedPhases[index] = completedPhase
+	} else {
+		c.CompletedPhases = append(c.CompletedPhases, completedPhase)
+	}
+
+	c.captureArtifacts(phase)
+
+	if err := c.save(); err != nil {
+		slog.WarnContext(ctx, "Failed saving bootstrap checkpoint, --resume won't skip this phase",
+			slog.String("phase", phase), slog.Any("error", err),
+		)
+	}
+}
+
+// recordKubeaidConfigCommit records the hash of the kubeaid-config commit pushed while setting up
+// the given type of cluster, and persists the checkpoint.
+func (c *bootstrapCheckpoint) recordKubeaidConfigCommit(ctx context.Context,
+	clusterType, commitHash string,
+) {
+	if c == nil {
+		return
+	}
+
+	if c.Artifacts.KubeaidConfigCommits == nil {
+		c.Artifacts.KubeaidConfigCommits = map[string]string{}
+	}
+	c.Artifacts.KubeaidConfigCommits[clusterType] = commitHash
+
+	if err := c.save(); err != nil {
+		slog.WarnContext(ctx, "Failed saving bootstrap checkpoint", slog.Any("error", err))
+	}
+}
+
+// captureArtifacts copies the artifacts the given phase has just produced, and the ones held in
+// globals, into the checkpoint.
+func (c *bootstrapCheckpoint) captureArtifacts(phase string) {
+	switch phase {
+	case bootstrapPhaseManagementCluster:
+		if globals.CloudProviderName != constants.CloudProviderBareMetal {
+			c.Artifacts.ManagementClusterKubeconfig = constants.OutputPathManagementClusterHostKubeconfig
+		}
+
+	// KUBECONFIG points to the main cluster's kubeconfig now. With the Local provider, that's the
+	// K3D management cluster's one.
+	case bootstrapPhaseMainCluster:
+		c.Artifacts.MainClusterKubeconfig = os.Getenv(constants.EnvNameKubeconfig)
+	}
+
+	c.Artifacts.ControlPlaneLBPrivateIP = globals.ControlPlaneLBPrivateIP
+	c.Artifacts.ControlPlaneHostname = globals.ControlPlaneHostname
+	c.Artifacts.ControlPlaneLBBootstrapPublicIP = globals.ControlPlaneLBBootstrapPublicIP
+	c.Artifacts.CoturnFloatingIPs = slices.Clone(globals.CoturnFloatingIPs)
+}
+
+// restoreArtifacts copies the recorded artifacts back into globals, for phases after a skipped
+// one to find them where they'd have been, had it run.
+func (c *bootstrapCheckpoint) restoreArtifacts() {
+	globals.ControlPlaneLBPrivateIP = c.Artifacts.ControlPlaneLBPrivateIP
+	globals.ControlPlaneHostname = c.Artifacts.ControlPlaneHostname
+	globals.ControlPlaneLBBootstrapPublicIP = c.Artifacts.ControlPlaneLBBootstrapPublicIP
+	globals.CoturnFloatingIPs = slices.Clone(c.Artifacts.CoturnFloatingIPs)
+}
+
+/*
+skip reports whether a resumed run skips the given phase : it has been recorded as completed,
+and check (when given) confirms what it produced is still in place.
+
+The first phase which doesn't get skipped ends the resume. Every phase after it runs, even when
+recorded as completed, since it may depend on what the re-run phase changes.
+*/
+func (c *bootstrapCheckpoint) skip(ctx context.Context, phase string, check func() error) bool {
+	if (c == nil) || !c.resuming {
+		return false
+	}
+
+	if !c.completed(phase) {
+		c.resuming = false
+		return false
+	}
+
+	if check != nil {
+		if err := check(); err != nil {
+			slog.WarnContext(ctx, "Re-running phase recorded as completed, since its idempotency check failed",
+				slog.String("phase", phase), slog.Any("error", err),
+			)
+			c.resuming = false
+			return false
+		}
+	}
+
+	slog.InfoContext(ctx, "Skipping phase completed by an earlier run", slog.String("phase", phase))
+	progress.FromCtx(ctx).Substep("Skipped : completed by an earlier run")
+	return true
+}
+
+/*
+openBootstrapCheckpoint returns the checkpoint the bootstrap of the given cluster records its
+progress in.
+
+With resume, it's the one an earlier run left behind : the last completed phase gets printed, and
+the recorded artifacts get restored into globals. When there's none, the bootstrap starts from
+the beginning.
+
+Panics if an existing checkpoint can't be read.
+*/
+func openBootstrapCheckpoint(ctx context.Context, clusterName string, resume bool) *bootstrapCheckpoint {
+	filePath := bootstrapCheckpointFilePath(clusterName)
+
+	checkpoint, err := loadBootstrapCheckpoint(filePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if resume {
+			slog.InfoContext(ctx, "No bootstrap checkpoint to resume from, bootstrapping from the beginning",
+				slog.String("path", filePath),
+			)
+		}
+		return newBootstrapCheckpoint(clusterName, filePath)
+
+	case err != nil:
+		if !resume {
+			// It gets overwritten anyway.
+			return newBootstrapCheckpoint(clusterName, filePath)
+		}
+		assert.AssertErrNil(ctx, err, "Failed reading bootstrap checkpoint")
+	}
+
+	if !resume {
+		slog.InfoContext(ctx,
+			"Replacing the bootstrap checkpoint left by an earlier run. Pass --resume to continue from it instead",
+			slog.String("path", filePath),
+		)
+		return newBootstrapCheckpoint(clusterName, filePath)
+	}
+
+	lastCompletedPhase, ok := checkpoint.lastCompletedPhase()
+	if !ok {
+		fmt.Print("No bootstrap phase has completed yet : bootstrapping from the beginning.\n\n") //nolint:forbidigo // operator-facing terminal output
+		return checkpoint
+	}
+
+	fmt.Printf( //nolint:forbidigo // operator-facing terminal output
+		"Resuming bootstrap : last completed phase is %q (completed at %s).\n\n",
+		lastCompletedPhase.Title, lastCompletedPhase.CompletedAt.Local().Format(time.DateTime),
+	)
+
+	checkpoint.restoreArtifacts()
+	checkpoint.resuming = true
+
+	return checkpoint
+}
+
+type bootstrapCheckpointCtxKey struct{}
+
+// withBootstrapCheckpoint returns ctx carrying the given checkpoint, for helpers down the call
+// tree (like SetupKubeAidConfig) to record artifacts in.
+func withBootstrapCheckpoint(ctx context.Context, c *bootstrapCheckpoint) context.Context {
+	return context.WithValue(ctx, bootstrapCheckpointCtxKey{}, c)
+}
+
+// bootstrapCheckpointFromCtx returns the checkpoint attached to ctx via withBootstrapCheckpoint,
+// or nil (on which every recording method is a no-op) when none is attached.
+func bootstrapCheckpointFromCtx(ctx context.Context) *bootstrapCheckpoint {
+	c, _ := ctx.Value(bootstrapCheckpointCtxKey{}).(*bootstrapCheckpoint)
+	return c
+}
+
+/*
+skipCheckMainClusterPhase is the idempotency check for skipping the main cluster phase : the
+recorded main cluster kubeconfig must still be there, the main cluster must be reachable using it,
+and when using ClusterAPI, 'clusterctl move' must have been executed (unless skipped by the user).
+*/
+func skipCheckMainClusterPhase(ctx context.Context,
+	checkpoint *bootstrapCheckpoint,
+	skipClusterctlMove bool,
+) error {
+	kubeconfigPath := checkpoint.Artifacts.MainClusterKubeconfig
+	if kubeconfigPath == "" {
+		return errors.New("no main cluster kubeconfig recorded")
+	}
+
+	if _, err := os.Stat(kubeconfigPath); err != nil {
+		return fmt.Errorf("checking main cluster kubeconfig: %w", err)
+	}
+
+	clusterClient, err := kubernetes.CreateKubernetesClient(ctx, kubeconfigPath)
+	if err != nil {
+		return fmt.Errorf("constructing main cluster client: %w", err)
+	}
+
+	if err := clusterClient.List(ctx, &coreV1.NodeList{}, client.Limit(1)); err != nil {
+		return fmt.Errorf("main cluster isn't reachable: %w", err)
+	}
+
+	if kubernetes.UsingClusterAPI() && !skipClusterctlMove && !kubernetes.IsClusterctlMoveExecuted(ctx) {
+		return errors.New("'clusterctl move' hasn't been executed")
+	}
+
+	return nil
+}
+
+// restoreSkippedDevEnv redoes the cheap parts of the skipped management cluster phase, which later
+// phases rely on : the AWS specific environment variables, and the local kubeaid-config clone.
+func restoreSkippedDevEnv(ctx context.Context, gitAuthMethod transport.AuthMethod) {
+	if globals.CloudProviderName == constants.CloudProviderAWS {
+		err := aws.SetAWSSpecificEnvs(ctx)
+		assert.AssertErrNil(ctx, err, "Failed setting AWS specific environment variables")
+	}
+
+	_ = gitUtils.CloneRepo(ctx, config.ParsedGeneralConfig.Forks.KubeaidConfigFork.URL, gitAuthMethod)
+}
+
+// restoreSkippedMainCluster points KUBECONFIG to the recorded main cluster kubeconfig, and
+// connects to the main cluster's ArgoCD, like the skipped main cluster phase would have left
+// things.
+func restoreSkippedMainCluster(ctx context.Context, checkpoint *bootstrapCheckpoint) {
+	utils.MustSetEnv(constants.EnvNameKubeconfig, checkpoint.Artifacts.MainClusterKubeconfig)
+
+	err := kubernetes.RecreateArgoCDApplicationClient(ctx, nil)
+	assert.AssertErrNil(ctx, err, "Failed connecting to the main cluster's ArgoCD")
+}
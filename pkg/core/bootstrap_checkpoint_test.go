@@ -0,0 +1,145 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/globals"
+)
+
+// Mutates the globals.ControlPlane* and globals.CoturnFloatingIPs globals, and KUBECONFIG —
+// sequential only.
+func TestBootstrapCheckpointRecordAndLoad(t *testing.T) {
+	origPrivateIP, origHostname := globals.ControlPlaneLBPrivateIP, globals.ControlPlaneHostname
+	origPublicIP, origCoturnIPs := globals.ControlPlaneLBBootstrapPublicIP, globals.CoturnFloatingIPs
+	t.Cleanup(func() {
+		globals.ControlPlaneLBPrivateIP, globals.ControlPlaneHostname = origPrivateIP, origHostname
+		globals.ControlPlaneLBBootstrapPublicIP, globals.CoturnFloatingIPs = origPublicIP, origCoturnIPs
+	})
+
+	ctx := context.Background()
+	filePath := filepath.Join(t.TempDir(), "checkpoints", "demo.json")
+
+	_, err := loadBootstrapCheckpoint(filePath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	globals.ControlPlaneLBPrivateIP = "10.0.0.2"
+	globals.ControlPlaneHostname = "api.demo.example.com"
+	globals.ControlPlaneLBBootstrapPublicIP = "203.0.113.10"
+	globals.CoturnFloatingIPs = []string{"203.0.113.20"}
+
+	checkpoint := newBootstrapCheckpoint("demo", filePath)
+	checkpoint.recordPhase(ctx, bootstrapPhaseInfrastructure, "Provisioning Hetzner infrastructure")
+	checkpoint.recordKubeaidConfigCommit(ctx, constants.ClusterTypeManagement, "abc123")
+	t.Setenv(constants.EnvNameKubeconfig, constants.OutputPathMainClusterKubeconfig)
+	checkpoint.recordPhase(ctx, bootstrapPhaseMainCluster, "Provisioning main cluster")
+
+	// Re-running a phase keeps its place in the order.
+	checkpoint.recordPhase(ctx, bootstrapPhaseInfrastructure, "Provisioning Hetzner infrastructure")
+
+	loaded, err := loadBootstrapCheckpoint(filePath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "demo", loaded.Cluster)
+	assert.True(t, loaded.completed(bootstrapPhaseInfrastructure))
+	assert.True(t, loaded.completed(bootstrapPhaseMainCluster))
+	assert.False(t, loaded.completed(bootstrapPhaseArgoCDApps))
+
+	lastCompletedPhase, ok := loaded.lastCompletedPhase()
+	require.True(t, ok)
+	assert.Equal(t, bootstrapPhaseMainCluster, lastCompletedPhase.Name)
+	assert.Equal(t, "Provisioning main cluster", lastCompletedPhase.Title)
+
+	assert.Equal(t, bootstrapArtifacts{
+		MainClusterKubeconfig:           constants.OutputPathMainClusterKubeconfig,
+		ControlPlaneLBPrivateIP:         "10.0.0.2",
+		ControlPlaneHostname:            "api.demo.example.com",
+		ControlPlaneLBBootstrapPublicIP: "203.0.113.10",
+		CoturnFloatingIPs:               []string{"203.0.113.20"},
+		KubeaidConfigCommits:            map[string]string{constants.ClusterTypeManagement: "abc123"},
+	}, loaded.Artifacts)
+
+	// No temp file gets left behind by the atomic writes.
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	globals.ControlPlaneLBPrivateIP, globals.ControlPlaneHostname = "", ""
+	globals.ControlPlaneLBBootstrapPublicIP, globals.CoturnFloatingIPs = "", nil
+
+	loaded.restoreArtifacts()
+	assert.Equal(t, "10.0.0.2", globals.ControlPlaneLBPrivateIP)
+	assert.Equal(t, "api.demo.example.com", globals.ControlPlaneHostname)
+	assert.Equal(t, "203.0.113.10", globals.ControlPlaneLBBootstrapPublicIP)
+	assert.Equal(t, []string{"203.0.113.20"}, globals.CoturnFloatingIPs)
+}
+
+func TestBootstrapCheckpointSkip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	failingCheck := func() error { return errors.New("gone") }
+
+	newResumingCheckpoint := func() *bootstrapCheckpoint {
+		checkpoint := newBootstrapCheckpoint("demo", filepath.Join(t.TempDir(), "demo.json"))
+		checkpoint.CompletedPhases = []bootstrapCheckpointPhase{
+			{Name: bootstrapPhaseManagementCluster},
+			{Name: bootstrapPhaseMainCluster},
+			{Name: bootstrapPhaseArgoCDApps},
+		}
+		checkpoint.resuming = true
+		return checkpoint
+	}
+
+	t.Run("nil checkpoint never skips", func(t *testing.T) {
+		t.Parallel()
+
+		var checkpoint *bootstrapCheckpoint
+		assert.False(t, checkpoint.skip(ctx, bootstrapPhaseMainCluster, nil))
+	})
+
+	t.Run("not resuming never skips", func(t *testing.T) {
+		t.Parallel()
+
+		checkpoint := newResumingCheckpoint()
+		checkpoint.resuming = false
+		assert.False(t, checkpoint.skip(ctx, bootstrapPhaseMainCluster, nil))
+	})
+
+	t.Run("completed phases get skipped", func(t *testing.T) {
+		t.Parallel()
+
+		checkpoint := newResumingCheckpoint()
+		assert.True(t, checkpoint.skip(ctx, bootstrapPhaseManagementCluster, nil))
+		assert.True(t, checkpoint.skip(ctx, bootstrapPhaseMainCluster, func() error { return nil }))
+		assert.True(t, checkpoint.skip(ctx, bootstrapPhaseArgoCDApps, nil))
+	})
+
+	t.Run("an incomplete phase ends the resume", func(t *testing.T) {
+		t.Parallel()
+
+		checkpoint := newResumingCheckpoint()
+		assert.True(t, checkpoint.skip(ctx, bootstrapPhaseManagementCluster, nil))
+		assert.False(t, checkpoint.skip(ctx, bootstrapPhaseDisasterRecovery, nil))
+		assert.False(t, checkpoint.skip(ctx, bootstrapPhaseArgoCDApps, nil))
+	})
+
+	t.Run("a failing idempotency check ends the resume", func(t *testing.T) {
+		t.Parallel()
+
+		checkpoint := newResumingCheckpoint()
+		assert.False(t, checkpoint.skip(ctx, bootstrapPhaseManagementCluster, failingCheck))
+		assert.False(t, checkpoint.skip(ctx, bootstrapPhaseMainCluster, nil))
+		assert.False(t, checkpoint.skip(ctx, bootstrapPhaseArgoCDApps, nil))
+	})
+}
@@ -5,6 +5,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -35,14 +36,34 @@ import (
 type BootstrapClusterArgs struct {
 	*CreateDevEnvArgs
 	SkipClusterctlMove bool
+
+	// Resume continues from the last phase recorded as completed in the cluster's bootstrap
+	// checkpoint, skipping the ones before it whose idempotency checks pass.
+	Resume bool
 }
 
 func BootstrapCluster(ctx context.Context, args BootstrapClusterArgs) {
 	bootstrapStarted := time.Now()
+
+	// Opened before the progress UI starts, so what a resumed run continues from gets printed
+	// first. A disaster recovery bootstraps a new cluster under the same name : it keeps no
+	// checkpoint, rather than clobbering the original bootstrap's.
+	var checkpoint *bootstrapCheckpoint
+	if !args.IsPartOfDisasterRecovery {
+		checkpoint = openBootstrapCheckpoint(ctx, config.ParsedGeneralConfig.Cluster.Name, args.Resume)
+		ctx = withBootstrapCheckpoint(ctx, checkpoint)
+	}
+
 	bar := progress.New("Bootstrapping cluster")
 	defer bar.Finish()
 	ctx = progress.WithBar(ctx, bar)
 
+	// The host-firewall lockdown is asked about at the very end. Resolve it upfront too, so a
+	// non-interactive run missing that decision fails now, not after an hour of provisioning.
+	if config.UsingHetznerBareMetal() {
+		lockdownSetting(ctx)
+	}
+
 	// No NetBird preflight here: the control-plane endpoint is public
 	// for the whole bootstrap. Hetzner clusters bring the LB up with
 	// its public interface enabled (preCreateControlPlaneLB) — a re-run
@@ -58,16 +79,24 @@ func BootstrapCluster(ctx context.Context, args BootstrapClusterArgs) {
 	//        CrossPlane provider, Hetzner Bare Metal doesn't have any. So, we can't use CrossPlane
 	//        as of now.
 	if globals.CloudProviderName == constants.CloudProviderHetzner {
-		bar.Describe("Provisioning Hetzner infrastructure")
+		const phaseTitle = "Provisioning Hetzner infrastructure"
+		bar.Describe(phaseTitle)
 
-		hetznerCloudProvider, ok := globals.CloudProvider.(*hetzner.Hetzner)
-		assert.Assert(ctx, ok, "Failed type-casting globals.CloudProvider to *hetzner.Hetzner")
+		// Skipping it leaves the recorded artifacts (already restored into globals) in place. Except
+		// with Hetzner Bare Metal, where it always runs (being idempotent) : it also derives the
+		// storage plans and node-group labels later phases render, which don't get recorded.
+		if config.UsingHetznerBareMetal() || !checkpoint.skip(ctx, bootstrapPhaseInfrastructure, nil) {
+			hetznerCloudProvider, ok := globals.CloudProvider.(*hetzner.Hetzner)
+			assert.Assert(ctx, ok, "Failed type-casting globals.CloudProvider to *hetzner.Hetzner")
 
-		assert.AssertErrNil(
-			ctx,
-			hetznerCloudProvider.ProvisionPrerequisiteInfrastructure(ctx),
-			"Failed provisioning prerequisite Hetzner infrastructure",
-		)
+			assert.AssertErrNil(
+				ctx,
+				hetznerCloudProvider.ProvisionPrerequisiteInfrastructure(ctx),
+				"Failed provisioning prerequisite Hetzner infrastructure",
+			)
+
+			checkpoint.recordPhase(ctx, bootstrapPhaseInfrastructure, phaseTitle)
+		}
 	}
 
 	// Detect git authentication method. Fast (no network), so no
@@ -86,13 +115,38 @@ func BootstrapCluster(ctx context.Context, args BootstrapClusterArgs) {
 		devEnvPhaseTitle = "Preparing kubeaid-config"
 	}
 	bar.Describe(devEnvPhaseTitle)
-	CreateDevEnv(ctx, args.CreateDevEnvArgs)
 
-	bar.Describe("Provisioning main cluster")
-	provisionAndSetupMainCluster(ctx, ProvisionAndSetupMainClusterArgs{
-		BootstrapClusterArgs: &args,
-		GitAuthMethod:        gitAuthMethod,
-	})
+	// Provisioning the main cluster needs the management cluster set up by this very run. So the
+	// management cluster phase only gets skipped, when the main cluster one will be too.
+	skipCheckMainCluster := func() error {
+		return skipCheckMainClusterPhase(ctx, checkpoint, args.SkipClusterctlMove)
+	}
+	skipCheckManagementCluster := func() error {
+		if !checkpoint.completed(bootstrapPhaseMainCluster) {
+			return errors.New("main cluster phase hasn't completed")
+		}
+		return skipCheckMainCluster()
+	}
+	if checkpoint.skip(ctx, bootstrapPhaseManagementCluster, skipCheckManagementCluster) {
+		restoreSkippedDevEnv(ctx, gitAuthMethod)
+	} else {
+		CreateDevEnv(ctx, args.CreateDevEnvArgs)
+
+		checkpoint.recordPhase(ctx, bootstrapPhaseManagementCluster, devEnvPhaseTitle)
+	}
+
+	const mainClusterPhaseTitle = "Provisioning main cluster"
+	bar.Describe(mainClusterPhaseTitle)
+	if checkpoint.skip(ctx, bootstrapPhaseMainCluster, skipCheckMainCluster) {
+		restoreSkippedMainCluster(ctx, checkpoint)
+	} else {
+		provisionAndSetupMainCluster(ctx, ProvisionAndSetupMainClusterArgs{
+			BootstrapClusterArgs: &args,
+			GitAuthMethod:        gitAuthMethod,
+		})
+
+		checkpoint.recordPhase(ctx, bootstrapPhaseMainCluster, mainClusterPhaseTitle)
+	}
 
 	// Construct main cluster client.
 	mainClusterClient, err := kubernetes.CreateKubernetesClient(ctx,
@@ -115,10 +169,15 @@ func BootstrapCluster(ctx context.Context, args BootstrapClusterArgs) {
 	}
 
 	// Setup Disaster Recovery, if the user wants.
-	if config.ParsedGeneralConfig.Cloud.DisasterRecovery != nil && globals.CloudProvider != nil {
-		bar.Describe("Setting up disaster recovery")
-		err = globals.CloudProvider.SetupDisasterRecovery(ctx)
-		assert.AssertErrNil(ctx, err, "Failed setting up disaster recovery")
+	if disasterRecoveryEnabled() {
+		const phaseTitle = "Setting up disaster recovery"
+		bar.Describe(phaseTitle)
+		if !checkpoint.skip(ctx, bootstrapPhaseDisasterRecovery, nil) {
+			err = setupDisasterRecovery(ctx)
+			assert.AssertErrNil(ctx, err, "Failed setting up disaster recovery")
+
+			checkpoint.recordPhase(ctx, bootstrapPhaseDisasterRecovery, phaseTitle)
+		}
 	}
 
 	// When this is part of a disaster recovery, we don't want to progress any further here,
@@ -128,103 +187,22 @@ func BootstrapCluster(ctx context.Context, args BootstrapClusterArgs) {
 	}
 
 	// Sync all ArgoCD Apps.
-	//
-	// On Hetzner VPN clusters a chain of apps must come up in a
-	// guaranteed order, with gates between:
-	//
-	//   ccm → traefik → [wait LB IP + operator DNS]
-	//       → cert-manager → keycloakx → [wait keycloak-tls Ready,
-	//                                     reconcile Keycloak realm + clients]
-	//       → netbird → [wait netbird-tls Ready]
-	//
-	// netbird-management fetches Keycloak's OIDC config over TLS and
-	// authenticates as its OIDC client, so keycloak's cert must be Ready
-	// and the realm + clients must exist before netbird syncs; cert-manager
-	// must be up before either Ingress cert can issue; and a Synced
-	// ArgoCD App only means its manifests were applied, not that the
-	// cert was issued. orderedApps makes that sequence explicit instead
-	// of leaning on the alphabetical order ArgoCD's List returns.
-	bar.Describe("Syncing ArgoCD applications")
-	var orderedApps []kubernetes.AppSyncStep
-	if config.VPNClusterEnabled() && globals.CloudProviderName == constants.CloudProviderHetzner {
-		// ccm-hcloud manages LoadBalancers for HCloud nodes and must be up before
-		// traefik so the ingress LB Service gets an IP. ccm-hetzner (bare-metal /
-		// hybrid) follows; it doesn't own LBs so traefik-ordering is less critical
-		// but sync order is still declared to keep the sequence deterministic.
-		// WaitForIngressLBDNS then waits for the operator to point DNS at the IP.
-		if config.UsingHCloud() {
-			orderedApps = append(orderedApps,
-				kubernetes.AppSyncStep{Name: constants.ArgoCDAppCCMHCloud})
-		}
-		if config.UsingHetznerBareMetal() {
-			orderedApps = append(orderedApps,
-				kubernetes.AppSyncStep{Name: constants.ArgoCDAppCCMHetzner})
-		}
-		orderedApps = append(orderedApps, kubernetes.AppSyncStep{
-			Name: constants.ArgoCDAppTraefik,
-			AfterSync: func(ctx context.Context) error {
-				return hetzner.WaitForIngressLBDNS(ctx, mainClusterClient)
-			},
-		})
+	const argoCDAppsPhaseTitle = "Syncing ArgoCD applications"
+	bar.Describe(argoCDAppsPhaseTitle)
+	if !checkpoint.skip(ctx, bootstrapPhaseArgoCDApps, nil) {
+		syncAllArgoCDAppsInOrder(ctx, mainClusterClient, args.SkipMonitoringSetup)
+		checkpoint.recordPhase(ctx, bootstrapPhaseArgoCDApps, argoCDAppsPhaseTitle)
 	}
-	if config.VPNClusterEnabled() {
-		// cert-manager must be running before keycloakx / netbird sync
-		// so it can issue their Ingress certs. After each of those
-		// syncs, gate on the Certificate object itself being Ready —
-		// a failed cert otherwise surfaces much later as a cryptic
-		// netbird-management x509 crashloop. Cert names match the
-		// tls.secretName rendered into values-keycloakx / values-netbird.
-		orderedApps = append(orderedApps,
-			kubernetes.AppSyncStep{Name: constants.ArgoCDAppCertManager})
-		// cloudnative-pg installs the postgresql.cnpg.io/v1 Cluster CRD
-		// that the keycloakx (managed mode → keycloak-pgsql) and netbird
-		// (always → netbird-pgsql) charts both materialise during sync.
-		// Without this step those apps fail with "could not find version
-		// v1 of postgresql.cnpg.io/Cluster" until the generic remaining-
-		// apps loop happens to sync cnpg, which can be much later.
-		orderedApps = append(orderedApps,
-			kubernetes.AppSyncStep{Name: constants.ArgoCDAppCloudNativePG})
-		if config.ManagedKeycloakEnabled() {
-			orderedApps = append(orderedApps, kubernetes.AppSyncStep{
-				Name:      constants.ArgoCDAppKeycloakx,
-				AfterSync: keycloakxAfterSync(mainClusterClient),
-			})
-		}
-		orderedApps = append(orderedApps, kubernetes.AppSyncStep{
-			Name:      constants.ArgoCDAppNetbird,
-			AfterSync: netbirdAfterSync(mainClusterClient),
-		})
-	}
-	err = kubernetes.SyncAllArgoCDApps(ctx, args.SkipMonitoringSetup, orderedApps)
-	assert.AssertErrNil(ctx, err, "Failed syncing all ArgoCD apps")
 
 	// When we have setup Disaster Recovery,
 	// trigger the first Velero and SealedSecret backups.
-	if config.ParsedGeneralConfig.Cloud.DisasterRecovery != nil && globals.CloudProvider != nil {
-		bar.Describe("Creating initial backups")
-
-		// Create the first Velero backup.
-		releaseVelero := bar.InProgress("Creating initial Velero backup")
-		veleroErr := kubernetes.CreateBackup(ctx, "init", mainClusterClient)
-		releaseVelero()
-		if veleroErr != nil {
-			assert.AssertErrNil(ctx, veleroErr, "Failed creating initial Velero backup")
+	if disasterRecoveryEnabled() {
+		const phaseTitle = "Creating initial backups"
+		bar.Describe(phaseTitle)
+		if !checkpoint.skip(ctx, bootstrapPhaseInitialBackups, nil) {
+			createInitialBackups(ctx, mainClusterClient)
+			checkpoint.recordPhase(ctx, bootstrapPhaseInitialBackups, phaseTitle)
 		}
-		bar.Substep("Created initial Velero backup")
-
-		// Create first Sealed Secrets backup.
-		releaseSS := bar.InProgress("Triggering Sealed Secrets backup CRONJob")
-		err = kubernetes.TriggerCRONJob(
-			ctx,
-			types.NamespacedName{
-				Name:      constants.CRONJobNameBackupSealedSecrets,
-				Namespace: constants.NamespaceSealedSecrets,
-			},
-			mainClusterClient,
-		)
-		releaseSS()
-		assert.AssertErrNil(ctx, err, "Failed triggering Sealed Secrets backup CRONJob")
-		bar.Substep("Triggered Sealed Secrets backup CRONJob")
 	}
 
 	// Read the Keycloak admin password while kube-apiserver is still
@@ -294,6 +272,109 @@ func BootstrapCluster(ctx context.Context, args BootstrapClusterArgs) {
 	printPostBootstrapNextSteps(keycloakAdminPassword, time.Since(bootstrapStarted))
 }
 
+// syncAllArgoCDAppsInOrder syncs all the main cluster's ArgoCD Apps.
+//
+// On Hetzner VPN clusters a chain of apps must come up in a
+// guaranteed order, with gates between:
+//
+//	ccm → traefik → [wait LB IP + operator DNS]
+//	    → cert-manager → keycloakx → [wait keycloak-tls Ready,
+//	                                  reconcile Keycloak realm + clients]
+//	    → netbird → [wait netbird-tls Ready]
+//
+// netbird-management fetches Keycloak's OIDC config over TLS and
+// authenticates as its OIDC client, so keycloak's cert must be Ready
+// and the realm + clients must exist before netbird syncs; cert-manager
+// must be up before either Ingress cert can issue; and a Synced
+// ArgoCD App only means its manifests were applied, not that the
+// cert was issued. orderedApps makes that sequence explicit instead
+// of leaning on the alphabetical order ArgoCD's List returns.
+func syncAllArgoCDAppsInOrder(ctx context.Context,
+	mainClusterClient client.Client,
+	skipMonitoringSetup bool,
+) {
+	var orderedApps []kubernetes.AppSyncStep
+	if config.VPNClusterEnabled() && globals.CloudProviderName == constants.CloudProviderHetzner {
+		// ccm-hcloud manages LoadBalancers for HCloud nodes and must be up before
+		// traefik so the ingress LB Service gets an IP. ccm-hetzner (bare-metal /
+		// hybrid) follows; it doesn't own LBs so traefik-ordering is less critical
+		// but sync order is still declared to keep the sequence deterministic.
+		// WaitForIngressLBDNS then waits for the operator to point DNS at the IP.
+		if config.UsingHCloud() {
+			orderedApps = append(orderedApps,
+				kubernetes.AppSyncStep{Name: constants.ArgoCDAppCCMHCloud})
+		}
+		if config.UsingHetznerBareMetal() {
+			orderedApps = append(orderedApps,
+				kubernetes.AppSyncStep{Name: constants.ArgoCDAppCCMHetzner})
+		}
+		orderedApps = append(orderedApps, kubernetes.AppSyncStep{
+			Name: constants.ArgoCDAppTraefik,
+			AfterSync: func(ctx context.Context) error {
+				return hetzner.WaitForIngressLBDNS(ctx, mainClusterClient)
+			},
+		})
+	}
+	if config.VPNClusterEnabled() {
+		// cert-manager must be running before keycloakx / netbird sync
+		// so it can issue their Ingress certs. After each of those
+		// syncs, gate on the Certificate object itself being Ready —
+		// a failed cert otherwise surfaces much later as a cryptic
+		// netbird-management x509 crashloop. Cert names match the
+		// tls.secretName rendered into values-keycloakx / values-netbird.
+		orderedApps = append(orderedApps,
+			kubernetes.AppSyncStep{Name: constants.ArgoCDAppCertManager})
+		// cloudnative-pg installs the postgresql.cnpg.io/v1 Cluster CRD
+		// that the keycloakx (managed mode → keycloak-pgsql) and netbird
+		// (always → netbird-pgsql) charts both materialise during sync.
+		// Without this step those apps fail with "could not find version
+		// v1 of postgresql.cnpg.io/Cluster" until the generic remaining-
+		// apps loop happens to sync cnpg, which can be much later.
+		orderedApps = append(orderedApps,
+			kubernetes.AppSyncStep{Name: constants.ArgoCDAppCloudNativePG})
+		if config.ManagedKeycloakEnabled() {
+			orderedApps = append(orderedApps, kubernetes.AppSyncStep{
+				Name:      constants.ArgoCDAppKeycloakx,
+				AfterSync: keycloakxAfterSync(mainClusterClient),
+			})
+		}
+		orderedApps = append(orderedApps, kubernetes.AppSyncStep{
+			Name:      constants.ArgoCDAppNetbird,
+			AfterSync: netbirdAfterSync(mainClusterClient),
+		})
+	}
+	err := kubernetes.SyncAllArgoCDApps(ctx, skipMonitoringSetup, orderedApps)
+	assert.AssertErrNil(ctx, err, "Failed syncing all ArgoCD apps")
+}
+
+// createInitialBackups triggers the first Velero and Sealed Secrets backups.
+func createInitialBackups(ctx context.Context, mainClusterClient client.Client) {
+	bar := progress.FromCtx(ctx)
+
+	// Create the first Velero backup.
+	releaseVelero := bar.InProgress("Creating initial Velero backup")
+	veleroErr := kubernetes.CreateBackup(ctx, "init", mainClusterClient, kubernetes.VeleroBackupOptions{})
+	releaseVelero()
+	if veleroErr != nil {
+		assert.AssertErrNil(ctx, veleroErr, "Failed creating initial Velero backup")
+	}
+	bar.Substep("Created initial Velero backup")
+
+	// Create first Sealed Secrets backup.
+	releaseSS := bar.InProgress("Triggering Sealed Secrets backup CRONJob")
+	err := kubernetes.TriggerCRONJob(
+		ctx,
+		types.NamespacedName{
+			Name:      constants.CRONJobNameBackupSealedSecrets,
+			Namespace: constants.NamespaceSealedSecrets,
+		},
+		mainClusterClient,
+	)
+	releaseSS()
+	assert.AssertErrNil(ctx, err, "Failed triggering Sealed Secrets backup CRONJob")
+	bar.Substep("Triggered Sealed Secrets backup CRONJob")
+}
+
 // readKeycloakAdminPasswordForPanel reads the Keycloak admin password
 // for the post-bootstrap next-steps panel, or returns "" when there's
 // no managed Keycloak to surface or the read failed. Empty string is
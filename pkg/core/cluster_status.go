@@ -0,0 +1,353 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+)
+
+// Exit codes of `cluster status`, so a cron job / monitoring check can tell an unhealthy cluster
+// apart from one that couldn't be fully looked at.
+const (
+	ClusterStatusExitHealthy   = 0
+	ClusterStatusExitUnhealthy = 1
+	ClusterStatusExitUnknown   = 2
+)
+
+// clusterStatusCheck looks at one aspect of the cluster's health, and becomes one section of the
+// report.
+type clusterStatusCheck struct {
+	name,
+	title string
+
+	run func(ctx context.Context) ([]kubernetes.StatusItem, error)
+}
+
+// clusterStatusSection is the outcome of a clusterStatusCheck. Error is set when the check itself
+// failed, in which case Items tells nothing.
+type clusterStatusSection struct {
+	Name    string                  `json:"name"`
+	Title   string                  `json:"-"`
+	Healthy bool                    `json:"healthy"`
+	Error   string                  `json:"error,omitempty"`
+	Items   []kubernetes.StatusItem `json:"items"`
+}
+
+type clusterStatusReport struct {
+	Healthy  bool                   `json:"healthy"`
+	Sections []clusterStatusSection `json:"sections"`
+}
+
+/*
+Prints a read-only, one-screen health overview of the main cluster : its Nodes, ClusterAPI
+Machines, ArgoCD Apps, cert-manager Certificates, the Sealed Secrets controller and backup
+freshness.
+
+outputFormat "json" prints the full report as JSON, anything else the human-readable one, where
+healthy items are only counted. Returns the exit code the command should exit with :
+ClusterStatusExitUnhealthy when anything is unhealthy, otherwise ClusterStatusExitUnknown when a
+section couldn't be checked, or the main cluster couldn't be reached at all.
+*/
+func ClusterStatus(ctx context.Context, outputFormat string) int {
+	// Set the KUBECONFIG environment variable to the main cluster's kubeconfig.
+	utils.MustSetEnv(constants.EnvNameKubeconfig, constants.OutputPathMainClusterKubeconfig)
+
+	// Construct a client to the main cluster.
+	var report clusterStatusReport
+	mainClusterClient, err := kubernetes.CreateKubernetesClient(ctx,
+		constants.OutputPathMainClusterKubeconfig,
+	)
+	if err != nil {
+		report = unreachableClusterStatusReport(err)
+	} else {
+		report = runClusterStatusChecks(ctx, clusterStatusChecks(mainClusterClient))
+	}
+
+	if outputFormat == outputFormatJSON {
+		output, err := json.MarshalIndent(report, "", "  ")
+		assert.AssertErrNil(ctx, err, "Failed JSON encoding cluster status")
+
+		fmt.Println(string(output)) //nolint:forbidigo // operator-facing terminal output
+	} else {
+		fmt.Print(renderClusterStatus(report)) //nolint:forbidigo // operator-facing terminal output
+	}
+
+	return report.exitCode()
+}
+
+// clusterStatusChecks returns the checks `cluster status` runs against the main cluster, in the
+// order their sections get printed.
+func clusterStatusChecks(mainClusterClient client.Client) []clusterStatusCheck {
+	checks := []clusterStatusCheck{
+		{
+			name:  "nodes",
+			title: "Nodes",
+			run: func(ctx context.Context) ([]kubernetes.StatusItem, error) {
+				return kubernetes.NodeStatuses(ctx, mainClusterClient)
+			},
+		},
+	}
+
+	// After clusterctl move, the main cluster manages its own ClusterAPI resources.
+	if kubernetes.UsingClusterAPI() {
+		checks = append(checks, clusterStatusCheck{
+			name:  "capi",
+			title: "ClusterAPI Machines",
+			run: func(ctx context.Context) ([]kubernetes.StatusItem, error) {
+				return kubernetes.CAPIStatuses(ctx, mainClusterClient)
+			},
+		})
+	}
+
+	return append(checks,
+		clusterStatusCheck{
+			name:  "argocd-apps",
+			title: "ArgoCD Apps",
+			run: func(ctx context.Context) ([]kubernetes.StatusItem, error) {
+				return kubernetes.ArgoCDAppStatuses(ctx, mainClusterClient)
+			},
+		},
+		clusterStatusCheck{
+			name:  "certificates",
+			title: "Certificates",
+			run: func(ctx context.Context) ([]kubernetes.StatusItem, error) {
+				return kubernetes.CertificateStatuses(ctx, mainClusterClient)
+			},
+		},
+		clusterStatusCheck{
+			name:  "sealed-secrets",
+			title: "Sealed Secrets controller",
+			run: func(ctx context.Context) ([]kubernetes.StatusItem, error) {
+				item, err := kubernetes.SealedSecretsControllerStatus(ctx, mainClusterClient)
+				if err != nil {
+					return nil, err
+				}
+				return []kubernetes.StatusItem{item}, nil
+			},
+		},
+		clusterStatusCheck{
+			name:  "backups",
+			title: "Backups",
+			run:   backupStatusItems,
+		},
+	)
+}
+
+// runClusterStatusChecks runs every check, one failing check not stopping the others.
+func runClusterStatusChecks(ctx context.Context, checks []clusterStatusCheck) clusterStatusReport {
+	report := clusterStatusReport{Healthy: true}
+
+	for _, check := range checks {
+		section := clusterStatusSection{
+			Name:    check.name,
+			Title:   check.title,
+			Healthy: true,
+			Items:   []kubernetes.StatusItem{},
+		}
+
+		items, err := check.run(ctx)
+		if err != nil {
+			section.Healthy = false
+			section.Error = err.Error()
+		}
+		for _, item := range items {
+			section.Items = append(section.Items, item)
+			section.Healthy = section.Healthy && item.Healthy
+		}
+
+		report.Healthy = report.Healthy && section.Healthy
+		report.Sections = append(report.Sections, section)
+	}
+
+	return report
+}
+
+// unreachableClusterStatusReport is the report when no client to the main cluster could be
+// constructed : nothing is known about its health.
+func unreachableClusterStatusReport(err error) clusterStatusReport {
+	return clusterStatusReport{
+		Sections: []clusterStatusSection{{
+			Name:  "cluster",
+			Title: "Cluster",
+			Error: "unreachable : " + err.Error(),
+			Items: []kubernetes.StatusItem{},
+		}},
+	}
+}
+
+// exitCode returns ClusterStatusExitUnhealthy when any item is unhealthy, ClusterStatusExitUnknown
+// when nothing is known to be unhealthy but a section couldn't be checked, and
+// ClusterStatusExitHealthy otherwise.
+func (r clusterStatusReport) exitCode() int {
+	exitCode := ClusterStatusExitHealthy
+
+	for _, section := range r.Sections {
+		if section.Error != "" {
+			exitCode = ClusterStatusExitUnknown
+		}
+
+		for _, item := range section.Items {
+			if !item.Healthy {
+				return ClusterStatusExitUnhealthy
+			}
+		}
+	}
+
+	return exitCode
+}
+
+// backupStatusItems reads backup freshness from backup-exporter, the same way `backup status`
+// does : from the current kubeconfig, which ClusterStatus has pointed to the main cluster.
+func backupStatusItems(ctx context.Context) ([]kubernetes.StatusItem, error) {
+	clientset, err := kubernetes.CreateClientset(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := kubernetes.CreateRESTConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := fetchBackupStatus(ctx, clientset, restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var response backupResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed decoding backup-exporter response: %w", err)
+	}
+
+	return backupResponseStatusItems(response, time.Now()), nil
+}
+
+// backupResponseStatusItems maps every backup stream, and every operator error, to a StatusItem :
+// a stream is healthy only when backup-exporter reports it so.
+func backupResponseStatusItems(response backupResponse, now time.Time) []kubernetes.StatusItem {
+	resources := make([]backupResource, len(response.Resources))
+	copy(resources, response.Resources)
+	sortResources(resources)
+
+	collectedAt := collectedAtByOperator(response.Collectors)
+
+	items := make([]kubernetes.StatusItem, 0, len(response.OperatorErrors)+len(resources))
+	for _, operatorError := range response.OperatorErrors {
+		items = append(items, kubernetes.StatusItem{
+			Name:   operatorError.Operator,
+			Status: backupStatusCollectorError,
+			Detail: operatorError.Type,
+		})
+	}
+	for _, r := range resources {
+		items = append(items, kubernetes.StatusItem{
+			Name:    fmt.Sprintf("%s/%s (%s %s)", r.Namespace, r.ResourceName, r.Operator, r.Stream),
+			Status:  formatStatus(r),
+			Detail:  "latest backup : " + formatLatestAge(r, collectedAt[r.Operator], now),
+			Healthy: r.Status == backupStatusHealthy,
+		})
+	}
+	return items
+}
+
+// renderClusterStatus renders the human-readable report : a ✓ / ✗ line per section, followed by a
+// table of just its unhealthy items, so the whole report fits one screen even for a large
+// cluster. Multi-line details (the Sealed Secrets controller diagnosis) follow the table.
+func renderClusterStatus(report clusterStatusReport) string {
+	var (
+		healthyStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+		unhealthyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+		noteStyle      = lipgloss.NewStyle().Faint(true)
+	)
+
+	var b strings.Builder
+
+	for _, section := range report.Sections {
+		unhealthy := make([]kubernetes.StatusItem, 0, len(section.Items))
+		for _, item := range section.Items {
+			if !item.Healthy {
+				unhealthy = append(unhealthy, item)
+			}
+		}
+
+		switch {
+		case section.Error != "":
+			fmt.Fprintf(&b, "%s %s : couldn't be checked\n", unhealthyStyle.Render("✗"), section.Title)
+			fmt.Fprintf(&b, "    %s\n", noteStyle.Render(section.Error))
+			continue
+
+		case len(section.Items) == 0:
+			fmt.Fprintf(&b, "%s %s : %s\n", healthyStyle.Render("✓"), section.Title, noteStyle.Render("none found"))
+			continue
+
+		case len(unhealthy) == 0:
+			fmt.Fprintf(&b, "%s %s : %d healthy\n", healthyStyle.Render("✓"), section.Title, len(section.Items))
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s %s : %d of %d unhealthy\n",
+			unhealthyStyle.Render("✗"), section.Title, len(unhealthy), len(section.Items),
+		)
+		b.WriteString(renderUnhealthyStatusItems(unhealthy))
+	}
+
+	switch report.exitCode() {
+	case ClusterStatusExitHealthy:
+		fmt.Fprintf(&b, "\n%s\n", healthyStyle.Render("Cluster is healthy."))
+
+	case ClusterStatusExitUnhealthy:
+		fmt.Fprintf(&b, "\n%s\n", unhealthyStyle.Render("Cluster is NOT healthy."))
+
+	default:
+		fmt.Fprintf(&b, "\n%s\n", unhealthyStyle.Render("Cluster health is unknown."))
+	}
+
+	return b.String()
+}
+
+// renderUnhealthyStatusItems lays items out as an indented NAME / STATUS / DETAIL table. A
+// multi-line detail gets only its first line in the table, and is printed in full below it.
+func renderUnhealthyStatusItems(items []kubernetes.StatusItem) string {
+	var b strings.Builder
+
+	w := tabwriter.NewWriter(&b, tabwriterMinWidth, tabwriterTabWidth, tabwriterPadding, ' ', 0)
+
+	// Writes to a strings.Builder-backed tabwriter never fail.
+	_, _ = fmt.Fprintln(w, "    NAME\tSTATUS\tDETAIL")
+
+	var multiLineDetails []kubernetes.StatusItem
+	for _, item := range items {
+		detail, _, isMultiLine := strings.Cut(item.Detail, "\n")
+		if isMultiLine {
+			multiLineDetails = append(multiLineDetails, item)
+		}
+
+		_, _ = fmt.Fprintf(w, "    %s\t%s\t%s\n", item.Name, item.Status, detail)
+	}
+
+	// Flush only fails when the underlying writer does, and a strings.Builder never does.
+	_ = w.Flush()
+
+	for _, item := range multiLineDetails {
+		fmt.Fprintf(&b, "\n    %s :\n", item.Name)
+		for line := range strings.SplitSeq(item.Detail, "\n") {
+			fmt.Fprintf(&b, "      %s\n", line)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+)
+
+func TestRunClusterStatusChecks(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	healthyCheck := clusterStatusCheck{
+		name:  "nodes",
+		title: "Nodes",
+		run: func(context.Context) ([]kubernetes.StatusItem, error) {
+			return []kubernetes.StatusItem{{Name: "cp-1", Status: "Ready", Healthy: true}}, nil
+		},
+	}
+	emptyCheck := clusterStatusCheck{
+		name:  "certificates",
+		title: "Certificates",
+		run: func(context.Context) ([]kubernetes.StatusItem, error) {
+			return nil, nil
+		},
+	}
+	unhealthyCheck := clusterStatusCheck{
+		name:  "argocd-apps",
+		title: "ArgoCD Apps",
+		run: func(context.Context) ([]kubernetes.StatusItem, error) {
+			return []kubernetes.StatusItem{
+				{Name: "cert-manager", Status: "Synced / Healthy", Healthy: true},
+				{Name: "traefik", Status: "Synced / Degraded", Detail: "Deployment exceeded its progress deadline"},
+			}, nil
+		},
+	}
+	failingCheck := clusterStatusCheck{
+		name:  "backups",
+		title: "Backups",
+		run: func(context.Context) ([]kubernetes.StatusItem, error) {
+			return nil, errors.New("no backup-exporter service found")
+		},
+	}
+
+	tests := []struct {
+		name         string
+		checks       []clusterStatusCheck
+		wantHealthy  bool
+		wantExitCode int
+	}{
+		{
+			name:         "healthy",
+			checks:       []clusterStatusCheck{healthyCheck, emptyCheck},
+			wantHealthy:  true,
+			wantExitCode: ClusterStatusExitHealthy,
+		},
+		{
+			name:         "unhealthy item",
+			checks:       []clusterStatusCheck{healthyCheck, unhealthyCheck},
+			wantExitCode: ClusterStatusExitUnhealthy,
+		},
+		{
+			name:         "failing check",
+			checks:       []clusterStatusCheck{healthyCheck, failingCheck},
+			wantExitCode: ClusterStatusExitUnknown,
+		},
+		{
+			name:         "unhealthy item outranks a failing check",
+			checks:       []clusterStatusCheck{failingCheck, unhealthyCheck},
+			wantExitCode: ClusterStatusExitUnhealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := runClusterStatusChecks(ctx, tt.checks)
+			require.Len(t, report.Sections, len(tt.checks))
+
+			assert.Equal(t, tt.wantHealthy, report.Healthy)
+			assert.Equal(t, tt.wantExitCode, report.exitCode())
+		})
+	}
+
+	t.Run("every check runs, and gets its own section", func(t *testing.T) {
+		t.Parallel()
+
+		report := runClusterStatusChecks(ctx, []clusterStatusCheck{failingCheck, healthyCheck, emptyCheck})
+
+		assert.Equal(t, clusterStatusSection{
+			Name:  "backups",
+			Title: "Backups",
+			Error: "no backup-exporter service found",
+			Items: []kubernetes.StatusItem{},
+		}, report.Sections[0])
+		assert.True(t, report.Sections[1].Healthy)
+		assert.True(t, report.Sections[2].Healthy)
+		assert.Empty(t, report.Sections[2].Items)
+	})
+}
+
+func TestRenderClusterStatus(t *testing.T) {
+	t.Parallel()
+
+	report := clusterStatusReport{
+		Sections: []clusterStatusSection{
+			{
+				Name: "nodes", Title: "Nodes", Healthy: true,
+				Items: []kubernetes.StatusItem{{Name: "cp-1", Status: "Ready", Healthy: true}},
+			},
+			{Name: "certificates", Title: "Certificates", Healthy: true},
+			{
+				Name: "argocd-apps", Title: "ArgoCD Apps",
+				Items: []kubernetes.StatusItem{
+					{Name: "cert-manager", Status: "Synced / Healthy", Healthy: true},
+					{Name: "traefik", Status: "Synced / Degraded", Detail: "Deployment exceeded its progress deadline"},
+				},
+			},
+			{
+				Name: "sealed-secrets", Title: "Sealed Secrets controller",
+				Items: []kubernetes.StatusItem{{
+					Name:   "sealed-secrets/sealed-secrets-controller",
+					Status: "Unavailable",
+					Detail: "Deployment 0/1 available\nPod sealed-secrets-controller-abc phase=Pending",
+				}},
+			},
+			{Name: "backups", Title: "Backups", Error: "no backup-exporter service found"},
+		},
+	}
+
+	output := renderClusterStatus(report)
+
+	assert.Contains(t, output, "Nodes : 1 healthy")
+	assert.Contains(t, output, "Certificates : none found")
+	assert.Contains(t, output, "ArgoCD Apps : 1 of 2 unhealthy")
+	assert.Contains(t, output, "Deployment exceeded its progress deadline")
+	assert.Contains(t, output, "Backups : couldn't be checked")
+	assert.Contains(t, output, "no backup-exporter service found")
+	assert.Contains(t, output, "Cluster is NOT healthy.")
+
+	// Healthy items are only counted.
+	assert.NotContains(t, output, "cert-manager")
+
+	// A multi-line detail is printed in full, below the table.
+	assert.Contains(t, output, "      Pod sealed-secrets-controller-abc phase=Pending\n")
+}
+
+func TestUnreachableClusterStatusReport(t *testing.T) {
+	t.Parallel()
+
+	report := unreachableClusterStatusReport(errors.New("connection refused"))
+
+	assert.Equal(t, ClusterStatusExitUnknown, report.exitCode())
+
+	output := renderClusterStatus(report)
+	assert.Contains(t, output, "Cluster : couldn't be checked")
+	assert.Contains(t, output, "unreachable : connection refused")
+	assert.Contains(t, output, "Cluster health is unknown.")
+}
+
+func TestBackupResponseStatusItems(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	collectedAt := now.Add(-5 * time.Minute)
+	age := float64(time.Hour / time.Second)
+
+	response := backupResponse{
+		Collectors: []backupCollector{{Operator: "velero", CollectedAt: &collectedAt}},
+		Resources: []backupResource{
+			{
+				Operator: "velero", Stream: "daily", Namespace: "keycloak", ResourceName: "keycloak",
+				LatestBackupAgeSeconds: &age, Status: backupStatusHealthy,
+			},
+			{
+				Operator: "velero", Stream: "daily", Namespace: "gitea", ResourceName: "gitea",
+				LatestBackupAgeSeconds: &age, Status: backupStatusExceedsRPO,
+			},
+		},
+		OperatorErrors: []backupOperatorError{{Operator: "cnpg", Type: "list_failed"}},
+	}
+
+	assert.Equal(t, []kubernetes.StatusItem{
+		{Name: "cnpg", Status: backupStatusCollectorError, Detail: "list_failed"},
+		{
+			Name: "gitea/gitea (velero daily)", Status: backupStatusExceedsRPO,
+			Detail: "latest backup : 1h 5m",
+		},
+		{
+			Name: "keycloak/keycloak (velero daily)", Status: backupStatusHealthy,
+			Detail: "latest backup : 1h 5m", Healthy: true,
+		},
+	}, backupResponseStatusItems(response, now))
+}
@@ -154,6 +154,11 @@ func DeleteCluster(ctx context.Context) {
 		assert.AssertErrNil(ctx, err, "Failed deleting HetznerBareMetalHosts")
 
 		slog.InfoContext(ctx, "Deleted HetznerBareMetalHosts")
+
+		// Delete the A records KubeAid CLI created for the cluster's endpoints (when
+		// cluster.dnsRecords is set), now that the IPs they point to are gone.
+		err = hetzner.DeleteDNSRecords(ctx)
+		assert.AssertErrNil(ctx, err, "Failed deleting DNS records")
 	}
 
 	slog.InfoContext(ctx, "Deleted cluster successuly")
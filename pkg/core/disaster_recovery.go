@@ -0,0 +1,39 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/objectstorage"
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/globals"
+)
+
+// disasterRecoveryEnabled reports whether the cluster gets set up for Disaster Recovery :
+// the user provided the cloud.disasterRecovery block, and there's something to back up into.
+// Bare Metal clusters have no cloud provider, so they can only back up into an S3-compatible
+// object storage.
+func disasterRecoveryEnabled() bool {
+	if config.ParsedGeneralConfig.Cloud.DisasterRecovery == nil {
+		return false
+	}
+	return (globals.CloudProvider != nil) || config.S3CompatibleDisasterRecoveryEnabled()
+}
+
+// setupDisasterRecovery sets up the provisioned cluster for Disaster Recovery, through the
+// cloud provider when there is one, and directly against the S3-compatible object storage
+// otherwise (Bare Metal).
+func setupDisasterRecovery(ctx context.Context) error {
+	if globals.CloudProvider != nil {
+		return globals.CloudProvider.SetupDisasterRecovery(ctx)
+	}
+
+	s3Client, err := objectstorage.NewS3ClientFromConfig()
+	if err != nil {
+		return fmt.Errorf("creating object storage client: %w", err)
+	}
+	return objectstorage.SetupDisasterRecovery(ctx, s3Client)
+}
@@ -35,7 +35,8 @@ var (
 // the freshly-synced Keycloak: wait for keycloakx to be Healthy, log
 // in as admin against the cluster's public Keycloak DNS using the
 // password kubeaid-cli rendered into the keycloak-admin Secret, then
-// materialise NetBird's realm-side resources via gocloak.
+// materialise NetBird's realm-side resources, the kubernetes OIDC
+// client and the cluster.keycloak users and groups via gocloak.
 //
 // Runs as the keycloakx after-sync hook — before the netbird app
 // syncs — so netbird-management starts against OIDC clients that
@@ -70,10 +71,7 @@ func reconcileNetBirdInKeycloak(ctx context.Context, clusterClient client.Client
 	}
 
 	cluster := config.ParsedGeneralConfig.Cluster
-	// The keycloakx Helm chart serves Keycloak under the /auth relative
-	// path (pre-17 Keycloak default, preserved by the chart for URL
-	// stability); gocloak's basePath needs the /auth suffix.
-	baseURL := "https://" + cluster.Keycloak.DNS + "/auth"
+	baseURL := keycloakAdminBaseURL(cluster.Keycloak)
 
 	return retryKeycloakReconcile(ctx, func(ctx context.Context) error {
 		reconciler, err := keycloak.NewReconciler(ctx, baseURL,
@@ -96,17 +94,56 @@ func reconcileNetBirdInKeycloak(ctx context.Context, clusterClient client.Client
 		// kubelogin tokens share the audience mapper, and the
 		// groups scope so kube-API RBAC can key off the same
 		// Keycloak group memberships NetBird sees.
-		return reconciler.ReconcileKubernetes(ctx, keycloak.KubernetesSpec{
+		if err := reconciler.ReconcileKubernetes(ctx, keycloak.KubernetesSpec{
 			Realm:       cluster.Keycloak.Realm,
 			ClusterName: cluster.Name,
 			DefaultScopes: []string{
 				keycloak.NetBirdAPIScopeName,
 				keycloak.NetBirdGroupsScopeName,
 			},
-		})
+		}); err != nil {
+			return err
+		}
+
+		// Users and groups from cluster.keycloak — the groups the
+		// rendered ClusterRoleBindings bind, and their members.
+		return reconciler.ReconcileDirectory(ctx, keycloakDirectorySpec(cluster.Keycloak))
 	})
 }
 
+// keycloakAdminBaseURL is the base URL gocloak logs into the managed
+// Keycloak at. The keycloakx Helm chart serves Keycloak under the /auth
+// relative path (pre-17 Keycloak default, preserved by the chart for
+// URL stability); gocloak's basePath needs the /auth suffix.
+func keycloakAdminBaseURL(cfg *config.KeycloakConfig) string {
+	return "https://" + cfg.DNS + "/auth"
+}
+
+// keycloakDirectorySpec translates the cluster.keycloak users and
+// groups into the spec ReconcileDirectory takes. Users are always
+// enabled: offboarding an engineer means removing them from the list
+// (with prune on), not flipping a flag.
+func keycloakDirectorySpec(cfg *config.KeycloakConfig) keycloak.DirectorySpec {
+	spec := keycloak.DirectorySpec{
+		Realm: cfg.Realm,
+		Prune: cfg.Prune,
+	}
+	for _, group := range cfg.Groups {
+		spec.Groups = append(spec.Groups, keycloak.GroupSpec{Name: group.Name})
+	}
+	for _, user := range cfg.Users {
+		spec.Users = append(spec.Users, keycloak.UserSpec{
+			Username:  user.Username,
+			Email:     user.Email,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Enabled:   true,
+			Groups:    user.Groups,
+		})
+	}
+	return spec
+}
+
 // retryKeycloakReconcile runs attempt up to keycloakReconcileMaxAttempts
 // times, keycloakReconcileRetryInterval apart, returning nil on the
 // first success. On exhaustion it returns the last error wrapped with
@@ -132,7 +169,7 @@ func retryKeycloakReconcile(ctx context.Context, attempt func(context.Context) e
 		}
 	}
 	return fmt.Errorf(
-		"reconciling NetBird in Keycloak failed after %d attempts: %w",
+		"reconciling Keycloak failed after %d attempts: %w",
 		keycloakReconcileMaxAttempts, lastErr,
 	)
 }
@@ -12,6 +12,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/keycloak"
 )
 
 func TestRetryKeycloakReconcile(t *testing.T) {
@@ -77,3 +80,38 @@ func TestRetryKeycloakReconcile(t *testing.T) {
 		assert.Equal(t, 1, calls)
 	})
 }
+
+func TestKeycloakDirectorySpec(t *testing.T) {
+	t.Parallel()
+
+	spec := keycloakDirectorySpec(&config.KeycloakConfig{
+		Realm: "acme",
+		Prune: true,
+		Groups: []config.KeycloakGroupConfig{
+			{Name: "platform-admins", ClusterRoles: []string{"cluster-admin"}},
+		},
+		Users: []config.KeycloakUserConfig{
+			{
+				Username:  "alice",
+				Email:     "alice@acme.com",
+				FirstName: "Alice",
+				Groups:    []string{"platform-admins"},
+			},
+		},
+	})
+
+	assert.Equal(t, keycloak.DirectorySpec{
+		Realm:  "acme",
+		Prune:  true,
+		Groups: []keycloak.GroupSpec{{Name: "platform-admins"}},
+		Users: []keycloak.UserSpec{
+			{
+				Username:  "alice",
+				Email:     "alice@acme.com",
+				FirstName: "Alice",
+				Enabled:   true,
+				Groups:    []string{"platform-admins"},
+			},
+		},
+	}, spec)
+}
@@ -14,8 +14,11 @@ import (
 
 	kubeoneCmd "k8c.io/kubeone/pkg/cmd"
 
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/sshkey"
 )
 
 // kubeoneLogLine matches KubeOne's logrus text lines - 'INFO[10:28:22 IST] Installing kubeadm...'.
@@ -108,7 +111,20 @@ func runKubeOne(ctx context.Context, logLabel string, kubeoneArgs ...string) err
 
 const kubeoneErrorTailLines = 30
 
+// executeKubeOne runs the embedded KubeOne root command. Passphrase protected SSH private keys
+// are served to it by an in-process SSH agent, which the rendered KubeOne manifest points at via
+// $KUBEAID_CLI_SSH_KEY_AGENT_SOCK.
 func executeKubeOne(ctx context.Context, kubeoneArgs []string) error {
+	agentSocket, stopAgent, err := sshkey.ServeAgent()
+	if err != nil {
+		return err
+	}
+	defer stopAgent()
+
+	if agentSocket != "" {
+		utils.MustSetEnv(constants.EnvNameSSHKeyAgentSocket, agentSocket)
+	}
+
 	kubeoneRootCmd := kubeoneCmd.NewRoot()
 	kubeoneRootCmd.SetArgs(kubeoneArgs)
 	return kubeoneRootCmd.ExecuteContext(ctx)
@@ -27,7 +27,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
 	gitUtils "github.com/Obmondo/kubeaid-cli/pkg/utils/git"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
@@ -85,6 +87,22 @@ func lockdownDecision(ld *bool) (run, skipConfirm bool) {
 	return *ld, *ld
 }
 
+// lockdownSetting returns cluster.lockdown, falling back to the answers file's lockdown
+// decision when general.yaml leaves it unset. Panics when running non-interactively without
+// either : the run would otherwise end on a prompt nobody can answer.
+func lockdownSetting(ctx context.Context) *bool {
+	if lockdown := config.ParsedGeneralConfig.Cluster.Lockdown; lockdown != nil {
+		return lockdown
+	}
+
+	proceed, answered, err := answers.Parsed.Confirm(answers.DecisionLockdown)
+	assert.AssertErrNil(ctx, err, "Can't decide whether to lock down the host firewall")
+	if !answered {
+		return nil
+	}
+	return &proceed
+}
+
 // lockdownInBootstrap runs the host-firewall lockdown step at the end of the
 // bootstrap flow. It is gated: only runs on Hetzner bare-metal after clusterctl
 // move, and honors cluster.lockdown. Operator declining is a graceful skip
@@ -95,8 +113,8 @@ func lockdownInBootstrap(ctx context.Context, clusterClient client.Client, gitAu
 		return
 	}
 
-	if run, _ := lockdownDecision(config.ParsedGeneralConfig.Cluster.Lockdown); !run {
-		slog.InfoContext(ctx, "Host Firewall (CCNP) lockdown skipped (cluster.lockdown=false)")
+	if run, _ := lockdownDecision(lockdownSetting(ctx)); !run {
+		slog.InfoContext(ctx, "Host Firewall (CCNP) lockdown skipped (lockdown=false)")
 		return
 	}
 
@@ -126,8 +144,9 @@ func runLockdownStep(ctx context.Context, clusterClient client.Client, gitAuthMe
 
 	// Runs after bar.Finish() in the bootstrap flow, so the confirm prompt has
 	// clean stdout — no progress-bar pause/resume needed. cluster.lockdown=true
-	// pre-approves it (CI-safe); nil keeps the interactive confirm.
-	if _, skipConfirm := lockdownDecision(config.ParsedGeneralConfig.Cluster.Lockdown); !skipConfirm {
+	// (or lockdown: true in the answers file) pre-approves it (CI-safe); nil keeps
+	// the interactive confirm.
+	if _, skipConfirm := lockdownDecision(lockdownSetting(ctx)); !skipConfirm {
 		if err := promptLockdownConfirm(accessLine); err != nil {
 			return err
 		}
@@ -21,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/ui"
 )
@@ -39,7 +40,8 @@ const (
 // lockdown: without it the operator's Pod webhook (failurePolicy: Fail) blocks
 // every Pod create. When the Secret is missing and stdin is a terminal the
 // operator chooses paste-now / wait / defer; without a terminal it polls then
-// fails (CI expects the Secret via secrets.yaml).
+// fails (CI expects the Secret via secrets.yaml). The answers file can settle
+// the choice upfront (wait / defer), and must when running non-interactively.
 //
 // Returns proceedWithLockdown=false when the operator defers — the caller must
 // then skip lockdown and the LB public-interface disable, or they'd lose
@@ -72,17 +74,32 @@ func AwaitOperatorToken(
 	printKeycloakUserSetupForNetBird(keycloakAdminPassword)
 	printNetBirdOperatorInstructions(netbirdDashboardHost())
 
+	var choice netBirdTokenChoice
+
+	answer, answered, err := answers.Parsed.Choose(answers.DecisionNetBirdOperatorToken)
+	switch {
+	case err != nil:
+		return false, err
+
+	// Decided upfront, in the answers file.
+	case answered:
+		choice = netBirdTokenDefer
+		if answer == answers.NetBirdOperatorTokenWait {
+			choice = netBirdTokenWait
+		}
+
 	// No TTY (CI): poll-then-fail — the Secret is expected via secrets.yaml.
-	if !stdinIsTerminal() {
+	case !stdinIsTerminal():
 		if err := waitForNetBirdOperatorSecret(ctx, clusterClient); err != nil {
 			return false, err
 		}
 		return true, nil
-	}
 
-	choice, err := promptNetBirdTokenChoice()
-	if err != nil {
-		return false, fmt.Errorf("NetBird API-key prompt: %w", err)
+	default:
+		choice, err = promptNetBirdTokenChoice()
+		if err != nil {
+			return false, fmt.Errorf("NetBird API-key prompt: %w", err)
+		}
 	}
 
 	switch choice {
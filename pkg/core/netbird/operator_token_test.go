@@ -5,8 +5,10 @@ package netbird
 
 import (
 	"context"
+	"errors"
 	"io"
 	"os"
+	"path"
 	"strings"
 	"testing"
 
@@ -16,6 +18,7 @@ import (
 	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 )
 
@@ -303,6 +306,68 @@ func TestAwaitNetBirdOperatorToken_DeferSkipsLockdown(t *testing.T) {
 	}
 }
 
+// TestAwaitNetBirdOperatorToken_NonInteractive verifies a non-interactive run
+// never prompts: it defers when the answers file says so, and fails naming the
+// decision when the answers file says nothing.
+// Mutates answers.Parsed — sequential only.
+func TestAwaitNetBirdOperatorToken_NonInteractive(t *testing.T) {
+	netBirdVPNTestConfig(t)
+	forceInteractive(t, netBirdTokenPasteNow, "pasted-pat")
+	promptNetBirdTokenChoice = func() (netBirdTokenChoice, error) {
+		t.Fatal("prompted in non-interactive mode")
+		return netBirdTokenPasteNow, nil
+	}
+
+	prevAnswers := answers.Parsed
+	t.Cleanup(func() { answers.Parsed = prevAnswers })
+
+	answersFilePath := path.Join(t.TempDir(), "answers.yaml")
+
+	t.Run("defer from the answers file", func(t *testing.T) {
+		if err := os.WriteFile(answersFilePath, []byte("netbirdOperatorToken: defer\n"), 0o600); err != nil {
+			t.Fatalf("writing answers file: %v", err)
+		}
+		parsed, err := answers.Load(answersFilePath, true)
+		if err != nil {
+			t.Fatalf("loading answers file: %v", err)
+		}
+		answers.Parsed = parsed
+
+		fakeClient := crFake.NewClientBuilder().WithScheme(newPostgresTestScheme(t)).Build()
+
+		var proceed bool
+		captureStdout(t, func() {
+			proceed, err = AwaitOperatorToken(context.Background(), fakeClient, "")
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if proceed {
+			t.Error("expected proceedWithLockdown=false when the answers file defers")
+		}
+	})
+
+	t.Run("missing decision", func(t *testing.T) {
+		parsed, err := answers.Load("", true)
+		if err != nil {
+			t.Fatalf("loading answers: %v", err)
+		}
+		answers.Parsed = parsed
+
+		fakeClient := crFake.NewClientBuilder().WithScheme(newPostgresTestScheme(t)).Build()
+
+		captureStdout(t, func() {
+			_, err = AwaitOperatorToken(context.Background(), fakeClient, "")
+		})
+		if !errors.Is(err, answers.ErrMissingDecision) {
+			t.Fatalf("expected ErrMissingDecision, got: %v", err)
+		}
+		if !strings.Contains(err.Error(), string(answers.DecisionNetBirdOperatorToken)) {
+			t.Errorf("expected the error to name the decision, got: %v", err)
+		}
+	})
+}
+
 // TestCreateNetBirdOperatorSecret verifies the Secret is written with the
 // NB_API_KEY value in the expected namespace/name.
 func TestCreateNetBirdOperatorSecret(t *testing.T) {
@@ -17,6 +17,7 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
 )
@@ -82,7 +83,7 @@ func neutralizeSingleNodePDBs(ctx context.Context) ([]removedPDB, func()) {
 		))
 	}
 
-	if !confirmPDBRemoval(bar, blockingPDBs) {
+	if !confirmPDBRemoval(ctx, bar, blockingPDBs) {
 		assert.Assert(ctx, false, manualPDBInstructions(blockingPDBs))
 	}
 
@@ -182,10 +183,20 @@ func restorePDBs(ctx context.Context, removed []removedPDB) {
 }
 
 // confirmPDBRemoval asks the operator for consent to remove the listed PDBs for the duration
-// of the upgrade (same huh-form shape as the lockdown confirm). Returns false when declined -
-// and when the prompt itself can't run (no TTY), so non-interactive runs fail safe into the
-// manual instructions instead of silently mutating the cluster.
-func confirmPDBRemoval(bar *progress.Bar, blockingPDBs []policyV1.PodDisruptionBudget) bool {
+// of the upgrade (same huh-form shape as the lockdown confirm), unless the answers file already
+// decided. Returns false when declined - and when the prompt itself can't run (no TTY), so
+// unattended runs fail safe into the manual instructions instead of silently mutating the
+// cluster. Panics when running non-interactively without a decision.
+func confirmPDBRemoval(ctx context.Context,
+	bar *progress.Bar,
+	blockingPDBs []policyV1.PodDisruptionBudget,
+) bool {
+	proceed, answered, err := answers.Parsed.Confirm(answers.DecisionRemoveBlockingPDBs)
+	assert.AssertErrNil(ctx, err, "Can't decide whether to remove the blocking PodDisruptionBudgets")
+	if answered {
+		return proceed
+	}
+
 	description := fmt.Sprintf(
 		"The cluster has a single node : evicted pods have nowhere to reschedule, so these\n"+
 			"PodDisruptionBudgets would deadlock KubeOne's drain :\n\n%s\n\n"+
@@ -195,7 +206,7 @@ func confirmPDBRemoval(bar *progress.Bar, blockingPDBs []policyV1.PodDisruptionB
 		pdbNameList(blockingPDBs),
 	)
 
-	proceed := false
+	proceed = false
 
 	bar.Pause()
 	defer bar.Resume()
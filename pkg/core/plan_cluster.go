@@ -0,0 +1,430 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	gitUtils "github.com/Obmondo/kubeaid-cli/pkg/utils/git"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/templates"
+)
+
+type PlanClusterArgs struct {
+	SkipMonitoringSetup bool
+
+	// OutputFormat is either "" (colorized per-file diff, followed by the JSON summary) or "json"
+	// (only the JSON summary).
+	OutputFormat string
+}
+
+const (
+	kubeaidConfigFileAdded   = "added"
+	kubeaidConfigFileChanged = "changed"
+	kubeaidConfigFileRemoved = "removed"
+)
+
+// kubeaidConfigFileChange is a single file, the next bootstrap / upgrade / sync would add, change
+// or remove in the KubeAid Config repository.
+type kubeaidConfigFileChange struct {
+	// Path relative to the KubeAid Config repository root.
+	path string
+	kind string
+
+	before,
+	after []byte
+
+	// A Sealed Secret's contents are ciphertext, re-encrypted on every seal : diffing them tells
+	// nothing. So only the fact that it'd be (re-)sealed is shown.
+	sealed bool
+}
+
+type kubeaidConfigPlan struct {
+	changes []kubeaidConfigFileChange
+
+	// Sealed Secrets which can't be told apart from unchanged ones, since the sealed-secrets
+	// controller's certificate (part of the kubeaid-sha256 header) couldn't be read.
+	unverified []string
+}
+
+// kubeaidConfigPlanSummary is the machine readable summary of a kubeaidConfigPlan.
+type kubeaidConfigPlanSummary struct {
+	Added      []string `json:"added"`
+	Changed    []string `json:"changed"`
+	Removed    []string `json:"removed"`
+	Unverified []string `json:"unverified,omitempty"`
+}
+
+/*
+Renders every KubeAid config file for the cluster into a scratch worktree, and prints how it
+differs from the KubeAid Config repository's default branch : a colorized unified diff per file,
+followed by a JSON summary of the added, changed and removed files.
+
+Sealed Secrets are rendered in memory only : their plaintext is never written or printed. One is
+reported as changed, when its plaintext hash no longer matches the kubeaid-sha256 header of the
+committed file - exactly when a bootstrap / upgrade / sync would re-seal it.
+
+Nothing gets committed or pushed, and nothing gets written to the cluster. The cluster is only
+read from, for the sealed-secrets controller's certificate and the template values fetched from
+it.
+*/
+func PlanCluster(ctx context.Context, args PlanClusterArgs) {
+	plan := planKubeaidConfig(ctx, args)
+
+	summary, err := json.MarshalIndent(plan.summary(), "", "  ")
+	assert.AssertErrNil(ctx, err, "Failed marshalling plan summary")
+
+	output := string(summary) + "\n"
+	if args.OutputFormat != outputFormatJSON {
+		output = renderKubeaidConfigPlan(plan) + "\n" + output
+	}
+	fmt.Print(output) //nolint:forbidigo // operator-facing terminal output
+}
+
+// planKubeaidConfig renders the KubeAid config files into the scratch worktree and diffs them
+// against the default branch. The progress bar is finished by the time it returns, so the
+// spinner doesn't interleave with the diff PlanCluster prints.
+func planKubeaidConfig(ctx context.Context, args PlanClusterArgs) *kubeaidConfigPlan {
+	bar := progress.New("Planning kubeaid-config changes")
+	defer bar.Finish()
+	ctx = progress.WithBar(ctx, bar)
+
+	gitAuthMethod := gitUtils.GetGitAuthMethod(ctx)
+
+	// Fetches the latest state of the default branch.
+	repo := gitUtils.CloneRepo(ctx, config.ParsedGeneralConfig.Forks.KubeaidConfigFork.URL, gitAuthMethod)
+	defaultBranchName := gitUtils.GetDefaultBranchName(ctx, gitAuthMethod, repo)
+	bar.Substep("Cloned kubeaid-config repo")
+
+	clusterDirInRepo := path.Join("k8s", config.ParsedGeneralConfig.Forks.KubeaidConfigFork.Directory)
+
+	baseFiles, err := gitUtils.ReadBranchFiles(repo, defaultBranchName, clusterDirInRepo)
+	assert.AssertErrNil(ctx, err, "Failed reading cluster dir from kubeaid-config default branch",
+		slog.String("branch", defaultBranchName),
+	)
+
+	// The KubePrometheus build script runs against the KubeAid repository.
+	if !args.SkipMonitoringSetup {
+		cloneKubeAidFork(ctx, gitAuthMethod)
+		bar.Substep("Cloned KubeAid repo")
+	}
+
+	// The scratch worktree starts off as the default branch's cluster dir, so files rendered the
+	// same way as before show up unchanged, and files the KubePrometheus build script no longer
+	// generates show up removed. Cleared upfront, so nothing from a previous run lingers.
+	scratchDir := path.Join(constants.TempDirectory, "planned-kubeaid-config")
+	err = os.RemoveAll(scratchDir)
+	assert.AssertErrNil(ctx, err, "Failed clearing scratch directory", slog.String("path", scratchDir))
+
+	err = writeKubeaidConfigFiles(scratchDir, baseFiles)
+	assert.AssertErrNil(ctx, err, "Failed populating scratch directory", slog.String("path", scratchDir))
+
+	templateValues := getTemplateValues(ctx)
+
+	createOrUpdateNonSecretFiles(ctx, templateValues,
+		path.Join(scratchDir, clusterDirInRepo), args.SkipMonitoringSetup,
+	)
+
+	plannedFiles, err := readKubeaidConfigFiles(scratchDir)
+	assert.AssertErrNil(ctx, err, "Failed reading scratch directory", slog.String("path", scratchDir))
+
+	sealedSecretChanges, unverified := planSealedSecretFiles(ctx,
+		templateValues, scratchDir, clusterDirInRepo, baseFiles,
+	)
+	bar.Substep("Rendered kubeaid-config files")
+
+	return computeKubeaidConfigPlan(baseFiles, plannedFiles, sealedSecretChanges, unverified)
+}
+
+/*
+Renders the Sealed Secrets in memory, and tells which of them would get sealed for the first
+time (added) or re-sealed (changed), compared to the given files from the default branch. Those
+are expected to be in the scratch directory as well, since the kubeaid-sha256 header is read from
+disk.
+
+The sealed-secrets controller's certificate is read from the main cluster, if it has been
+provisioned. Otherwise, Sealed Secrets already in the default branch are returned as unverified.
+*/
+func planSealedSecretFiles(ctx context.Context,
+	templateValues *TemplateValues,
+	scratchDir,
+	clusterDirInRepo string,
+	baseFiles map[string][]byte,
+) ([]kubeaidConfigFileChange, []string) {
+	certBytes := readMainClusterSealingCert(ctx)
+
+	var (
+		changes    []kubeaidConfigFileChange
+		unverified []string
+	)
+	for _, embeddedTemplateName := range getEmbeddedSecretTemplateNames() {
+		repoPath := path.Join(clusterDirInRepo, strings.TrimSuffix(embeddedTemplateName, ".tmpl"))
+
+		ctxWithPath := logger.AppendSlogAttributesToCtx(ctx, []slog.Attr{
+			slog.String("path", repoPath),
+		})
+
+		plaintextBytes := templates.ParseAndExecuteTemplate(ctxWithPath,
+			&KubeaidConfigFileTemplates,
+			path.Join("templates/", embeddedTemplateName),
+			templateValues,
+		)
+
+		baseContents, exists := baseFiles[repoPath]
+		switch {
+		case !exists:
+			changes = append(changes, kubeaidConfigFileChange{
+				path: repoPath, kind: kubeaidConfigFileAdded, sealed: true,
+			})
+
+		case certBytes == nil:
+			unverified = append(unverified, repoPath)
+
+		case !kubernetes.SealedSecretUpToDate(path.Join(scratchDir, repoPath), plaintextBytes, certBytes):
+			changes = append(changes, kubeaidConfigFileChange{
+				path: repoPath, kind: kubeaidConfigFileChanged, before: baseContents, sealed: true,
+			})
+		}
+	}
+	return changes, unverified
+}
+
+// Returns the sealed-secrets controller's certificate from the main cluster, or nil if the main
+// cluster hasn't been provisioned yet or can't be reached.
+func readMainClusterSealingCert(ctx context.Context) []byte {
+	if _, err := os.Stat(constants.OutputPathMainClusterKubeconfig); err != nil {
+		slog.WarnContext(ctx, "Main cluster not provisioned yet, Sealed Secret changes can't be verified")
+		return nil
+	}
+
+	certBytes, err := kubernetes.LoadSealingCertFromKubeconfig(ctx,
+		constants.OutputPathMainClusterKubeconfig,
+	)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed reading sealed-secrets controller certificate, Sealed Secret changes can't be verified",
+			logger.Error(err),
+		)
+		return nil
+	}
+	return certBytes
+}
+
+// computeKubeaidConfigPlan diffs the planned files against the base ones (both keyed by path
+// relative to the KubeAid Config repository root). Sealed Secrets are skipped, since they're
+// planned separately and come in as sealedSecretChanges.
+func computeKubeaidConfigPlan(baseFiles,
+	plannedFiles map[string][]byte,
+	sealedSecretChanges []kubeaidConfigFileChange,
+	unverified []string,
+) *kubeaidConfigPlan {
+	sealedSecretPaths := map[string]bool{}
+	for _, change := range sealedSecretChanges {
+		sealedSecretPaths[change.path] = true
+	}
+	for _, filePath := range unverified {
+		sealedSecretPaths[filePath] = true
+	}
+
+	plan := &kubeaidConfigPlan{
+		changes:    slices.Clone(sealedSecretChanges),
+		unverified: slices.Sorted(slices.Values(unverified)),
+	}
+
+	for filePath, after := range plannedFiles {
+		if sealedSecretPaths[filePath] {
+			continue
+		}
+
+		before, exists := baseFiles[filePath]
+		switch {
+		case !exists:
+			plan.changes = append(plan.changes, kubeaidConfigFileChange{
+				path: filePath, kind: kubeaidConfigFileAdded, after: after,
+			})
+
+		case !bytes.Equal(before, after):
+			plan.changes = append(plan.changes, kubeaidConfigFileChange{
+				path: filePath, kind: kubeaidConfigFileChanged, before: before, after: after,
+			})
+		}
+	}
+
+	for filePath, before := range baseFiles {
+		if _, exists := plannedFiles[filePath]; !exists && !sealedSecretPaths[filePath] {
+			plan.changes = append(plan.changes, kubeaidConfigFileChange{
+				path: filePath, kind: kubeaidConfigFileRemoved, before: before,
+			})
+		}
+	}
+
+	slices.SortFunc(plan.changes, func(a, b kubeaidConfigFileChange) int {
+		return strings.Compare(a.path, b.path)
+	})
+	return plan
+}
+
+func (p *kubeaidConfigPlan) summary() kubeaidConfigPlanSummary {
+	summary := kubeaidConfigPlanSummary{
+		Added:      []string{},
+		Changed:    []string{},
+		Removed:    []string{},
+		Unverified: p.unverified,
+	}
+	for _, change := range p.changes {
+		switch change.kind {
+		case kubeaidConfigFileAdded:
+			summary.Added = append(summary.Added, change.path)
+		case kubeaidConfigFileChanged:
+			summary.Changed = append(summary.Changed, change.path)
+		case kubeaidConfigFileRemoved:
+			summary.Removed = append(summary.Removed, change.path)
+		}
+	}
+	return summary
+}
+
+// renderKubeaidConfigPlan renders the plan as a unified diff per file, colorized when stdout is
+// a terminal.
+func renderKubeaidConfigPlan(plan *kubeaidConfigPlan) string {
+	var (
+		fileHeaderStyle = lipgloss.NewStyle().Bold(true)
+		addedStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+		removedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+		hunkStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+		noteStyle       = lipgloss.NewStyle().Faint(true)
+	)
+
+	var b strings.Builder
+
+	if len(plan.changes) == 0 {
+		b.WriteString("No changes : kubeaid-config is up to date.\n")
+	}
+
+	for _, change := range plan.changes {
+		fromFile, toFile := "a/"+change.path, "b/"+change.path
+		switch change.kind {
+		case kubeaidConfigFileAdded:
+			fromFile = "/dev/null"
+		case kubeaidConfigFileRemoved:
+			toFile = "/dev/null"
+		}
+
+		b.WriteString(fileHeaderStyle.Render("--- "+fromFile) + "\n")
+		b.WriteString(fileHeaderStyle.Render("+++ "+toFile) + "\n")
+
+		if change.sealed {
+			note := "Sealed Secret : plaintext changed, would be re-sealed"
+			if change.kind == kubeaidConfigFileAdded {
+				note = "Sealed Secret : would be sealed"
+			}
+			b.WriteString(noteStyle.Render(note) + "\n\n")
+			continue
+		}
+
+		diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:       splitDiffLines(change.before),
+			B:       splitDiffLines(change.after),
+			Context: 3,
+		})
+		for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+			switch {
+			case strings.HasPrefix(line, "@@"):
+				line = hunkStyle.Render(line)
+			case strings.HasPrefix(line, "+"):
+				line = addedStyle.Render(line)
+			case strings.HasPrefix(line, "-"):
+				line = removedStyle.Render(line)
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	for _, filePath := range plan.unverified {
+		b.WriteString(noteStyle.Render(fmt.Sprintf(
+			"%s : Sealed Secret, can't tell whether it would be re-sealed", filePath,
+		)) + "\n")
+	}
+
+	summary := plan.summary()
+	b.WriteString(fmt.Sprintf("Plan : %d to add, %d to change, %d to remove.\n",
+		len(summary.Added), len(summary.Changed), len(summary.Removed),
+	))
+	return b.String()
+}
+
+// splitDiffLines splits the file contents into lines, each ending with a newline, as
+// difflib expects. Unlike difflib.SplitLines, a trailing newline doesn't yield an extra empty
+// line.
+func splitDiffLines(contents []byte) []string {
+	if len(contents) == 0 {
+		return nil
+	}
+
+	lines := strings.SplitAfter(string(contents), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n"
+	return lines
+}
+
+// writeKubeaidConfigFiles writes the given files (keyed by path relative to rootDir) under
+// rootDir.
+func writeKubeaidConfigFiles(rootDir string, files map[string][]byte) error {
+	for filePath, contents := range files {
+		absoluteFilePath := path.Join(rootDir, filePath)
+
+		if err := os.MkdirAll(path.Dir(absoluteFilePath), 0o750); err != nil {
+			return fmt.Errorf("creating intermediate dirs for %s: %w", absoluteFilePath, err)
+		}
+		if err := os.WriteFile(absoluteFilePath, contents, 0o600); err != nil {
+			return fmt.Errorf("writing %s: %w", absoluteFilePath, err)
+		}
+	}
+	return nil
+}
+
+// readKubeaidConfigFiles returns every file under rootDir, keyed by its path relative to
+// rootDir.
+func readKubeaidConfigFiles(rootDir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := filepath.WalkDir(rootDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relativeFilePath, err := filepath.Rel(rootDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		contents, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", filePath, err)
+		}
+		files[filepath.ToSlash(relativeFilePath)] = contents
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeKubeaidConfigPlan(t *testing.T) {
+	baseFiles := map[string][]byte{
+		"k8s/demo/argocd-apps/values-cilium.yaml":                 []byte("cilium:\n  k8sServicePort: 6443\n"),
+		"k8s/demo/argocd-apps/templates/cilium.yaml":              []byte("kind: Application\n"),
+		"k8s/demo/kube-prometheus/stale.yaml":                     []byte("kind: ConfigMap\n"),
+		"k8s/demo/sealed-secrets/argocd/kubeaid-config.yaml":      []byte("kind: SealedSecret\n"),
+		"k8s/demo/sealed-secrets/obmondo/obmondo-clientcert.yaml": []byte("kind: SealedSecret\n"),
+	}
+	plannedFiles := map[string][]byte{
+		"k8s/demo/argocd-apps/values-cilium.yaml":                 []byte("cilium:\n  k8sServicePort: 443\n"),
+		"k8s/demo/argocd-apps/templates/cilium.yaml":              []byte("kind: Application\n"),
+		"k8s/demo/argocd-apps/templates/traefik.yaml":             []byte("kind: Application\n"),
+		"k8s/demo/sealed-secrets/argocd/kubeaid-config.yaml":      []byte("kind: SealedSecret\n"),
+		"k8s/demo/sealed-secrets/obmondo/obmondo-clientcert.yaml": []byte("kind: SealedSecret\n"),
+	}
+
+	plan := computeKubeaidConfigPlan(baseFiles, plannedFiles,
+		[]kubeaidConfigFileChange{{
+			path:   "k8s/demo/sealed-secrets/argocd/kubeaid-config.yaml",
+			kind:   kubeaidConfigFileChanged,
+			sealed: true,
+		}},
+		[]string{"k8s/demo/sealed-secrets/obmondo/obmondo-clientcert.yaml"},
+	)
+
+	assert.Equal(t,
+		kubeaidConfigPlanSummary{
+			Added:      []string{"k8s/demo/argocd-apps/templates/traefik.yaml"},
+			Changed:    []string{"k8s/demo/argocd-apps/values-cilium.yaml", "k8s/demo/sealed-secrets/argocd/kubeaid-config.yaml"},
+			Removed:    []string{"k8s/demo/kube-prometheus/stale.yaml"},
+			Unverified: []string{"k8s/demo/sealed-secrets/obmondo/obmondo-clientcert.yaml"},
+		},
+		plan.summary(),
+	)
+
+	// Nothing changed : empty lists, rather than nulls, in the JSON summary.
+	plan = computeKubeaidConfigPlan(baseFiles, baseFiles, nil, nil)
+	assert.Equal(t,
+		kubeaidConfigPlanSummary{Added: []string{}, Changed: []string{}, Removed: []string{}},
+		plan.summary(),
+	)
+}
+
+func TestRenderKubeaidConfigPlan(t *testing.T) {
+	plan := &kubeaidConfigPlan{
+		changes: []kubeaidConfigFileChange{
+			{
+				path:   "k8s/demo/argocd-apps/values-cilium.yaml",
+				kind:   kubeaidConfigFileChanged,
+				before: []byte("cilium:\n  k8sServicePort: 6443\n"),
+				after:  []byte("cilium:\n  k8sServicePort: 443\n"),
+			},
+			{
+				path:   "k8s/demo/sealed-secrets/argocd/kubeaid-config.yaml",
+				kind:   kubeaidConfigFileChanged,
+				before: []byte("kind: SealedSecret\n"),
+				sealed: true,
+			},
+		},
+	}
+
+	// Not a terminal : rendered without colors.
+	assert.Equal(t,
+		"--- a/k8s/demo/argocd-apps/values-cilium.yaml\n"+
+			"+++ b/k8s/demo/argocd-apps/values-cilium.yaml\n"+
+			"@@ -1,2 +1,2 @@\n"+
+			" cilium:\n"+
+			"-  k8sServicePort: 6443\n"+
+			"+  k8sServicePort: 443\n"+
+			"\n"+
+			"--- a/k8s/demo/sealed-secrets/argocd/kubeaid-config.yaml\n"+
+			"+++ b/k8s/demo/sealed-secrets/argocd/kubeaid-config.yaml\n"+
+			"Sealed Secret : plaintext changed, would be re-sealed\n"+
+			"\n"+
+			"Plan : 0 to add, 2 to change, 0 to remove.\n",
+		renderKubeaidConfigPlan(plan),
+	)
+}
+
+func TestReadWriteKubeaidConfigFiles(t *testing.T) {
+	files := map[string][]byte{
+		"k8s/demo/argocd-apps/values-cilium.yaml": []byte("cilium: {}\n"),
+		"k8s/demo/kubeone/kubeone-cluster.yaml":   []byte("kind: KubeOneCluster\n"),
+	}
+
+	rootDir := t.TempDir()
+	require.NoError(t, writeKubeaidConfigFiles(rootDir, files))
+
+	readFiles, err := readKubeaidConfigFiles(rootDir)
+	require.NoError(t, err)
+	assert.Equal(t, files, readFiles)
+}
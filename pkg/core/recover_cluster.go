@@ -13,6 +13,7 @@ import (
 
 	awsServices "github.com/Obmondo/kubeaid-cli/pkg/cloud/aws/services"
 	"github.com/Obmondo/kubeaid-cli/pkg/cloud/azure"
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/objectstorage"
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
@@ -23,8 +24,6 @@ import (
 
 func RecoverCluster(ctx context.Context, managementClusterName string, skipPRWorkflow bool) {
 	switch globals.CloudProviderName {
-	case constants.CloudProviderHetzner:
-	case constants.CloudProviderBareMetal:
 	case constants.CloudProviderLocal:
 		panic("unimplemented")
 
@@ -76,6 +75,19 @@ func RecoverCluster(ctx context.Context, managementClusterName string, skipPRWor
 		)
 		assert.AssertErrNil(ctx, dlErr, "Failed downloading Azure Blob Container contents")
 
+	// Hetzner and Bare Metal clusters back up into an S3-compatible object storage
+	// (Hetzner Object Storage, MinIO, Ceph RGW).
+	case constants.CloudProviderHetzner, constants.CloudProviderBareMetal:
+		s3Client, err := objectstorage.NewS3ClientFromConfig()
+		assert.AssertErrNil(ctx, err, "Failed creating object storage client")
+
+		err = awsServices.DownloadS3BucketContents(ctx,
+			s3Client,
+			sealedSecretsKeysBackupsBucketName,
+			true,
+		)
+		assert.AssertErrNil(ctx, err, "Failed downloading object storage bucket contents")
+
 	default:
 		panic("unreachable")
 	}
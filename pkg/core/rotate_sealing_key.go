@@ -0,0 +1,237 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/git"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/templates"
+)
+
+type RotateSealingKeyArgs struct {
+	SkipPRWorkflow bool
+
+	// How long to wait for ArgoCD to sync the re-sealed SealedSecrets, before giving up without
+	// retiring the old keys.
+	SyncTimeout time.Duration
+}
+
+/*
+Rotates the main cluster's Sealed Secrets sealing key :
+
+	(1) Creates a new active key, and restarts the sealed-secrets controller so it seals with it.
+
+	(2) Re-seals every file under the cluster's sealed-secrets/ directory in the KubeAid Config
+	    repository with the new key, and pushes them through the usual PR workflow.
+
+	(3) Once the PR is merged, waits for ArgoCD to report every SealedSecret synced, and only then
+	    retires the old keys : relabels them, so the restarted controller no longer loads them.
+	    They're kept as Secrets, for SealedSecrets from old backups.
+
+If ArgoCD doesn't get there within args.SyncTimeout, the old keys stay active. Re-running then
+creates yet another key : harmless, since every key stays active until the final step.
+*/
+func RotateSealingKey(ctx context.Context, args RotateSealingKeyArgs) {
+	bar := progress.New("Rotating Sealed Secrets sealing key")
+	ctx = progress.WithBar(ctx, bar)
+	defer bar.Finish()
+
+	// Set the KUBECONFIG environment variable to the main cluster's kubeconfig.
+	utils.MustSetEnv(constants.EnvNameKubeconfig, constants.OutputPathMainClusterKubeconfig)
+
+	// Construct a client to the main cluster.
+	mainClusterClient, err := kubernetes.CreateKubernetesClient(ctx,
+		constants.OutputPathMainClusterKubeconfig,
+	)
+	assert.AssertErrNil(ctx, err, "Failed constructing Kubernetes cluster client")
+
+	// The previous certificate proves which kubeaid-sha256 headers are still valid.
+	previousCertBytes, err := kubernetes.LoadSealingCert(ctx)
+	assert.AssertErrNil(ctx, err, "Failed reading sealed-secrets controller certificate")
+
+	// (1) Create the new key.
+
+	keyName, publicKey, err := kubernetes.CreateSealedSecretsKey(ctx, mainClusterClient)
+	assert.AssertErrNil(ctx, err, "Failed creating sealed-secrets key")
+
+	err = kubernetes.RestartSealedSecretsController(ctx, mainClusterClient)
+	assert.AssertErrNil(ctx, err, "Failed restarting sealed-secrets controller")
+
+	_, err = kubernetes.WaitForSealingCert(ctx, publicKey)
+	assert.AssertErrNil(ctx, err, "Failed switching to the new sealed-secrets key",
+		slog.String("key", keyName),
+	)
+	bar.Substep("Created sealed-secrets key " + keyName)
+
+	// (2) Re-seal and push.
+
+	pushResealedSecretFiles(ctx, args.SkipPRWorkflow, previousCertBytes)
+
+	// (3) Retire the old keys.
+
+	err = kubernetes.WaitForSealedSecretsSynced(ctx, mainClusterClient, args.SyncTimeout)
+	assert.AssertErrNil(ctx, err,
+		"SealedSecrets didn't sync with the new key. The old keys are kept active : re-run once ArgoCD has synced",
+	)
+	bar.Substep("Confirmed SealedSecrets synced")
+
+	retiredKeyNames, err := kubernetes.RetireSealedSecretsKeys(ctx, mainClusterClient, keyName)
+	assert.AssertErrNil(ctx, err, "Failed retiring old sealed-secrets keys")
+
+	err = kubernetes.RestartSealedSecretsController(ctx, mainClusterClient)
+	assert.AssertErrNil(ctx, err, "Failed restarting sealed-secrets controller")
+	bar.Substep(fmt.Sprintf("Retired %d old sealed-secrets key(s)", len(retiredKeyNames)))
+
+	slog.InfoContext(ctx, "Rotated Sealed Secrets sealing key",
+		slog.String("key", keyName),
+		slog.Any("retired", retiredKeyNames),
+	)
+}
+
+// pushResealedSecretFiles re-seals every Sealed Secret file of the cluster in the KubeAid Config
+// repository with the controller's current key, then commits and pushes them (waiting for the PR
+// to be merged, unless skipPRWorkflow).
+func pushResealedSecretFiles(ctx context.Context, skipPRWorkflow bool, previousCertBytes []byte) {
+	bar := progress.FromCtx(ctx)
+
+	// Detect git authentication method.
+	gitAuthMethod := git.GetGitAuthMethod(ctx)
+
+	// Clone the KubeAid Config repository locally, if it's not already there.
+	repo := git.CloneRepo(ctx, config.ParsedGeneralConfig.Forks.KubeaidConfigFork.URL, gitAuthMethod)
+
+	workTree, err := repo.Worktree()
+	assert.AssertErrNil(ctx, err, "Failed getting kubeaid-config repo worktree")
+
+	defaultBranchName := git.GetDefaultBranchName(ctx, gitAuthMethod, repo)
+
+	targetBranchName := defaultBranchName
+	if !skipPRWorkflow {
+		// Create and checkout to a new branch.
+		newBranchName := fmt.Sprintf("kubeaid-%s-%d",
+			config.ParsedGeneralConfig.Cluster.Name,
+			time.Now().Unix(),
+		)
+		git.CreateAndCheckoutToBranch(ctx, repo, newBranchName, workTree, gitAuthMethod)
+
+		targetBranchName = newBranchName
+	}
+
+	clusterDir := utils.GetClusterDir()
+
+	sealedSecretFilePaths, err := findSealedSecretFiles(path.Join(clusterDir, "sealed-secrets"))
+	assert.AssertErrNil(ctx, err, "Failed listing Sealed Secret files")
+
+	plaintexts := renderSealedSecretPlaintexts(ctx, clusterDir, getTemplateValues(ctx))
+
+	for _, filePath := range sealedSecretFilePaths {
+		ctxWithPath := logger.AppendSlogAttributesToCtx(ctx, []slog.Attr{
+			slog.String("path", filePath),
+		})
+
+		err := kubernetes.ResealSealedSecretFile(ctxWithPath,
+			filePath, previousCertBytes, plaintexts[filePath],
+		)
+		assert.AssertErrNil(ctxWithPath, err, "Failed re-sealing Sealed Secret")
+	}
+	bar.Substep(fmt.Sprintf("Re-sealed %d Sealed Secret file(s)", len(sealedSecretFilePaths)))
+
+	// Add, commit and push the changes.
+	commitMessage := fmt.Sprintf("(cluster/%s) : re-sealed Sealed Secrets with a rotated key",
+		config.ParsedGeneralConfig.Cluster.Name,
+	)
+	commitHash := git.AddCommitAndPushChanges(ctx,
+		repo,
+		workTree,
+		targetBranchName,
+		gitAuthMethod,
+		config.ParsedGeneralConfig.Cluster.Name,
+		commitMessage,
+		defaultBranchName,
+	)
+	if commitHash.IsZero() {
+		bar.Substep("kubeaid-config already up to date")
+		return
+	}
+	bar.Substep("Pushed kubeaid-config branch")
+
+	if !skipPRWorkflow {
+		// Wait until the PR from the new to the default branch gets merged. With a forge API
+		// token configured, the PR gets opened for the user, otherwise the user needs to open it.
+		git.WaitUntilPRMerged(ctx,
+			repo,
+			defaultBranchName,
+			commitHash,
+			gitAuthMethod,
+			targetBranchName,
+		)
+		bar.Substep("Confirmed kubeaid-config PR merged")
+	}
+}
+
+// findSealedSecretFiles returns the path of every YAML / JSON file under directory, sorted. None
+// when directory doesn't exist.
+func findSealedSecretFiles(directory string) ([]string, error) {
+	var filePaths []string
+
+	err := filepath.WalkDir(directory, func(filePath string, entry fs.DirEntry, err error) error {
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && (filePath == directory):
+			return fs.SkipDir
+
+		case err != nil:
+			return err
+
+		case entry.IsDir():
+			return nil
+		}
+
+		switch filepath.Ext(filePath) {
+		case ".yaml", ".yml", ".json":
+			filePaths = append(filePaths, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filePaths, nil
+}
+
+// renderSealedSecretPlaintexts renders the plaintext of every Sealed Secret kubeaid-cli generates,
+// from templateValues, keyed by the path of the file it gets sealed into. These only let
+// ResealSealedSecretFile keep a valid kubeaid-sha256 header : it checks them against the header
+// before use.
+func renderSealedSecretPlaintexts(ctx context.Context,
+	clusterDir string,
+	templateValues *TemplateValues,
+) map[string][]byte {
+	plaintexts := map[string][]byte{}
+	for _, embeddedTemplateName := range getEmbeddedSecretTemplateNames() {
+		filePath := path.Join(clusterDir, strings.TrimSuffix(embeddedTemplateName, ".tmpl"))
+
+		plaintexts[filePath] = templates.ParseAndExecuteTemplate(ctx,
+			&KubeaidConfigFileTemplates,
+			path.Join("templates/", embeddedTemplateName),
+			templateValues,
+		)
+	}
+	return plaintexts
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindSealedSecretFiles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing directory", func(t *testing.T) {
+		t.Parallel()
+
+		filePaths, err := findSealedSecretFiles(filepath.Join(t.TempDir(), "sealed-secrets"))
+		require.NoError(t, err)
+		assert.Empty(t, filePaths)
+	})
+
+	t.Run("nested files", func(t *testing.T) {
+		t.Parallel()
+
+		directory := filepath.Join(t.TempDir(), "sealed-secrets")
+		for _, name := range []string{
+			"argocd/repo-kubeaid.yaml",
+			"monitoring/alertmanager-main.yml",
+			"velero/cloud-credentials.json",
+			"README.md",
+		} {
+			filePath := filepath.Join(directory, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o750))
+			require.NoError(t, os.WriteFile(filePath, []byte("{}"), 0o600))
+		}
+
+		filePaths, err := findSealedSecretFiles(directory)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(directory, "argocd/repo-kubeaid.yaml"),
+			filepath.Join(directory, "monitoring/alertmanager-main.yml"),
+			filepath.Join(directory, "velero/cloud-credentials.json"),
+		}, filePaths)
+	})
+}
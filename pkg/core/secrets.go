@@ -0,0 +1,369 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/git"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+)
+
+// Statuses of a Sealed Secret file, as `secrets list` reports them.
+const (
+	// The kubeaid-sha256 header matches the plaintext rendered from the current config, sealed
+	// with the cached controller certificate : a bootstrap / sync would leave the file untouched.
+	sealedSecretStatusCurrent = "current"
+
+	// The header doesn't match : the plaintext changed, or the controller's key got rotated.
+	sealedSecretStatusStale = "stale"
+
+	// The file has no header : it was sealed before kubeaid-cli wrote headers, or by hand.
+	sealedSecretStatusMissing = "missing"
+
+	// kubeaid-cli renders the Sealed Secret, but it hasn't been sealed into kubeaid-config yet.
+	sealedSecretStatusNew = "new"
+
+	// kubeaid-cli doesn't render the Sealed Secret : there's no plaintext to compare against.
+	sealedSecretStatusManual = "manual"
+
+	// kubeaid-cli renders the Sealed Secret from a value only the cluster or the cloud provider
+	// has : its plaintext can't be rendered, so neither compared against nor re-sealed, offline.
+	sealedSecretStatusUnknown = "unknown"
+)
+
+// sealedSecretFile is a Sealed Secret file of the cluster, in the KubeAid Config repository.
+type sealedSecretFile struct {
+	// Path relative to the cluster directory, like sealed-secrets/argocd/repo-kubeaid.yaml.
+	path   string
+	status string
+
+	// The kubeaid-sha256 header's hash. "" when there's none.
+	hash string
+
+	// The plaintext kubeaid-cli renders for it. nil for a manual or unknown one.
+	plaintext []byte
+}
+
+// ListSealedSecrets prints every Sealed Secret file of the cluster in the KubeAid Config
+// repository, with its status : whether a bootstrap / sync would re-seal it. Works offline.
+func ListSealedSecrets(ctx context.Context) {
+	files := readSealedSecretFiles(ctx)
+
+	fmt.Print(renderSealedSecretFiles(files)) //nolint:forbidigo // operator-facing terminal output
+}
+
+// DiffSealedSecrets prints, for every Sealed Secret file a bootstrap / sync would re-seal, which of
+// its fields changed : names only, never values. Works offline.
+func DiffSealedSecrets(ctx context.Context) {
+	files := readSealedSecretFiles(ctx)
+
+	key, err := kubernetes.SealedFieldsKey()
+	assert.AssertErrNil(ctx, err, "Failed loading sealed fields key")
+
+	var b strings.Builder
+	for _, file := range files {
+		if (file.status == sealedSecretStatusCurrent) || (file.status == sealedSecretStatusManual) {
+			continue
+		}
+
+		if file.status == sealedSecretStatusUnknown {
+			b.WriteString(renderSealedSecretFieldsDiff(file, nil, false, nil))
+			continue
+		}
+
+		newFields, err := kubernetes.SealedSecretFields(key, file.plaintext)
+		assert.AssertErrNil(ctx, err, "Failed reading Sealed Secret fields", slog.String("path", file.path))
+
+		var (
+			oldFields map[string]string
+			recorded  bool
+		)
+		if file.hash != "" {
+			oldFields, recorded, err = kubernetes.RecordedSealedFields(file.hash)
+			assert.AssertErrNil(ctx, err, "Failed reading recorded Sealed Secret fields")
+		}
+
+		b.WriteString(renderSealedSecretFieldsDiff(file, oldFields, recorded, newFields))
+	}
+
+	if b.Len() == 0 {
+		b.WriteString("Every Sealed Secret is current.\n")
+	}
+	fmt.Print(b.String()) //nolint:forbidigo // operator-facing terminal output
+}
+
+/*
+ResealSealedSecrets re-renders and re-seals every Sealed Secret file a bootstrap / sync would
+re-seal (or just the one at only, a path relative to the cluster directory), with the cached
+controller certificate. Works offline.
+
+The changes stay in the local KubeAid Config repository clone, for the operator to review, commit
+and push.
+*/
+func ResealSealedSecrets(ctx context.Context, only string) {
+	clusterDir := utils.GetClusterDir()
+	files := readSealedSecretFiles(ctx)
+
+	if only != "" {
+		only = filepath.ToSlash(filepath.Clean(only))
+
+		index := slices.IndexFunc(files, func(file sealedSecretFile) bool { return file.path == only })
+		assert.Assert(ctx, index >= 0, fmt.Sprintf("%s isn't a Sealed Secret file of the cluster", only))
+		assert.Assert(ctx, files[index].status != sealedSecretStatusManual,
+			fmt.Sprintf("%s isn't rendered by kubeaid-cli, so can't be re-sealed", only),
+		)
+		assert.Assert(ctx, files[index].status != sealedSecretStatusUnknown,
+			fmt.Sprintf("%s is rendered from a value only the cluster or the cloud provider has,"+
+				" so can't be re-sealed offline : a bootstrap / sync re-seals it", only),
+		)
+
+		files = files[index : index+1]
+	}
+
+	certBytes, err := kubernetes.LoadCachedSealingCert()
+	assert.AssertErrNil(ctx, err, "Failed loading cached sealing certificate")
+
+	var resealed, unknown []string
+	for _, file := range files {
+		switch file.status {
+		case sealedSecretStatusCurrent, sealedSecretStatusManual:
+			continue
+
+		case sealedSecretStatusUnknown:
+			unknown = append(unknown, file.path)
+			continue
+		}
+
+		filePath := path.Join(clusterDir, file.path)
+		ctxWithPath := logger.AppendSlogAttributesToCtx(ctx, []slog.Attr{
+			slog.String("path", filePath),
+		})
+
+		err := utils.CreateIntermediateDirsForFile(filePath)
+		assert.AssertErrNil(ctxWithPath, err, "Failed creating intermediate dirs")
+
+		err = kubernetes.SealIfPlaintextChangedWithCert(ctxWithPath, filePath, file.plaintext, certBytes)
+		assert.AssertErrNil(ctxWithPath, err, "Failed re-sealing Sealed Secret")
+
+		resealed = append(resealed, file.path)
+	}
+
+	if len(unknown) > 0 {
+		//nolint:forbidigo // operator-facing terminal output
+		fmt.Printf("Left alone, rendered from a value only the cluster or the cloud provider has"+
+			" (a bootstrap / sync re-seals them) :\n  %s\n\n",
+			strings.Join(unknown, "\n  "),
+		)
+	}
+
+	if len(resealed) == 0 {
+		fmt.Println("Nothing to re-seal : every Sealed Secret is current.") //nolint:forbidigo // operator-facing terminal output
+		return
+	}
+
+	//nolint:forbidigo // operator-facing terminal output
+	fmt.Printf("Re-sealed, in %s :\n  %s\n\nReview, commit and push them from there.\n",
+		clusterDir, strings.Join(resealed, "\n  "),
+	)
+}
+
+// readSealedSecretFiles returns every Sealed Secret file of the cluster in the KubeAid Config
+// repository, and the ones kubeaid-cli renders but which don't exist yet, sorted by path. The
+// local clone is used as is, so this works offline; it only gets cloned when it isn't there yet.
+func readSealedSecretFiles(ctx context.Context) []sealedSecretFile {
+	if _, err := os.Stat(utils.GetKubeAidConfigDir()); err != nil {
+		git.CloneRepo(ctx, config.ParsedGeneralConfig.Forks.KubeaidConfigFork.URL, git.GetGitAuthMethod(ctx))
+	}
+	clusterDir := utils.GetClusterDir()
+
+	certBytes, err := kubernetes.LoadCachedSealingCert()
+	assert.AssertErrNil(ctx, err, "Failed loading cached sealing certificate")
+
+	filePaths, err := findSealedSecretFiles(path.Join(clusterDir, "sealed-secrets"))
+	assert.AssertErrNil(ctx, err, "Failed listing Sealed Secret files")
+
+	plaintexts := map[string][]byte{}
+	for filePath, plaintext := range renderSealedSecretPlaintexts(ctx, clusterDir, getOfflineTemplateValues(ctx)) {
+		relativeFilePath, err := filepath.Rel(clusterDir, filePath)
+		assert.AssertErrNil(ctx, err, "Failed resolving Sealed Secret path")
+		plaintexts[filepath.ToSlash(relativeFilePath)] = plaintext
+	}
+
+	// Rendered offline, these got a blank where the cluster's / cloud provider's value goes.
+	unknownPaths := map[string]bool{}
+	for _, templateName := range liveSealedSecretTemplateNames() {
+		relativeFilePath := strings.TrimSuffix(templateName, ".tmpl")
+		delete(plaintexts, relativeFilePath)
+		unknownPaths[relativeFilePath] = true
+	}
+
+	hashes := map[string]string{}
+	for _, filePath := range filePaths {
+		relativeFilePath, err := filepath.Rel(clusterDir, filePath)
+		assert.AssertErrNil(ctx, err, "Failed resolving Sealed Secret path")
+
+		hash, err := kubernetes.KubeaidHashHeader(filePath)
+		assert.AssertErrNil(ctx, err, "Failed reading Sealed Secret", slog.String("path", filePath))
+
+		hashes[filepath.ToSlash(relativeFilePath)] = hash
+	}
+
+	return classifySealedSecretFiles(hashes, plaintexts, unknownPaths, certBytes)
+}
+
+// classifySealedSecretFiles determines the status of every Sealed Secret file, given the
+// kubeaid-sha256 header hash of each existing one, the plaintext of each one kubeaid-cli renders,
+// and the ones kubeaid-cli renders but can't offline, all keyed by path.
+func classifySealedSecretFiles(hashes map[string]string,
+	plaintexts map[string][]byte,
+	unknownPaths map[string]bool,
+	certBytes []byte,
+) []sealedSecretFile {
+	var files []sealedSecretFile
+
+	for filePath, hash := range hashes {
+		file := sealedSecretFile{path: filePath, hash: hash, plaintext: plaintexts[filePath]}
+
+		switch {
+		case unknownPaths[filePath]:
+			file.status = sealedSecretStatusUnknown
+
+		case file.plaintext == nil:
+			file.status = sealedSecretStatusManual
+
+		case hash == "":
+			file.status = sealedSecretStatusMissing
+
+		case hash == kubernetes.KubeaidHash(file.plaintext, certBytes):
+			file.status = sealedSecretStatusCurrent
+
+		default:
+			file.status = sealedSecretStatusStale
+		}
+
+		files = append(files, file)
+	}
+
+	for filePath, plaintext := range plaintexts {
+		if _, exists := hashes[filePath]; !exists {
+			files = append(files, sealedSecretFile{
+				path: filePath, status: sealedSecretStatusNew, plaintext: plaintext,
+			})
+		}
+	}
+
+	for filePath := range unknownPaths {
+		if _, exists := hashes[filePath]; !exists {
+			files = append(files, sealedSecretFile{path: filePath, status: sealedSecretStatusUnknown})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files
+}
+
+// renderSealedSecretFiles lays files out as a PATH / STATUS table.
+func renderSealedSecretFiles(files []sealedSecretFile) string {
+	var b strings.Builder
+
+	w := tabwriter.NewWriter(&b, tabwriterMinWidth, tabwriterTabWidth, tabwriterPadding, ' ', 0)
+
+	// Writes to a strings.Builder-backed tabwriter never fail.
+	_, _ = fmt.Fprintln(w, "PATH\tSTATUS")
+	for _, file := range files {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", file.path, file.status)
+	}
+
+	// Flush only fails when the underlying writer does, and a strings.Builder never does.
+	_ = w.Flush()
+
+	return b.String()
+}
+
+// renderSealedSecretFieldsDiff renders which fields of file got added (+), changed (~) or
+// removed (-), going from oldFields (when recorded) to newFields.
+func renderSealedSecretFieldsDiff(file sealedSecretFile,
+	oldFields map[string]string,
+	recorded bool,
+	newFields map[string]string,
+) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s)\n", file.path, file.status)
+
+	switch {
+	case file.status == sealedSecretStatusUnknown:
+		b.WriteString("  fields unknown : it's rendered from a value only the cluster or the cloud provider has\n")
+		return b.String()
+
+	case file.status == sealedSecretStatusNew:
+		oldFields = map[string]string{}
+
+	case !recorded:
+		b.WriteString("  previous fields unknown : it wasn't sealed by this kubeaid-cli installation\n")
+		for _, field := range sortedKeys(newFields) {
+			fmt.Fprintf(&b, "  ? %s\n", field)
+		}
+		return b.String()
+	}
+
+	changes := 0
+	for _, field := range sortedKeys(mergeFieldNames(oldFields, newFields)) {
+		oldHash, inOld := oldFields[field]
+		newHash, inNew := newFields[field]
+
+		switch {
+		case !inOld:
+			fmt.Fprintf(&b, "  + %s\n", field)
+
+		case !inNew:
+			fmt.Fprintf(&b, "  - %s\n", field)
+
+		case oldHash != newHash:
+			fmt.Fprintf(&b, "  ~ %s\n", field)
+
+		default:
+			continue
+		}
+		changes++
+	}
+
+	if changes == 0 {
+		b.WriteString("  no field changed : only the sealing certificate did\n")
+	}
+	return b.String()
+}
+
+func mergeFieldNames(a, b map[string]string) map[string]string {
+	merged := make(map[string]string, len(a)+len(b))
+	for field := range a {
+		merged[field] = ""
+	}
+	for field := range b {
+		merged[field] = ""
+	}
+	return merged
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
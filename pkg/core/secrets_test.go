@@ -0,0 +1,124 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+)
+
+func TestClassifySealedSecretFiles(t *testing.T) {
+	t.Parallel()
+
+	cert := []byte("cert")
+	plaintext := []byte("kind: Secret\n")
+
+	files := classifySealedSecretFiles(
+		map[string]string{
+			"sealed-secrets/argocd/current.yaml":  kubernetes.KubeaidHash(plaintext, cert),
+			"sealed-secrets/argocd/stale.yaml":    kubernetes.KubeaidHash(plaintext, []byte("rotated")),
+			"sealed-secrets/argocd/missing.yaml":  "",
+			"sealed-secrets/argocd/manual.yaml":   "",
+			"sealed-secrets/netbird/netbird.yaml": kubernetes.KubeaidHash(plaintext, cert),
+		},
+		map[string][]byte{
+			"sealed-secrets/argocd/current.yaml": plaintext,
+			"sealed-secrets/argocd/stale.yaml":   plaintext,
+			"sealed-secrets/argocd/missing.yaml": plaintext,
+			"sealed-secrets/argocd/new.yaml":     plaintext,
+		},
+		map[string]bool{
+			"sealed-secrets/netbird/netbird.yaml":                true,
+			"sealed-secrets/capi-cluster/cloud-credentials.yaml": true,
+		},
+		cert,
+	)
+
+	statuses := map[string]string{}
+	paths := []string{}
+	for _, file := range files {
+		statuses[file.path] = file.status
+		paths = append(paths, file.path)
+	}
+
+	assert.Equal(t, map[string]string{
+		"sealed-secrets/argocd/current.yaml": sealedSecretStatusCurrent,
+		"sealed-secrets/argocd/stale.yaml":   sealedSecretStatusStale,
+		"sealed-secrets/argocd/missing.yaml": sealedSecretStatusMissing,
+		"sealed-secrets/argocd/manual.yaml":  sealedSecretStatusManual,
+		"sealed-secrets/argocd/new.yaml":     sealedSecretStatusNew,
+
+		// Rendered from the cluster's / cloud provider's values : whether or not sealed yet.
+		"sealed-secrets/netbird/netbird.yaml":                sealedSecretStatusUnknown,
+		"sealed-secrets/capi-cluster/cloud-credentials.yaml": sealedSecretStatusUnknown,
+	}, statuses)
+	assert.IsIncreasing(t, paths)
+}
+
+func TestRenderSealedSecretFieldsDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+
+		file      sealedSecretFile
+		oldFields map[string]string
+		recorded  bool
+		newFields map[string]string
+
+		want string
+	}{
+		{
+			name:      "added, changed and removed fields",
+			file:      sealedSecretFile{path: "a.yaml", status: sealedSecretStatusStale},
+			oldFields: map[string]string{"stringData.password": "1", "stringData.url": "2", "stringData.user": "3"},
+			recorded:  true,
+			newFields: map[string]string{"stringData.password": "4", "stringData.url": "2", "stringData.token": "5"},
+			want: "a.yaml (stale)\n" +
+				"  ~ stringData.password\n" +
+				"  + stringData.token\n" +
+				"  - stringData.user\n",
+		},
+		{
+			name:      "only the certificate changed",
+			file:      sealedSecretFile{path: "a.yaml", status: sealedSecretStatusStale},
+			oldFields: map[string]string{"stringData.password": "1"},
+			recorded:  true,
+			newFields: map[string]string{"stringData.password": "1"},
+			want:      "a.yaml (stale)\n  no field changed : only the sealing certificate did\n",
+		},
+		{
+			name: "unknown file",
+			file: sealedSecretFile{path: "a.yaml", status: sealedSecretStatusUnknown},
+			want: "a.yaml (unknown)\n" +
+				"  fields unknown : it's rendered from a value only the cluster or the cloud provider has\n",
+		},
+		{
+			name:      "new file",
+			file:      sealedSecretFile{path: "a.yaml", status: sealedSecretStatusNew},
+			newFields: map[string]string{"stringData.password": "1"},
+			want:      "a.yaml (new)\n  + stringData.password\n",
+		},
+		{
+			name:      "unrecorded previous fields",
+			file:      sealedSecretFile{path: "a.yaml", status: sealedSecretStatusMissing},
+			newFields: map[string]string{"stringData.password": "1"},
+			want: "a.yaml (missing)\n" +
+				"  previous fields unknown : it wasn't sealed by this kubeaid-cli installation\n" +
+				"  ? stringData.password\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := renderSealedSecretFieldsDiff(tc.file, tc.oldFields, tc.recorded, tc.newFields)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
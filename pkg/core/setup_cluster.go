@@ -48,29 +48,7 @@ func SetupCluster(ctx context.Context, args SetupClusterArgs) {
 
 	bar := progress.FromCtx(ctx)
 
-	{
-		// Clone the KubeAid fork locally (if not already cloned).
-		// PinnedRef tells CloneRepo to skip the default-branch fetch
-		// dance on re-runs — kubeaid-cli only ever HardResetRepoToRef
-		// against this fixed version, never walks default-branch
-		// history. One narrow fetch instead of pulling every ref + tag
-		// per re-run.
-		kubeAidRepo := gitUtils.CloneRepo(
-			ctx,
-			config.ParsedGeneralConfig.Forks.KubeaidFork.URL,
-			args.GitAuthMethod,
-			gitUtils.CloneRepoOptions{
-				PinnedRef: config.ParsedGeneralConfig.Forks.KubeaidFork.Version,
-			},
-		)
-
-		// Hard reset to the KubeAid git ref (tag / branch) from the general config.
-		gitUtils.HardResetRepoToRef(
-			ctx,
-			kubeAidRepo,
-			config.ParsedGeneralConfig.Forks.KubeaidFork.Version,
-		)
-	}
+	cloneKubeAidFork(ctx, args.GitAuthMethod)
 
 	// Create required namespaces before syncing all the ArgoCD Apps.
 	// Otherwise, some syncing of ArgoCD Apps might fail.
@@ -264,7 +242,7 @@ func SetupCluster(ctx context.Context, args SetupClusterArgs) {
 			slog.String("namespace", constants.NamespaceNetBird))
 	}
 
-	// Same ordering for the DNS-01 issuer's Cloudflare token: its
+	// Same ordering for the DNS-01 issuer's provider credential: its
 	// SealedSecret targets the cert-manager namespace, which the
 	// cert-manager app only creates AFTER the secrets app has synced.
 	if acmeDNS01Enabled() {
@@ -744,3 +722,27 @@ func managementClusterRootChildResources() []*argoCDV1Alpha1.SyncOperationResour
 	}
 	return resources
 }
+
+// Clones the KubeAid fork locally (if not already cloned), and hard resets it to the KubeAid git
+// ref (tag / branch) from the general config.
+func cloneKubeAidFork(ctx context.Context, gitAuthMethod transport.AuthMethod) {
+	// PinnedRef tells CloneRepo to skip the default-branch fetch
+	// dance on re-runs — kubeaid-cli only ever HardResetRepoToRef
+	// against this fixed version, never walks default-branch
+	// history. One narrow fetch instead of pulling every ref + tag
+	// per re-run.
+	kubeAidRepo := gitUtils.CloneRepo(
+		ctx,
+		config.ParsedGeneralConfig.Forks.KubeaidFork.URL,
+		gitAuthMethod,
+		gitUtils.CloneRepoOptions{
+			PinnedRef: config.ParsedGeneralConfig.Forks.KubeaidFork.Version,
+		},
+	)
+
+	gitUtils.HardResetRepoToRef(
+		ctx,
+		kubeAidRepo,
+		config.ParsedGeneralConfig.Forks.KubeaidFork.Version,
+	)
+}
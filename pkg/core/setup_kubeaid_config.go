@@ -144,6 +144,14 @@ func SetupKubeAidConfig(ctx context.Context, args SetupKubeAidConfigArgs) {
 		// Create / update non Secret files.
 		createOrUpdateNonSecretFiles(ctx, templateValues, clusterDir, args.SkipMonitoringSetup)
 
+		// Catch Helm values the charts would reject now, rather than when ArgoCD fails syncing them
+		// minutes after the push.
+		err = validateRenderedHelmValues(ctx,
+			utils.GetKubeAidConfigDir(), clusterDir, getEmbeddedNonSecretTemplateNames(),
+		)
+		assert.AssertErrNil(ctx, err, "Rendered Helm values are invalid. Nothing has been pushed")
+		bar.Substep("Validated Helm values")
+
 		// Create / update Secret files.
 		createOrUpdateSealedSecretFiles(ctx, templateValues, clusterDir)
 	}
@@ -176,16 +184,16 @@ func SetupKubeAidConfig(ctx context.Context, args SetupKubeAidConfigArgs) {
 	}
 	bar.Substep("Pushed kubeaid-config branch")
 
-	if !args.SkipPRWorkflow {
-		/*
-			The user now needs to go ahead and create a PR from the new to the default branch. Then he
-			needs to merge that branch.
-
-			NOTE : We can't create the PR for the user, since PRs are not part of the core git lib.
-						 They are specific to the git platform the user is on.
-		*/
+	// Recorded for `cluster bootstrap --resume`, when bootstrapping.
+	clusterType := constants.ClusterTypeManagement
+	if settingUpMainCluster {
+		clusterType = constants.ClusterTypeMain
+	}
+	bootstrapCheckpointFromCtx(ctx).recordKubeaidConfigCommit(ctx, clusterType, commitHash.String())
 
-		// Wait until the user creates a PR and merges it to the default branch.
+	if !args.SkipPRWorkflow {
+		// Wait until the PR from the new to the default branch gets merged. With a forge API
+		// token configured, the PR gets opened for the user, otherwise the user needs to open it.
 		git.WaitUntilPRMerged(
 			ctx,
 			repo,
@@ -0,0 +1,706 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	argoCDV1Aplha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/charmbracelet/huh"
+	yqCmdLib "github.com/mikefarah/yq/v4/cmd"
+	caphV1Beta1 "github.com/syself/cluster-api-provider-hetzner/api/v1beta1"
+	k8sAPIErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capaV1Beta2 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	capzV1Beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	kubeadmControlPlaneV1Beta1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta1"
+	clusterAPIV1Beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterctlClientLib "sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/aws"
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/azure"
+	"github.com/Obmondo/kubeaid-cli/pkg/cloud/hetzner"
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/globals"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+)
+
+type SyncCAPIClusterArgs struct {
+	SkipPRWorkflow bool
+	// Yes skips the upfront confirmation gate, for unattended runs.
+	Yes bool
+}
+
+type (
+	// capiMachineSet is the sizing of a group of Cluster API Machines : the control plane, or a
+	// node-group.
+	capiMachineSet struct {
+		// NodeGroup is the node-group's name. Empty for the control plane.
+		NodeGroup string
+
+		// AWS instance type / Azure VM size / HCloud server type.
+		MachineType string
+		// Root volume (OS disk) size in GiB. Zero where it comes with the machine type (HCloud).
+		RootVolumeSize uint32
+
+		// Only for the control plane.
+		Replicas uint32
+
+		// Only for node-groups : the cluster-autoscaler bounds.
+		MinSize,
+		MaxSize uint
+	}
+
+	// capiMachineSetDrift is how a machine set running in the cluster differs from general.yaml.
+	capiMachineSetDrift struct {
+		desired capiMachineSet
+
+		// deltas holds one "field : current → desired" line per drifted field.
+		deltas []string
+
+		// rotate is set when the infrastructure MachineTemplate changes : being immutable, it
+		// gets recreated, and every Machine replaced.
+		rotate bool
+	}
+)
+
+func (m capiMachineSet) isControlPlane() bool {
+	return len(m.NodeGroup) == 0
+}
+
+// resourceName returns the name shared by the machine set's KubeadmControlPlane /
+// MachineDeployment and its infrastructure MachineTemplate, in the capi-cluster chart.
+func (m capiMachineSet) resourceName() string {
+	if m.isControlPlane() {
+		return fmt.Sprintf("%s-control-plane", config.ParsedGeneralConfig.Cluster.Name)
+	}
+	return fmt.Sprintf("%s-%s", config.ParsedGeneralConfig.Cluster.Name, m.NodeGroup)
+}
+
+func (m capiMachineSet) String() string {
+	if m.isControlPlane() {
+		return "control-plane"
+	}
+	return fmt.Sprintf("node-group %s", m.NodeGroup)
+}
+
+// SyncClusterUsingCAPI reconciles the machine sizing of a Cluster API provisioned cluster (AWS,
+// Azure or HCloud) with general.yaml, without a Kubernetes version change (that's
+// 'cluster upgrade's job) : control-plane replicas, node-group autoscaler bounds and, for the
+// control plane and each node-group, the instance type and root volume size.
+//
+// The live KubeadmControlPlane, MachineDeployments and infrastructure MachineTemplates get
+// diffed against general.yaml. The drifted fields are patched into values-capi-cluster.yaml
+// (PR workflow unless skipped) - patched rather than re-rendered, since the file also carries
+// values only known while bootstrapping (like Azure's UAMI client ID). Machine template changes
+// then need a rotation : the templates are immutable and named by the chart with fixed names,
+// so they get deleted and recreated, and every Machine of the machine set replaced, one machine
+// set at a time.
+//
+// The whole run is gated behind one upfront confirmation, showing the diff - --yes skips it,
+// for unattended runs. The rotation asks again separately : on decline (or without a TTY), the
+// template changes stay pending while replicas and autoscaler bounds still get synced.
+func SyncClusterUsingCAPI(ctx context.Context, args SyncCAPIClusterArgs) {
+	bar := progress.New("Syncing cluster with general.yaml")
+	defer bar.Finish()
+	ctx = progress.WithBar(ctx, bar)
+	bar.Describe("Syncing cluster with general.yaml")
+
+	clusterClient, clusterctlClient := newCapiClusterClients(ctx)
+
+	// (1) Pre-flight.
+
+	assertCAPIClusterAtTargetK8sVersion(ctx, clusterClient)
+
+	// (2) Diff the live machine sets against general.yaml.
+
+	drifts := []capiMachineSetDrift{}
+	for _, desired := range desiredCAPIMachineSets(globals.CloudProviderName) {
+		live, err := getLiveCAPIMachineSet(ctx, clusterClient, globals.CloudProviderName, desired)
+		if k8sAPIErrors.IsNotFound(err) {
+			slog.WarnContext(ctx,
+				"Skipping machine set missing from the cluster : 'cluster sync' resizes existing node-groups, it doesn't add them",
+				slog.String("machine-set", desired.String()),
+			)
+			continue
+		}
+		assert.AssertErrNil(ctx, err, "Failed reading the live machine set",
+			slog.String("machine-set", desired.String()),
+		)
+
+		if drift := diffCAPIMachineSet(desired, live); len(drift.deltas) > 0 {
+			drifts = append(drifts, drift)
+		}
+	}
+
+	if len(drifts) == 0 {
+		slog.InfoContext(ctx, "Cluster is already in sync with general.yaml 🎉")
+		bar.Substep("Cluster already in sync with general.yaml 🎉")
+		return
+	}
+	for _, drift := range drifts {
+		for _, delta := range drift.deltas {
+			bar.Substep(fmt.Sprintf("%s : %s", drift.desired, delta))
+		}
+	}
+
+	// (3) Ask before touching anything - a casually issued 'cluster sync' must stay harmless.
+
+	if !args.Yes {
+		confirmCAPIClusterSync(ctx, bar, drifts)
+	}
+
+	// (4) Patch the drifted fields into values-capi-cluster.yaml and push it to the KubeAid Config
+	//     repository.
+
+	valuesFileChanged := pushCapiClusterValuesFileChanges(ctx, args.SkipPRWorkflow,
+		func(capiClusterValuesFilePath string) {
+			for _, drift := range drifts {
+				for _, expression := range capiValuesFileExpressions(globals.CloudProviderName, drift) {
+					yqCmd := yqCmdLib.New()
+					yqCmd.SetArgs([]string{"eval", expression, capiClusterValuesFilePath, "--inplace"})
+					err := yqCmd.ExecuteContext(ctx)
+					assert.AssertErrNil(ctx, err, "Failed updating values-capi-cluster.yaml",
+						slog.String("expression", expression),
+					)
+				}
+			}
+
+			// Carry the source of truth along with its effect.
+			createOrUpdateGeneralConfigFile(ctx, getTemplateValues(ctx), utils.GetClusterDir())
+		},
+	)
+	if !valuesFileChanged {
+		slog.InfoContext(ctx,
+			"values-capi-cluster.yaml is already up to date - reconciling the cluster anyway : a previous sync may have died before reaching it",
+		)
+	}
+	bar.Substep("values-capi-cluster.yaml updated in kubeaid-config")
+
+	// (5) Reconcile the machine sets, one at a time.
+
+	bar.Describe("Reconciling machine sets")
+
+	{
+		// Port-forward ArgoCD and create ArgoCD client.
+		argoCDClient, argoCDErr := kubernetes.NewArgoCDClient(ctx, clusterClient)
+		assert.AssertErrNil(ctx, argoCDErr, "Failed creating ArgoCD client")
+
+		// Create ArgoCD application client.
+		globals.ArgoCDApplicationClientCloser, globals.ArgoCDApplicationClient = argoCDClient.NewApplicationClientOrDie()
+		defer globals.ArgoCDApplicationClientCloser.Close()
+	}
+
+	rotate := false
+	if toRotate := machineSetsToRotate(drifts); len(toRotate) > 0 {
+		rotate = confirmMachineRotation(ctx, bar, toRotate)
+		if !rotate {
+			slog.InfoContext(ctx,
+				"Machine rotation declined - the instance type / root volume changes stay pending until a consented rerun of 'kubeaid-cli cluster sync'",
+			)
+			bar.Substep("Machine template changes left pending (declined)")
+		}
+	}
+
+	for _, drift := range drifts {
+		syncCAPIMachineSet(ctx, clusterClient, clusterctlClient, drift, rotate && drift.rotate)
+	}
+
+	slog.InfoContext(ctx, "Cluster is in sync with general.yaml 🎉")
+	bar.Substep("Cluster in sync with general.yaml 🎉")
+}
+
+// assertCAPIClusterAtTargetK8sVersion refuses to sync a cluster whose KubeadmControlPlane runs
+// a Kubernetes version other than general.yaml's cluster.k8sVersion : that's a pending upgrade.
+func assertCAPIClusterAtTargetK8sVersion(ctx context.Context, clusterClient client.Client) {
+	targetVersion := config.ParsedGeneralConfig.Cluster.K8sVersion
+
+	kubeadmControlPlane := &kubeadmControlPlaneV1Beta1.KubeadmControlPlane{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      capiMachineSet{}.resourceName(),
+			Namespace: kubernetes.GetCapiClusterNamespace(),
+		},
+	}
+	err := kubernetes.GetKubernetesResource(ctx, clusterClient, kubeadmControlPlane)
+	assert.AssertErrNil(ctx, err, "Failed getting the KubeadmControlPlane")
+
+	currentVersion := kubeadmControlPlane.Spec.Version
+	assert.Assert(ctx, currentVersion == targetVersion, fmt.Sprintf(
+		"The cluster runs Kubernetes %s while general.yaml declares %s - run 'kubeaid-cli cluster upgrade' first. 'cluster sync' only reconciles non-version changes",
+		currentVersion, targetVersion,
+	))
+
+	progress.FromCtx(ctx).Substep(fmt.Sprintf("Cluster is at Kubernetes %s", currentVersion))
+}
+
+// desiredCAPIMachineSets returns the control plane and node-group sizing general.yaml declares,
+// for the given cloud provider. Hetzner bare-metal machine sets are left out : their sizing is
+// the hardware's.
+func desiredCAPIMachineSets(cloudProviderName string) []capiMachineSet {
+	machineSets := []capiMachineSet{}
+
+	switch cloudProviderName {
+	case constants.CloudProviderAWS:
+		awsConfig := config.ParsedGeneralConfig.Cloud.AWS
+
+		machineSets = append(machineSets, capiMachineSet{
+			MachineType: awsConfig.ControlPlane.InstanceType,
+			Replicas:    awsConfig.ControlPlane.Replicas,
+		})
+		for _, nodeGroup := range awsConfig.NodeGroups {
+			machineSets = append(machineSets, capiMachineSet{
+				NodeGroup:      nodeGroup.Name,
+				MachineType:    nodeGroup.InstanceType,
+				RootVolumeSize: nodeGroup.RootVolumeSize,
+				MinSize:        nodeGroup.MinSize,
+				MaxSize:        nodeGroup.Maxsize,
+			})
+		}
+
+	case constants.CloudProviderAzure:
+		azureConfig := config.ParsedGeneralConfig.Cloud.Azure
+
+		machineSets = append(machineSets, capiMachineSet{
+			MachineType:    azureConfig.ControlPlane.VMSize,
+			RootVolumeSize: azureConfig.ControlPlane.DiskSizeGB,
+			Replicas:       azureConfig.ControlPlane.Replicas,
+		})
+		for _, nodeGroup := range azureConfig.NodeGroups {
+			machineSets = append(machineSets, capiMachineSet{
+				NodeGroup:      nodeGroup.Name,
+				MachineType:    nodeGroup.VMSize,
+				RootVolumeSize: nodeGroup.DiskSizeGB,
+				MinSize:        nodeGroup.MinSize,
+				MaxSize:        nodeGroup.Maxsize,
+			})
+		}
+
+	case constants.CloudProviderHetzner:
+		hetznerConfig := config.ParsedGeneralConfig.Cloud.Hetzner
+
+		if hcloudControlPlane := hetznerConfig.ControlPlane.HCloud; hcloudControlPlane != nil {
+			machineSets = append(machineSets, capiMachineSet{
+				MachineType: hcloudControlPlane.MachineType,
+				Replicas:    uint32(hcloudControlPlane.Replicas), //nolint:gosec // G115: a handful of control-plane nodes.
+			})
+		}
+		for _, nodeGroup := range hetznerConfig.NodeGroups.HCloud {
+			machineSets = append(machineSets, capiMachineSet{
+				NodeGroup:   nodeGroup.Name,
+				MachineType: nodeGroup.MachineType,
+				MinSize:     nodeGroup.MinSize,
+				MaxSize:     nodeGroup.Maxsize,
+			})
+		}
+	}
+
+	return machineSets
+}
+
+// getLiveCAPIMachineSet reads the sizing the desired machine set currently runs with, from its
+// KubeadmControlPlane / MachineDeployment and infrastructure MachineTemplate. Returns a NotFound
+// error when the machine set doesn't exist in the cluster.
+func getLiveCAPIMachineSet(ctx context.Context,
+	clusterClient client.Client,
+	cloudProviderName string,
+	desired capiMachineSet,
+) (capiMachineSet, error) {
+	live := capiMachineSet{NodeGroup: desired.NodeGroup}
+
+	name := desired.resourceName()
+	objectMeta := metaV1.ObjectMeta{
+		Name:      name,
+		Namespace: kubernetes.GetCapiClusterNamespace(),
+	}
+
+	if desired.isControlPlane() {
+		kubeadmControlPlane := &kubeadmControlPlaneV1Beta1.KubeadmControlPlane{ObjectMeta: objectMeta}
+		if err := kubernetes.GetKubernetesResource(ctx, clusterClient, kubeadmControlPlane); err != nil {
+			return live, fmt.Errorf("getting KubeadmControlPlane %s: %w", name, err)
+		}
+
+		if replicas := kubeadmControlPlane.Spec.Replicas; replicas != nil {
+			live.Replicas = uint32(*replicas) //nolint:gosec // G115: replica counts aren't negative.
+		}
+	} else {
+		machineDeployment := &clusterAPIV1Beta1.MachineDeployment{ObjectMeta: objectMeta}
+		if err := kubernetes.GetKubernetesResource(ctx, clusterClient, machineDeployment); err != nil {
+			return live, fmt.Errorf("getting MachineDeployment %s: %w", name, err)
+		}
+
+		live.MinSize = parseAutoscalerBound(machineDeployment.Annotations[clusterAPIV1Beta1.AutoscalerMinSizeAnnotation])
+		live.MaxSize = parseAutoscalerBound(machineDeployment.Annotations[clusterAPIV1Beta1.AutoscalerMaxSizeAnnotation])
+	}
+
+	switch cloudProviderName {
+	case constants.CloudProviderAWS:
+		awsMachineTemplate := &capaV1Beta2.AWSMachineTemplate{ObjectMeta: objectMeta}
+		if err := kubernetes.GetKubernetesResource(ctx, clusterClient, awsMachineTemplate); err != nil {
+			return live, fmt.Errorf("getting AWSMachineTemplate %s: %w", name, err)
+		}
+
+		spec := awsMachineTemplate.Spec.Template.Spec
+		live.MachineType = spec.InstanceType
+		if spec.RootVolume != nil {
+			live.RootVolumeSize = uint32(spec.RootVolume.Size) //nolint:gosec // G115: volume sizes in GiB.
+		}
+
+	case constants.CloudProviderAzure:
+		azureMachineTemplate := &capzV1Beta1.AzureMachineTemplate{ObjectMeta: objectMeta}
+		if err := kubernetes.GetKubernetesResource(ctx, clusterClient, azureMachineTemplate); err != nil {
+			return live, fmt.Errorf("getting AzureMachineTemplate %s: %w", name, err)
+		}
+
+		spec := azureMachineTemplate.Spec.Template.Spec
+		live.MachineType = spec.VMSize
+		if spec.OSDisk.DiskSizeGB != nil {
+			live.RootVolumeSize = uint32(*spec.OSDisk.DiskSizeGB) //nolint:gosec // G115: disk sizes in GiB.
+		}
+
+	case constants.CloudProviderHetzner:
+		hcloudMachineTemplate := &caphV1Beta1.HCloudMachineTemplate{ObjectMeta: objectMeta}
+		if err := kubernetes.GetKubernetesResource(ctx, clusterClient, hcloudMachineTemplate); err != nil {
+			return live, fmt.Errorf("getting HCloudMachineTemplate %s: %w", name, err)
+		}
+
+		live.MachineType = string(hcloudMachineTemplate.Spec.Template.Spec.Type)
+
+	default:
+		return live, fmt.Errorf("unsupported cloud provider %s", cloudProviderName)
+	}
+
+	return live, nil
+}
+
+// parseAutoscalerBound parses a cluster-autoscaler min / max size annotation. Missing or
+// malformed annotations read as 0, which shows up as drift.
+func parseAutoscalerBound(annotation string) uint {
+	bound, err := strconv.ParseUint(annotation, 10, 0)
+	if err != nil {
+		return 0
+	}
+	return uint(bound)
+}
+
+// diffCAPIMachineSet compares a machine set's live sizing with the desired one. A desired zero
+// root volume size means general.yaml has no say on it, and isn't compared.
+func diffCAPIMachineSet(desired, live capiMachineSet) capiMachineSetDrift {
+	drift := capiMachineSetDrift{desired: desired, deltas: []string{}}
+
+	addDelta := func(field string, current, target any) {
+		drift.deltas = append(drift.deltas, fmt.Sprintf("%s : %v → %v", field, current, target))
+	}
+
+	if desired.MachineType != live.MachineType {
+		addDelta("machine type", live.MachineType, desired.MachineType)
+		drift.rotate = true
+	}
+	if (desired.RootVolumeSize > 0) && (desired.RootVolumeSize != live.RootVolumeSize) {
+		addDelta("root volume size (GiB)", live.RootVolumeSize, desired.RootVolumeSize)
+		drift.rotate = true
+	}
+
+	if desired.isControlPlane() {
+		if desired.Replicas != live.Replicas {
+			addDelta("replicas", live.Replicas, desired.Replicas)
+		}
+		return drift
+	}
+
+	if desired.MinSize != live.MinSize {
+		addDelta("min size", live.MinSize, desired.MinSize)
+	}
+	if desired.MaxSize != live.MaxSize {
+		addDelta("max size", live.MaxSize, desired.MaxSize)
+	}
+	return drift
+}
+
+// capiValuesFileExpressions returns the yq expressions patching a drifted machine set's sizing
+// into values-capi-cluster.yaml, following the layout values-capi-cluster.yaml.tmpl renders.
+func capiValuesFileExpressions(cloudProviderName string, drift capiMachineSetDrift) []string {
+	desired := drift.desired
+
+	var (
+		section,
+		machineTypeKey,
+		rootVolumeSizeKey string
+	)
+	switch cloudProviderName {
+	case constants.CloudProviderAWS:
+		section, machineTypeKey, rootVolumeSizeKey = "aws", "instanceType", "rootVolumeSize"
+
+	case constants.CloudProviderAzure:
+		section, machineTypeKey, rootVolumeSizeKey = "azure", "vmSize", "diskSizeGB"
+
+	case constants.CloudProviderHetzner:
+		section, machineTypeKey = "hetzner", "machineType"
+
+	default:
+		return nil
+	}
+
+	var path string
+	switch {
+	case desired.isControlPlane() && (cloudProviderName == constants.CloudProviderHetzner):
+		path = ".hetzner.controlPlane.hcloud"
+
+	case desired.isControlPlane():
+		path = fmt.Sprintf(".%s.controlPlane", section)
+
+	case cloudProviderName == constants.CloudProviderHetzner:
+		path = fmt.Sprintf(`(.hetzner.nodeGroups.hcloud[] | select(.name == "%s"))`, desired.NodeGroup)
+
+	default:
+		path = fmt.Sprintf(`(.%s.nodeGroups[] | select(.name == "%s"))`, section, desired.NodeGroup)
+	}
+
+	expressions := []string{
+		fmt.Sprintf(`%s.%s = "%s"`, path, machineTypeKey, desired.MachineType),
+	}
+	if (len(rootVolumeSizeKey) > 0) && (desired.RootVolumeSize > 0) {
+		expressions = append(expressions,
+			fmt.Sprintf("%s.%s = %d", path, rootVolumeSizeKey, desired.RootVolumeSize),
+		)
+	}
+
+	if desired.isControlPlane() {
+		expressions = append(expressions, fmt.Sprintf("%s.replicas = %d", path, desired.Replicas))
+	} else {
+		expressions = append(expressions,
+			fmt.Sprintf("%s.minSize = %d", path, desired.MinSize),
+			fmt.Sprintf("%s.maxSize = %d", path, desired.MaxSize),
+		)
+	}
+
+	return expressions
+}
+
+// capiMachineTemplateUpdates translates the desired machine set sizing into the cloud provider
+// specific MachineTemplate updates, for CloudProvider.UpdateMachineTemplate.
+func capiMachineTemplateUpdates(cloudProviderName string, desired capiMachineSet) (any, error) {
+	switch cloudProviderName {
+	case constants.CloudProviderAWS:
+		return aws.AWSMachineTemplateUpdates{
+			InstanceType:   desired.MachineType,
+			RootVolumeSize: desired.RootVolumeSize,
+		}, nil
+
+	case constants.CloudProviderAzure:
+		return azure.AzureMachineTemplateUpdates{
+			VMSize:     desired.MachineType,
+			DiskSizeGB: desired.RootVolumeSize,
+		}, nil
+
+	case constants.CloudProviderHetzner:
+		return hetzner.HetznerMachineTemplateUpdates{
+			HCloudMachineTemplateUpdates: hetzner.HCloudMachineTemplateUpdates{
+				MachineType: desired.MachineType,
+			},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported cloud provider %s", cloudProviderName)
+	}
+}
+
+// syncCAPIMachineSet reconciles one drifted machine set : when rotating, recreates its
+// infrastructure MachineTemplate. Then syncs its KubeadmControlPlane / MachineDeployment in the
+// capi-cluster ArgoCD App (picking up replicas / autoscaler bounds), and when rotating, rolls
+// its Machines and waits until the rollout settles.
+func syncCAPIMachineSet(ctx context.Context,
+	clusterClient client.Client,
+	clusterctlClient clusterctlClientLib.Client,
+	drift capiMachineSetDrift,
+	rotate bool,
+) {
+	bar := progress.FromCtx(ctx)
+
+	desired := drift.desired
+	name := desired.resourceName()
+
+	ctx = logger.AppendSlogAttributesToCtx(ctx, []slog.Attr{
+		slog.String("machine-set", desired.String()),
+	})
+
+	if rotate {
+		updates, err := capiMachineTemplateUpdates(globals.CloudProviderName, desired)
+		assert.AssertErrNil(ctx, err, "Failed constructing MachineTemplate updates")
+
+		// The MachineTemplate is immutable : delete and recreate it.
+		// REFER : https://cluster-api.sigs.k8s.io/tasks/updating-machine-templates.
+		err = globals.CloudProvider.UpdateMachineTemplate(ctx, clusterClient, name, updates)
+		assert.AssertErrNil(ctx, err, "Failed updating MachineTemplate")
+		bar.Substep(fmt.Sprintf("Recreated the %s MachineTemplate", desired))
+	}
+
+	syncResource := &argoCDV1Aplha1.SyncOperationResource{
+		Group: "cluster.x-k8s.io",
+		Kind:  "MachineDeployment",
+		Name:  name,
+	}
+	rolloutResource := fmt.Sprintf("machinedeployment/%s", name)
+	if desired.isControlPlane() {
+		syncResource = &argoCDV1Aplha1.SyncOperationResource{
+			Group: "controlplane.cluster.x-k8s.io",
+			Kind:  "KubeadmControlPlane",
+			Name:  name,
+		}
+		rolloutResource = fmt.Sprintf("kubeadmcontrolplane/%s", name)
+	}
+
+	// NOTE : When calculating diff, we ignore the .spec.replicas field for the MachineDeployment
+	//        resource. So, syncing it doesn't touch the node-group's current replica count.
+	err := kubernetes.SyncArgoCDApp(ctx, constants.ArgoCDAppCapiCluster,
+		[]*argoCDV1Aplha1.SyncOperationResource{syncResource},
+	)
+	assert.AssertErrNil(ctx, err, "Failed syncing capi-cluster ArgoCD app")
+
+	if !rotate {
+		bar.Substep(fmt.Sprintf("Synced %s", desired))
+		return
+	}
+
+	err = clusterctlClient.RolloutRestart(ctx, clusterctlClientLib.RolloutRestartOptions{
+		Namespace: kubernetes.GetCapiClusterNamespace(),
+		Resources: []string{rolloutResource},
+	})
+	assert.AssertErrNil(ctx, err, "Failed triggering rollout")
+
+	if desired.isControlPlane() {
+		err = kubernetes.WaitForControlPlaneRolloutComplete(ctx, clusterClient)
+	} else {
+		err = kubernetes.WaitForMachineDeploymentRolloutComplete(ctx, clusterClient, name)
+	}
+	assert.AssertErrNil(ctx, err, "Failed waiting for the rollout to settle")
+	bar.Substep(fmt.Sprintf("Rolled out %s", desired))
+}
+
+// machineSetsToRotate returns the drifts whose MachineTemplate changes.
+func machineSetsToRotate(drifts []capiMachineSetDrift) []capiMachineSetDrift {
+	toRotate := []capiMachineSetDrift{}
+	for _, drift := range drifts {
+		if drift.rotate {
+			toRotate = append(toRotate, drift)
+		}
+	}
+	return toRotate
+}
+
+// formatCAPIMachineSetDrifts renders drifts as an indented, per machine set list of deltas.
+func formatCAPIMachineSetDrifts(drifts []capiMachineSetDrift) string {
+	lines := []string{}
+	for _, drift := range drifts {
+		lines = append(lines, fmt.Sprintf("  %s", drift.desired))
+		for _, delta := range drift.deltas {
+			lines = append(lines, fmt.Sprintf("    %s", delta))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// confirmCAPIClusterSync shows the drift and asks for an explicit yes, before anything is pushed
+// or applied. Declining aborts; so does having no TTY to ask on - unattended runs opt in with
+// --yes (or syncCluster in the answers file).
+func confirmCAPIClusterSync(ctx context.Context, bar *progress.Bar, drifts []capiMachineSetDrift) {
+	clusterName := config.ParsedGeneralConfig.Cluster.Name
+
+	proceed, answered, err := answers.Parsed.Confirm(answers.DecisionSyncCluster)
+	assert.AssertErrNil(ctx, err, "Can't decide whether to sync the cluster. Pass --yes to sync unattended")
+	if answered {
+		assert.Assert(ctx, proceed, "Sync declined by the answers file - nothing was pushed or applied")
+		return
+	}
+
+	description := fmt.Sprintf(
+		"Cluster : %s\n\n"+
+			"These machine sets differ from general.yaml :\n\n%s\n\n"+
+			"A sync will :\n"+
+			"  1. Patch them into values-capi-cluster.yaml and push it to KubeAid Config\n"+
+			"  2. Sync replicas and autoscaler bounds via the capi-cluster ArgoCD App\n\n"+
+			"Machine type / root volume changes replace the machines, so they keep their own\n"+
+			"prompt : never rolled out silently.",
+		clusterName, formatCAPIMachineSetDrifts(drifts),
+	)
+
+	bar.Pause()
+	defer bar.Resume()
+
+	err = huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title("Sync cluster with general.yaml").
+				Description(description),
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Sync cluster %s now?", clusterName)).
+				Affirmative("Yes, sync it").
+				Negative("No, abort").
+				Value(&proceed),
+		),
+	).Run()
+	assert.AssertErrNil(ctx, err,
+		"Couldn't ask for sync confirmation (no TTY?). Pass --yes to run 'cluster sync' unattended",
+	)
+
+	assert.Assert(ctx, proceed,
+		"Sync declined - nothing was pushed or applied. "+
+			"Rerun when ready, or pass --yes to skip this prompt",
+	)
+}
+
+// confirmMachineRotation asks the operator for consent to replace the Machines of the machine
+// sets whose MachineTemplate changes - unless the answers file already decided. Returns false
+// when declined, and when the prompt itself can't run (no TTY). Panics when running
+// non-interactively without a decision.
+func confirmMachineRotation(ctx context.Context,
+	bar *progress.Bar,
+	toRotate []capiMachineSetDrift,
+) bool {
+	proceed, answered, err := answers.Parsed.Confirm(answers.DecisionRotateMachines)
+	assert.AssertErrNil(ctx, err, "Can't decide whether to rotate the drifted machines")
+	if answered {
+		return proceed
+	}
+
+	description := fmt.Sprintf(
+		"These machine sets need new machines :\n\n%s\n\n"+
+			"Their MachineTemplates get recreated and Cluster API replaces every machine,\n"+
+			"one machine set at a time - each new machine joins before an old one is drained\n"+
+			"and deleted.",
+		formatCAPIMachineSetDrifts(toRotate),
+	)
+
+	proceed = false
+
+	bar.Pause()
+	defer bar.Resume()
+
+	if err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title("Machine templates differ from general.yaml").
+				Description(description),
+			huh.NewConfirm().
+				Title("Rotate the machines now?").
+				Affirmative("Yes, replace them").
+				Negative("No, leave them for later").
+				Value(&proceed),
+		),
+	).Run(); err != nil {
+		return false
+	}
+
+	return proceed
+}
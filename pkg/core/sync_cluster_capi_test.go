@@ -0,0 +1,171 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+func TestDiffCAPIMachineSet(t *testing.T) {
+	testCases := []struct {
+		name           string
+		desired        capiMachineSet
+		live           capiMachineSet
+		expectedDeltas []string
+		expectedRotate bool
+	}{
+		{
+			name:           "control plane in sync",
+			desired:        capiMachineSet{MachineType: "t3.large", Replicas: 3},
+			live:           capiMachineSet{MachineType: "t3.large", Replicas: 3},
+			expectedDeltas: []string{},
+		},
+		{
+			name:           "control plane scaled out : no rotation",
+			desired:        capiMachineSet{MachineType: "t3.large", Replicas: 3},
+			live:           capiMachineSet{MachineType: "t3.large", Replicas: 1},
+			expectedDeltas: []string{"replicas : 1 → 3"},
+		},
+		{
+			name:           "node-group instance type changed : rotation",
+			desired:        capiMachineSet{NodeGroup: "workers", MachineType: "t3.xlarge", RootVolumeSize: 50, MinSize: 1, MaxSize: 3},
+			live:           capiMachineSet{NodeGroup: "workers", MachineType: "t3.large", RootVolumeSize: 50, MinSize: 1, MaxSize: 3},
+			expectedDeltas: []string{"machine type : t3.large → t3.xlarge"},
+			expectedRotate: true,
+		},
+		{
+			name:    "node-group root volume and autoscaler bounds changed",
+			desired: capiMachineSet{NodeGroup: "workers", MachineType: "Standard_B2s", RootVolumeSize: 100, MinSize: 2, MaxSize: 5},
+			live:    capiMachineSet{NodeGroup: "workers", MachineType: "Standard_B2s", RootVolumeSize: 50, MinSize: 1, MaxSize: 5},
+			expectedDeltas: []string{
+				"root volume size (GiB) : 50 → 100",
+				"min size : 1 → 2",
+			},
+			expectedRotate: true,
+		},
+		{
+			// HCloud root volumes come with the server type : general.yaml's rootVolumeSize has
+			// no say on the MachineTemplate.
+			name:           "unset desired root volume size isn't compared",
+			desired:        capiMachineSet{NodeGroup: "workers", MachineType: "cpx31", MinSize: 1, MaxSize: 3},
+			live:           capiMachineSet{NodeGroup: "workers", MachineType: "cpx31", RootVolumeSize: 160, MinSize: 1, MaxSize: 3},
+			expectedDeltas: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			drift := diffCAPIMachineSet(testCase.desired, testCase.live)
+			assert.Equal(t, testCase.expectedDeltas, drift.deltas)
+			assert.Equal(t, testCase.expectedRotate, drift.rotate)
+		})
+	}
+}
+
+func TestCAPIValuesFileExpressions(t *testing.T) {
+	testCases := []struct {
+		name                string
+		cloudProviderName   string
+		desired             capiMachineSet
+		expectedExpressions []string
+	}{
+		{
+			name:              "AWS control plane",
+			cloudProviderName: constants.CloudProviderAWS,
+			desired:           capiMachineSet{MachineType: "t3.xlarge", Replicas: 3},
+			expectedExpressions: []string{
+				`.aws.controlPlane.instanceType = "t3.xlarge"`,
+				".aws.controlPlane.replicas = 3",
+			},
+		},
+		{
+			name:              "Azure node-group",
+			cloudProviderName: constants.CloudProviderAzure,
+			desired:           capiMachineSet{NodeGroup: "workers", MachineType: "Standard_D4s_v3", RootVolumeSize: 128, MinSize: 1, MaxSize: 4},
+			expectedExpressions: []string{
+				`(.azure.nodeGroups[] | select(.name == "workers")).vmSize = "Standard_D4s_v3"`,
+				`(.azure.nodeGroups[] | select(.name == "workers")).diskSizeGB = 128`,
+				`(.azure.nodeGroups[] | select(.name == "workers")).minSize = 1`,
+				`(.azure.nodeGroups[] | select(.name == "workers")).maxSize = 4`,
+			},
+		},
+		{
+			name:              "HCloud control plane",
+			cloudProviderName: constants.CloudProviderHetzner,
+			desired:           capiMachineSet{MachineType: "cpx41", Replicas: 3},
+			expectedExpressions: []string{
+				`.hetzner.controlPlane.hcloud.machineType = "cpx41"`,
+				".hetzner.controlPlane.hcloud.replicas = 3",
+			},
+		},
+		{
+			name:              "HCloud node-group : no root volume size",
+			cloudProviderName: constants.CloudProviderHetzner,
+			desired:           capiMachineSet{NodeGroup: "workers", MachineType: "cpx31", RootVolumeSize: 80, MinSize: 1, MaxSize: 3},
+			expectedExpressions: []string{
+				`(.hetzner.nodeGroups.hcloud[] | select(.name == "workers")).machineType = "cpx31"`,
+				`(.hetzner.nodeGroups.hcloud[] | select(.name == "workers")).minSize = 1`,
+				`(.hetzner.nodeGroups.hcloud[] | select(.name == "workers")).maxSize = 3`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			expressions := capiValuesFileExpressions(testCase.cloudProviderName,
+				capiMachineSetDrift{desired: testCase.desired},
+			)
+			assert.Equal(t, testCase.expectedExpressions, expressions)
+		})
+	}
+}
+
+// Mutates config.ParsedGeneralConfig — sequential only.
+func TestDesiredCAPIMachineSets(t *testing.T) {
+	saved := config.ParsedGeneralConfig
+	t.Cleanup(func() { config.ParsedGeneralConfig = saved })
+
+	config.ParsedGeneralConfig = &config.GeneralConfig{
+		Cluster: config.ClusterConfig{Name: "demo"},
+		Cloud: config.CloudConfig{
+			Hetzner: &config.HetznerConfig{
+				Mode: constants.HetznerModeHybrid,
+				ControlPlane: config.HetznerControlPlane{
+					HCloud: &config.HCloudControlPlane{MachineType: "cpx31", Replicas: 3},
+				},
+				NodeGroups: config.HetznerNodeGroups{
+					HCloud: []config.HCloudAutoScalableNodeGroup{{
+						AutoScalableNodeGroup: config.AutoScalableNodeGroup{
+							NodeGroup: config.NodeGroup{Name: "workers"},
+							MinSize:   1,
+							Maxsize:   3,
+						},
+						MachineType:    "cpx41",
+						RootVolumeSize: 80,
+					}},
+					BareMetal: []*config.HetznerBareMetalNodeGroup{{
+						NodeGroup: config.NodeGroup{Name: "storage"},
+					}},
+				},
+			},
+		},
+	}
+
+	machineSets := desiredCAPIMachineSets(constants.CloudProviderHetzner)
+
+	// The bare-metal node-group is left out, and the HCloud root volume comes with the server
+	// type.
+	assert.Equal(t, []capiMachineSet{
+		{MachineType: "cpx31", Replicas: 3},
+		{NodeGroup: "workers", MachineType: "cpx41", MinSize: 1, MaxSize: 3},
+	}, machineSets)
+
+	assert.Equal(t, "demo-control-plane", machineSets[0].resourceName())
+	assert.Equal(t, "demo-workers", machineSets[1].resourceName())
+}
@@ -17,6 +17,7 @@ import (
 	kubeonessh "k8c.io/kubeone/pkg/ssh"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/git"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
@@ -124,7 +125,7 @@ func SyncClusterUsingKubeOne(ctx context.Context, args SyncKubeOneClusterArgs) {
 			))
 		}
 
-		if confirmKubeletReconcile(bar, driftedHosts) {
+		if confirmKubeletReconcile(ctx, bar, driftedHosts) {
 			forceUpgrade = true
 			removedPDBs, stopPDBGuard = neutralizeSingleNodePDBs(ctx)
 			bar.Substep("Reconciling kubelet flags : KubeOne rolls the nodes one at a time")
@@ -273,10 +274,17 @@ func parseKubeadmFlagsEnv(content string) map[string]string {
 
 // confirmClusterSync shows what the sync is about to do and asks for an explicit yes, before
 // anything is pushed or applied. Declining aborts; so does having no TTY to ask on -
-// unattended runs opt in with --yes.
+// unattended runs opt in with --yes (or syncCluster in the answers file).
 func confirmClusterSync(ctx context.Context, bar *progress.Bar, currentVersion string) {
 	clusterName := config.ParsedGeneralConfig.Cluster.Name
 
+	proceed, answered, err := answers.Parsed.Confirm(answers.DecisionSyncCluster)
+	assert.AssertErrNil(ctx, err, "Can't decide whether to sync the cluster. Pass --yes to sync unattended")
+	if answered {
+		assert.Assert(ctx, proceed, "Sync declined by the answers file - nothing was pushed or applied")
+		return
+	}
+
 	description := fmt.Sprintf(
 		"Cluster : %s (Kubernetes %s)\n\n"+
 			"A sync will :\n"+
@@ -288,12 +296,10 @@ func confirmClusterSync(ctx context.Context, bar *progress.Bar, currentVersion s
 		clusterName, currentVersion,
 	)
 
-	proceed := false
-
 	bar.Pause()
 	defer bar.Resume()
 
-	err := huh.NewForm(
+	err = huh.NewForm(
 		huh.NewGroup(
 			huh.NewNote().
 				Title("Sync cluster with general.yaml").
@@ -317,9 +323,19 @@ func confirmClusterSync(ctx context.Context, bar *progress.Bar, currentVersion s
 
 // confirmKubeletReconcile asks the operator for consent to reconcile the drifted kubelet
 // flags - KubeOne's per-node upgrade procedure cordons, drains and restarts one node at a
-// time. Returns false when declined, and when the prompt itself can't run (no TTY) : the
-// sync then proceeds with a plain, non-disruptive apply.
-func confirmKubeletReconcile(bar *progress.Bar, driftedHosts []kubeletFlagDrift) bool {
+// time - unless the answers file already decided. Returns false when declined, and when the
+// prompt itself can't run (no TTY) : the sync then proceeds with a plain, non-disruptive
+// apply. Panics when running non-interactively without a decision.
+func confirmKubeletReconcile(ctx context.Context,
+	bar *progress.Bar,
+	driftedHosts []kubeletFlagDrift,
+) bool {
+	proceed, answered, err := answers.Parsed.Confirm(answers.DecisionReconcileKubeletFlags)
+	assert.AssertErrNil(ctx, err, "Can't decide whether to reconcile the drifted kubelet flags")
+	if answered {
+		return proceed
+	}
+
 	hostLines := make([]string, 0, len(driftedHosts))
 	for _, driftedHost := range driftedHosts {
 		hostLines = append(hostLines, fmt.Sprintf("  %s", driftedHost.hostAddress))
@@ -335,7 +351,7 @@ func confirmKubeletReconcile(bar *progress.Bar, driftedHosts []kubeletFlagDrift)
 		strings.Join(hostLines, "\n"),
 	)
 
-	proceed := false
+	proceed = false
 
 	bar.Pause()
 	defer bar.Resume()
@@ -0,0 +1,61 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/keycloak"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+)
+
+// SyncKeycloakDirectory makes the managed Keycloak realm's users and groups match
+// cluster.keycloak.users / groups in general.yaml : the day-2 counterpart of the bootstrap's
+// keycloakx after-sync hook, so engineers get onboarded and offboarded by merging a
+// kubeaid-config PR and running it.
+//
+// Logs into Keycloak as admin, with the password from the keycloak-admin Secret in the main
+// cluster. The rendered ClusterRoleBindings aren't touched : ArgoCD syncs those.
+func SyncKeycloakDirectory(ctx context.Context) {
+	assert.Assert(ctx, config.ManagedKeycloakEnabled(),
+		"`cluster sync-keycloak` only applies to VPN clusters with cluster.keycloak.mode set to managed",
+	)
+
+	bar := progress.New("Syncing Keycloak users and groups with general.yaml")
+	defer bar.Finish()
+	ctx = progress.WithBar(ctx, bar)
+
+	mainClusterClient, err := kubernetes.CreateKubernetesClient(ctx,
+		constants.OutputPathMainClusterKubeconfig,
+	)
+	assert.AssertErrNil(ctx, err, "Failed constructing main cluster client")
+
+	adminPassword, err := readSecretValue(ctx, mainClusterClient,
+		constants.NamespaceKeycloak,
+		constants.SecretNameKeycloakAdmin,
+		constants.SecretKeyKeycloakPassword,
+	)
+	assert.AssertErrNil(ctx, err, "Failed reading the Keycloak admin password")
+
+	keycloakConfig := config.ParsedGeneralConfig.Cluster.Keycloak
+
+	release := bar.InProgress("Logging into Keycloak admin and reconciling users and groups")
+	err = retryKeycloakReconcile(ctx, func(ctx context.Context) error {
+		reconciler, err := keycloak.NewReconciler(ctx, keycloakAdminBaseURL(keycloakConfig),
+			constants.KeycloakAdminUsername, adminPassword,
+		)
+		if err != nil {
+			return err
+		}
+		return reconciler.ReconcileDirectory(ctx, keycloakDirectorySpec(keycloakConfig))
+	})
+	release()
+	assert.AssertErrNil(ctx, err, "Failed reconciling Keycloak users and groups")
+
+	bar.Substep("Keycloak users and groups in sync with general.yaml 🎉")
+}
@@ -17,7 +17,10 @@ import (
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	corenetbird "github.com/Obmondo/kubeaid-cli/pkg/core/netbird"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
+	"github.com/Obmondo/kubeaid-cli/pkg/repository/forge"
+	repourl "github.com/Obmondo/kubeaid-cli/pkg/repository/url"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	gitUtils "github.com/Obmondo/kubeaid-cli/pkg/utils/git"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
 )
 
@@ -116,8 +119,26 @@ type TemplateValues struct {
 
 	ExtraKnownHosts []string
 
+	// Credentials ArgoCD reads an HTTP(S) KubeAid Config repository with, sealed into the
+	// repo-kubeaid-config Secret (instead of the deploy key).
+	//
+	// With a GitHub App (git.https.githubApp), ArgoCD mints the installation access tokens itself,
+	// from GitHubAppPrivateKey, against the GitHubAppEnterpriseBaseURL API ("" for github.com).
+	// Otherwise, it authenticates with the access token (GitHTTPSUsername / GitHTTPSToken) from
+	// secrets.yaml, or else the KUBEAID_CLI_GIT_TOKEN environment variable; anonymously when
+	// there's neither.
+	GitHTTPSUsername,
+	GitHTTPSToken,
+	GitHubAppPrivateKey,
+	GitHubAppEnterpriseBaseURL string
+
 	*config.DisasterRecoveryConfig
 
+	// ObjectStorageCredentials is secrets.yaml's objectStorage access key pair, sealed into the
+	// Velero and Sealed Secrets backuper credential Secrets. Only consumed when the backups go
+	// to an S3-compatible object storage (cloud.disasterRecovery.s3).
+	ObjectStorageCredentials *config.ObjectStorageCredentials
+
 	*config.ObmondoConfig
 
 	// Subject CN of the Obmondo-issued mTLS cert (ObmondoConfig.CertPath),
@@ -201,12 +222,17 @@ type TemplateValues struct {
 	// netbird.AwaitOperatorToken instead.
 	NetBirdAPIKey string
 
-	// CloudflareAPIToken is secrets.yaml's acme.cloudflareApiToken,
-	// sealed into the cert-manager/cloudflare-api-token Secret the
-	// DNS-01 ClusterIssuer's solver references. Only consumed when
-	// cluster.acmeDNS01 is set (parser validation requires the token
-	// by then).
-	CloudflareAPIToken string
+	// ACMECredentials is secrets.yaml's acme block, with the credentials it
+	// leaves blank falling back to cluster.dnsRecords' ones, sealed into the
+	// cert-manager Secret the DNS-01 ClusterIssuer's solver references.
+	// Only consumed when cluster.acmeDNS01 is set (parser validation
+	// requires the provider's credential by then).
+	ACMECredentials *config.ACMECredentials
+
+	// ACMERFC2136 is the nameserver and TSIG key the rfc2136 DNS-01 solver
+	// sends its updates to : cluster.acmeDNS01.rfc2136, or else
+	// cluster.dnsRecords.rfc2136's. Nil unless either is set.
+	ACMERFC2136 *config.ACMERFC2136Config
 
 	// KubeaidStoragectlVersion is the pinned kubeaid-storagectl release
 	// tag rendered into global.kubeaidStoragectl.version in the
@@ -216,6 +242,16 @@ type TemplateValues struct {
 	// binary that matches the kubeaid-cli release that bootstrapped it.
 	KubeaidStoragectlVersion string
 
+	// KubeaidStoragectlExcludeCeph is kubeaidStoragectl.excludeCeph, rendered into
+	// global.kubeaidStoragectl.excludeCeph in the capi-cluster Helm values, so the nodes'
+	// storage plans leave Rook Ceph's share of the disks unallocated.
+	KubeaidStoragectlExcludeCeph bool
+
+	// KubeaidStoragectlAllowWipeWWNs is kubeaidStoragectl.allowWipeWWNs, rendered into
+	// global.kubeaidStoragectl.allowWipeWWNs in the capi-cluster Helm values, for the nodes'
+	// `kubeaid-storagectl plan execute --allow-wipe-wwn`.
+	KubeaidStoragectlAllowWipeWWNs []string
+
 	// HetznerBareMetalFirewallEnabled is true when the cluster is a Hetzner
 	// bare-metal deployment and firewall.enabled is not explicitly false.
 	// Computed at render time so the cilium values template can gate the
@@ -236,6 +272,16 @@ func operatorStoragectlVersionOverride() string {
 	return cfg.Version
 }
 
+// storagectlAllowWipeWWNs returns general.yaml's `kubeaidStoragectl.allowWipeWWNs`, or nil when
+// the block is omitted.
+func storagectlAllowWipeWWNs() []string {
+	cfg := config.ParsedGeneralConfig.KubeaidStoragectl
+	if cfg == nil {
+		return nil
+	}
+	return cfg.AllowWipeWWNs
+}
+
 // storagectlVersion returns the kubeaid-storagectl version string to
 // pin in the capi-cluster Helm values, in priority order:
 //
@@ -260,7 +306,46 @@ func storagectlVersion(operatorOverride, cliVersion string) string {
 	return cliVersion
 }
 
+// gitHubDotComAPIURL is github.com's API URL. ArgoCD only wants a GitHub App's API URL for GitHub
+// Enterprise.
+const gitHubDotComAPIURL = "https://api.github.com"
+
+// setGitHTTPSTemplateValues sets the credentials ArgoCD reads the HTTP(S) KubeAid Config
+// repository with : the GitHub App's private key (git.https.githubApp), or else the access token.
+func setGitHTTPSTemplateValues(templateValues *TemplateValues, kubeaidConfigURL *repourl.Parsed) {
+	gitConfig := config.ParsedGeneralConfig.Git
+
+	if (gitConfig.HTTPS == nil) || (gitConfig.HTTPS.GitHubApp == nil) {
+		templateValues.GitHTTPSUsername, templateValues.GitHTTPSToken = gitUtils.HTTPSTokenCredentials()
+		return
+	}
+
+	if config.ParsedSecretsConfig.Git != nil {
+		templateValues.GitHubAppPrivateKey = config.ParsedSecretsConfig.Git.GitHubAppPrivateKey
+	}
+
+	var apiURL string
+	if gitConfig.Forge != nil {
+		apiURL = gitConfig.Forge.APIURL
+	}
+	if apiURL = forge.GitHubAPIURL(kubeaidConfigURL, apiURL); apiURL != gitHubDotComAPIURL {
+		templateValues.GitHubAppEnterpriseBaseURL = apiURL
+	}
+}
+
 func getTemplateValues(ctx context.Context) *TemplateValues {
+	return buildTemplateValues(ctx, false)
+}
+
+// getOfflineTemplateValues returns the template values without reaching the cluster, or the cloud
+// provider's / Hetzner Robot's API, for the commands working offline. The values only those have
+// are left blank : the Sealed Secret templates rendering one are liveSealedSecretTemplateNames( ),
+// which mustn't get sealed from these.
+func getOfflineTemplateValues(ctx context.Context) *TemplateValues {
+	return buildTemplateValues(ctx, true)
+}
+
+func buildTemplateValues(ctx context.Context, offline bool) *TemplateValues {
 	// Precompute netbird-operator overlay flags so the values template
 	// stays nil-safe (see values-netbird-operator.yaml.tmpl).
 	netbirdCfg := config.ParsedGeneralConfig.Cluster.NetBird
@@ -295,7 +380,8 @@ func getTemplateValues(ctx context.Context) *TemplateValues {
 
 		BareMetalConfig: config.ParsedGeneralConfig.Cloud.BareMetal,
 
-		DisasterRecoveryConfig: config.ParsedGeneralConfig.Cloud.DisasterRecovery,
+		DisasterRecoveryConfig:   config.ParsedGeneralConfig.Cloud.DisasterRecovery,
+		ObjectStorageCredentials: config.ParsedSecretsConfig.ObjectStorage,
 
 		ObmondoConfig: config.ParsedGeneralConfig.Obmondo,
 
@@ -309,12 +395,15 @@ func getTemplateValues(ctx context.Context) *TemplateValues {
 		NetBirdOperatorEnabled:     corenetbird.OperatorEnabled(),
 		NetBirdRouterEnabled:       netbirdRouterEnabled,
 
-		CloudflareAPIToken: cloudflareAPIToken(),
+		ACMECredentials: config.ACMEDNS01Credentials(config.ParsedGeneralConfig.Cluster, config.ParsedSecretsConfig),
+		ACMERFC2136:     config.ACMEDNS01RFC2136(config.ParsedGeneralConfig.Cluster),
 
 		KubeaidStoragectlVersion: storagectlVersion(
 			operatorStoragectlVersionOverride(),
 			globals.KubeaidCLIVersion,
 		),
+		KubeaidStoragectlExcludeCeph:   config.CephExcluded(),
+		KubeaidStoragectlAllowWipeWWNs: storagectlAllowWipeWWNs(),
 
 		HetznerBareMetalFirewallEnabled: hetznerBareMetalFirewallEnabled(),
 	}
@@ -323,7 +412,7 @@ func getTemplateValues(ctx context.Context) *TemplateValues {
 	// kubelet-csr-approver values template. Empty map for non-Hetzner
 	// or non-bare-metal setups (the values template guards on the map
 	// being non-empty before rendering its per-host /32 entries).
-	if globals.CloudProviderName == constants.CloudProviderHetzner {
+	if !offline && (globals.CloudProviderName == constants.CloudProviderHetzner) {
 		if hetznerProvider, ok := globals.CloudProvider.(*hetzner.Hetzner); ok {
 			publicIPs, err := hetznerProvider.GetHetznerBareMetalHostPublicIPs(ctx)
 			assert.AssertErrNil(ctx, err,
@@ -382,14 +471,16 @@ func getTemplateValues(ctx context.Context) *TemplateValues {
 		// that genuinely needs a cluster read — kubeaid-cli has no
 		// way to know CNPG's randomly-generated password ahead of
 		// the cluster being up.
-		clusterClient, _ := kubernetes.CreateKubernetesClient(ctx,
-			constants.OutputPathMainClusterKubeconfig,
-		)
-		templateValues.NetBirdPostgresDSN = readSecretValueOrEmpty(ctx, clusterClient,
-			constants.NamespaceNetBird,
-			constants.SecretNameNetBird,
-			constants.SecretKeyNetBirdPostgresDSN,
-		)
+		if !offline {
+			clusterClient, _ := kubernetes.CreateKubernetesClient(ctx,
+				constants.OutputPathMainClusterKubeconfig,
+			)
+			templateValues.NetBirdPostgresDSN = readSecretValueOrEmpty(ctx, clusterClient,
+				constants.NamespaceNetBird,
+				constants.SecretNameNetBird,
+				constants.SecretKeyNetBirdPostgresDSN,
+			)
+		}
 
 		templateValues.NetBirdClientID = constants.NetBirdClientID
 		templateValues.NetBirdBackendClientID = constants.NetBirdBackendClientID
@@ -416,13 +507,19 @@ func getTemplateValues(ctx context.Context) *TemplateValues {
 		templateValues.KeycloakAdminPassword = config.ParsedSecretsConfig.Keycloak.AdminPassword
 	}
 
+	if kubeaidConfigFork := config.ParsedGeneralConfig.Forks.KubeaidConfigFork; repourl.UsingHTTPBasedProtocol(kubeaidConfigFork.URL) {
+		setGitHTTPSTemplateValues(templateValues, kubeaidConfigFork.ParsedURL)
+	}
+
 	// Set cloud provider specific values.
 	switch globals.CloudProviderName {
 	case constants.CloudProviderAWS:
-		accountID, accountErr := aws.GetAccountID(ctx)
-		assert.AssertErrNil(ctx, accountErr, "Failed getting AWS account ID")
+		if !offline {
+			accountID, accountErr := aws.GetAccountID(ctx)
+			assert.AssertErrNil(ctx, accountErr, "Failed getting AWS account ID")
 
-		templateValues.AWSAccountID = accountID
+			templateValues.AWSAccountID = accountID
+		}
 		templateValues.AWSB64EncodedCredentials = os.Getenv(
 			constants.EnvNameAWSB64EcodedCredentials,
 		)
@@ -590,6 +687,25 @@ func getEmbeddedNonSecretTemplateNames() []string {
 		)
 	}
 
+	// ClusterRoleBindings granting the cluster.keycloak.groups their
+	// clusterRoles. The NetBird clusterProxy impersonates a member's
+	// groups, so the bindings apply to their kubectl requests.
+	if config.KeycloakClusterRoleBindingsEnabled() {
+		embeddedTemplateNames = append(embeddedTemplateNames,
+			"argocd-apps/templates/k8s-configs.yaml.tmpl",
+			"k8s-configs/keycloak-groups.clusterrolebindings.yaml.tmpl",
+		)
+	}
+
+	// The Let's Encrypt ClusterIssuer, when its DNS-01 solver is one the
+	// KubeAid cert-manager chart can't render.
+	if acmeDNS01Enabled() &&
+		(config.ParsedGeneralConfig.Cluster.ACMEDNS01.Provider != constants.ACMEDNS01ProviderCloudflare) {
+		embeddedTemplateNames = append(embeddedTemplateNames,
+			constants.CertManagerClusterIssuerTemplateNames...,
+		)
+	}
+
 	// Add cloud provider specific templates.
 	switch globals.CloudProviderName {
 	case constants.CloudProviderAWS:
@@ -678,6 +794,14 @@ func getEmbeddedNonSecretTemplateNames() []string {
 		embeddedTemplateNames = constants.CommonNonSecretTemplateNames
 	}
 
+	// Hetzner and Bare Metal clusters back up into an S3-compatible object storage, if the user
+	// wants disaster recovery.
+	if config.S3CompatibleDisasterRecoveryEnabled() {
+		embeddedTemplateNames = append(embeddedTemplateNames,
+			constants.S3CompatibleDisasterRecoverySpecificNonSecretTemplateNames...,
+		)
+	}
+
 	// VPN cluster (any Keycloak mode): traefik for the NetBird Mgmt
 	// Ingress (and Keycloak ingress when managed), CloudNativePG
 	// for NetBird's Postgres backend, and NetBird Mgmt + Signal +
@@ -753,16 +877,6 @@ func acmeDNS01Enabled() bool {
 	return config.ParsedGeneralConfig.Cluster.ACMEDNS01 != nil
 }
 
-// cloudflareAPIToken returns secrets.yaml's acme.cloudflareApiToken,
-// nil-safe — the acme block only exists on clusters using DNS-01.
-func cloudflareAPIToken() string {
-	creds := config.ParsedSecretsConfig.ACME
-	if creds == nil {
-		return ""
-	}
-	return creds.CloudflareAPIToken
-}
-
 // hetznerBareMetalFirewallEnabled reports whether the Cilium host-firewall
 // hostNetworkPolicy block should be rendered in the cilium values overlay.
 // True when all of:
@@ -864,6 +978,14 @@ func getEmbeddedSecretTemplateNames() []string {
 		// No additional provider-specific secret templates needed.
 	}
 
+	// Object storage credentials for the Velero and Sealed Secrets backups, on Hetzner and Bare
+	// Metal clusters.
+	if config.S3CompatibleDisasterRecoveryEnabled() {
+		embeddedTemplateNames = append(embeddedTemplateNames,
+			constants.S3CompatibleDisasterRecoverySpecificSecretTemplateNames...,
+		)
+	}
+
 	if config.ObmondoIntegrationEnabled() {
 		embeddedTemplateNames = append(embeddedTemplateNames,
 			constants.ObmondoClientCertSecretTemplateNames...,
@@ -910,14 +1032,40 @@ func getEmbeddedSecretTemplateNames() []string {
 		)
 	}
 
-	// Cloudflare API token for the DNS-01 ClusterIssuer — gated on the
-	// cluster.acmeDNS01 block (parser validation already guarantees the
-	// token exists alongside it).
+	// DNS provider credential for the DNS-01 ClusterIssuer — gated on
+	// the cluster.acmeDNS01 block (parser validation already guarantees
+	// the credential exists alongside it).
 	if acmeDNS01Enabled() {
 		embeddedTemplateNames = append(embeddedTemplateNames,
-			constants.CertManagerCloudflareAPITokenSecretTemplateName,
+			constants.CertManagerDNS01SecretTemplateNames[config.ParsedGeneralConfig.Cluster.ACMEDNS01.Provider],
 		)
 	}
 
 	return embeddedTemplateNames
 }
+
+// liveSealedSecretTemplateNames returns the Sealed Secret templates, out of
+// getEmbeddedSecretTemplateNames( ), rendering a value only the cluster or the cloud provider has.
+// getOfflineTemplateValues( ) leaves it blank.
+func liveSealedSecretTemplateNames() []string {
+	var templateNames []string
+
+	// The CNPG generated postgres DSN, read back from the cluster.
+	if config.VPNClusterEnabled() {
+		templateNames = append(templateNames, constants.NetBirdSecretTemplateName)
+	}
+
+	switch globals.CloudProviderName {
+	// CAPA's credentials, encoded by clusterawsadm while setting the cluster up.
+	case constants.CloudProviderAWS:
+		templateNames = append(templateNames, constants.CAPIClusterCloudCredentialsSecretTemplateName)
+
+	// The storage account's access key, fetched from Azure while provisioning.
+	case constants.CloudProviderAzure:
+		if config.ParsedGeneralConfig.Cloud.DisasterRecovery != nil {
+			templateNames = append(templateNames, constants.SealedSecretsBackuperSecretTemplateName)
+		}
+	}
+
+	return templateNames
+}
@@ -0,0 +1,351 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+// certManagerSecretKeySelector, certManagerACMESolver and
+// certManagerClusterIssuer transcribe the part of cert-manager.io/v1's
+// ClusterIssuer schema the rendered ClusterIssuer uses, field names
+// included (github.com/cert-manager/cert-manager/pkg/apis). Decoding
+// strictly into them catches a misnamed or misplaced field, which
+// cert-manager would otherwise silently drop.
+type (
+	certManagerSecretKeySelector struct {
+		Name string `json:"name"`
+		Key  string `json:"key"`
+	}
+
+	certManagerACMESolver struct {
+		Selector *struct {
+			DNSZones []string `json:"dnsZones"`
+		} `json:"selector,omitempty"`
+
+		HTTP01 *struct {
+			Ingress struct {
+				IngressClassName string `json:"ingressClassName"`
+			} `json:"ingress"`
+		} `json:"http01,omitempty"`
+
+		DNS01 *struct {
+			Route53 *struct {
+				Region                   string                        `json:"region"`
+				HostedZoneID             string                        `json:"hostedZoneID,omitempty"`
+				Role                     string                        `json:"role,omitempty"`
+				AccessKeyIDSecretRef     *certManagerSecretKeySelector `json:"accessKeyIDSecretRef"`
+				SecretAccessKeySecretRef *certManagerSecretKeySelector `json:"secretAccessKeySecretRef"`
+			} `json:"route53,omitempty"`
+
+			AzureDNS *struct {
+				SubscriptionID        string                        `json:"subscriptionID"`
+				ResourceGroupName     string                        `json:"resourceGroupName"`
+				HostedZoneName        string                        `json:"hostedZoneName,omitempty"`
+				Environment           string                        `json:"environment"`
+				TenantID              string                        `json:"tenantID"`
+				ClientID              string                        `json:"clientID"`
+				ClientSecretSecretRef *certManagerSecretKeySelector `json:"clientSecretSecretRef"`
+			} `json:"azureDNS,omitempty"`
+
+			Webhook *struct {
+				GroupName  string         `json:"groupName"`
+				SolverName string         `json:"solverName"`
+				Config     map[string]any `json:"config"`
+			} `json:"webhook,omitempty"`
+
+			RFC2136 *struct {
+				Nameserver          string                        `json:"nameserver"`
+				TSIGKeyName         string                        `json:"tsigKeyName"`
+				TSIGAlgorithm       string                        `json:"tsigAlgorithm"`
+				TSIGSecretSecretRef *certManagerSecretKeySelector `json:"tsigSecretSecretRef"`
+			} `json:"rfc2136,omitempty"`
+		} `json:"dns01,omitempty"`
+	}
+
+	certManagerClusterIssuer struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+		Metadata   struct {
+			Name        string            `json:"name"`
+			Labels      map[string]string `json:"labels"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Spec struct {
+			ACME struct {
+				Email               string `json:"email"`
+				Server              string `json:"server"`
+				PrivateKeySecretRef struct {
+					Name string `json:"name"`
+				} `json:"privateKeySecretRef"`
+				Solvers []certManagerACMESolver `json:"solvers"`
+			} `json:"acme"`
+		} `json:"spec"`
+	}
+)
+
+// TestCertManagerDNS01Solver verifies the ClusterIssuer's DNS-01 solver
+// renders the provider's block, referencing the Secret (name + keys)
+// its sealed-secrets/cert-manager template creates : through the KubeAid
+// cert-manager chart's issuer values for cloudflare, and as a
+// cert-manager ClusterIssuer for the other providers.
+func TestCertManagerDNS01Solver(t *testing.T) {
+	const (
+		valuesTmplPath        = "templates/argocd-apps/values-cert-manager.yaml.tmpl"
+		clusterIssuerTmplPath = "templates/k8s-configs/letsencrypt-prod.clusterissuer.yaml.tmpl"
+	)
+
+	type chartValues struct {
+		Issuer struct {
+			Enabled bool             `json:"enabled"`
+			Solvers []map[string]any `json:"solvers"`
+		} `json:"issuer"`
+	}
+
+	t.Run("cloudflare", func(t *testing.T) {
+		out := renderEmbeddedTemplate(t, valuesTmplPath, TemplateValues{
+			ClusterConfig: config.ClusterConfig{
+				ACMEEmail: "ops@acme.com",
+				ACMEDNS01: &config.ACMEDNS01Config{Provider: "cloudflare", DNSZones: []string{"acme.com"}},
+			},
+		})
+
+		var values chartValues
+		require.NoError(t, yaml.Unmarshal([]byte(out), &values), out)
+
+		// The HTTP-01 catch-all, followed by the DNS-01 solver.
+		assert.True(t, values.Issuer.Enabled)
+		require.Len(t, values.Issuer.Solvers, 2)
+		assert.Equal(t, "http", values.Issuer.Solvers[0]["type"])
+		assert.Equal(t, map[string]any{
+			"type":          "dns",
+			"cloudProvider": "cloudflare",
+			"issuerEmail":   "ops@acme.com",
+			"cloudProviderSecretRef": map[string]any{
+				"name": "cloudflare-api-token",
+				"key":  "api-token",
+			},
+			"dnsZones": []any{"acme.com"},
+		}, values.Issuer.Solvers[1])
+	})
+
+	tests := []struct {
+		name       string
+		dns01      *config.ACMEDNS01Config
+		dnsRecords *config.DNSRecordsConfig
+		wantDNS01  string
+	}{
+		{
+			name: "route53",
+			dns01: &config.ACMEDNS01Config{
+				Provider: "route53",
+				DNSZones: []string{"acme.com"},
+				Route53:  &config.ACMERoute53Config{Region: "eu-west-1", HostedZoneID: "Z0123"},
+			},
+			wantDNS01: `{"route53": {
+				"region": "eu-west-1",
+				"hostedZoneID": "Z0123",
+				"accessKeyIDSecretRef": {"name": "route53-credentials", "key": "access-key-id"},
+				"secretAccessKeySecretRef": {"name": "route53-credentials", "key": "secret-access-key"}
+			}}`,
+		},
+		{
+			name: "azuredns",
+			dns01: &config.ACMEDNS01Config{
+				Provider: "azuredns",
+				AzureDNS: &config.ACMEAzureDNSConfig{
+					SubscriptionID:    "subscription",
+					ResourceGroupName: "dns",
+					TenantID:          "tenant",
+					ClientID:          "client",
+					Environment:       "AzurePublicCloud",
+				},
+			},
+			wantDNS01: `{"azureDNS": {
+				"subscriptionID": "subscription",
+				"resourceGroupName": "dns",
+				"environment": "AzurePublicCloud",
+				"tenantID": "tenant",
+				"clientID": "client",
+				"clientSecretSecretRef": {"name": "azuredns-credentials", "key": "client-secret"}
+			}}`,
+		},
+		{
+			name: "hetzner",
+			dns01: &config.ACMEDNS01Config{
+				Provider: "hetzner",
+				Hetzner: &config.ACMEHetznerConfig{
+					GroupName:  "acme.acme.com",
+					SolverName: "hetzner",
+					ZoneName:   "acme.com",
+					APIURL:     "https://api.hetzner.cloud/v1",
+				},
+			},
+			wantDNS01: `{"webhook": {
+				"groupName": "acme.acme.com",
+				"solverName": "hetzner",
+				"config": {
+					"secretName": "hetzner-dns-api-token",
+					"zoneName": "acme.com",
+					"apiUrl": "https://api.hetzner.cloud/v1"
+				}
+			}}`,
+		},
+		{
+			name: "rfc2136",
+			dns01: &config.ACMEDNS01Config{
+				Provider: "rfc2136",
+				RFC2136: &config.ACMERFC2136Config{
+					Nameserver:    "ns1.acme.com:53",
+					TSIGKeyName:   "cert-manager.",
+					TSIGAlgorithm: "hmac-sha256",
+				},
+			},
+			wantDNS01: `{"rfc2136": {
+				"nameserver": "ns1.acme.com:53",
+				"tsigKeyName": "cert-manager.",
+				"tsigAlgorithm": "HMACSHA256",
+				"tsigSecretSecretRef": {"name": "rfc2136-tsig-secret", "key": "tsig-secret"}
+			}}`,
+		},
+		{
+			name:  "rfc2136 reusing cluster.dnsRecords.rfc2136",
+			dns01: &config.ACMEDNS01Config{Provider: "rfc2136"},
+			dnsRecords: &config.DNSRecordsConfig{
+				Provider: "rfc2136",
+				RFC2136: &config.RFC2136Config{
+					Nameserver:    "ns1.acme.com",
+					Zone:          "acme.com",
+					TSIGKeyName:   "kubeaid",
+					TSIGAlgorithm: "hmac-sha512",
+				},
+			},
+			wantDNS01: `{"rfc2136": {
+				"nameserver": "ns1.acme.com",
+				"tsigKeyName": "kubeaid",
+				"tsigAlgorithm": "HMACSHA512",
+				"tsigSecretSecretRef": {"name": "rfc2136-tsig-secret", "key": "tsig-secret"}
+			}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := config.ClusterConfig{
+				ACMEEmail:  "ops@acme.com",
+				ACMEDNS01:  tc.dns01,
+				DNSRecords: tc.dnsRecords,
+			}
+			templateValues := TemplateValues{
+				ClusterConfig: cluster,
+				ACMERFC2136:   config.ACMEDNS01RFC2136(cluster),
+			}
+
+			// The chart's issuer gets disabled, in favour of the rendered
+			// ClusterIssuer.
+			var values chartValues
+			out := renderEmbeddedTemplate(t, valuesTmplPath, templateValues)
+			require.NoError(t, yaml.Unmarshal([]byte(out), &values), out)
+			assert.False(t, values.Issuer.Enabled)
+			assert.Empty(t, values.Issuer.Solvers)
+
+			var clusterIssuer certManagerClusterIssuer
+			out = renderEmbeddedTemplate(t, clusterIssuerTmplPath, templateValues)
+			require.NoError(t, yaml.UnmarshalStrict([]byte(out), &clusterIssuer), out)
+
+			assert.Equal(t, "cert-manager.io/v1", clusterIssuer.APIVersion)
+			assert.Equal(t, "ClusterIssuer", clusterIssuer.Kind)
+			assert.Equal(t, "letsencrypt-prod", clusterIssuer.Metadata.Name)
+
+			acme := clusterIssuer.Spec.ACME
+			assert.Equal(t, "ops@acme.com", acme.Email)
+			assert.Equal(t, "https://acme-v02.api.letsencrypt.org/directory", acme.Server)
+			assert.Equal(t, "letsencrypt-prod-account-key", acme.PrivateKeySecretRef.Name)
+
+			// The HTTP-01 catch-all, followed by the DNS-01 solver.
+			require.Len(t, acme.Solvers, 2)
+			require.NotNil(t, acme.Solvers[0].HTTP01)
+			assert.Equal(t, "traefik", acme.Solvers[0].HTTP01.Ingress.IngressClassName)
+			assert.Nil(t, acme.Solvers[0].Selector)
+
+			dns01Solver := acme.Solvers[1]
+			assert.Nil(t, dns01Solver.HTTP01)
+			if len(tc.dns01.DNSZones) > 0 {
+				require.NotNil(t, dns01Solver.Selector)
+				assert.Equal(t, tc.dns01.DNSZones, dns01Solver.Selector.DNSZones)
+			} else {
+				assert.Nil(t, dns01Solver.Selector)
+			}
+
+			gotDNS01, err := json.Marshal(dns01Solver.DNS01)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.wantDNS01, string(gotDNS01))
+
+			// Every provider has a sealed Secret template, and the
+			// ClusterIssuer gets synced by the k8s-configs App.
+			assert.Contains(t, constants.CertManagerDNS01SecretTemplateNames, tc.dns01.Provider)
+			assert.Contains(t, constants.CertManagerClusterIssuerTemplateNames,
+				strings.TrimPrefix(clusterIssuerTmplPath, "templates/"),
+			)
+		})
+	}
+}
+
+// TestCertManagerDNS01Secrets verifies each provider's Secret lands in
+// cert-manager, under the name and keys its solver references.
+func TestCertManagerDNS01Secrets(t *testing.T) {
+	acmeCreds := &config.ACMECredentials{
+		CloudflareAPIToken: "cf-token",
+		Route53:            &config.ACMERoute53Credentials{AccessKeyID: "AKIA", SecretAccessKey: "aws-secret"},
+		AzureDNS:           &config.ACMEAzureDNSCredentials{ClientSecret: "azure-secret"},
+		Hetzner:            &config.ACMEHetznerCredentials{APIToken: "hetzner-token"},
+		RFC2136:            &config.ACMERFC2136Credentials{TSIGSecret: "c2VjcmV0"},
+	}
+
+	tests := map[string]struct {
+		wantName string
+		wantData map[string]string
+	}{
+		"cloudflare": {"cloudflare-api-token", map[string]string{"api-token": "cf-token"}},
+		"route53": {"route53-credentials", map[string]string{
+			"access-key-id":     "AKIA",
+			"secret-access-key": "aws-secret",
+		}},
+		"azuredns": {"azuredns-credentials", map[string]string{"client-secret": "azure-secret"}},
+		"hetzner":  {"hetzner-dns-api-token", map[string]string{"api-key": "hetzner-token"}},
+		"rfc2136":  {"rfc2136-tsig-secret", map[string]string{"tsig-secret": "c2VjcmV0"}},
+	}
+
+	require.Len(t, constants.CertManagerDNS01SecretTemplateNames, len(tests))
+	for provider, tc := range tests {
+		t.Run(provider, func(t *testing.T) {
+			out := renderEmbeddedTemplate(t,
+				"templates/"+constants.CertManagerDNS01SecretTemplateNames[provider],
+				TemplateValues{ACMECredentials: acmeCreds},
+			)
+
+			var secret struct {
+				Metadata struct {
+					Name      string `json:"name"`
+					Namespace string `json:"namespace"`
+				} `json:"metadata"`
+				StringData map[string]string `json:"stringData"`
+			}
+			require.NoError(t, yaml.Unmarshal([]byte(out), &secret), out)
+
+			assert.Equal(t, tc.wantName, secret.Metadata.Name)
+			assert.Equal(t, "cert-manager", secret.Metadata.Namespace)
+			assert.Equal(t, tc.wantData, secret.StringData)
+		})
+	}
+}
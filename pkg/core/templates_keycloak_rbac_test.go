@@ -0,0 +1,72 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/templates"
+)
+
+// TestKeycloakGroupsClusterRoleBindingsTemplate covers
+// keycloak-groups.clusterrolebindings.yaml.tmpl: one ClusterRoleBinding per
+// group and ClusterRole, whose Group subject is the Keycloak group name.
+func TestKeycloakGroupsClusterRoleBindingsTemplate(t *testing.T) {
+	const tmplPath = "templates/k8s-configs/keycloak-groups.clusterrolebindings.yaml.tmpl"
+
+	tv := &TemplateValues{}
+	tv.Keycloak = &config.KeycloakConfig{
+		Groups: []config.KeycloakGroupConfig{
+			{Name: "platform-admins", ClusterRoles: []string{"cluster-admin"}},
+			{Name: "developers", ClusterRoles: []string{"edit", "view"}},
+			{Name: "auditors"},
+		},
+	}
+
+	rendered := templates.ParseAndExecuteTemplate(
+		context.Background(), &KubeaidConfigFileTemplates, tmplPath, tv,
+	)
+
+	var bindings []map[string]any
+	for document := range strings.SplitSeq(string(rendered), "\n---\n") {
+		if strings.TrimSpace(strings.TrimPrefix(document, "---")) == "" {
+			continue
+		}
+		var binding map[string]any
+		require.NoError(t, yaml.Unmarshal([]byte(document), &binding),
+			"rendered output must be valid YAML:\n%s", rendered)
+		bindings = append(bindings, binding)
+	}
+	require.Len(t, bindings, 3, "one binding per group and ClusterRole:\n%s", rendered)
+
+	want := []struct{ name, clusterRole, group string }{
+		{"keycloak:platform-admins:cluster-admin", "cluster-admin", "platform-admins"},
+		{"keycloak:developers:edit", "edit", "developers"},
+		{"keycloak:developers:view", "view", "developers"},
+	}
+	for i, w := range want {
+		binding := bindings[i]
+		assert.Equal(t, "ClusterRoleBinding", binding["kind"])
+		assert.Equal(t, w.name, subMap(t, binding, "metadata")["name"])
+
+		roleRef := subMap(t, binding, "roleRef")
+		assert.Equal(t, "ClusterRole", roleRef["kind"])
+		assert.Equal(t, w.clusterRole, roleRef["name"])
+
+		subjects, ok := binding["subjects"].([]any)
+		require.True(t, ok)
+		require.Len(t, subjects, 1)
+		subject, ok := subjects[0].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "Group", subject["kind"])
+		assert.Equal(t, w.group, subject["name"])
+	}
+}
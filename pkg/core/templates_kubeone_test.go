@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/templates"
 )
 
@@ -58,3 +59,49 @@ func TestKubeOneTemplateRendersKubeletConfig(t *testing.T) {
 	))
 	assert.NotContains(t, rendered, "kubelet:")
 }
+
+// TestKubeOneTemplatePassphraseProtectedKey proves KubeOne gets pointed at the in-process SSH
+// agent for passphrase protected keys, since it can't unlock the key file itself, and keeps
+// reading the key file otherwise.
+func TestKubeOneTemplatePassphraseProtectedKey(t *testing.T) {
+	controlPlaneAddress := "192.0.2.10"
+	workerAddress := "192.0.2.20"
+
+	values := &TemplateValues{
+		ClusterConfig: config.ClusterConfig{Name: "demo", K8sVersion: "v1.35.6"},
+		BareMetalConfig: &config.BareMetalConfig{
+			SSH: config.BareMetalSSHConfig{
+				Port: 22,
+				SSHKeyPairConfig: &config.SSHKeyPairConfig{
+					PrivateKeyFilePath:  "/home/op/.ssh/id_ed25519",
+					PassphraseProtected: true,
+				},
+			},
+			ControlPlane: config.BareMetalControlPlane{
+				Endpoint: config.BareMetalControlPlaneEndpoint{Host: controlPlaneAddress, Port: 6443},
+				Hosts:    []*config.BareMetalHost{{PublicAddress: &controlPlaneAddress}},
+			},
+			NodeGroups: []config.BareMetalNodeGroup{{
+				NodeGroup: config.NodeGroup{Name: "workers"},
+				Hosts: []*config.BareMetalHost{{
+					PublicAddress: &workerAddress,
+					SSH: &config.BareMetalSSHConfig{
+						SSHKeyPairConfig: &config.SSHKeyPairConfig{
+							PrivateKeyFilePath: "/home/op/.ssh/id_ed25519_worker",
+						},
+					},
+				}},
+			}},
+		},
+	}
+
+	rendered := string(templates.ParseAndExecuteTemplate(
+		t.Context(), &KubeaidConfigFileTemplates,
+		"templates/kubeone/kubeone-cluster.yaml.tmpl", values,
+	))
+	t.Logf("--- rendered kubeone-cluster.yaml ---\n%s", rendered)
+
+	assert.Equal(t, 1, strings.Count(rendered, "sshAgentSocket: env:"+constants.EnvNameSSHKeyAgentSocket))
+	assert.NotContains(t, rendered, "sshPrivateKeyFile: /home/op/.ssh/id_ed25519\n")
+	assert.Contains(t, rendered, "sshPrivateKeyFile: /home/op/.ssh/id_ed25519_worker")
+}
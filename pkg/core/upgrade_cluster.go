@@ -38,26 +38,7 @@ func UpgradeCluster(ctx context.Context, args UpgradeClusterArgs) {
 	// Update the values-capi-cluster.yaml file in the kubeaid-config repo.
 	updateCapiClusterValuesFile(ctx, &args)
 
-	// Set KUBECONFIG environment variable.
-	utils.MustSetEnv(constants.EnvNameKubeconfig, constants.OutputPathMainClusterKubeconfig)
-	//
-	// If 'clusterctl move' wasn't executed, then we need to communicate with the management
-	// cluster instead.
-	if !kubernetes.IsClusterctlMoveExecuted(ctx) {
-		mgmtKubeconfig, mgmtErr := kubernetes.GetManagementClusterKubeconfigPath(ctx)
-		assert.AssertErrNil(ctx, mgmtErr, "Failed getting management cluster kubeconfig path")
-		utils.MustSetEnv(constants.EnvNameKubeconfig, mgmtKubeconfig)
-	}
-
-	// Construct the Kubernetes cluster client.
-	clusterClient, err := kubernetes.CreateKubernetesClient(ctx,
-		utils.MustGetEnv(constants.EnvNameKubeconfig),
-	)
-	assert.AssertErrNil(ctx, err, "Failed constructing Kubernetes cluster client")
-
-	// Construct the clusterctl client.
-	clusterctlClient, err := clusterctlClientLib.New(ctx, "")
-	assert.AssertErrNil(ctx, err, "Failed constructing clusterctl client")
+	clusterClient, clusterctlClient := newCapiClusterClients(ctx)
 
 	{
 		// Port-forward ArgoCD and create ArgoCD client.
@@ -100,9 +81,74 @@ func UpgradeCluster(ctx context.Context, args UpgradeClusterArgs) {
 	}
 }
 
+// newCapiClusterClients points KUBECONFIG at the cluster owning the Cluster API resources - the
+// main cluster, or the management cluster if 'clusterctl move' wasn't executed - and constructs
+// the Kubernetes and clusterctl clients for it.
+func newCapiClusterClients(ctx context.Context) (client.Client, clusterctlClientLib.Client) {
+	// Set KUBECONFIG environment variable.
+	utils.MustSetEnv(constants.EnvNameKubeconfig, constants.OutputPathMainClusterKubeconfig)
+	//
+	// If 'clusterctl move' wasn't executed, then we need to communicate with the management
+	// cluster instead.
+	if !kubernetes.IsClusterctlMoveExecuted(ctx) {
+		mgmtKubeconfig, mgmtErr := kubernetes.GetManagementClusterKubeconfigPath(ctx)
+		assert.AssertErrNil(ctx, mgmtErr, "Failed getting management cluster kubeconfig path")
+		utils.MustSetEnv(constants.EnvNameKubeconfig, mgmtKubeconfig)
+	}
+
+	// Construct the Kubernetes cluster client.
+	clusterClient, err := kubernetes.CreateKubernetesClient(ctx,
+		utils.MustGetEnv(constants.EnvNameKubeconfig),
+	)
+	assert.AssertErrNil(ctx, err, "Failed constructing Kubernetes cluster client")
+
+	// Construct the clusterctl client.
+	clusterctlClient, err := clusterctlClientLib.New(ctx, "")
+	assert.AssertErrNil(ctx, err, "Failed constructing clusterctl client")
+
+	return clusterClient, clusterctlClient
+}
+
 // Update the values-capi-cluster.yaml file in the KubeAid Config repo.
 // Once the change get merged to the default branch, we'll trigger the actual rollout process.
 func updateCapiClusterValuesFile(ctx context.Context, args *UpgradeClusterArgs) {
+	pushCapiClusterValuesFileChanges(ctx, args.SkipPRWorkflow,
+		func(capiClusterValuesFilePath string) {
+			// If the user wants Kubernetes version upgrade,
+			// then update the Kubernetes version.
+			if len(args.NewKubernetesVersion) > 0 {
+				yqCmd := yqCmdLib.New()
+				yqCmd.SetArgs([]string{
+					"eval",
+					fmt.Sprintf("(.global.kubernetes.version) = \"%s\"", args.NewKubernetesVersion),
+					capiClusterValuesFilePath,
+					"--inplace",
+				})
+				err := yqCmd.ExecuteContext(ctx)
+				assert.AssertErrNil(ctx, err,
+					"Failed updating Kubernetes version in values-capi-cluster.yaml file",
+				)
+			}
+
+			// When the user wants to also upgrade the OS,
+			// make necessary cloud provider specific updates.
+			err := globals.CloudProvider.UpdateCapiClusterValuesFile(ctx,
+				capiClusterValuesFilePath,
+				args.CloudSpecificUpdates,
+			)
+			assert.AssertErrNil(ctx, err, "Failed updating cloud provider specific values in values-capi-cluster.yaml")
+		},
+	)
+}
+
+// pushCapiClusterValuesFileChanges lets updateValuesFile edit the cluster's
+// values-capi-cluster.yaml in the KubeAid Config repo, then pushes the change and (unless the
+// PR workflow is skipped) waits until it gets merged to the default branch. Returns false when
+// the file was already up to date, and nothing got pushed.
+func pushCapiClusterValuesFileChanges(ctx context.Context,
+	skipPRWorkflow bool,
+	updateValuesFile func(capiClusterValuesFilePath string),
+) bool {
 	// Detect git authentication method.
 	gitAuthMethod := git.GetGitAuthMethod(ctx)
 
@@ -125,7 +171,7 @@ func updateCapiClusterValuesFile(ctx context.Context, args *UpgradeClusterArgs)
 		      merge it to the default branch.
 	*/
 	targetBranchName := defaultBranchName
-	if !args.SkipPRWorkflow {
+	if !skipPRWorkflow {
 		// Create and checkout to a new branch.
 		newBranchName := fmt.Sprintf("kubeaid-%s-%d",
 			config.ParsedGeneralConfig.Cluster.Name,
@@ -137,33 +183,7 @@ func updateCapiClusterValuesFile(ctx context.Context, args *UpgradeClusterArgs)
 	}
 
 	// Update values-capi-cluster.yaml file (using yq).
-	{
-		capiClusterValuesFilePath := path.Join(utils.GetClusterDir(), "argocd-apps/values-capi-cluster.yaml")
-
-		// If the user wants Kubernetes version upgrade,
-		// then update the Kubernetes version.
-		if len(args.NewKubernetesVersion) > 0 {
-			yqCmd := yqCmdLib.New()
-			yqCmd.SetArgs([]string{
-				"eval",
-				fmt.Sprintf("(.global.kubernetes.version) = \"%s\"", args.NewKubernetesVersion),
-				capiClusterValuesFilePath,
-				"--inplace",
-			})
-			err := yqCmd.ExecuteContext(ctx)
-			assert.AssertErrNil(ctx, err,
-				"Failed updating Kubernetes version in values-capi-cluster.yaml file",
-			)
-		}
-
-		// When the user wants to also upgrade the OS,
-		// make necessary cloud provider specific updates.
-		err := globals.CloudProvider.UpdateCapiClusterValuesFile(ctx,
-			capiClusterValuesFilePath,
-			args.CloudSpecificUpdates,
-		)
-		assert.AssertErrNil(ctx, err, "Failed updating cloud provider specific values in values-capi-cluster.yaml")
-	}
+	updateValuesFile(path.Join(utils.GetClusterDir(), "argocd-apps/values-capi-cluster.yaml"))
 
 	// Add, commit and push the changes.
 	commitMessage := fmt.Sprintf("(cluster/%s) : updated values-capi-cluster.yaml",
@@ -178,17 +198,13 @@ func updateCapiClusterValuesFile(ctx context.Context, args *UpgradeClusterArgs)
 		commitMessage,
 		defaultBranchName,
 	)
+	if commitHash.IsZero() {
+		return false
+	}
 
-	if !args.SkipPRWorkflow {
-		/*
-			The user now needs to go ahead and create a PR from the new to the default branch. Then he
-			needs to merge that branch.
-
-			NOTE : We can't create the PR for the user, since PRs are not part of the core git lib.
-						 They are specific to the git platform the user is on.
-		*/
-
-		// Wait until the user creates a PR and merges it to the default branch.
+	if !skipPRWorkflow {
+		// Wait until the PR from the new to the default branch gets merged. With a forge API
+		// token configured, the PR gets opened for the user, otherwise the user needs to open it.
 		git.WaitUntilPRMerged(ctx,
 			repo,
 			defaultBranchName,
@@ -197,6 +213,8 @@ func updateCapiClusterValuesFile(ctx context.Context, args *UpgradeClusterArgs)
 			targetBranchName,
 		)
 	}
+
+	return true
 }
 
 func upgradeControlPlane(ctx context.Context,
@@ -485,7 +485,6 @@ func pushKubeOneManifestChanges(ctx context.Context,
 	bar.Substep("Pushed kubeaid-config branch")
 
 	if !skipPRWorkflow {
-		releasePRWait := bar.InProgress("Waiting for you to merge the PR")
 		git.WaitUntilPRMerged(
 			ctx,
 			repo,
@@ -494,7 +493,6 @@ func pushKubeOneManifestChanges(ctx context.Context,
 			gitAuthMethod,
 			targetBranchName,
 		)
-		releasePRWait()
 		bar.Substep("Confirmed PR merged")
 	}
 
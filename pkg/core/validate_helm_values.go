@@ -0,0 +1,191 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	gitUtils "github.com/Obmondo/kubeaid-cli/pkg/utils/git"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+)
+
+// The parts of a rendered ArgoCD App, which tell the Helm chart it deploys and the values file it
+// uses.
+type argoCDAppHelmSources struct {
+	Metadata struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+
+	Spec struct {
+		Destination struct {
+			Namespace string `yaml:"namespace"`
+		} `yaml:"destination"`
+
+		Source  *argoCDAppSource  `yaml:"source"`
+		Sources []argoCDAppSource `yaml:"sources"`
+	} `yaml:"spec"`
+}
+
+type argoCDAppSource struct {
+	RepoURL string `yaml:"repoURL"`
+	Path    string `yaml:"path"`
+	Helm    struct {
+		ValueFiles []string `yaml:"valueFiles"`
+	} `yaml:"helm"`
+}
+
+// Prefix of a value file path, which points into the KubeAid Config repository (referenced as the
+// 'values' source).
+const argoCDValuesRefPrefix = "$values/"
+
+/*
+Validates the Helm values files rendered in the given cluster directory, before they get pushed :
+each against the values.schema.json of the KubeAid Helm chart it's meant for, followed by a dry
+render of that chart.
+
+Only the ArgoCD Apps in appTemplateNames are considered, so ArgoCD Apps left over from a previous
+render don't get in the way. Every violation gets logged, with the values file and field it's
+about.
+
+It expects the KubeAid repository to be already cloned, at the version from the general config.
+*/
+func validateRenderedHelmValues(ctx context.Context,
+	kubeaidConfigDir,
+	clusterDir string,
+	appTemplateNames []string,
+) error {
+	targets, err := helmValuesValidationTargets(
+		kubeaidConfigDir, clusterDir, utils.GetKubeAidDir(),
+		config.ParsedGeneralConfig.Forks.KubeaidFork.URL,
+		appTemplateNames,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.ValidateHelmValues(ctx, targets)
+
+	var validationErr *kubernetes.HelmValuesValidationError
+	if errors.As(err, &validationErr) {
+		for _, violation := range validationErr.Violations {
+			// Relative to the KubeAid Config repository, since that's where the operator will look.
+			valuesFilePath, relErr := filepath.Rel(kubeaidConfigDir, violation.ValuesFilePath)
+			if relErr != nil {
+				valuesFilePath = violation.ValuesFilePath
+			}
+
+			slog.ErrorContext(ctx, "Invalid Helm values",
+				slog.String("file", valuesFilePath),
+				slog.String("field", violation.Field),
+				slog.String("error", violation.Message),
+			)
+		}
+	}
+	return err
+}
+
+// helmValuesValidationTargets returns the KubeAid Helm chart each of the given ArgoCD Apps
+// deploys, along with its values file. ArgoCD Apps not deploying a Helm chart from the KubeAid
+// repository (like the one syncing the Sealed Secrets) are skipped.
+func helmValuesValidationTargets(kubeaidConfigDir,
+	clusterDir,
+	kubeaidDir,
+	kubeaidForkURL string,
+	appTemplateNames []string,
+) ([]*kubernetes.HelmValuesValidationArgs, error) {
+	targets := []*kubernetes.HelmValuesValidationArgs{}
+	for _, appTemplateName := range appTemplateNames {
+		if !strings.HasPrefix(appTemplateName, "argocd-apps/templates/") {
+			continue
+		}
+
+		appFilePath := path.Join(clusterDir, strings.TrimSuffix(appTemplateName, ".tmpl"))
+
+		appFileContents, err := os.ReadFile(appFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("reading ArgoCD App %s: %w", appFilePath, err)
+		}
+
+		var app argoCDAppHelmSources
+		if err := yaml.Unmarshal(appFileContents, &app); err != nil {
+			return nil, fmt.Errorf("parsing ArgoCD App %s: %w", appFilePath, err)
+		}
+
+		sources := app.Spec.Sources
+		if app.Spec.Source != nil {
+			sources = append(sources, *app.Spec.Source)
+		}
+
+		for _, source := range sources {
+			if (source.RepoURL != kubeaidForkURL) || !strings.HasPrefix(source.Path, "argocd-helm-charts/") {
+				continue
+			}
+
+			for _, valueFile := range source.Helm.ValueFiles {
+				if !strings.HasPrefix(valueFile, argoCDValuesRefPrefix) {
+					continue
+				}
+
+				targets = append(targets, &kubernetes.HelmValuesValidationArgs{
+					ChartPath: path.Join(kubeaidDir, source.Path),
+					ValuesFilePath: path.Join(kubeaidConfigDir,
+						strings.TrimPrefix(valueFile, argoCDValuesRefPrefix),
+					),
+					ReleaseName: app.Metadata.Name,
+					Namespace:   app.Spec.Destination.Namespace,
+				})
+			}
+		}
+	}
+	return targets, nil
+}
+
+/*
+Renders the KubeAid config files for the cluster into a scratch directory, and validates the Helm
+values files among them, the same way it's done before those files get pushed to the KubeAid
+Config repository.
+
+The KubeAid repository gets cloned (if not already cloned), at the version from the general config.
+Nothing gets pushed, and the KubeAid Config repository isn't touched.
+
+Panics, if any of the Helm values files is invalid.
+*/
+func ValidateRenderedHelmValues(ctx context.Context) {
+	bar := progress.New("Validating rendered Helm values")
+	defer bar.Finish()
+	ctx = progress.WithBar(ctx, bar)
+
+	cloneKubeAidFork(ctx, gitUtils.GetGitAuthMethod(ctx))
+	bar.Substep("Cloned KubeAid repo")
+
+	// Mirrors the KubeAid Config repository's layout, so value file references in the rendered
+	// ArgoCD Apps resolve. Cleared upfront, so nothing rendered by a previous run lingers.
+	scratchDir := path.Join(constants.TempDirectory, "rendered-kubeaid-config")
+	err := os.RemoveAll(scratchDir)
+	assert.AssertErrNil(ctx, err, "Failed clearing scratch directory", slog.String("path", scratchDir))
+
+	clusterDir := path.Join(scratchDir, "k8s", config.ParsedGeneralConfig.Forks.KubeaidConfigFork.Directory)
+
+	// Monitoring doesn't matter here : KubePrometheus isn't deployed using a Helm chart.
+	createOrUpdateNonSecretFiles(ctx, getTemplateValues(ctx), clusterDir, true)
+	bar.Substep("Rendered kubeaid-config files")
+
+	err = validateRenderedHelmValues(ctx, scratchDir, clusterDir, getEmbeddedNonSecretTemplateNames())
+	assert.AssertErrNil(ctx, err, "Rendered Helm values are invalid")
+	bar.Substep("Validated Helm values")
+}
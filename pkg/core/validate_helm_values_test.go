@@ -0,0 +1,105 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/kubernetes"
+)
+
+func TestHelmValuesValidationTargets(t *testing.T) {
+	const (
+		kubeaidDir     = "/tmp/kubeaid-core/kubeaid"
+		kubeaidForkURL = "https://github.com/Obmondo/KubeAid"
+	)
+
+	kubeaidConfigDir := t.TempDir()
+	clusterDir := path.Join(kubeaidConfigDir, "k8s", "demo.example.com")
+
+	apps := map[string]string{
+		// Helm chart from the KubeAid repository, with its values file in the KubeAid Config
+		// repository.
+		"cilium.yaml": `
+metadata:
+  name: cilium
+spec:
+  destination:
+    namespace: cilium
+  sources:
+    - repoURL: https://github.com/Obmondo/KubeAid
+      path: argocd-helm-charts/cilium
+      helm:
+        valueFiles:
+          - $values/k8s/demo.example.com/argocd-apps/values-cilium.yaml
+    - repoURL: https://gitea.example.com/kubeaid-config
+      ref: values
+`,
+
+		// Plain manifests, from the KubeAid Config repository.
+		"secrets.yaml": `
+metadata:
+  name: secrets
+spec:
+  destination:
+    namespace: sealed-secrets
+  source:
+    repoURL: https://gitea.example.com/kubeaid-config
+    path: k8s/demo.example.com/sealed-secrets
+`,
+
+		// Manifests from a third party repository.
+		"external-snapshotter.yaml": `
+metadata:
+  name: external-snapshotter
+spec:
+  sources:
+    - repoURL: https://github.com/kubernetes-csi/external-snapshotter.git
+      path: deploy/kubernetes/snapshot-controller
+`,
+
+		// Left over from a previous render : not in the template list.
+		"kube2iam.yaml": `
+metadata:
+  name: kube2iam
+spec:
+  source:
+    repoURL: https://github.com/Obmondo/KubeAid
+    path: argocd-helm-charts/kube2iam
+`,
+	}
+	for name, contents := range apps {
+		appFilePath := path.Join(clusterDir, "argocd-apps/templates", name)
+		require.NoError(t, os.MkdirAll(path.Dir(appFilePath), 0o750))
+		require.NoError(t, os.WriteFile(appFilePath, []byte(contents), 0o600))
+	}
+
+	targets, err := helmValuesValidationTargets(kubeaidConfigDir, clusterDir, kubeaidDir, kubeaidForkURL,
+		[]string{
+			"argocd-apps/values-cilium.yaml.tmpl",
+			"argocd-apps/templates/cilium.yaml.tmpl",
+			"argocd-apps/templates/secrets.yaml.tmpl",
+			"argocd-apps/templates/external-snapshotter.yaml.tmpl",
+		},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*kubernetes.HelmValuesValidationArgs{{
+		ChartPath:      "/tmp/kubeaid-core/kubeaid/argocd-helm-charts/cilium",
+		ValuesFilePath: path.Join(clusterDir, "argocd-apps/values-cilium.yaml"),
+		ReleaseName:    "cilium",
+		Namespace:      "cilium",
+	}}, targets)
+
+	// A rendered ArgoCD App that's missing is an error, not something to silently skip.
+	_, err = helmValuesValidationTargets(kubeaidConfigDir, clusterDir, kubeaidDir, kubeaidForkURL,
+		[]string{"argocd-apps/templates/traefik.yaml.tmpl"},
+	)
+	require.Error(t, err)
+}
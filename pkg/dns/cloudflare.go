@@ -0,0 +1,247 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const cloudflareAPIURL = "https://api.cloudflare.com/client/v4"
+
+// cloudflareRecordComment is set on every record KubeAid CLI creates, so it's recognizable in the
+// Cloudflare dashboard. The TXT owner record is what ownership actually gets decided by.
+const cloudflareRecordComment = "Managed by KubeAid CLI"
+
+type (
+	// Cloudflare manages records through the Cloudflare API.
+	Cloudflare struct {
+		httpClient *http.Client
+		baseURL    string
+		token      string
+
+		mu sync.Mutex
+
+		// zoneIDs caches the zone ID of each FQDN.
+		zoneIDs map[string]string
+	}
+
+	// cloudflareResponse is the envelope every Cloudflare API response comes in.
+	cloudflareResponse struct {
+		Success bool `json:"success"`
+		Errors  []struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+		Result json.RawMessage `json:"result"`
+	}
+
+	cloudflareZone struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	cloudflareRecord struct {
+		ID      string `json:"id,omitempty"`
+		Type    string `json:"type"`
+		Name    string `json:"name"`
+		Content string `json:"content"`
+		TTL     int    `json:"ttl,omitempty"`
+		Proxied *bool  `json:"proxied,omitempty"`
+		Comment string `json:"comment,omitempty"`
+	}
+)
+
+// NewCloudflare returns a Cloudflare provider, authenticating with the given API token. The token
+// needs Zone:Read and DNS:Edit on the zones.
+func NewCloudflare(token string) *Cloudflare {
+	return &Cloudflare{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    cloudflareAPIURL,
+		token:      token,
+		zoneIDs:    map[string]string{},
+	}
+}
+
+func (c *Cloudflare) Records(ctx context.Context, fqdn string, recordType RecordType) ([]string, error) {
+	records, _, err := c.records(ctx, fqdn, recordType)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(records))
+	for _, record := range records {
+		values = append(values, unquoteTXT(record.Content))
+	}
+	return values, nil
+}
+
+func (c *Cloudflare) SetRecords(ctx context.Context,
+	fqdn string,
+	recordType RecordType,
+	values []string,
+	ttl int,
+) error {
+	existing, zoneID, err := c.records(ctx, fqdn, recordType)
+	if err != nil {
+		return err
+	}
+
+	proxied := false
+	for i, value := range values {
+		record := cloudflareRecord{
+			Type:    string(recordType),
+			Name:    normalizeFQDN(fqdn),
+			Content: value,
+			TTL:     ttl,
+			Comment: cloudflareRecordComment,
+		}
+		// The A records must resolve to the actual IPs : STUN / TURN and the Kubernetes API server
+		// can't go through Cloudflare's HTTP proxy.
+		if recordType == RecordTypeA {
+			record.Proxied = &proxied
+		}
+
+		// Update the existing records in place, rather than deleting and re-creating them, so the
+		// name never stops resolving.
+		if i < len(existing) {
+			err = c.do(ctx, http.MethodPut, "/zones/"+zoneID+"/dns_records/"+existing[i].ID, record, nil)
+		} else {
+			err = c.do(ctx, http.MethodPost, "/zones/"+zoneID+"/dns_records", record, nil)
+		}
+		if err != nil {
+			return fmt.Errorf("writing %s record %s: %w", recordType, fqdn, err)
+		}
+	}
+
+	for _, extra := range existing[min(len(values), len(existing)):] {
+		if err := c.do(ctx, http.MethodDelete, "/zones/"+zoneID+"/dns_records/"+extra.ID, nil, nil); err != nil {
+			return fmt.Errorf("deleting %s record %s: %w", recordType, fqdn, err)
+		}
+	}
+	return nil
+}
+
+func (c *Cloudflare) DeleteRecords(ctx context.Context, fqdn string, recordType RecordType) error {
+	existing, zoneID, err := c.records(ctx, fqdn, recordType)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range existing {
+		if err := c.do(ctx, http.MethodDelete, "/zones/"+zoneID+"/dns_records/"+record.ID, nil, nil); err != nil {
+			return fmt.Errorf("deleting %s record %s: %w", recordType, fqdn, err)
+		}
+	}
+	return nil
+}
+
+// records returns the records of the given type at fqdn, along with the ID of the zone they
+// belong to.
+func (c *Cloudflare) records(ctx context.Context,
+	fqdn string,
+	recordType RecordType,
+) ([]cloudflareRecord, string, error) {
+	zoneID, err := c.zoneID(ctx, fqdn)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query := url.Values{
+		"type":     {string(recordType)},
+		"name":     {normalizeFQDN(fqdn)},
+		"per_page": {"100"},
+	}
+
+	var records []cloudflareRecord
+	if err := c.do(ctx,
+		http.MethodGet, "/zones/"+zoneID+"/dns_records?"+query.Encode(), nil, &records,
+	); err != nil {
+		return nil, "", fmt.Errorf("listing %s records of %s: %w", recordType, fqdn, err)
+	}
+	return records, zoneID, nil
+}
+
+// zoneID returns the ID of the most specific Cloudflare zone fqdn belongs to.
+func (c *Cloudflare) zoneID(ctx context.Context, fqdn string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fqdn = normalizeFQDN(fqdn)
+	if zoneID, ok := c.zoneIDs[fqdn]; ok {
+		return zoneID, nil
+	}
+
+	for _, candidate := range parentDomains(fqdn) {
+		var zones []cloudflareZone
+		if err := c.do(ctx,
+			http.MethodGet, "/zones?"+url.Values{"name": {candidate}}.Encode(), nil, &zones,
+		); err != nil {
+			return "", fmt.Errorf("looking up Cloudflare zone %s: %w", candidate, err)
+		}
+		if len(zones) > 0 {
+			c.zoneIDs[fqdn] = zones[0].ID
+			return zones[0].ID, nil
+		}
+	}
+	return "", fmt.Errorf("no Cloudflare zone found for %s, which the API token can access", fqdn)
+}
+
+// do sends requestBody (when non-nil) JSON encoded, and decodes the result of the response
+// envelope into result (when non-nil).
+func (c *Cloudflare) do(ctx context.Context, method, path string, requestBody, result any) error {
+	var body io.Reader
+	if requestBody != nil {
+		encoded, err := json.Marshal(requestBody)
+		if err != nil {
+			return fmt.Errorf("encoding request body: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	request.Header.Set("Accept", "application/json")
+	if requestBody != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer response.Body.Close()
+
+	var envelope cloudflareResponse
+	if err := json.NewDecoder(response.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("%s %s : HTTP %d : decoding response: %w", method, path, response.StatusCode, err)
+	}
+
+	if !envelope.Success || (response.StatusCode < 200) || (response.StatusCode > 299) {
+		messages := make([]string, 0, len(envelope.Errors))
+		for _, apiError := range envelope.Errors {
+			messages = append(messages, fmt.Sprintf("%s (code %d)", apiError.Message, apiError.Code))
+		}
+		return fmt.Errorf("%s %s : HTTP %d : %s", method, path, response.StatusCode, strings.Join(messages, "; "))
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Result, result); err != nil {
+		return fmt.Errorf("decoding result of %s %s: %w", method, path, err)
+	}
+	return nil
+}
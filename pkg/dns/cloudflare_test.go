@@ -0,0 +1,137 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCloudflare serves the slice of the Cloudflare API the provider uses, for a single zone.
+type fakeCloudflare struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	nextID  int
+	records map[string]cloudflareRecord
+}
+
+func (f *fakeCloudflare) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	assert.Equal(f.t, "Bearer cf-token", r.Header.Get("Authorization"))
+
+	var result any
+	switch path := r.URL.Path; {
+	case path == "/zones":
+		zones := []cloudflareZone{}
+		if r.URL.Query().Get("name") == "example.com" {
+			zones = append(zones, cloudflareZone{ID: "zone-1", Name: "example.com"})
+		}
+		result = zones
+
+	case (path == "/zones/zone-1/dns_records") && (r.Method == http.MethodGet):
+		records := []cloudflareRecord{}
+		for _, record := range f.records {
+			if (record.Type == r.URL.Query().Get("type")) && (record.Name == r.URL.Query().Get("name")) {
+				records = append(records, record)
+			}
+		}
+		result = records
+
+	case (path == "/zones/zone-1/dns_records") && (r.Method == http.MethodPost):
+		var record cloudflareRecord
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&record))
+		f.nextID++
+		record.ID = fmt.Sprintf("record-%d", f.nextID)
+		f.records[record.ID] = record
+		result = record
+
+	case strings.HasPrefix(path, "/zones/zone-1/dns_records/"):
+		id := strings.TrimPrefix(path, "/zones/zone-1/dns_records/")
+		if _, ok := f.records[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":81044,"message":"Record does not exist."}]}`))
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			delete(f.records, id)
+			result = map[string]string{"id": id}
+			break
+		}
+
+		var record cloudflareRecord
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&record))
+		record.ID = id
+		f.records[id] = record
+		result = record
+
+	default:
+		f.t.Errorf("unexpected request %s %s", r.Method, path)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	encodedResult, err := json.Marshal(result)
+	require.NoError(f.t, err)
+	require.NoError(f.t, json.NewEncoder(w).Encode(cloudflareResponse{Success: true, Result: encodedResult}))
+}
+
+func TestCloudflare(t *testing.T) {
+	ctx := t.Context()
+
+	fake := &fakeCloudflare{t: t, records: map[string]cloudflareRecord{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	provider := NewCloudflare("cf-token")
+	provider.baseURL = server.URL
+
+	require.NoError(t, UpsertA(ctx, provider, "staging", "api.staging.example.com", "192.0.2.1", 300))
+	require.NoError(t, UpsertA(ctx, provider, "staging", "api.staging.example.com", "192.0.2.2", 300))
+
+	values, err := provider.Records(ctx, "api.staging.example.com", RecordTypeA)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.2"}, values)
+
+	// The A record got updated in place, is never proxied, and is recognizable in the dashboard.
+	require.Len(t, fake.records, 2)
+	for _, record := range fake.records {
+		assert.Equal(t, cloudflareRecordComment, record.Comment)
+		if record.Type == string(RecordTypeA) {
+			require.NotNil(t, record.Proxied)
+			assert.False(t, *record.Proxied)
+		}
+	}
+
+	require.NoError(t, DeleteA(ctx, provider, "staging", "api.staging.example.com"))
+	assert.Empty(t, fake.records)
+
+	_, err = provider.Records(ctx, "api.staging.example.org", RecordTypeA)
+	require.ErrorContains(t, err, "no Cloudflare zone found")
+}
+
+func TestCloudflareAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}]}`))
+	}))
+	defer server.Close()
+
+	provider := NewCloudflare("cf-token")
+	provider.baseURL = server.URL
+
+	_, err := provider.Records(t.Context(), "api.staging.example.com", RecordTypeA)
+	require.ErrorContains(t, err, "HTTP 403 : Authentication error (code 10000)")
+}
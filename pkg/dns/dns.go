@@ -0,0 +1,243 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dns manages the A records of a cluster's public endpoints (control-plane, Keycloak,
+// NetBird, STUN / TURN) in the operator's DNS provider : Cloudflare, Hetzner DNS, or any
+// nameserver accepting RFC2136 dynamic updates. Without it, bootstrap waits for a human to create
+// those records.
+//
+// Every A record KubeAid CLI creates gets claimed for the cluster by a TXT owner record next to
+// it, the way external-dns does it. Records KubeAid CLI didn't create are never modified or
+// deleted.
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+)
+
+const (
+	ProviderCloudflare = "cloudflare"
+	ProviderHetzner    = "hetzner"
+	ProviderRFC2136    = "rfc2136"
+)
+
+type (
+	// Provider is implemented once per supported DNS provider. It operates on whole RRsets : every
+	// record of a type at a name.
+	Provider interface {
+		// Records returns the values of the given type at fqdn. Empty when there are none.
+		Records(ctx context.Context, fqdn string, recordType RecordType) ([]string, error)
+
+		// SetRecords replaces the records of the given type at fqdn with values.
+		SetRecords(ctx context.Context, fqdn string, recordType RecordType, values []string, ttl int) error
+
+		// DeleteRecords deletes the records of the given type at fqdn. Deleting records which don't
+		// exist isn't an error.
+		DeleteRecords(ctx context.Context, fqdn string, recordType RecordType) error
+	}
+
+	RecordType string
+)
+
+const (
+	RecordTypeA   RecordType = "A"
+	RecordTypeTXT RecordType = "TXT"
+)
+
+// ownerRecordPrefix is prepended to an A record's FQDN, to get the FQDN of its TXT owner record.
+// The underscore keeps it clear of any hostname.
+const ownerRecordPrefix = "_kubeaid-cli."
+
+var (
+	// ErrOwnedByAnotherCluster is returned when an A record got created by KubeAid CLI, for another
+	// cluster.
+	ErrOwnedByAnotherCluster = errors.New("DNS record is owned by another cluster")
+
+	// ErrNotOwned is returned when an A record exists, pointing elsewhere, but wasn't created by
+	// KubeAid CLI.
+	ErrNotOwned = errors.New("DNS record wasn't created by KubeAid CLI")
+)
+
+// ownerRecordFQDN returns the FQDN of the TXT record claiming the A record at fqdn.
+func ownerRecordFQDN(fqdn string) string {
+	return ownerRecordPrefix + fqdn
+}
+
+// ownerRecordValue returns the value of the TXT record, claiming an A record for the given
+// cluster.
+func ownerRecordValue(clusterName string) string {
+	return "heritage=kubeaid-cli,cluster=" + clusterName
+}
+
+// UpsertA points fqdn to ip, claiming the A record for the given cluster. An A record already
+// pointing to ip, which KubeAid CLI didn't create, is left alone (and unclaimed). Any other A
+// record KubeAid CLI didn't create for this cluster is refused, rather than overwritten.
+func UpsertA(ctx context.Context, provider Provider, clusterName, fqdn, ip string, ttl int) error {
+	fqdn = normalizeFQDN(fqdn)
+
+	owned, err := ownedBy(ctx, provider, clusterName, fqdn)
+	if err != nil {
+		return err
+	}
+
+	if !owned {
+		existing, err := provider.Records(ctx, fqdn, RecordTypeA)
+		if err != nil {
+			return fmt.Errorf("getting A records of %s: %w", fqdn, err)
+		}
+
+		switch {
+		case slices.Equal(existing, []string{ip}):
+			slog.InfoContext(ctx, "A record already points to the expected IP; leaving it unmanaged",
+				slog.String("fqdn", fqdn), slog.String("ip", ip),
+			)
+			return nil
+
+		case len(existing) > 0:
+			return fmt.Errorf("%w : %s points to %s, instead of %s. Delete the record or point it to %s yourself",
+				ErrNotOwned, fqdn, strings.Join(existing, ", "), ip, ip,
+			)
+		}
+
+		// Claim the record before creating it. If we crash in between, the next run still recognizes
+		// it as ours.
+		if err := provider.SetRecords(ctx,
+			ownerRecordFQDN(fqdn), RecordTypeTXT, []string{ownerRecordValue(clusterName)}, ttl,
+		); err != nil {
+			return fmt.Errorf("creating TXT owner record for %s: %w", fqdn, err)
+		}
+	}
+
+	if err := provider.SetRecords(ctx, fqdn, RecordTypeA, []string{ip}, ttl); err != nil {
+		return fmt.Errorf("upserting A record %s -> %s: %w", fqdn, ip, err)
+	}
+
+	slog.InfoContext(ctx, "Upserted A record", slog.String("fqdn", fqdn), slog.String("ip", ip))
+	return nil
+}
+
+// DeleteA deletes the A record at fqdn along with its TXT owner record, when it's claimed by the
+// given cluster. Otherwise, it's left alone.
+func DeleteA(ctx context.Context, provider Provider, clusterName, fqdn string) error {
+	fqdn = normalizeFQDN(fqdn)
+
+	owned, err := ownedBy(ctx, provider, clusterName, fqdn)
+	if errors.Is(err, ErrOwnedByAnotherCluster) {
+		slog.WarnContext(ctx, "Not deleting A record owned by another cluster", slog.String("fqdn", fqdn))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !owned {
+		slog.InfoContext(ctx, "Not deleting A record KubeAid CLI didn't create", slog.String("fqdn", fqdn))
+		return nil
+	}
+
+	if err := provider.DeleteRecords(ctx, fqdn, RecordTypeA); err != nil {
+		return fmt.Errorf("deleting A record %s: %w", fqdn, err)
+	}
+	if err := provider.DeleteRecords(ctx, ownerRecordFQDN(fqdn), RecordTypeTXT); err != nil {
+		return fmt.Errorf("deleting TXT owner record for %s: %w", fqdn, err)
+	}
+
+	slog.InfoContext(ctx, "Deleted A record", slog.String("fqdn", fqdn))
+	return nil
+}
+
+// ownedBy reports whether the A record at fqdn is claimed by the given cluster. Returns
+// ErrOwnedByAnotherCluster, when it's claimed by another one.
+func ownedBy(ctx context.Context, provider Provider, clusterName, fqdn string) (bool, error) {
+	owners, err := provider.Records(ctx, ownerRecordFQDN(fqdn), RecordTypeTXT)
+	if err != nil {
+		return false, fmt.Errorf("getting TXT owner record for %s: %w", fqdn, err)
+	}
+
+	switch {
+	case len(owners) == 0:
+		return false, nil
+
+	case slices.Contains(owners, ownerRecordValue(clusterName)):
+		return true, nil
+
+	default:
+		return false, fmt.Errorf("%w : %s (%s)", ErrOwnedByAnotherCluster, fqdn, strings.Join(owners, ", "))
+	}
+}
+
+// normalizeFQDN lowercases the given FQDN, and strips the trailing dot.
+func normalizeFQDN(fqdn string) string {
+	return strings.TrimSuffix(strings.ToLower(fqdn), ".")
+}
+
+// parentDomains returns fqdn followed by each of its parent domains, down to the second level
+// domain : the candidate zones fqdn can belong to, most specific first.
+func parentDomains(fqdn string) []string {
+	labels := strings.Split(normalizeFQDN(fqdn), ".")
+
+	var domains []string
+	for i := 0; i < len(labels)-1; i++ {
+		domains = append(domains, strings.Join(labels[i:], "."))
+	}
+	return domains
+}
+
+// relativeName returns fqdn relative to the given zone : "@" for the zone apex.
+func relativeName(fqdn, zone string) string {
+	if fqdn == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(fqdn, "."+zone)
+}
+
+// unquoteTXT strips the surrounding double quotes some providers return TXT values with.
+func unquoteTXT(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// FromConfig returns the Provider configured in general.yaml (cluster.dnsRecords), authenticated
+// with the credentials from secrets.yaml. Returns nil, when cluster.dnsRecords isn't set : the
+// operator manages the records.
+func FromConfig() (Provider, error) {
+	recordsConfig := config.ParsedGeneralConfig.Cluster.DNSRecords
+	if recordsConfig == nil {
+		return nil, nil
+	}
+
+	switch recordsConfig.Provider {
+	case ProviderCloudflare:
+		return NewCloudflare(cloudflareAPIToken()), nil
+
+	case ProviderHetzner:
+		return NewHetzner(config.ParsedSecretsConfig.Hetzner.APIToken), nil
+
+	case ProviderRFC2136:
+		return NewRFC2136(*recordsConfig.RFC2136, config.ParsedSecretsConfig.DNS.TSIGSecret)
+
+	default:
+		return nil, fmt.Errorf("unsupported DNS provider %q", recordsConfig.Provider)
+	}
+}
+
+// cloudflareAPIToken returns secrets.yaml's dns.cloudflareApiToken, falling back to the token the
+// ACME DNS-01 solver already uses (acme.cloudflareApiToken).
+func cloudflareAPIToken() string {
+	secretsConfig := config.ParsedSecretsConfig
+	if (secretsConfig.DNS != nil) && (secretsConfig.DNS.CloudflareAPIToken != "") {
+		return secretsConfig.DNS.CloudflareAPIToken
+	}
+	if secretsConfig.ACME != nil {
+		return secretsConfig.ACME.CloudflareAPIToken
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider keeps the records in memory.
+type fakeProvider struct {
+	records map[string][]string
+}
+
+func newFakeProvider() *fakeProvider {
+	return &fakeProvider{records: map[string][]string{}}
+}
+
+func fakeKey(fqdn string, recordType RecordType) string {
+	return string(recordType) + " " + fqdn
+}
+
+func (f *fakeProvider) Records(_ context.Context, fqdn string, recordType RecordType) ([]string, error) {
+	return f.records[fakeKey(fqdn, recordType)], nil
+}
+
+func (f *fakeProvider) SetRecords(_ context.Context, fqdn string, recordType RecordType, values []string, _ int) error {
+	f.records[fakeKey(fqdn, recordType)] = values
+	return nil
+}
+
+func (f *fakeProvider) DeleteRecords(_ context.Context, fqdn string, recordType RecordType) error {
+	delete(f.records, fakeKey(fqdn, recordType))
+	return nil
+}
+
+func TestUpsertAAndDeleteA(t *testing.T) {
+	ctx := t.Context()
+	provider := newFakeProvider()
+
+	require.NoError(t, UpsertA(ctx, provider, "staging", "Keycloak.Example.com.", "192.0.2.1", 300))
+	assert.Equal(t, map[string][]string{
+		"A keycloak.example.com":                {"192.0.2.1"},
+		"TXT _kubeaid-cli.keycloak.example.com": {"heritage=kubeaid-cli,cluster=staging"},
+	}, provider.records)
+
+	// Re-pointing a record the cluster owns.
+	require.NoError(t, UpsertA(ctx, provider, "staging", "keycloak.example.com", "192.0.2.2", 300))
+	assert.Equal(t, []string{"192.0.2.2"}, provider.records["A keycloak.example.com"])
+
+	// Another cluster can neither re-point nor delete it.
+	err := UpsertA(ctx, provider, "production", "keycloak.example.com", "192.0.2.3", 300)
+	require.ErrorIs(t, err, ErrOwnedByAnotherCluster)
+
+	require.NoError(t, DeleteA(ctx, provider, "production", "keycloak.example.com"))
+	assert.Equal(t, []string{"192.0.2.2"}, provider.records["A keycloak.example.com"])
+
+	require.NoError(t, DeleteA(ctx, provider, "staging", "keycloak.example.com"))
+	assert.Empty(t, provider.records)
+}
+
+func TestUpsertALeavesUnownedRecordsAlone(t *testing.T) {
+	ctx := t.Context()
+	provider := newFakeProvider()
+	provider.records["A netbird.example.com"] = []string{"192.0.2.1"}
+
+	// Already pointing to the expected IP : left unmanaged.
+	require.NoError(t, UpsertA(ctx, provider, "staging", "netbird.example.com", "192.0.2.1", 300))
+	assert.NotContains(t, provider.records, "TXT _kubeaid-cli.netbird.example.com")
+
+	// Pointing elsewhere : refused.
+	err := UpsertA(ctx, provider, "staging", "netbird.example.com", "192.0.2.2", 300)
+	require.ErrorIs(t, err, ErrNotOwned)
+	assert.Equal(t, []string{"192.0.2.1"}, provider.records["A netbird.example.com"])
+
+	// Never deleted.
+	require.NoError(t, DeleteA(ctx, provider, "staging", "netbird.example.com"))
+	assert.Equal(t, []string{"192.0.2.1"}, provider.records["A netbird.example.com"])
+}
+
+func TestParentDomains(t *testing.T) {
+	assert.Equal(t,
+		[]string{"api.staging.example.com", "staging.example.com", "example.com"},
+		parentDomains("API.staging.example.com."),
+	)
+	assert.Equal(t, []string{"example.com"}, parentDomains("example.com"))
+}
+
+func TestRelativeName(t *testing.T) {
+	assert.Equal(t, "api.staging", relativeName("api.staging.example.com", "example.com"))
+	assert.Equal(t, "@", relativeName("example.com", "example.com"))
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// Hetzner manages records in Hetzner DNS, through the Cloud API. hcloud-go v1, which the rest of
+// KubeAid CLI uses, predates DNS being part of the Cloud API.
+type Hetzner struct {
+	client *hcloud.Client
+
+	mu sync.Mutex
+
+	// zones caches the zone of each FQDN.
+	zones map[string]*hcloud.Zone
+}
+
+// NewHetzner returns a Hetzner DNS provider, authenticating with the given Cloud API token.
+func NewHetzner(token string, options ...hcloud.ClientOption) *Hetzner {
+	return &Hetzner{
+		client: hcloud.NewClient(append([]hcloud.ClientOption{hcloud.WithToken(token)}, options...)...),
+		zones:  map[string]*hcloud.Zone{},
+	}
+}
+
+func (h *Hetzner) Records(ctx context.Context, fqdn string, recordType RecordType) ([]string, error) {
+	rrset, _, err := h.rrset(ctx, fqdn, recordType)
+	if (err != nil) || (rrset == nil) {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(rrset.Records))
+	for _, record := range rrset.Records {
+		values = append(values, unquoteTXT(record.Value))
+	}
+	return values, nil
+}
+
+func (h *Hetzner) SetRecords(ctx context.Context,
+	fqdn string,
+	recordType RecordType,
+	values []string,
+	ttl int,
+) error {
+	rrset, zone, err := h.rrset(ctx, fqdn, recordType)
+	if err != nil {
+		return err
+	}
+
+	records := make([]hcloud.ZoneRRSetRecord, 0, len(values))
+	for _, value := range values {
+		// Hetzner DNS wants TXT values quoted.
+		if recordType == RecordTypeTXT {
+			value = strconv.Quote(value)
+		}
+		records = append(records, hcloud.ZoneRRSetRecord{Value: value})
+	}
+
+	if rrset == nil {
+		result, _, err := h.client.Zone.CreateRRSet(ctx, zone, hcloud.ZoneRRSetCreateOpts{
+			Name:    relativeName(normalizeFQDN(fqdn), zone.Name),
+			Type:    hcloud.ZoneRRSetType(recordType),
+			TTL:     &ttl,
+			Records: records,
+		})
+		if err != nil {
+			return fmt.Errorf("creating %s record %s: %w", recordType, fqdn, err)
+		}
+		return h.waitFor(ctx, result.Action)
+	}
+
+	action, _, err := h.client.Zone.SetRRSetRecords(ctx, rrset, hcloud.ZoneRRSetSetRecordsOpts{Records: records})
+	if err != nil {
+		return fmt.Errorf("setting %s record %s: %w", recordType, fqdn, err)
+	}
+	if err := h.waitFor(ctx, action); err != nil {
+		return err
+	}
+
+	if (rrset.TTL != nil) && (*rrset.TTL == ttl) {
+		return nil
+	}
+	action, _, err = h.client.Zone.ChangeRRSetTTL(ctx, rrset, hcloud.ZoneRRSetChangeTTLOpts{TTL: &ttl})
+	if err != nil {
+		return fmt.Errorf("changing TTL of %s record %s: %w", recordType, fqdn, err)
+	}
+	return h.waitFor(ctx, action)
+}
+
+func (h *Hetzner) DeleteRecords(ctx context.Context, fqdn string, recordType RecordType) error {
+	rrset, _, err := h.rrset(ctx, fqdn, recordType)
+	if (err != nil) || (rrset == nil) {
+		return err
+	}
+
+	result, _, err := h.client.Zone.DeleteRRSet(ctx, rrset)
+	if err != nil {
+		return fmt.Errorf("deleting %s record %s: %w", recordType, fqdn, err)
+	}
+	return h.waitFor(ctx, result.Action)
+}
+
+// rrset returns the RRset of the given type at fqdn (nil when there's none), along with the zone
+// it belongs to.
+func (h *Hetzner) rrset(ctx context.Context,
+	fqdn string,
+	recordType RecordType,
+) (*hcloud.ZoneRRSet, *hcloud.Zone, error) {
+	zone, err := h.zone(ctx, fqdn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rrset, _, err := h.client.Zone.GetRRSetByNameAndType(ctx,
+		zone, relativeName(normalizeFQDN(fqdn), zone.Name), hcloud.ZoneRRSetType(recordType),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting %s record %s: %w", recordType, fqdn, err)
+	}
+	return rrset, zone, nil
+}
+
+// zone returns the most specific Hetzner DNS zone fqdn belongs to.
+func (h *Hetzner) zone(ctx context.Context, fqdn string) (*hcloud.Zone, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fqdn = normalizeFQDN(fqdn)
+	if zone, ok := h.zones[fqdn]; ok {
+		return zone, nil
+	}
+
+	for _, candidate := range parentDomains(fqdn) {
+		zone, _, err := h.client.Zone.GetByName(ctx, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("looking up Hetzner DNS zone %s: %w", candidate, err)
+		}
+		if zone != nil {
+			h.zones[fqdn] = zone
+			return zone, nil
+		}
+	}
+	return nil, fmt.Errorf("no Hetzner DNS zone found for %s, in the Hetzner project", fqdn)
+}
+
+// waitFor waits until the given action (if any) completes.
+func (h *Hetzner) waitFor(ctx context.Context, action *hcloud.Action) error {
+	if action == nil {
+		return nil
+	}
+	if err := h.client.Action.WaitFor(ctx, action); err != nil {
+		return fmt.Errorf("waiting for Hetzner DNS action %d: %w", action.ID, err)
+	}
+	return nil
+}
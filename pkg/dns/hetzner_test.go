@@ -0,0 +1,181 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHetznerDNS serves the slice of the Hetzner Cloud API the provider uses, for the example.com
+// zone. Every action completes right away.
+type fakeHetznerDNS struct {
+	t *testing.T
+
+	mu     sync.Mutex
+	rrsets map[string]schema.ZoneRRSet
+}
+
+func newFakeHetznerDNS(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	fake := &fakeHetznerDNS{t: t, rrsets: map[string]schema.ZoneRRSet{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /zones/{zone}", fake.getZone)
+	mux.HandleFunc("GET /zones/1/rrsets/{name}/{type}", fake.getRRSet)
+	mux.HandleFunc("POST /zones/1/rrsets", fake.createRRSet)
+	mux.HandleFunc("POST /zones/1/rrsets/{name}/{type}/actions/set_records", fake.setRecords)
+	mux.HandleFunc("POST /zones/1/rrsets/{name}/{type}/actions/change_ttl", fake.changeTTL)
+	mux.HandleFunc("DELETE /zones/1/rrsets/{name}/{type}", fake.deleteRRSet)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Cleanup(func() {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		assert.Empty(t, fake.rrsets)
+	})
+	return server
+}
+
+func (f *fakeHetznerDNS) getZone(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("zone") != "example.com" {
+		f.notFound(w)
+		return
+	}
+	f.respond(w, schema.ZoneGetResponse{Zone: schema.Zone{ID: 1, Name: "example.com"}})
+}
+
+func (f *fakeHetznerDNS) getRRSet(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rrset, ok := f.rrsets[r.PathValue("name")+"/"+r.PathValue("type")]
+	if !ok {
+		f.notFound(w)
+		return
+	}
+	f.respond(w, schema.ZoneRRSetGetResponse{RRSet: rrset})
+}
+
+func (f *fakeHetznerDNS) createRRSet(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var request schema.ZoneRRSetCreateRequest
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(&request))
+
+	id := request.Name + "/" + request.Type
+	rrset := schema.ZoneRRSet{
+		ID:      id,
+		Name:    request.Name,
+		Type:    request.Type,
+		TTL:     request.TTL,
+		Records: request.Records,
+		Zone:    1,
+	}
+	f.rrsets[id] = rrset
+
+	f.respond(w, schema.ZoneRRSetCreateResponse{RRSet: rrset, Action: completedAction()})
+}
+
+func (f *fakeHetznerDNS) setRecords(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var request schema.ZoneRRSetSetRecordsRequest
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(&request))
+
+	id := r.PathValue("name") + "/" + r.PathValue("type")
+	rrset := f.rrsets[id]
+	rrset.Records = request.Records
+	f.rrsets[id] = rrset
+
+	f.respond(w, schema.ActionGetResponse{Action: completedAction()})
+}
+
+func (f *fakeHetznerDNS) changeTTL(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var request schema.ZoneRRSetChangeTTLRequest
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(&request))
+
+	id := r.PathValue("name") + "/" + r.PathValue("type")
+	rrset := f.rrsets[id]
+	rrset.TTL = request.TTL
+	f.rrsets[id] = rrset
+
+	f.respond(w, schema.ActionGetResponse{Action: completedAction()})
+}
+
+func (f *fakeHetznerDNS) deleteRRSet(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.rrsets, r.PathValue("name")+"/"+r.PathValue("type"))
+	f.respond(w, schema.ActionGetResponse{Action: completedAction()})
+}
+
+func (f *fakeHetznerDNS) respond(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(f.t, json.NewEncoder(w).Encode(body))
+}
+
+func (f *fakeHetznerDNS) notFound(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"error":{"code":"not_found","message":"not found"}}`))
+}
+
+func completedAction() schema.Action {
+	return schema.Action{ID: 1, Status: string(hcloud.ActionStatusSuccess)}
+}
+
+func TestHetzner(t *testing.T) {
+	ctx := t.Context()
+
+	server := newFakeHetznerDNS(t)
+	provider := NewHetzner("hcloud-token", hcloud.WithEndpoint(server.URL))
+
+	require.NoError(t, UpsertA(ctx, provider, "staging", "ingress.staging.example.com", "192.0.2.1", 300))
+
+	// The ownership TXT record is stored quoted, but read back as is.
+	rrset, _, err := provider.rrset(ctx, "_kubeaid-cli.ingress.staging.example.com", RecordTypeTXT)
+	require.NoError(t, err)
+	require.NotNil(t, rrset)
+	assert.Equal(t, `"heritage=kubeaid-cli,cluster=staging"`, rrset.Records[0].Value)
+
+	require.NoError(t, provider.SetRecords(ctx, "ingress.staging.example.com", RecordTypeA, []string{"192.0.2.2"}, 600))
+
+	rrset, _, err = provider.rrset(ctx, "ingress.staging.example.com", RecordTypeA)
+	require.NoError(t, err)
+	require.NotNil(t, rrset)
+	assert.Equal(t, "ingress.staging", rrset.Name)
+	assert.Equal(t, []hcloud.ZoneRRSetRecord{{Value: "192.0.2.2"}}, rrset.Records)
+	assert.Equal(t, 600, *rrset.TTL)
+
+	require.NoError(t, DeleteA(ctx, provider, "staging", "ingress.staging.example.com"))
+
+	values, err := provider.Records(ctx, "ingress.staging.example.com", RecordTypeA)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	_, err = provider.Records(ctx, "ingress.staging.example.org", RecordTypeA)
+	require.ErrorContains(t, err, "no Hetzner DNS zone found")
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	miekgDNS "github.com/miekg/dns"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+)
+
+const (
+	// rfc2136Timeout caps each exchange with the nameserver.
+	rfc2136Timeout = 10 * time.Second
+
+	// tsigFudge is the permitted clock skew, in seconds, between us and the nameserver.
+	tsigFudge = 300
+)
+
+// tsigAlgorithms maps the supported TSIG algorithms to their name in the TSIG RR.
+var tsigAlgorithms = map[string]string{
+	"hmac-sha256": miekgDNS.HmacSHA256,
+	"hmac-sha512": miekgDNS.HmacSHA512,
+}
+
+// RFC2136 manages records through TSIG signed dynamic updates (RFC2136), sent to the zone's
+// primary nameserver. Records are read back by querying that nameserver directly, so there are no
+// caches in the way. Everything goes over TCP.
+type RFC2136 struct {
+	nameserver,
+	zone string
+
+	tsigKeyName,
+	tsigAlgorithm string
+
+	// client signs the updates, and verifies the signature of the nameserver's responses.
+	client *miekgDNS.Client
+
+	// now is overridable by tests.
+	now func() time.Time
+}
+
+// NewRFC2136 returns an RFC2136 provider for the given nameserver and zone, signing the updates
+// with the given base64 encoded TSIG secret.
+func NewRFC2136(rfc2136Config config.RFC2136Config, tsigSecret string) (*RFC2136, error) {
+	tsigAlgorithm, ok := tsigAlgorithms[rfc2136Config.TSIGAlgorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TSIG algorithm %q", rfc2136Config.TSIGAlgorithm)
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(tsigSecret); err != nil {
+		return nil, fmt.Errorf("decoding TSIG secret : it must be base64 encoded: %w", err)
+	}
+
+	nameserver := rfc2136Config.Nameserver
+	if _, _, err := net.SplitHostPort(nameserver); err != nil {
+		nameserver = net.JoinHostPort(nameserver, "53")
+	}
+
+	tsigKeyName := miekgDNS.Fqdn(normalizeFQDN(rfc2136Config.TSIGKeyName))
+
+	return &RFC2136{
+		nameserver:    nameserver,
+		zone:          normalizeFQDN(rfc2136Config.Zone),
+		tsigKeyName:   tsigKeyName,
+		tsigAlgorithm: tsigAlgorithm,
+		client: &miekgDNS.Client{
+			Net:        "tcp",
+			Timeout:    rfc2136Timeout,
+			TsigSecret: map[string]string{tsigKeyName: tsigSecret},
+		},
+		now: time.Now,
+	}, nil
+}
+
+func (r *RFC2136) Records(ctx context.Context, fqdn string, recordType RecordType) ([]string, error) {
+	fqdn = normalizeFQDN(fqdn)
+
+	name, rrType, err := r.nameAndType(fqdn, recordType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := new(miekgDNS.Msg).SetQuestion(name, rrType)
+
+	response, _, err := r.client.ExchangeContext(ctx, query, r.nameserver)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s records of %s: %w", recordType, fqdn, err)
+	}
+	if response.Rcode == miekgDNS.RcodeNameError {
+		return nil, nil
+	}
+	if response.Rcode != miekgDNS.RcodeSuccess {
+		return nil, fmt.Errorf("querying %s records of %s : nameserver answered %s",
+			recordType, fqdn, miekgDNS.RcodeToString[response.Rcode])
+	}
+
+	var values []string
+	for _, answer := range response.Answer {
+		if !strings.EqualFold(answer.Header().Name, name) {
+			continue
+		}
+
+		switch answer := answer.(type) {
+		case *miekgDNS.A:
+			if recordType == RecordTypeA {
+				values = append(values, answer.A.String())
+			}
+
+		case *miekgDNS.TXT:
+			if recordType == RecordTypeTXT {
+				values = append(values, strings.Join(answer.Txt, ""))
+			}
+		}
+	}
+	return values, nil
+}
+
+func (r *RFC2136) SetRecords(ctx context.Context,
+	fqdn string,
+	recordType RecordType,
+	values []string,
+	ttl int,
+) error {
+	return r.update(ctx, normalizeFQDN(fqdn), recordType, values, ttl)
+}
+
+func (r *RFC2136) DeleteRecords(ctx context.Context, fqdn string, recordType RecordType) error {
+	return r.update(ctx, normalizeFQDN(fqdn), recordType, nil, 0)
+}
+
+// update atomically replaces the RRset of the given type at fqdn with values : a single UPDATE
+// message deleting the RRset, then adding each value (RFC2136 section 2.5). With no values, the
+// RRset just gets deleted.
+func (r *RFC2136) update(ctx context.Context,
+	fqdn string,
+	recordType RecordType,
+	values []string,
+	ttl int,
+) error {
+	name, rrType, err := r.nameAndType(fqdn, recordType)
+	if err != nil {
+		return err
+	}
+
+	header := miekgDNS.RR_Header{
+		Name:   name,
+		Rrtype: rrType,
+		Class:  miekgDNS.ClassINET,
+		Ttl:    uint32(ttl), //nolint:gosec // G115: TTLs are validated to be small positive numbers.
+	}
+
+	records := make([]miekgDNS.RR, 0, len(values))
+	for _, value := range values {
+		switch recordType {
+		case RecordTypeA:
+			ip := net.ParseIP(value).To4()
+			if ip == nil {
+				return fmt.Errorf("%s isn't an IPv4 address", value)
+			}
+			records = append(records, &miekgDNS.A{Hdr: header, A: ip})
+
+		case RecordTypeTXT:
+			records = append(records, &miekgDNS.TXT{Hdr: header, Txt: []string{value}})
+		}
+	}
+
+	message := new(miekgDNS.Msg).SetUpdate(miekgDNS.Fqdn(r.zone))
+	message.RemoveRRset([]miekgDNS.RR{&miekgDNS.ANY{Hdr: header}})
+	message.Insert(records)
+	message.SetTsig(r.tsigKeyName, r.tsigAlgorithm, tsigFudge, r.now().Unix())
+
+	response, _, err := r.client.ExchangeContext(ctx, message, r.nameserver)
+
+	// A nameserver rejecting the TSIG key answers NOTAUTH, without signing its response.
+	if (response != nil) && (response.Rcode == miekgDNS.RcodeNotAuth) {
+		return fmt.Errorf("updating %s records of %s : nameserver rejected the TSIG key %s",
+			recordType, fqdn, strings.TrimSuffix(r.tsigKeyName, "."))
+	}
+	if err != nil {
+		return fmt.Errorf("updating %s records of %s: %w", recordType, fqdn, err)
+	}
+	if response.Rcode != miekgDNS.RcodeSuccess {
+		return fmt.Errorf("updating %s records of %s : nameserver answered %s",
+			recordType, fqdn, miekgDNS.RcodeToString[response.Rcode])
+	}
+	return nil
+}
+
+// nameAndType converts fqdn and recordType to their wire counterparts, making sure fqdn belongs to
+// the zone.
+func (r *RFC2136) nameAndType(fqdn string, recordType RecordType) (string, uint16, error) {
+	if (fqdn != r.zone) && !strings.HasSuffix(fqdn, "."+r.zone) {
+		return "", 0, fmt.Errorf("%s doesn't belong to the zone %s (cluster.dnsRecords.rfc2136.zone)", fqdn, r.zone)
+	}
+
+	if _, ok := miekgDNS.IsDomainName(fqdn); !ok {
+		return "", 0, fmt.Errorf("%s isn't a valid domain name", fqdn)
+	}
+	name := miekgDNS.Fqdn(fqdn)
+
+	switch recordType {
+	case RecordTypeA:
+		return name, miekgDNS.TypeA, nil
+
+	case RecordTypeTXT:
+		return name, miekgDNS.TypeTXT, nil
+
+	default:
+		return "", 0, fmt.Errorf("unsupported record type %s", recordType)
+	}
+}
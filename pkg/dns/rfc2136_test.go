@@ -0,0 +1,222 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"encoding/base64"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	miekgDNS "github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+)
+
+const (
+	testTSIGKeyName = "kubeaid."
+	testTSIGSecret  = "c2VjcmV0LXNoYXJlZC13aXRoLXRoZS1uYW1lc2VydmVy"
+)
+
+// fakeNameserver is an authoritative nameserver for example.com, accepting dynamic updates signed
+// with the test TSIG key (hmac-sha256), and signing its responses to them.
+type fakeNameserver struct {
+	t       *testing.T
+	address string
+
+	// acceptUnverified makes it apply updates whose TSIG signature doesn't verify.
+	acceptUnverified bool
+
+	mu      sync.Mutex
+	records map[string][]string
+}
+
+func newFakeNameserver(t *testing.T, tsigSecret string) *fakeNameserver {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	nameserver := &fakeNameserver{t: t, address: listener.Addr().String(), records: map[string][]string{}}
+
+	started := make(chan struct{})
+	server := &miekgDNS.Server{
+		Listener:          listener,
+		Handler:           miekgDNS.HandlerFunc(nameserver.handle),
+		TsigSecret:        map[string]string{testTSIGKeyName: tsigSecret},
+		NotifyStartedFunc: func() { close(started) },
+
+		// The default one turns dynamic updates away.
+		MsgAcceptFunc: func(miekgDNS.Header) miekgDNS.MsgAcceptAction { return miekgDNS.MsgAccept },
+	}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	<-started
+	return nameserver
+}
+
+func (f *fakeNameserver) handle(w miekgDNS.ResponseWriter, request *miekgDNS.Msg) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	response := new(miekgDNS.Msg).SetReply(request)
+	if request.Opcode == miekgDNS.OpcodeUpdate {
+		f.handleUpdate(w, request, response)
+	} else {
+		f.handleQuery(request, response)
+	}
+	_ = w.WriteMsg(response)
+}
+
+func (f *fakeNameserver) handleQuery(request, response *miekgDNS.Msg) {
+	question := request.Question[0]
+
+	values, ok := f.records[recordKey(question.Name, question.Qtype)]
+	if !ok {
+		response.Rcode = miekgDNS.RcodeNameError
+		return
+	}
+
+	header := miekgDNS.RR_Header{Name: question.Name, Rrtype: question.Qtype, Class: miekgDNS.ClassINET, Ttl: 300}
+	for _, value := range values {
+		if question.Qtype == miekgDNS.TypeA {
+			response.Answer = append(response.Answer, &miekgDNS.A{Hdr: header, A: net.ParseIP(value)})
+		} else {
+			response.Answer = append(response.Answer, &miekgDNS.TXT{Hdr: header, Txt: []string{value}})
+		}
+	}
+}
+
+func (f *fakeNameserver) handleUpdate(w miekgDNS.ResponseWriter, request, response *miekgDNS.Msg) {
+	assert.Equal(f.t, "example.com.", request.Question[0].Name)
+	assert.Equal(f.t, miekgDNS.TypeSOA, request.Question[0].Qtype)
+
+	tsig := request.IsTsig()
+	if (tsig == nil) || ((w.TsigStatus() != nil) && !f.acceptUnverified) {
+		response.Rcode = miekgDNS.RcodeNotAuth
+		return
+	}
+	assert.Equal(f.t, miekgDNS.HmacSHA256, tsig.Algorithm)
+
+	for _, update := range request.Ns {
+		header := update.Header()
+		key := recordKey(header.Name, header.Rrtype)
+
+		switch header.Class {
+		case miekgDNS.ClassANY:
+			delete(f.records, key)
+
+		case miekgDNS.ClassINET:
+			switch update := update.(type) {
+			case *miekgDNS.A:
+				f.records[key] = append(f.records[key], update.A.String())
+
+			case *miekgDNS.TXT:
+				f.records[key] = append(f.records[key], strings.Join(update.Txt, ""))
+
+			default:
+				f.t.Errorf("unexpected update %v", update)
+			}
+
+		default:
+			f.t.Errorf("unexpected update class %v", header.Class)
+		}
+	}
+
+	response.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsig.Fudge, int64(tsig.TimeSigned)) //nolint:gosec // G115: the test's own clock.
+}
+
+func recordKey(name string, rrType uint16) string {
+	return strings.ToLower(name) + " " + miekgDNS.TypeToString[rrType]
+}
+
+func newTestRFC2136(t *testing.T, nameserver *fakeNameserver, tsigSecret string) *RFC2136 {
+	t.Helper()
+
+	provider, err := NewRFC2136(config.RFC2136Config{
+		Nameserver:    nameserver.address,
+		Zone:          "example.com",
+		TSIGKeyName:   testTSIGKeyName,
+		TSIGAlgorithm: "hmac-sha256",
+	}, tsigSecret)
+	require.NoError(t, err)
+	return provider
+}
+
+func TestRFC2136(t *testing.T) {
+	ctx := t.Context()
+
+	nameserver := newFakeNameserver(t, testTSIGSecret)
+	provider := newTestRFC2136(t, nameserver, testTSIGSecret)
+
+	values, err := provider.Records(ctx, "stun.example.com", RecordTypeA)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	require.NoError(t, UpsertA(ctx, provider, "staging", "stun.example.com", "192.0.2.1", 300))
+	require.NoError(t, UpsertA(ctx, provider, "staging", "stun.example.com", "192.0.2.2", 300))
+	assert.Equal(t, map[string][]string{
+		"stun.example.com. A":                {"192.0.2.2"},
+		"_kubeaid-cli.stun.example.com. TXT": {"heritage=kubeaid-cli,cluster=staging"},
+	}, nameserver.records)
+
+	values, err = provider.Records(ctx, "stun.example.com", RecordTypeA)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.2"}, values)
+
+	require.NoError(t, DeleteA(ctx, provider, "staging", "stun.example.com"))
+	assert.Empty(t, nameserver.records)
+
+	_, err = provider.Records(ctx, "stun.example.org", RecordTypeA)
+	require.ErrorContains(t, err, "doesn't belong to the zone example.com")
+}
+
+func TestRFC2136RejectedTSIGKey(t *testing.T) {
+	nameserver := newFakeNameserver(t, testTSIGSecret)
+	provider := newTestRFC2136(t, nameserver, base64.StdEncoding.EncodeToString([]byte("wrong-secret")))
+
+	err := provider.SetRecords(t.Context(), "stun.example.com", RecordTypeA, []string{"192.0.2.1"}, 300)
+	require.ErrorContains(t, err, "nameserver rejected the TSIG key kubeaid")
+	assert.Empty(t, nameserver.records)
+}
+
+// TestRFC2136ForgedResponse verifies a response which isn't signed with our TSIG secret gets
+// rejected : here, by a nameserver which takes any update, and signs its responses with another
+// secret.
+func TestRFC2136ForgedResponse(t *testing.T) {
+	nameserver := newFakeNameserver(t, base64.StdEncoding.EncodeToString([]byte("forged-secret")))
+	provider := newTestRFC2136(t, nameserver, testTSIGSecret)
+	nameserver.mu.Lock()
+	nameserver.acceptUnverified = true
+	nameserver.mu.Unlock()
+
+	err := provider.SetRecords(t.Context(), "stun.example.com", RecordTypeA, []string{"192.0.2.1"}, 300)
+	require.ErrorIs(t, err, miekgDNS.ErrSig)
+}
+
+func TestNewRFC2136(t *testing.T) {
+	rfc2136Config := config.RFC2136Config{
+		Nameserver:    "ns1.example.com",
+		Zone:          "Example.com.",
+		TSIGKeyName:   testTSIGKeyName,
+		TSIGAlgorithm: "hmac-sha256",
+	}
+
+	provider, err := NewRFC2136(rfc2136Config, testTSIGSecret)
+	require.NoError(t, err)
+	assert.Equal(t, "ns1.example.com:53", provider.nameserver)
+	assert.Equal(t, "example.com", provider.zone)
+	assert.Equal(t, miekgDNS.HmacSHA256, provider.tsigAlgorithm)
+
+	_, err = NewRFC2136(rfc2136Config, "not base64!")
+	require.ErrorContains(t, err, "must be base64 encoded")
+
+	rfc2136Config.TSIGAlgorithm = "hmac-md5"
+	_, err = NewRFC2136(rfc2136Config, testTSIGSecret)
+	require.ErrorContains(t, err, `unsupported TSIG algorithm "hmac-md5"`)
+}
@@ -53,6 +53,11 @@ var (
 	AzureStorageAccountAccessKey string
 	IsDebugModeEnabled bool
 
+	// IsNonInteractiveModeEnabled is --non-interactive, and AnswersFilePath --answers-file.
+	// Both get folded into answers.Parsed, which is what approval points consult.
+	IsNonInteractiveModeEnabled bool
+	AnswersFilePath             string
+
 	// LogFile is this run's log file under outputs/logs/, opened once in
 	// cmd/kubeaid-core/root/root.go. Writers other than the slog logger (e.g. captured KubeOne
 	// output) must reuse this handle - the file isn't opened in append mode, so a second file
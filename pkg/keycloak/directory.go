@@ -0,0 +1,236 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+// DirectorySpec declares the users and groups of a Keycloak realm,
+// populated from cluster.keycloak.users / groups. It lets operators
+// onboard and offboard engineers through a kubeaid-config PR.
+type DirectorySpec struct {
+	// Realm is the Keycloak realm name (see cluster.keycloak.realm).
+	Realm string
+
+	// Users are created when missing and updated when their profile
+	// drifted. Each user's memberships of the groups listed in Groups
+	// are kept in sync with UserSpec.Groups.
+	Users []UserSpec
+
+	// Groups are created when missing.
+	Groups []GroupSpec
+
+	// Prune deletes the users ReconcileDirectory created, and the
+	// groups ReconcileGroup created, that are no longer listed —
+	// turning the lists into the realm's source of truth. Users and
+	// groups created by other means (the admin console, NetBird, LDAP
+	// / Kerberos federation, a client's service account) are never
+	// pruned.
+	Prune bool
+}
+
+// managedByAttribute marks the users ReconcileDirectory created, and
+// the groups ReconcileGroup created, with managedByAttributeValue :
+// the only ones ReconcileDirectory's pruning may delete.
+const (
+	managedByAttribute      = "kubeaid.io/managed-by"
+	managedByAttributeValue = "kubeaid-cli"
+)
+
+// listPageSize is the page size used when walking Keycloak's paged
+// user and group listings.
+const listPageSize = 100
+
+// serviceAccountUsernamePrefix is the username prefix Keycloak gives
+// the user backing a client's service account.
+const serviceAccountUsernamePrefix = "service-account-"
+
+// ReconcileDirectory makes the realm's users and groups match spec.
+// Idempotent — a second call with the same spec writes nothing.
+//
+// Order matters — groups must exist before users can join them, and
+// pruning runs last, so a group renamed in the same PR has its new
+// members in place before the old group goes away.
+func (r *Reconciler) ReconcileDirectory(ctx context.Context, spec DirectorySpec) error {
+	if spec.Realm == "" {
+		return fmt.Errorf("ReconcileDirectory: spec.Realm is required")
+	}
+
+	groupIDs := make(map[string]string, len(spec.Groups))
+	for _, group := range spec.Groups {
+		id, err := r.ReconcileGroup(ctx, spec.Realm, group)
+		if err != nil {
+			return err
+		}
+		groupIDs[group.Name] = id
+	}
+
+	for _, user := range spec.Users {
+		id, _, err := r.upsertUser(ctx, spec.Realm, user, true)
+		if err != nil {
+			return err
+		}
+		if err := r.syncGroupMemberships(ctx, spec.Realm, id, user, groupIDs); err != nil {
+			return err
+		}
+	}
+
+	if !spec.Prune {
+		return nil
+	}
+	if err := r.pruneUsers(ctx, spec); err != nil {
+		return err
+	}
+	return r.pruneGroups(ctx, spec)
+}
+
+// syncGroupMemberships adds the user to the managed groups it should
+// be a member of, and removes it from the managed groups it no longer
+// should be. Memberships of groups outside groupIDs are left alone.
+func (r *Reconciler) syncGroupMemberships(
+	ctx context.Context,
+	realm, userID string,
+	user UserSpec,
+	groupIDs map[string]string,
+) error {
+	current, err := r.api.GetUserGroups(ctx, r.token, realm, userID, gocloak.GetGroupsParams{})
+	if err != nil {
+		return fmt.Errorf("listing groups of user %q in realm %q: %w", user.Username, realm, err)
+	}
+
+	for _, name := range user.Groups {
+		if findGroupByName(current, name) != nil {
+			continue
+		}
+		groupID, ok := groupIDs[name]
+		if !ok {
+			return fmt.Errorf("user %q is a member of undeclared group %q", user.Username, name)
+		}
+		if err := r.api.AddUserToGroup(ctx, r.token, realm, userID, groupID); err != nil {
+			return fmt.Errorf(
+				"adding user %q to group %q in realm %q: %w",
+				user.Username, name, realm, err,
+			)
+		}
+	}
+
+	for _, group := range current {
+		name := gocloak.PString(group.Name)
+		if _, managed := groupIDs[name]; !managed || slices.Contains(user.Groups, name) {
+			continue
+		}
+		if err := r.api.DeleteUserFromGroup(ctx, r.token, realm, userID, gocloak.PString(group.ID)); err != nil {
+			return fmt.Errorf(
+				"removing user %q from group %q in realm %q: %w",
+				user.Username, name, realm, err,
+			)
+		}
+	}
+	return nil
+}
+
+// pruneUsers deletes the users ReconcileDirectory created which spec
+// doesn't list anymore.
+func (r *Reconciler) pruneUsers(ctx context.Context, spec DirectorySpec) error {
+	users, err := r.listUsers(ctx, spec.Realm)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		username := gocloak.PString(user.Username)
+		if !isPrunableUser(user) || slices.ContainsFunc(spec.Users, func(u UserSpec) bool {
+			return u.Username == username
+		}) {
+			continue
+		}
+		if err := r.api.DeleteUser(ctx, r.token, spec.Realm, gocloak.PString(user.ID)); err != nil {
+			return fmt.Errorf("deleting user %q from realm %q: %w", username, spec.Realm, err)
+		}
+	}
+	return nil
+}
+
+// pruneGroups deletes the top-level groups ReconcileGroup created
+// which spec doesn't list anymore. Groups created by hand, or by
+// another tool, are left alone. Deleting a group takes its subgroups
+// with it.
+func (r *Reconciler) pruneGroups(ctx context.Context, spec DirectorySpec) error {
+	groups, err := r.listGroups(ctx, spec.Realm)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		name := gocloak.PString(group.Name)
+		if !isManaged(group.Attributes) ||
+			slices.ContainsFunc(spec.Groups, func(g GroupSpec) bool { return g.Name == name }) {
+			continue
+		}
+		if err := r.api.DeleteGroup(ctx, r.token, spec.Realm, gocloak.PString(group.ID)); err != nil {
+			return fmt.Errorf("deleting group %q from realm %q: %w", name, spec.Realm, err)
+		}
+	}
+	return nil
+}
+
+// listUsers returns every user in the realm, attributes included.
+// Keycloak pages the listing (100 users by default), so it's walked a
+// page at a time.
+func (r *Reconciler) listUsers(ctx context.Context, realm string) ([]*gocloak.User, error) {
+	var all []*gocloak.User
+	for first := 0; ; first += listPageSize {
+		page, err := r.api.GetUsers(ctx, r.token, realm, gocloak.GetUsersParams{
+			First: gocloak.IntP(first),
+			Max:   gocloak.IntP(listPageSize),
+
+			// Keep the attributes in, whatever the Keycloak version
+			// defaults to.
+			BriefRepresentation: gocloak.BoolP(false),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing users in realm %q: %w", realm, err)
+		}
+		all = append(all, page...)
+		if len(page) < listPageSize {
+			return all, nil
+		}
+	}
+}
+
+// isPrunableUser reports whether the user is one pruning may delete :
+// one ReconcileDirectory created. Service-account users belong to
+// their client (deleting one breaks the client's client-credentials
+// grant), and federated users belong to the LDAP / Kerberos directory
+// they're synced from, whatever they're marked with.
+func isPrunableUser(user *gocloak.User) bool {
+	if user.ServiceAccountClientID != nil ||
+		strings.HasPrefix(gocloak.PString(user.Username), serviceAccountUsernamePrefix) {
+		return false
+	}
+	return (gocloak.PString(user.FederationLink) == "") && isManaged(user.Attributes)
+}
+
+// isManaged reports whether the user or group, given its attributes,
+// is marked with managedByAttribute.
+func isManaged(attributes *map[string][]string) bool {
+	if attributes == nil {
+		return false
+	}
+	return slices.Contains((*attributes)[managedByAttribute], managedByAttributeValue)
+}
+
+// managedByAttributes returns the attributes marking a user or group
+// with managedByAttribute.
+func managedByAttributes() *map[string][]string {
+	return &map[string][]string{
+		managedByAttribute: {managedByAttributeValue},
+	}
+}
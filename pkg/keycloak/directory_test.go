@@ -0,0 +1,210 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package keycloak
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// findUser returns the *gocloak.User with the given username from the
+// fake's realm map, or nil if not found.
+func findUser(fake *fakeKeycloak, realm, username string) *gocloak.User {
+	for _, u := range fake.users[realm] {
+		if derefString(u.Username) == username {
+			return u
+		}
+	}
+	return nil
+}
+
+// groupNames returns the sorted names of the realm's groups.
+func groupNames(fake *fakeKeycloak, realm string) []string {
+	names := []string{}
+	for _, g := range fake.groups[realm] {
+		names = append(names, derefString(g.Name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// memberships returns the sorted names of the groups the user is a
+// member of.
+func memberships(fake *fakeKeycloak, realm, username string) []string {
+	names := []string{}
+	user := findUser(fake, realm, username)
+	if user == nil {
+		return names
+	}
+	for _, groupID := range fake.userGroups[realm][derefString(user.ID)] {
+		names = append(names, derefString(fake.groups[realm][groupID].Name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func testDirectorySpec() DirectorySpec {
+	return DirectorySpec{
+		Realm: testRealm,
+		Groups: []GroupSpec{
+			{Name: "platform-admins"},
+			{Name: "developers"},
+		},
+		Users: []UserSpec{
+			{
+				Username: "alice",
+				Email:    "alice@acme.com",
+				Enabled:  true,
+				Groups:   []string{"platform-admins", "developers"},
+			},
+			{
+				Username: "bob",
+				Email:    "bob@acme.com",
+				Enabled:  true,
+				Groups:   []string{"developers"},
+			},
+		},
+	}
+}
+
+func TestReconcileDirectory_RequiresRealm(t *testing.T) {
+	t.Parallel()
+
+	r, _ := newTestReconciler(t)
+	err := r.ReconcileDirectory(context.Background(), DirectorySpec{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.Realm")
+}
+
+// TestReconcileDirectory_HappyPathAndIdempotent creates the groups,
+// the users and their memberships, then verifies a second pass with
+// the same spec writes nothing.
+func TestReconcileDirectory_HappyPathAndIdempotent(t *testing.T) {
+	t.Parallel()
+
+	r, fake := newTestReconciler(t)
+	seedRealm(t, r, fake)
+
+	spec := testDirectorySpec()
+	require.NoError(t, r.ReconcileDirectory(context.Background(), spec))
+
+	assert.Equal(t, []string{"developers", "platform-admins"}, groupNames(fake, testRealm))
+	assert.Equal(t, []string{"developers", "platform-admins"}, memberships(fake, testRealm, "alice"))
+	assert.Equal(t, []string{"developers"}, memberships(fake, testRealm, "bob"))
+
+	alice := findUser(fake, testRealm, "alice")
+	require.NotNil(t, alice)
+	assert.True(t, gocloak.PBool(alice.Enabled))
+	assert.True(t, gocloak.PBool(alice.EmailVerified))
+
+	firstRun := fake.writeCount
+	require.NoError(t, r.ReconcileDirectory(context.Background(), spec))
+	assert.Equal(t, firstRun, fake.writeCount,
+		"second ReconcileDirectory must not write anything new")
+}
+
+// TestReconcileDirectory_SyncsMemberships checks a user dropped from a
+// declared group leaves it, while a membership of a group kubeaid-cli
+// doesn't manage survives.
+func TestReconcileDirectory_SyncsMemberships(t *testing.T) {
+	t.Parallel()
+
+	r, fake := newTestReconciler(t)
+	seedRealm(t, r, fake)
+
+	// A group created by hand in the admin console, which alice is a
+	// member of.
+	manualGroupID, err := r.api.CreateGroup(context.Background(), r.token, testRealm, gocloak.Group{
+		Name: gocloak.StringP("auditors"),
+	})
+	require.NoError(t, err)
+
+	spec := testDirectorySpec()
+	require.NoError(t, r.ReconcileDirectory(context.Background(), spec))
+
+	alice := findUser(fake, testRealm, "alice")
+	require.NotNil(t, alice)
+	require.NoError(t, r.api.AddUserToGroup(context.Background(), r.token, testRealm,
+		derefString(alice.ID), manualGroupID,
+	))
+
+	spec.Users[0].Groups = []string{"developers"}
+	require.NoError(t, r.ReconcileDirectory(context.Background(), spec))
+
+	assert.Equal(t, []string{"auditors", "developers"}, memberships(fake, testRealm, "alice"))
+}
+
+func TestReconcileDirectory_UndeclaredGroup(t *testing.T) {
+	t.Parallel()
+
+	r, fake := newTestReconciler(t)
+	seedRealm(t, r, fake)
+
+	spec := testDirectorySpec()
+	spec.Users[1].Groups = []string{"sre"}
+
+	err := r.ReconcileDirectory(context.Background(), spec)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `undeclared group "sre"`)
+}
+
+// TestReconcileDirectory_Prune offboards bob and drops the developers
+// group, leaving the netbird-backend service-account user, federated
+// users, and a user and group created by hand alone.
+func TestReconcileDirectory_Prune(t *testing.T) {
+	t.Parallel()
+
+	r, fake := newTestReconciler(t)
+	seedRealm(t, r, fake)
+
+	spec := testDirectorySpec()
+	require.NoError(t, r.ReconcileDirectory(context.Background(), spec))
+
+	fake.users[testRealm]["id-sa"] = &gocloak.User{
+		ID:       gocloak.StringP("id-sa"),
+		Username: gocloak.StringP("service-account-netbird-backend"),
+	}
+	fake.users[testRealm]["id-ldap"] = &gocloak.User{
+		ID:             gocloak.StringP("id-ldap"),
+		Username:       gocloak.StringP("carol"),
+		FederationLink: gocloak.StringP("ldap-provider"),
+	}
+
+	// A user and a group created by hand in the admin console (or by
+	// NetBird, on first login).
+	_, err := r.api.CreateUser(context.Background(), r.token, testRealm, gocloak.User{
+		Username: gocloak.StringP("dave"),
+	})
+	require.NoError(t, err)
+	_, err = r.api.CreateGroup(context.Background(), r.token, testRealm, gocloak.Group{
+		Name: gocloak.StringP("auditors"),
+	})
+	require.NoError(t, err)
+
+	spec.Users = spec.Users[:1]
+	spec.Users[0].Groups = []string{"platform-admins"}
+	spec.Groups = spec.Groups[:1]
+
+	// Without pruning, bob and the developers group stay.
+	require.NoError(t, r.ReconcileDirectory(context.Background(), spec))
+	assert.NotNil(t, findUser(fake, testRealm, "bob"))
+	assert.Equal(t, []string{"auditors", "developers", "platform-admins"}, groupNames(fake, testRealm))
+
+	spec.Prune = true
+	require.NoError(t, r.ReconcileDirectory(context.Background(), spec))
+
+	assert.Nil(t, findUser(fake, testRealm, "bob"), "bob is no longer listed")
+	assert.NotNil(t, findUser(fake, testRealm, "alice"))
+	assert.NotNil(t, findUser(fake, testRealm, "service-account-netbird-backend"),
+		"service-account users are never pruned")
+	assert.NotNil(t, findUser(fake, testRealm, "carol"), "federated users are never pruned")
+	assert.NotNil(t, findUser(fake, testRealm, "dave"), "users kubeaid-cli didn't create are never pruned")
+	assert.Equal(t, []string{"auditors", "platform-admins"}, groupNames(fake, testRealm),
+		"groups kubeaid-cli didn't create are never pruned")
+}
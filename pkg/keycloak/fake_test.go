@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -24,6 +26,7 @@ const (
 	pathSegClients         = "clients"
 	pathSegClientScopes    = "client-scopes"
 	pathSegUsers           = "users"
+	pathSegGroups          = "groups"
 	pathSegProtocolMappers = "protocol-mappers"
 )
 
@@ -40,6 +43,7 @@ const (
 //   - clients   : keyed on Keycloak's internal id (uuid)
 //   - scopes    : keyed on internal id
 //   - users     : keyed on internal id
+//   - groups    : keyed on internal id (top-level groups only)
 //
 // String IDs are generated by a monotonically-increasing counter
 // (see nextID); their exact values shouldn't matter to assertions.
@@ -49,10 +53,14 @@ type fakeKeycloak struct {
 	// realms maps realm name → presence sentinel.
 	realms map[string]bool
 
-	// clients/scopes/users keyed by realm, then by internal id.
+	// clients/scopes/users/groups keyed by realm, then by internal id.
 	clients map[string]map[string]*gocloak.Client
 	scopes  map[string]map[string]*gocloak.ClientScope
 	users   map[string]map[string]*gocloak.User
+	groups  map[string]map[string]*gocloak.Group
+
+	// userGroups[realm][userID] = group internal ids.
+	userGroups map[string]map[string][]string
 
 	// service-account user id keyed by realm + client internal id.
 	// Created on demand when GetClientServiceAccount is called for
@@ -77,6 +85,8 @@ func newFakeKeycloak() *fakeKeycloak {
 		clients:         make(map[string]map[string]*gocloak.Client),
 		scopes:          make(map[string]map[string]*gocloak.ClientScope),
 		users:           make(map[string]map[string]*gocloak.User),
+		groups:          make(map[string]map[string]*gocloak.Group),
+		userGroups:      make(map[string]map[string][]string),
 		serviceAccounts: make(map[string]map[string]string),
 		userClientRoles: make(map[string]map[string]map[string][]string),
 	}
@@ -174,6 +184,10 @@ func (f *fakeKeycloak) handleRealmsPrefixed(w http.ResponseWriter, r *http.Reque
 		f.handleUsersList(w, r, realm)
 	case parts[1] == pathSegUsers && len(parts) >= 3:
 		f.handleUserByID(w, r, realm, parts[2:])
+	case parts[1] == pathSegGroups && len(parts) == 2:
+		f.handleGroupsList(w, r, realm)
+	case parts[1] == pathSegGroups && len(parts) == 3:
+		f.handleGroupByID(w, r, realm, parts[2])
 	default:
 		http.NotFound(w, r)
 	}
@@ -259,6 +273,10 @@ func (f *fakeKeycloak) handleClientByID(w http.ResponseWriter, r *http.Request,
 		f.handleClientRolesList(w, r, realm, clientInternalID)
 	case len(parts) == 3 && parts[1] == "roles":
 		f.handleClientRoleByName(w, r, realm, clientInternalID, parts[2])
+	case len(parts) == 3 && parts[1] == pathSegProtocolMappers && parts[2] == "models":
+		f.handleClientProtocolMapperCreate(w, r, realm, clientInternalID)
+	case len(parts) == 4 && parts[1] == pathSegProtocolMappers && parts[2] == "models":
+		f.handleClientProtocolMapperUpdate(w, r, realm, clientInternalID, parts[3])
 	default:
 		http.NotFound(w, r)
 	}
@@ -465,6 +483,70 @@ func (f *fakeKeycloak) handleClientRoleByName(w http.ResponseWriter, r *http.Req
 	http.Error(w, "role not found", http.StatusNotFound)
 }
 
+func (f *fakeKeycloak) handleClientProtocolMapperCreate(w http.ResponseWriter, r *http.Request, realm, clientInternalID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.clients[realm][clientInternalID]
+	if !ok {
+		http.Error(w, "client not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var m gocloak.ProtocolMapperRepresentation
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	mappers := []gocloak.ProtocolMapperRepresentation{}
+	if c.ProtocolMappers != nil {
+		mappers = *c.ProtocolMappers
+	}
+	id := f.nextID()
+	m.ID = gocloak.StringP(id)
+	mappers = append(mappers, m)
+	c.ProtocolMappers = &mappers
+	f.noteWrite()
+	w.Header().Set("Location", "/admin/realms/"+realm+"/clients/"+clientInternalID+"/protocol-mappers/models/"+id)
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (f *fakeKeycloak) handleClientProtocolMapperUpdate(
+	w http.ResponseWriter, r *http.Request, realm, clientInternalID, mapperID string,
+) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.clients[realm][clientInternalID]
+	if !ok || c.ProtocolMappers == nil {
+		http.Error(w, "client or mapper not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPut {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var update gocloak.ProtocolMapperRepresentation
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for i := range *c.ProtocolMappers {
+		m := &(*c.ProtocolMappers)[i]
+		if m.ID != nil && *m.ID == mapperID {
+			update.ID = m.ID // preserve server-assigned ID
+			*m = update
+			f.noteWrite()
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	http.Error(w, "mapper not found", http.StatusNotFound)
+}
+
 // clientRolesFixture pre-seeds roles for known clients so role
 // tests don't need to set them up via a mutator API.
 var clientRolesFixture = map[string][]*gocloak.Role{
@@ -630,7 +712,10 @@ func (f *fakeKeycloak) handleUsersList(w http.ResponseWriter, r *http.Request, r
 			}
 			out = append(out, u)
 		}
-		writeJSON(w, out)
+		slices.SortFunc(out, func(a, b *gocloak.User) int {
+			return strings.Compare(derefString(a.ID), derefString(b.ID))
+		})
+		writeJSON(w, page(r, out))
 	case http.MethodPost:
 		var u gocloak.User
 		if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
@@ -654,6 +739,12 @@ func (f *fakeKeycloak) handleUsersList(w http.ResponseWriter, r *http.Request, r
 func (f *fakeKeycloak) handleUserByID(w http.ResponseWriter, r *http.Request, realm string, parts []string) {
 	userID := parts[0]
 	switch {
+	case len(parts) == 1:
+		f.handleUserBare(w, r, realm, userID)
+	case len(parts) == 2 && parts[1] == pathSegGroups:
+		f.handleUserGroupsList(w, r, realm, userID)
+	case len(parts) == 3 && parts[1] == pathSegGroups:
+		f.handleUserGroup(w, r, realm, userID, parts[2])
 	case len(parts) == 2 && parts[1] == "reset-password":
 		f.handleUserResetPassword(w, r, realm, userID)
 	case len(parts) >= 3 && parts[1] == "role-mappings" && parts[2] == "clients":
@@ -663,6 +754,159 @@ func (f *fakeKeycloak) handleUserByID(w http.ResponseWriter, r *http.Request, re
 	}
 }
 
+// handleUserBare serves `/users/{id}`: PUT patches the stored user
+// with the profile fields the body provides (gocloak omits nil
+// pointers), DELETE removes the user along with its memberships.
+func (f *fakeKeycloak) handleUserBare(w http.ResponseWriter, r *http.Request, realm, userID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	u, ok := f.users[realm][userID]
+	if !ok {
+		http.Error(w, "user not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		var update gocloak.User
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, field := range []struct{ dst, src **string }{
+			{&u.Email, &update.Email},
+			{&u.FirstName, &update.FirstName},
+			{&u.LastName, &update.LastName},
+		} {
+			if *field.src != nil {
+				*field.dst = *field.src
+			}
+		}
+		if update.Enabled != nil {
+			u.Enabled = update.Enabled
+		}
+		f.noteWrite()
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(f.users[realm], userID)
+		delete(f.userGroups[realm], userID)
+		f.noteWrite()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeKeycloak) handleUserGroupsList(w http.ResponseWriter, r *http.Request, realm, userID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.users[realm][userID] == nil {
+		http.Error(w, "user not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	out := []*gocloak.Group{}
+	for _, groupID := range f.userGroups[realm][userID] {
+		if g, ok := f.groups[realm][groupID]; ok {
+			out = append(out, g)
+		}
+	}
+	writeJSON(w, out)
+}
+
+// handleUserGroup serves `/users/{id}/groups/{groupID}`: PUT joins
+// the group, DELETE leaves it.
+func (f *fakeKeycloak) handleUserGroup(w http.ResponseWriter, r *http.Request, realm, userID, groupID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.users[realm][userID] == nil || f.groups[realm][groupID] == nil {
+		http.Error(w, "user or group not found", http.StatusNotFound)
+		return
+	}
+	if f.userGroups[realm] == nil {
+		f.userGroups[realm] = make(map[string][]string)
+	}
+	current := f.userGroups[realm][userID]
+
+	switch r.Method {
+	case http.MethodPut:
+		if !slices.Contains(current, groupID) {
+			f.userGroups[realm][userID] = append(current, groupID)
+		}
+	case http.MethodDelete:
+		f.userGroups[realm][userID] = slices.DeleteFunc(current, func(id string) bool { return id == groupID })
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	f.noteWrite()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --- groups ---
+
+func (f *fakeKeycloak) handleGroupsList(w http.ResponseWriter, r *http.Request, realm string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		var out []*gocloak.Group
+		for _, g := range f.groups[realm] {
+			out = append(out, g)
+		}
+		slices.SortFunc(out, func(a, b *gocloak.Group) int {
+			return strings.Compare(derefString(a.ID), derefString(b.ID))
+		})
+		writeJSON(w, page(r, out))
+	case http.MethodPost:
+		var g gocloak.Group
+		if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id := f.nextID()
+		g.ID = gocloak.StringP(id)
+		g.Path = gocloak.StringP("/" + derefString(g.Name))
+		if f.groups[realm] == nil {
+			f.groups[realm] = make(map[string]*gocloak.Group)
+		}
+		f.groups[realm][id] = &g
+		f.noteWrite()
+		w.Header().Set("Location", "/admin/realms/"+realm+"/groups/"+id)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleGroupByID serves `/groups/{id}`: only DELETE, which also
+// drops every membership of the group.
+func (f *fakeKeycloak) handleGroupByID(w http.ResponseWriter, r *http.Request, realm, groupID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.groups[realm][groupID] == nil {
+		http.Error(w, "group not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	delete(f.groups[realm], groupID)
+	for userID, groupIDs := range f.userGroups[realm] {
+		f.userGroups[realm][userID] = slices.DeleteFunc(groupIDs, func(id string) bool { return id == groupID })
+	}
+	f.noteWrite()
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (f *fakeKeycloak) handleUserResetPassword(w http.ResponseWriter, r *http.Request, realm, userID string) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -770,6 +1014,17 @@ func writeJSON(w http.ResponseWriter, body any) {
 	_ = json.NewEncoder(w).Encode(body)
 }
 
+// page applies the first / max query parameters Keycloak's paged
+// listings take to out.
+func page[T any](r *http.Request, out []T) []T {
+	first, _ := strconv.Atoi(r.URL.Query().Get("first"))
+	out = out[min(first, len(out)):]
+	if limit, err := strconv.Atoi(r.URL.Query().Get("max")); err == nil && limit < len(out) {
+		out = out[:limit]
+	}
+	return out
+}
+
 func derefString(p *string) string {
 	if p == nil {
 		return ""
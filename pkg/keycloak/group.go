@@ -0,0 +1,75 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package keycloak
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Nerzal/gocloak/v13"
+)
+
+// GroupSpec describes a top-level Keycloak group in the realm.
+// Subgroups aren't supported — the groups claim is emitted without
+// the full path, so a subgroup would be indistinguishable from a
+// top-level group of the same name anyway.
+type GroupSpec struct {
+	Name string
+}
+
+// ReconcileGroup ensures a top-level group with spec.Name exists in
+// the realm, and returns its internal id. Idempotent: an existing
+// group is left as is. A group it creates is marked with
+// managedByAttribute.
+func (r *Reconciler) ReconcileGroup(ctx context.Context, realm string, spec GroupSpec) (string, error) {
+	groups, err := r.listGroups(ctx, realm)
+	if err != nil {
+		return "", err
+	}
+	if existing := findGroupByName(groups, spec.Name); existing != nil && existing.ID != nil {
+		return *existing.ID, nil
+	}
+
+	id, err := r.api.CreateGroup(ctx, r.token, realm, gocloak.Group{
+		Name:       gocloak.StringP(spec.Name),
+		Attributes: managedByAttributes(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating group %q in realm %q: %w", spec.Name, realm, err)
+	}
+	return id, nil
+}
+
+// listGroups returns every top-level group in the realm, attributes
+// included. Keycloak pages the listing, so it's walked a page at a
+// time.
+func (r *Reconciler) listGroups(ctx context.Context, realm string) ([]*gocloak.Group, error) {
+	var all []*gocloak.Group
+	for first := 0; ; first += listPageSize {
+		page, err := r.api.GetGroups(ctx, r.token, realm, gocloak.GetGroupsParams{
+			First: gocloak.IntP(first),
+			Max:   gocloak.IntP(listPageSize),
+
+			// The brief representation Keycloak defaults to leaves the
+			// attributes out.
+			BriefRepresentation: gocloak.BoolP(false),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing groups in realm %q: %w", realm, err)
+		}
+		all = append(all, page...)
+		if len(page) < listPageSize {
+			return all, nil
+		}
+	}
+}
+
+func findGroupByName(groups []*gocloak.Group, name string) *gocloak.Group {
+	for _, g := range groups {
+		if g.Name != nil && *g.Name == name {
+			return g
+		}
+	}
+	return nil
+}
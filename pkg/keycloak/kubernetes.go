@@ -48,9 +48,19 @@ var defaultKubeloginRedirectURIs = []string{
 	"http://localhost:18000",
 }
 
+// kubernetesGroupsMapperName is the name of the Group Membership
+// ProtocolMapper attached to the kubernetes-<ClusterName> client.
+const kubernetesGroupsMapperName = "groups"
+
+// kubernetesGroupsClaim is the token claim the kubernetes client's
+// groups mapper puts the user's Keycloak group names in — the same
+// claim NetBird's JWT group sync reads.
+const kubernetesGroupsClaim = "groups"
+
 // ReconcileKubernetes upserts the `kubernetes-<ClusterName>` PUBLIC
-// PKCE OIDC client in the given realm and assigns any DefaultScopes
-// the caller asks for. Idempotent — calling with the same spec a
+// PKCE OIDC client in the given realm, attaches a Group Membership
+// ProtocolMapper to it, and assigns any DefaultScopes the caller asks
+// for. Idempotent — calling with the same spec a
 // second time is a no-op.
 //
 // The realm and any DefaultScopes referenced here must already
@@ -82,6 +92,24 @@ func (r *Reconciler) ReconcileKubernetes(ctx context.Context, spec KubernetesSpe
 		return err
 	}
 
+	// Attached to the client itself rather than inherited from
+	// NetBird's groups scope, so kubelogin tokens carry the groups
+	// claim whichever scopes the client gets.
+	if err := r.ReconcileProtocolMapperOnClient(ctx, spec.Realm, clientID, ProtocolMapperSpec{
+		Name:           kubernetesGroupsMapperName,
+		Protocol:       "openid-connect",
+		ProtocolMapper: "oidc-group-membership-mapper",
+		Config: map[string]string{
+			"claim.name":           kubernetesGroupsClaim,
+			"full.path":            "false",
+			"id.token.claim":       "true",
+			"access.token.claim":   "true",
+			"userinfo.token.claim": "true",
+		},
+	}); err != nil {
+		return err
+	}
+
 	if len(spec.DefaultScopes) > 0 {
 		if err := r.AssignClientDefaultScopes(ctx, spec.Realm, clientID, spec.DefaultScopes); err != nil {
 			return err
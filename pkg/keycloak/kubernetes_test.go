@@ -166,3 +166,35 @@ func TestReconcileKubernetes_Idempotent(t *testing.T) {
 	assert.Equal(t, firstRun, fake.writeCount,
 		"second ReconcileKubernetes must not write anything new")
 }
+
+// TestReconcileKubernetes_GroupsMapper verifies the kubernetes client
+// carries its own Group Membership mapper, so kube-apiserver RBAC can
+// key off the groups claim, and that a drifted mapper gets fixed.
+func TestReconcileKubernetes_GroupsMapper(t *testing.T) {
+	t.Parallel()
+
+	r, fake := newTestReconciler(t)
+	require.NoError(t, r.ReconcileRealm(context.Background(), "acme"))
+
+	spec := KubernetesSpec{Realm: "acme", ClusterName: "acme-vpn"}
+	require.NoError(t, r.ReconcileKubernetes(context.Background(), spec))
+
+	got := findClient(fake, "acme", "kubernetes-acme-vpn")
+	require.NotNil(t, got)
+	require.NotNil(t, got.ProtocolMappers)
+	require.Len(t, *got.ProtocolMappers, 1)
+
+	mapper := (*got.ProtocolMappers)[0]
+	assert.Equal(t, "oidc-group-membership-mapper", derefString(mapper.ProtocolMapper))
+	require.NotNil(t, mapper.Config)
+	assert.Equal(t, "groups", (*mapper.Config)["claim.name"])
+	assert.Equal(t, "false", (*mapper.Config)["full.path"])
+
+	// Simulate an operator flipping full.path in the admin console.
+	(*mapper.Config)["full.path"] = "true"
+	require.NoError(t, r.ReconcileKubernetes(context.Background(), spec))
+
+	got = findClient(fake, "acme", "kubernetes-acme-vpn")
+	require.Len(t, *got.ProtocolMappers, 1, "drifted mapper is updated, not duplicated")
+	assert.Equal(t, "false", (*(*got.ProtocolMappers)[0].Config)["full.path"])
+}
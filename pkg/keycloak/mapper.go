@@ -6,6 +6,7 @@ package keycloak
 import (
 	"context"
 	"fmt"
+	"maps"
 
 	"github.com/Nerzal/gocloak/v13"
 )
@@ -75,6 +76,81 @@ func (r *Reconciler) ReconcileProtocolMapperOnClientScope(
 	return nil
 }
 
+// ReconcileProtocolMapperOnClient is ReconcileProtocolMapperOnClientScope
+// for a mapper attached directly to the client identified by clientID
+// (the user-facing OIDC client_id), so it applies to that client's
+// tokens whichever client scopes are assigned. Same idempotency
+// rules: missing mapper is created, drifted mapper is updated,
+// otherwise no-op.
+func (r *Reconciler) ReconcileProtocolMapperOnClient(
+	ctx context.Context,
+	realm, clientID string,
+	spec ProtocolMapperSpec,
+) error {
+	clients, err := r.api.GetClients(ctx, r.token, realm, gocloak.GetClientsParams{
+		ClientID: gocloak.StringP(clientID),
+	})
+	if err != nil {
+		return fmt.Errorf("listing clients in realm %q: %w", realm, err)
+	}
+	target := findClientByClientID(clients, clientID)
+	if target == nil || target.ID == nil {
+		return fmt.Errorf("client %q not found in realm %q", clientID, realm)
+	}
+
+	config := maps.Clone(spec.Config)
+	desired := gocloak.ProtocolMapperRepresentation{
+		Name:           gocloak.StringP(spec.Name),
+		Protocol:       gocloak.StringP(spec.Protocol),
+		ProtocolMapper: gocloak.StringP(spec.ProtocolMapper),
+		Config:         &config,
+	}
+
+	if target.ProtocolMappers != nil {
+		for _, m := range *target.ProtocolMappers {
+			if m.Name == nil || *m.Name != spec.Name {
+				continue
+			}
+			if clientProtocolMapperConfigMatches(m.Config, spec.Config) {
+				return nil
+			}
+			desired.ID = m.ID
+			if err := r.api.UpdateClientProtocolMapper(ctx, r.token, realm,
+				*target.ID, gocloak.PString(m.ID), desired,
+			); err != nil {
+				return fmt.Errorf(
+					"updating protocol mapper %q on client %q in realm %q: %w",
+					spec.Name, clientID, realm, err,
+				)
+			}
+			return nil
+		}
+	}
+
+	if _, err := r.api.CreateClientProtocolMapper(ctx, r.token, realm, *target.ID, desired); err != nil {
+		return fmt.Errorf(
+			"creating protocol mapper %q on client %q in realm %q: %w",
+			spec.Name, clientID, realm, err,
+		)
+	}
+	return nil
+}
+
+// clientProtocolMapperConfigMatches is protocolMapperConfigMatches
+// for the untyped config map a client's protocol mappers carry.
+func clientProtocolMapperConfigMatches(existing *map[string]string, desired map[string]string) bool {
+	var got map[string]string
+	if existing != nil {
+		got = *existing
+	}
+	for key, want := range desired {
+		if got[key] != want {
+			return false
+		}
+	}
+	return true
+}
+
 // buildProtocolMapper translates ProtocolMapperSpec into the
 // gocloak representation. Pulled out so create and update paths
 // emit the same body — and so a spec that adds a new config key
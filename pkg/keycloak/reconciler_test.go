@@ -341,3 +341,24 @@ func TestReconcileUser(t *testing.T) {
 	assert.Equal(t, 2, fake.writeCount,
 		"second reconcile must be a no-op — kubeaid-cli does not rotate user passwords")
 }
+
+// TestReconcileUser_UpdatesDriftedProfile pins the update path: a
+// changed email reaches the live user, without resetting the password.
+func TestReconcileUser_UpdatesDriftedProfile(t *testing.T) {
+	t.Parallel()
+
+	r, fake := newTestReconciler(t)
+	seedRealm(t, r, fake)
+
+	spec := UserSpec{Username: "alice", Email: "alice@acme.com", Enabled: true}
+	require.NoError(t, r.ReconcileUser(context.Background(), testRealm, spec, "initial-pw"))
+	fake.writeCount = 0
+
+	spec.Email = "alice@acme.io"
+	require.NoError(t, r.ReconcileUser(context.Background(), testRealm, spec, "initial-pw"))
+	assert.Equal(t, 1, fake.writeCount, "drifted profile is updated with a single write")
+
+	got := findUser(fake, testRealm, "alice")
+	require.NotNil(t, got)
+	assert.Equal(t, "alice@acme.io", derefString(got.Email))
+}
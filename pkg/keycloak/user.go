@@ -22,12 +22,19 @@ type UserSpec struct {
 	// callers, so a zero-value spec doesn't silently create a
 	// disabled user.
 	Enabled bool
+
+	// Groups are the names of the top-level groups the user is a
+	// member of. Only honoured by ReconcileDirectory, which keeps the
+	// user's memberships of the groups it manages in sync with this
+	// list.
+	Groups []string
 }
 
 // ReconcileUser ensures a user with spec.Username exists in the
-// realm. When the user is created and initialPassword is non-empty,
-// the password is set as a non-temporary credential. On a re-run
-// (user already present) the existing password is preserved —
+// realm, with the email and names spec asks for. When the user is
+// created and initialPassword is non-empty, the password is set as a
+// non-temporary credential. On a re-run (user already present) a
+// drifted profile is updated but the existing password is preserved —
 // kubeaid-cli is not in the password-rotation business.
 func (r *Reconciler) ReconcileUser(
 	ctx context.Context,
@@ -35,17 +42,63 @@ func (r *Reconciler) ReconcileUser(
 	spec UserSpec,
 	initialPassword string,
 ) error {
+	id, created, err := r.upsertUser(ctx, realm, spec, false)
+	if err != nil {
+		return err
+	}
+
+	if created && initialPassword != "" {
+		if err := r.api.SetPassword(ctx, r.token, id, realm, initialPassword, false); err != nil {
+			return fmt.Errorf(
+				"setting initial password for user %q in realm %q: %w",
+				spec.Username, realm, err,
+			)
+		}
+	}
+	return nil
+}
+
+// upsertUser creates the user when missing, or updates its profile
+// when it drifted from spec. Returns the user's internal id, and
+// whether it got created. A user it creates is marked with
+// managedByAttribute when managed.
+func (r *Reconciler) upsertUser(ctx context.Context, realm string, spec UserSpec, managed bool) (string, bool, error) {
 	users, err := r.api.GetUsers(ctx, r.token, realm, gocloak.GetUsersParams{
 		Username: gocloak.StringP(spec.Username),
 		Exact:    gocloak.BoolP(true),
 	})
 	if err != nil {
-		return fmt.Errorf("listing users in realm %q: %w", realm, err)
+		return "", false, fmt.Errorf("listing users in realm %q: %w", realm, err)
+	}
+
+	desired := buildUser(spec)
+
+	if existing := findUserByUsername(users, spec.Username); existing != nil && existing.ID != nil {
+		if userProfileMatches(existing, desired) {
+			return *existing.ID, false, nil
+		}
+		// Keycloak's PUT only touches the fields present in the body,
+		// so credentials, attributes and required actions survive.
+		desired.ID = existing.ID
+		if err := r.api.UpdateUser(ctx, r.token, realm, desired); err != nil {
+			return "", false, fmt.Errorf("updating user %q in realm %q: %w", spec.Username, realm, err)
+		}
+		return *existing.ID, false, nil
+	}
+
+	if managed {
+		desired.Attributes = managedByAttributes()
 	}
-	if findUserByUsername(users, spec.Username) != nil {
-		return nil
+	id, err := r.api.CreateUser(ctx, r.token, realm, desired)
+	if err != nil {
+		return "", false, fmt.Errorf("creating user %q in realm %q: %w", spec.Username, realm, err)
 	}
+	return id, true, nil
+}
 
+// buildUser translates UserSpec into the gocloak representation.
+// Shared by the create and update paths, so both send the same body.
+func buildUser(spec UserSpec) gocloak.User {
 	user := gocloak.User{
 		Username: gocloak.StringP(spec.Username),
 		Enabled:  gocloak.BoolP(spec.Enabled),
@@ -63,21 +116,25 @@ func (r *Reconciler) ReconcileUser(
 	if spec.LastName != "" {
 		user.LastName = gocloak.StringP(spec.LastName)
 	}
+	return user
+}
 
-	id, err := r.api.CreateUser(ctx, r.token, realm, user)
-	if err != nil {
-		return fmt.Errorf("creating user %q in realm %q: %w", spec.Username, realm, err)
+// userProfileMatches reports whether existing agrees with every
+// profile field desired sets. Fields desired leaves unset are
+// ignored, so a value an admin filled in through the console isn't
+// reported as drift.
+func userProfileMatches(existing *gocloak.User, desired gocloak.User) bool {
+	pairs := []struct{ got, want *string }{
+		{existing.Email, desired.Email},
+		{existing.FirstName, desired.FirstName},
+		{existing.LastName, desired.LastName},
 	}
-
-	if initialPassword != "" {
-		if err := r.api.SetPassword(ctx, r.token, id, realm, initialPassword, false); err != nil {
-			return fmt.Errorf(
-				"setting initial password for user %q in realm %q: %w",
-				spec.Username, realm, err,
-			)
+	for _, p := range pairs {
+		if p.want != nil && gocloak.PString(p.got) != *p.want {
+			return false
 		}
 	}
-	return nil
+	return gocloak.PBool(existing.Enabled) == gocloak.PBool(desired.Enabled)
 }
 
 // findUserByUsername returns the user matching exactly. Keycloak's
@@ -29,6 +29,7 @@ import (
 
 	"github.com/Obmondo/kubeaid-cli/pkg/cert"
 	"github.com/Obmondo/kubeaid-cli/pkg/config/clusterdir"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/sops"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
 )
@@ -388,7 +389,10 @@ func FindOnDisk(certname string) (string, *WrittenPaths, error) {
 // certname is the intent, not an accident. Prompting instead would ask the
 // same question on every re-run, which is the case most likely to be
 // answered by reflex.
-func Write(configsDirectory string, config *ClusterConfig) (*WrittenPaths, error) {
+//
+// secrets.yaml gets SOPS encrypted, when the one it replaces was, or a
+// .sops.yaml creation rule covers it — like every other secrets.yaml writer.
+func Write(ctx context.Context, configsDirectory string, config *ClusterConfig) (*WrittenPaths, error) {
 	if err := os.MkdirAll(configsDirectory, 0o700); err != nil {
 		return nil, fmt.Errorf("creating %s: %w", configsDirectory, err)
 	}
@@ -401,7 +405,7 @@ func Write(configsDirectory string, config *ClusterConfig) (*WrittenPaths, error
 
 	// secrets.yaml and the private key are 0600 throughout: they carry the
 	// customer's cloud credentials and mTLS identity.
-	if err := os.WriteFile(written.Secrets, []byte(config.SecretsYAML), 0o600); err != nil {
+	if err := sops.WriteFile(ctx, written.Secrets, []byte(config.SecretsYAML), 0o600); err != nil {
 		return nil, fmt.Errorf("writing %s: %w", written.Secrets, err)
 	}
 
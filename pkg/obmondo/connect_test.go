@@ -224,7 +224,7 @@ func TestClusterNameReadsTheNameAndToleratesUnknownFields(t *testing.T) {
 func TestWriteLaysOutTheFilesWithRestrictivePermissions(t *testing.T) {
 	dir := filepath.Join(t.TempDir(), "configs")
 
-	written, err := Write(dir, &ClusterConfig{
+	written, err := Write(t.Context(), dir, &ClusterConfig{
 		GeneralYAML: "cluster:\n  name: demo\n",
 		SecretsYAML: "hetzner:\n  apiToken: \"tok\"\n",
 	})
@@ -252,7 +252,7 @@ func TestWriteOverwritesExistingConfig(t *testing.T) {
 	dir := t.TempDir()
 	require.NoError(t, os.WriteFile(filepath.Join(dir, "general.yaml"), []byte("stale"), 0o600))
 
-	written, err := Write(dir, &ClusterConfig{
+	written, err := Write(t.Context(), dir, &ClusterConfig{
 		GeneralYAML: "cluster:\n  name: demo\n",
 		SecretsYAML: "hetzner:\n  apiToken: tok\n",
 	})
@@ -267,7 +267,7 @@ func TestWriteOverwritesExistingConfig(t *testing.T) {
 // partial set must read as absent rather than as something to work with.
 func TestCompleteRequiresEveryFile(t *testing.T) {
 	dir := filepath.Join(t.TempDir(), "configs")
-	written, err := Write(dir, &ClusterConfig{
+	written, err := Write(t.Context(), dir, &ClusterConfig{
 		GeneralYAML: "cluster:\n  name: demo\n",
 		SecretsYAML: "x",
 		Puppet: &PuppetMaterial{
@@ -291,7 +291,7 @@ func TestCompleteRequiresEveryFile(t *testing.T) {
 // certname are separate identifiers.
 func TestVerifyOnDiskMatchesOnTheCertificateCN(t *testing.T) {
 	dir := filepath.Join(t.TempDir(), "configs")
-	_, err := Write(dir, &ClusterConfig{
+	_, err := Write(t.Context(), dir, &ClusterConfig{
 		GeneralYAML: "cluster:\n  name: something-else\n",
 		SecretsYAML: "x",
 		Puppet: &PuppetMaterial{
@@ -323,7 +323,7 @@ func TestVerifyOnDiskRejectsAnIncompleteDirectory(t *testing.T) {
 func TestWriteStoresPuppetMaterialAndPointsGeneralAtIt(t *testing.T) {
 	dir := filepath.Join(t.TempDir(), "configs")
 
-	written, err := Write(dir, &ClusterConfig{
+	written, err := Write(t.Context(), dir, &ClusterConfig{
 		GeneralYAML: "cluster:\n  name: demo\nobmondo:\n  customerID: acme\n  monitoring: true\n  certPath: \"\"\n  keyPath: \"\"\n",
 		SecretsYAML: "hetzner:\n  apiToken: \"tok\"\n",
 		Puppet: &PuppetMaterial{
@@ -353,7 +353,7 @@ func TestWriteStoresPuppetMaterialAndPointsGeneralAtIt(t *testing.T) {
 func TestWriteSkipsPuppetPathsWhenMonitoringIsOff(t *testing.T) {
 	dir := filepath.Join(t.TempDir(), "configs")
 
-	written, err := Write(dir, &ClusterConfig{
+	written, err := Write(t.Context(), dir, &ClusterConfig{
 		GeneralYAML: "cluster:\n  name: demo\n",
 		SecretsYAML: "hetzner:\n  apiToken: \"tok\"\n",
 	})
@@ -0,0 +1,123 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// apiClient is the JSON-over-HTTP plumbing shared by every forge implementation. The forges
+// only differ in their auth header and endpoint layout.
+type apiClient struct {
+	httpClient *http.Client
+	baseURL    string
+
+	authHeader,
+	authValue string
+}
+
+// APIError is returned when the forge API responds with a non-2xx status code.
+type APIError struct {
+	Method,
+	URL string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s : HTTP %d : %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// do sends requestBody (when non-nil) JSON encoded, and decodes the JSON response into
+// responseBody (when non-nil).
+func (c *apiClient) do(ctx context.Context,
+	method, path string,
+	requestBody, responseBody any,
+) error {
+	var body io.Reader
+	if requestBody != nil {
+		encoded, err := json.Marshal(requestBody)
+		if err != nil {
+			return fmt.Errorf("encoding request body: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	url := c.baseURL + path
+
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	request.Header.Set(c.authHeader, c.authValue)
+	request.Header.Set("Accept", "application/json")
+	if requestBody != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		// Cap what we read : the body only ends up in the error message.
+		errorBody, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return &APIError{
+			Method:     method,
+			URL:        url,
+			StatusCode: response.StatusCode,
+			Body:       string(bytes.TrimSpace(errorBody)),
+		}
+	}
+
+	if responseBody == nil {
+		return nil
+	}
+	if err := json.NewDecoder(response.Body).Decode(responseBody); err != nil {
+		return fmt.Errorf("decoding response of %s %s: %w", method, url, err)
+	}
+	return nil
+}
+
+// listPageSize is the page size paginated listings get walked with. Gitea caps pages at 50 items
+// by default (its MAX_RESPONSE_ITEMS), GitHub at 100.
+const listPageSize = 50
+
+// listAll walks every page of the listing at path, filtered by query, until a short page.
+// GitHub takes the page size through per_page, Gitea through limit : pageSizeParameter says
+// which.
+func listAll[T any](ctx context.Context,
+	client *apiClient,
+	path string,
+	query url.Values,
+	pageSizeParameter string,
+) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		pageQuery := url.Values{}
+		maps.Copy(pageQuery, query)
+		pageQuery.Set(pageSizeParameter, strconv.Itoa(listPageSize))
+		pageQuery.Set("page", strconv.Itoa(page))
+
+		var items []T
+		if err := client.do(ctx, http.MethodGet, path+"?"+pageQuery.Encode(), nil, &items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if len(items) < listPageSize {
+			return all, nil
+		}
+	}
+}
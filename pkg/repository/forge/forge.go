@@ -0,0 +1,202 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+// Package forge talks to the REST API of the forge (GitHub, GitLab or Gitea) hosting a git
+// repository : opening pull requests and reading back their merge, review and CI state. Plain
+// git has no notion of pull requests, so this is what lets KubeAid CLI drive the kubeaid-config
+// PR workflow end to end.
+package forge
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	repourl "github.com/Obmondo/kubeaid-cli/pkg/repository/url"
+)
+
+const (
+	TypeGitHub = "github"
+	TypeGitLab = "gitlab"
+	TypeGitea  = "gitea"
+)
+
+type (
+	// Forge is implemented once per supported forge.
+	Forge interface {
+		// OpenPullRequest opens a pull request from options.Head into options.Base. When one is
+		// already open between the two branches (e.g. a previous run got interrupted), that one
+		// is returned instead.
+		OpenPullRequest(ctx context.Context, options PullRequestOptions) (*PullRequest, error)
+
+		// GetPullRequest returns the current state of the given pull request.
+		GetPullRequest(ctx context.Context, number int) (*PullRequest, error)
+	}
+
+	PullRequestOptions struct {
+		Title,
+		Body,
+		Base,
+		Head string
+	}
+
+	PullRequest struct {
+		// Number is the forge-side identifier : the PR number on GitHub / Gitea, the merge
+		// request IID on GitLab.
+		Number int
+		URL    string
+
+		State  State
+		Review ReviewState
+		Checks CheckState
+	}
+
+	State string
+
+	ReviewState string
+
+	CheckState string
+)
+
+const (
+	StateOpen   State = "open"
+	StateMerged State = "merged"
+	StateClosed State = "closed"
+
+	ReviewStatePending          ReviewState = "pending"
+	ReviewStateApproved         ReviewState = "approved"
+	ReviewStateChangesRequested ReviewState = "changes requested"
+
+	// CheckStateNone means no CI reported on the head commit (yet).
+	CheckStateNone    CheckState = "none"
+	CheckStatePending CheckState = "pending"
+	CheckStateSuccess CheckState = "success"
+	CheckStateFailure CheckState = "failure"
+)
+
+// ErrUndetectable is returned by DetectType, when the forge type can't be inferred from the
+// repository host.
+var ErrUndetectable = errors.New("can't detect the forge type from the repository host")
+
+// DetectType infers the forge type from the repository host : github.com, gitlab.com, or a
+// self-hosted instance whose hostname contains 'gitlab' / 'gitea'.
+func DetectType(parsedURL *repourl.Parsed) (string, error) {
+	hostName := strings.ToLower(parsedURL.HostName())
+	switch {
+	case hostName == "github.com":
+		return TypeGitHub, nil
+
+	case strings.Contains(hostName, "gitlab"):
+		return TypeGitLab, nil
+
+	case strings.Contains(hostName, "gitea"):
+		return TypeGitea, nil
+
+	default:
+		return "", fmt.Errorf("%w %s : set git.forge.type", ErrUndetectable, hostName)
+	}
+}
+
+// New returns a Forge client for the given repository.
+// forgeType and apiURL are optional : when blank, they're derived from the repository host.
+// caBundle, when non-empty, is trusted in addition to the system certificate pool.
+func New(parsedURL *repourl.Parsed,
+	forgeType, apiURL, token string,
+	caBundle []byte,
+) (Forge, error) {
+	if token == "" {
+		return nil, errors.New("no forge API token provided")
+	}
+
+	if forgeType == "" {
+		var err error
+		if forgeType, err = DetectType(parsedURL); err != nil {
+			return nil, err
+		}
+	}
+
+	if apiURL == "" {
+		apiURL = defaultAPIURL(parsedURL, forgeType)
+	}
+
+	httpClient, err := newHTTPClient(caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &apiClient{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(apiURL, "/"),
+	}
+
+	switch forgeType {
+	case TypeGitHub:
+		client.authHeader, client.authValue = "Authorization", "Bearer "+token
+		return &gitHub{client, parsedURL.Owner, parsedURL.Repository}, nil
+
+	case TypeGitLab:
+		client.authHeader, client.authValue = "PRIVATE-TOKEN", token
+		return &gitLab{client, parsedURL.Owner + "/" + parsedURL.Repository}, nil
+
+	case TypeGitea:
+		client.authHeader, client.authValue = "Authorization", "token "+token
+		return &gitea{client, parsedURL.Owner, parsedURL.Repository}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported forge type %s", forgeType)
+	}
+}
+
+func defaultAPIURL(parsedURL *repourl.Parsed, forgeType string) string {
+	scheme, hostName := "https://", parsedURL.HostName()
+	switch parsedURL.Protocol {
+	// For HTTP(s) remotes, the web UI (and thus the API) is served on the same port.
+	case repourl.ProtocolHTTP:
+		scheme, hostName = "http://", parsedURL.Host
+
+	case repourl.ProtocolHTTPs:
+		hostName = parsedURL.Host
+	}
+
+	switch forgeType {
+	case TypeGitHub:
+		if hostName == "github.com" {
+			return "https://api.github.com"
+		}
+		return scheme + hostName + "/api/v3"
+
+	case TypeGitLab:
+		return scheme + hostName + "/api/v4"
+
+	default:
+		return scheme + hostName + "/api/v1"
+	}
+}
+
+func newHTTPClient(caBundle []byte) (*http.Client, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if len(caBundle) == 0 {
+		return httpClient, nil
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM(caBundle) {
+		return nil, errors.New("no certificates found in the git CA bundle")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	httpClient.Transport = transport
+	return httpClient, nil
+}
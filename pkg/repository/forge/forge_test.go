@@ -0,0 +1,289 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	repourl "github.com/Obmondo/kubeaid-cli/pkg/repository/url"
+)
+
+func TestDetectType(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantType string
+		wantErr  bool
+	}{
+		{url: "git@github.com:Obmondo/kubeaid-config.git", wantType: TypeGitHub},
+		{url: "https://gitlab.com/obmondo/kubeaid-config", wantType: TypeGitLab},
+		{url: "ssh://git@gitlab.example.com:2222/obmondo/kubeaid-config.git", wantType: TypeGitLab},
+		{url: "ssh://git@enableitdk-gitea:2222/anantharam/kubeaid-config.git", wantType: TypeGitea},
+		{url: "git@git.example.com:obmondo/kubeaid-config.git", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			parsedURL, err := repourl.Parse(tc.url)
+			require.NoError(t, err)
+
+			forgeType, err := DetectType(parsedURL)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrUndetectable)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantType, forgeType)
+		})
+	}
+}
+
+func TestDefaultAPIURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		forgeType string
+		want      string
+	}{
+		{url: "git@github.com:Obmondo/kubeaid-config.git", forgeType: TypeGitHub, want: "https://api.github.com"},
+		{url: "git@ghe.example.com:obmondo/kubeaid-config.git", forgeType: TypeGitHub, want: "https://ghe.example.com/api/v3"},
+		{url: "ssh://git@gitlab.example.com:2222/obmondo/kubeaid-config.git", forgeType: TypeGitLab, want: "https://gitlab.example.com/api/v4"},
+		{url: "https://gitea.example.com:3000/obmondo/kubeaid-config.git", forgeType: TypeGitea, want: "https://gitea.example.com:3000/api/v1"},
+		{url: "http://gitea.local/obmondo/kubeaid-config.git", forgeType: TypeGitea, want: "http://gitea.local/api/v1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			parsedURL, err := repourl.Parse(tc.url)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.want, defaultAPIURL(parsedURL, tc.forgeType))
+		})
+	}
+}
+
+// fakeForgeServer serves canned JSON responses keyed by "<method> <request URI>", and records
+// the bodies POSTed to it.
+func fakeForgeServer(t *testing.T,
+	wantAuthHeader, wantAuthValue string,
+	responses map[string]string,
+) (*httptest.Server, map[string]map[string]any) {
+	t.Helper()
+
+	posted := map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, wantAuthValue, r.Header.Get(wantAuthHeader))
+
+		key := r.Method + " " + r.RequestURI
+		if r.Method == http.MethodPost {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			decoded := map[string]any{}
+			require.NoError(t, json.Unmarshal(body, &decoded))
+			posted[r.RequestURI] = decoded
+		}
+
+		response, ok := responses[key]
+		if !ok {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, response)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, posted
+}
+
+func TestGitHubOpenPullRequest(t *testing.T) {
+	server, posted := fakeForgeServer(t, "Authorization", "Bearer gh-token", map[string]string{
+		"GET /repos/Obmondo/kubeaid-config/pulls?base=main&head=Obmondo%3Akubeaid-staging&state=open": `[]`,
+		"POST /repos/Obmondo/kubeaid-config/pulls":                                                    `{"number": 7}`,
+		"GET /repos/Obmondo/kubeaid-config/pulls/7":                                                   `{"number": 7, "html_url": "https://github.com/Obmondo/kubeaid-config/pull/7", "state": "open", "head": {"sha": "abc123"}}`,
+		"GET /repos/Obmondo/kubeaid-config/pulls/7/reviews?page=1&per_page=50":                        `[{"user": {"login": "alice"}, "state": "CHANGES_REQUESTED"}, {"user": {"login": "alice"}, "state": "APPROVED"}, {"user": {"login": "bob"}, "state": "COMMENTED"}]`,
+		"GET /repos/Obmondo/kubeaid-config/commits/abc123/status":                                     `{"state": "pending", "total_count": 2}`,
+		"GET /repos/Obmondo/kubeaid-config/commits/abc123/check-runs?per_page=100&page=1":             `{"total_count": 1, "check_runs": [{"status": "completed", "conclusion": "success"}]}`,
+	})
+
+	parsedURL, err := repourl.Parse("git@github.com:Obmondo/kubeaid-config.git")
+	require.NoError(t, err)
+
+	forge, err := New(parsedURL, "", server.URL, "gh-token", nil)
+	require.NoError(t, err)
+
+	pullRequest, err := forge.OpenPullRequest(context.Background(), PullRequestOptions{
+		Title: "(cluster/staging) : created / updated KubeAid config files",
+		Body:  "body",
+		Base:  "main",
+		Head:  "kubeaid-staging",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &PullRequest{
+		Number: 7,
+		URL:    "https://github.com/Obmondo/kubeaid-config/pull/7",
+		State:  StateOpen,
+		Review: ReviewStateApproved,
+		Checks: CheckStatePending,
+	}, pullRequest)
+	assert.Equal(t, map[string]any{
+		"title": "(cluster/staging) : created / updated KubeAid config files",
+		"body":  "body",
+		"head":  "kubeaid-staging",
+		"base":  "main",
+	}, posted["/repos/Obmondo/kubeaid-config/pulls"])
+}
+
+func TestGitHubCheckRuns(t *testing.T) {
+	tests := []struct {
+		name      string
+		checkRuns string
+		want      CheckState
+	}{
+		{
+			name:      "no CI",
+			checkRuns: `{"total_count": 0, "check_runs": []}`,
+			want:      CheckStateNone,
+		},
+		{
+			name:      "failing GitHub Actions",
+			checkRuns: `{"total_count": 2, "check_runs": [{"status": "completed", "conclusion": "success"}, {"status": "completed", "conclusion": "failure"}]}`,
+			want:      CheckStateFailure,
+		},
+		{
+			name:      "running GitHub Actions",
+			checkRuns: `{"total_count": 2, "check_runs": [{"status": "completed", "conclusion": "skipped"}, {"status": "in_progress"}]}`,
+			want:      CheckStatePending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, _ := fakeForgeServer(t, "Authorization", "Bearer gh-token", map[string]string{
+				"GET /repos/Obmondo/kubeaid-config/pulls/7":                                       `{"number": 7, "state": "open", "head": {"sha": "abc123"}}`,
+				"GET /repos/Obmondo/kubeaid-config/pulls/7/reviews?page=1&per_page=50":            `[]`,
+				"GET /repos/Obmondo/kubeaid-config/commits/abc123/status":                         `{"state": "pending", "total_count": 0}`,
+				"GET /repos/Obmondo/kubeaid-config/commits/abc123/check-runs?per_page=100&page=1": tc.checkRuns,
+			})
+
+			parsedURL, err := repourl.Parse("git@github.com:Obmondo/kubeaid-config.git")
+			require.NoError(t, err)
+
+			forge, err := New(parsedURL, "", server.URL, "gh-token", nil)
+			require.NoError(t, err)
+
+			pullRequest, err := forge.GetPullRequest(context.Background(), 7)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, pullRequest.Checks)
+		})
+	}
+}
+
+func TestGiteaOpenPullRequestReusesOpenOne(t *testing.T) {
+	// A full first page of other pull requests : the matching one is only on the second page.
+	firstPage := make([]string, 0, listPageSize)
+	for number := 100; len(firstPage) < listPageSize; number++ {
+		firstPage = append(firstPage, fmt.Sprintf(`{"number": %d, "head": {"ref": "other-%d"}, "base": {"ref": "main"}}`, number, number))
+	}
+
+	server, posted := fakeForgeServer(t, "Authorization", "token gitea-token", map[string]string{
+		"GET /repos/anantharam/kubeaid-config/pulls?limit=50&page=1&state=open": "[" + strings.Join(firstPage, ",") + "]",
+		"GET /repos/anantharam/kubeaid-config/pulls?limit=50&page=2&state=open": `[{"number": 2, "head": {"ref": "other"}, "base": {"ref": "main"}}, {"number": 3, "head": {"ref": "kubeaid-staging"}, "base": {"ref": "main"}}]`,
+		"GET /repos/anantharam/kubeaid-config/pulls/3":                          `{"number": 3, "html_url": "https://gitea/anantharam/kubeaid-config/pulls/3", "state": "closed", "merged": true, "head": {"sha": "def456"}}`,
+		"GET /repos/anantharam/kubeaid-config/pulls/3/reviews?limit=50&page=1":  `[]`,
+		"GET /repos/anantharam/kubeaid-config/commits/def456/status":            `{"state": "", "total_count": 0}`,
+	})
+
+	parsedURL, err := repourl.Parse("ssh://git@enableitdk-gitea:2222/anantharam/kubeaid-config.git")
+	require.NoError(t, err)
+
+	forge, err := New(parsedURL, "", server.URL, "gitea-token", nil)
+	require.NoError(t, err)
+
+	pullRequest, err := forge.OpenPullRequest(context.Background(), PullRequestOptions{
+		Base: "main",
+		Head: "kubeaid-staging",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &PullRequest{
+		Number: 3,
+		URL:    "https://gitea/anantharam/kubeaid-config/pulls/3",
+		State:  StateMerged,
+		Review: ReviewStatePending,
+		Checks: CheckStateNone,
+	}, pullRequest)
+	assert.Empty(t, posted)
+}
+
+func TestGitLabOpenPullRequest(t *testing.T) {
+	server, posted := fakeForgeServer(t, "PRIVATE-TOKEN", "gl-token", map[string]string{
+		"GET /projects/obmondo%2Fkubeaid-config/merge_requests?source_branch=kubeaid-staging&state=opened&target_branch=main": `[]`,
+		"POST /projects/obmondo%2Fkubeaid-config/merge_requests":                                                              `{"iid": 12}`,
+		"GET /projects/obmondo%2Fkubeaid-config/merge_requests/12":                                                            `{"iid": 12, "web_url": "https://gitlab.com/obmondo/kubeaid-config/-/merge_requests/12", "state": "opened", "head_pipeline": {"status": "failed"}}`,
+		"GET /projects/obmondo%2Fkubeaid-config/merge_requests/12/approvals":                                                  `{"approved": true, "approved_by": []}`,
+	})
+
+	parsedURL, err := repourl.Parse("https://gitlab.com/obmondo/kubeaid-config.git")
+	require.NoError(t, err)
+
+	forge, err := New(parsedURL, "", server.URL, "gl-token", nil)
+	require.NoError(t, err)
+
+	pullRequest, err := forge.OpenPullRequest(context.Background(), PullRequestOptions{
+		Title: "title",
+		Body:  "body",
+		Base:  "main",
+		Head:  "kubeaid-staging",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &PullRequest{
+		Number: 12,
+		URL:    "https://gitlab.com/obmondo/kubeaid-config/-/merge_requests/12",
+		State:  StateOpen,
+		Review: ReviewStatePending,
+		Checks: CheckStateFailure,
+	}, pullRequest)
+	assert.Equal(t, map[string]any{
+		"title":                "title",
+		"description":          "body",
+		"source_branch":        "kubeaid-staging",
+		"target_branch":        "main",
+		"remove_source_branch": true,
+	}, posted["/projects/obmondo%2Fkubeaid-config/merge_requests"])
+}
+
+func TestNewRequiresToken(t *testing.T) {
+	parsedURL, err := repourl.Parse("git@github.com:Obmondo/kubeaid-config.git")
+	require.NoError(t, err)
+
+	_, err = New(parsedURL, "", "", "", nil)
+	require.Error(t, err)
+}
+
+func TestAPIError(t *testing.T) {
+	server, _ := fakeForgeServer(t, "Authorization", "Bearer gh-token", map[string]string{})
+
+	parsedURL, err := repourl.Parse("git@github.com:Obmondo/kubeaid-config.git")
+	require.NoError(t, err)
+
+	forge, err := New(parsedURL, TypeGitHub, server.URL, "gh-token", nil)
+	require.NoError(t, err)
+
+	_, err = forge.GetPullRequest(context.Background(), 1)
+
+	var apiError *APIError
+	require.ErrorAs(t, err, &apiError)
+	assert.Equal(t, http.StatusNotFound, apiError.StatusCode)
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// giteaPageSizeParameter is the query parameter Gitea's paginated listings take the page size
+// through.
+const giteaPageSizeParameter = "limit"
+
+type gitea struct {
+	*apiClient
+
+	owner,
+	repository string
+}
+
+func (g *gitea) repoPath() string {
+	return "/repos/" + url.PathEscape(g.owner) + "/" + url.PathEscape(g.repository)
+}
+
+func (g *gitea) OpenPullRequest(ctx context.Context, options PullRequestOptions) (*PullRequest, error) {
+	// Unlike GitHub, Gitea can't filter the listing by head branch : every page of open pull
+	// requests gets looked through.
+	existing, err := listAll[gitHubStylePullRequest](ctx, g.apiClient,
+		g.repoPath()+"/pulls", url.Values{"state": {"open"}}, giteaPageSizeParameter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing open pull requests: %w", err)
+	}
+	for _, pr := range existing {
+		if (pr.Head.Ref == options.Head) && (pr.Base.Ref == options.Base) {
+			return g.GetPullRequest(ctx, pr.Number)
+		}
+	}
+
+	var created gitHubStylePullRequest
+	if err := g.do(ctx, http.MethodPost, g.repoPath()+"/pulls", map[string]string{
+		"title": options.Title,
+		"body":  options.Body,
+		"head":  options.Head,
+		"base":  options.Base,
+	}, &created); err != nil {
+		return nil, fmt.Errorf("creating pull request: %w", err)
+	}
+	return g.GetPullRequest(ctx, created.Number)
+}
+
+func (g *gitea) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	// Gitea has no Checks API : Gitea Actions report through commit statuses.
+	return getGitHubStylePullRequest(ctx, g.apiClient, g.repoPath(), number,
+		giteaPageSizeParameter, false,
+	)
+}
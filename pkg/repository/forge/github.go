@@ -0,0 +1,268 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// gitHubPageSizeParameter is the query parameter GitHub's paginated listings take the page size
+// through.
+const gitHubPageSizeParameter = "per_page"
+
+type gitHub struct {
+	*apiClient
+
+	owner,
+	repository string
+}
+
+// gitHubStylePullRequest is the subset of a pull request, as returned by the GitHub and Gitea
+// APIs (Gitea mirrors GitHub's schema), we care about.
+type gitHubStylePullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+	Head    struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+type gitHubStyleReview struct {
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	State string `json:"state"`
+}
+
+type gitHubStyleCombinedStatus struct {
+	State      string `json:"state"`
+	TotalCount int    `json:"total_count"`
+}
+
+type gitHubCheckRuns struct {
+	TotalCount int `json:"total_count"`
+	CheckRuns  []struct {
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	} `json:"check_runs"`
+}
+
+// maxCheckRunPages caps how many pages of check runs get fetched. 10 pages of 100 check runs is
+// far beyond what any kubeaid-config CI reports.
+const maxCheckRunPages = 10
+
+func (g *gitHub) repoPath() string {
+	return "/repos/" + url.PathEscape(g.owner) + "/" + url.PathEscape(g.repository)
+}
+
+func (g *gitHub) OpenPullRequest(ctx context.Context, options PullRequestOptions) (*PullRequest, error) {
+	query := url.Values{
+		"state": {"open"},
+		"head":  {g.owner + ":" + options.Head},
+		"base":  {options.Base},
+	}
+
+	var existing []gitHubStylePullRequest
+	if err := g.do(ctx, http.MethodGet, g.repoPath()+"/pulls?"+query.Encode(), nil, &existing); err != nil {
+		return nil, fmt.Errorf("listing open pull requests: %w", err)
+	}
+	if len(existing) > 0 {
+		return g.GetPullRequest(ctx, existing[0].Number)
+	}
+
+	var created gitHubStylePullRequest
+	if err := g.do(ctx, http.MethodPost, g.repoPath()+"/pulls", map[string]string{
+		"title": options.Title,
+		"body":  options.Body,
+		"head":  options.Head,
+		"base":  options.Base,
+	}, &created); err != nil {
+		return nil, fmt.Errorf("creating pull request: %w", err)
+	}
+	return g.GetPullRequest(ctx, created.Number)
+}
+
+func (g *gitHub) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	return getGitHubStylePullRequest(ctx, g.apiClient, g.repoPath(), number,
+		gitHubPageSizeParameter, true,
+	)
+}
+
+// getGitHubStylePullRequest assembles a PullRequest from the pull request, its reviews and the
+// combined commit status of its head, for the forges speaking GitHub's API dialect.
+// pageSizeParameter is the query parameter the forge's paginated listings take the page size
+// through.
+// GitHub Actions (and most other modern CI) report through the Checks API instead of commit
+// statuses : with withCheckRuns set, the head's check runs are merged into the CI state too.
+func getGitHubStylePullRequest(ctx context.Context,
+	client *apiClient,
+	repoPath string,
+	number int,
+	pageSizeParameter string,
+	withCheckRuns bool,
+) (*PullRequest, error) {
+	pullRequestPath := fmt.Sprintf("%s/pulls/%d", repoPath, number)
+
+	var pr gitHubStylePullRequest
+	if err := client.do(ctx, http.MethodGet, pullRequestPath, nil, &pr); err != nil {
+		return nil, fmt.Errorf("getting pull request #%d: %w", number, err)
+	}
+
+	reviews, err := listAll[gitHubStyleReview](ctx, client,
+		pullRequestPath+"/reviews", nil, pageSizeParameter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing reviews of pull request #%d: %w", number, err)
+	}
+
+	var status gitHubStyleCombinedStatus
+	if err := client.do(ctx,
+		http.MethodGet, repoPath+"/commits/"+url.PathEscape(pr.Head.SHA)+"/status", nil, &status,
+	); err != nil {
+		return nil, fmt.Errorf("getting CI status of pull request #%d: %w", number, err)
+	}
+	checks := gitHubStyleCheckState(status)
+
+	if withCheckRuns {
+		checkRunsState, err := getGitHubCheckRunsState(ctx, client, repoPath, pr.Head.SHA)
+		if err != nil {
+			return nil, fmt.Errorf("getting check runs of pull request #%d: %w", number, err)
+		}
+		checks = mergeCheckStates(checks, checkRunsState)
+	}
+
+	return &PullRequest{
+		Number: pr.Number,
+		URL:    pr.HTMLURL,
+		State:  gitHubStyleState(pr),
+		Review: gitHubStyleReviewState(reviews),
+		Checks: checks,
+	}, nil
+}
+
+// getGitHubCheckRunsState folds the check runs reported on the given commit into a single
+// state.
+func getGitHubCheckRunsState(ctx context.Context,
+	client *apiClient,
+	repoPath, sha string,
+) (CheckState, error) {
+	checkState := CheckStateNone
+	for page := 1; page <= maxCheckRunPages; page++ {
+		var checkRuns gitHubCheckRuns
+		if err := client.do(ctx, http.MethodGet,
+			fmt.Sprintf("%s/commits/%s/check-runs?per_page=100&page=%d",
+				repoPath, url.PathEscape(sha), page,
+			),
+			nil, &checkRuns,
+		); err != nil {
+			return "", err
+		}
+
+		for _, checkRun := range checkRuns.CheckRuns {
+			checkState = mergeCheckStates(checkState,
+				gitHubCheckRunState(checkRun.Status, checkRun.Conclusion),
+			)
+		}
+
+		if (len(checkRuns.CheckRuns) == 0) || (page*100 >= checkRuns.TotalCount) {
+			break
+		}
+	}
+	return checkState, nil
+}
+
+func gitHubCheckRunState(status, conclusion string) CheckState {
+	if status != "completed" {
+		return CheckStatePending
+	}
+
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return CheckStateSuccess
+
+	case "action_required":
+		return CheckStatePending
+
+	default:
+		// failure, cancelled, timed_out, stale.
+		return CheckStateFailure
+	}
+}
+
+// mergeCheckStates combines the CI states reported by different sources : any failure wins,
+// then anything still pending.
+func mergeCheckStates(a, b CheckState) CheckState {
+	for _, checkState := range []CheckState{
+		CheckStateFailure, CheckStatePending, CheckStateSuccess,
+	} {
+		if (a == checkState) || (b == checkState) {
+			return checkState
+		}
+	}
+	return CheckStateNone
+}
+
+func gitHubStyleState(pr gitHubStylePullRequest) State {
+	switch {
+	case pr.Merged:
+		return StateMerged
+
+	case pr.State == "closed":
+		return StateClosed
+
+	default:
+		return StateOpen
+	}
+}
+
+// gitHubStyleReviewState folds the review history into a single state : only the latest
+// approving / change-requesting review of each reviewer counts, and any outstanding change
+// request wins over approvals.
+func gitHubStyleReviewState(reviews []gitHubStyleReview) ReviewState {
+	latestByReviewer := map[string]string{}
+	for _, review := range reviews {
+		switch review.State {
+		case "APPROVED", "CHANGES_REQUESTED", "REQUEST_CHANGES", "DISMISSED":
+			latestByReviewer[review.User.Login] = review.State
+		}
+	}
+
+	reviewState := ReviewStatePending
+	for _, state := range latestByReviewer {
+		switch state {
+		case "CHANGES_REQUESTED", "REQUEST_CHANGES":
+			return ReviewStateChangesRequested
+
+		case "APPROVED":
+			reviewState = ReviewStateApproved
+		}
+	}
+	return reviewState
+}
+
+func gitHubStyleCheckState(status gitHubStyleCombinedStatus) CheckState {
+	if status.TotalCount == 0 {
+		return CheckStateNone
+	}
+
+	switch status.State {
+	case "success":
+		return CheckStateSuccess
+
+	case "failure", "error":
+		return CheckStateFailure
+
+	default:
+		return CheckStatePending
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package forge
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	repourl "github.com/Obmondo/kubeaid-cli/pkg/repository/url"
+)
+
+const (
+	// gitHubAppJWTLifetime is how long the JWT, KubeAid CLI authenticates as the GitHub App with,
+	// is valid for. GitHub caps it at 10 minutes.
+	gitHubAppJWTLifetime = 9 * time.Minute
+
+	// gitHubAppJWTClockSkew backdates the JWT's issued-at time, so a clock running slightly ahead
+	// of GitHub's doesn't get the JWT rejected.
+	gitHubAppJWTClockSkew = time.Minute
+
+	// gitHubAppTokenRefreshMargin is how long before its expiry an installation access token gets
+	// replaced. Leaves enough headroom for a long clone or push, started with the old token, to
+	// finish.
+	gitHubAppTokenRefreshMargin = 5 * time.Minute
+)
+
+// GitHubAppTokenSource mints installation access tokens for a GitHub App installation, and
+// caches them until shortly before they expire (installation access tokens are valid for an
+// hour). Safe for concurrent use.
+type GitHubAppTokenSource struct {
+	httpClient *http.Client
+	baseURL    string
+
+	appID,
+	installationID int64
+	privateKey *rsa.PrivateKey
+
+	// now is overridden by tests.
+	now func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// GitHubAPIURL returns the URL of the GitHub (Enterprise) API serving the given repository :
+// apiURL, or, when blank, the one derived from the repository host.
+func GitHubAPIURL(parsedURL *repourl.Parsed, apiURL string) string {
+	if apiURL == "" {
+		return defaultAPIURL(parsedURL, TypeGitHub)
+	}
+	return strings.TrimSuffix(apiURL, "/")
+}
+
+// NewGitHubAppTokenSource returns a GitHubAppTokenSource for the given installation of the given
+// GitHub App, authenticating with the App's PEM encoded private key.
+// apiURL is optional : when blank, it's derived from the repository host.
+// caBundle, when non-empty, is trusted in addition to the system certificate pool.
+func NewGitHubAppTokenSource(parsedURL *repourl.Parsed,
+	apiURL string,
+	appID, installationID int64,
+	privateKeyPEM []byte,
+	caBundle []byte,
+) (*GitHubAppTokenSource, error) {
+	if (appID <= 0) || (installationID <= 0) {
+		return nil, errors.New("GitHub App ID and installation ID are required")
+	}
+
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parsing GitHub App private key: %w", err)
+	}
+
+	httpClient, err := newHTTPClient(caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubAppTokenSource{
+		httpClient:     httpClient,
+		baseURL:        GitHubAPIURL(parsedURL, apiURL),
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     privateKey,
+		now:            time.Now,
+	}, nil
+}
+
+// Token returns an installation access token, minting a new one when there's no cached token, or
+// the cached one is about to expire.
+func (s *GitHubAppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if (s.token != "") && s.now().Add(gitHubAppTokenRefreshMargin).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	appJWT, err := s.signJWT()
+	if err != nil {
+		return "", err
+	}
+
+	client := &apiClient{
+		httpClient: s.httpClient,
+		baseURL:    s.baseURL,
+		authHeader: "Authorization",
+		authValue:  "Bearer " + appJWT,
+	}
+
+	var response struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	path := fmt.Sprintf("/app/installations/%d/access_tokens", s.installationID)
+	if err := client.do(ctx, http.MethodPost, path, nil, &response); err != nil {
+		return "", fmt.Errorf("minting GitHub App installation access token: %w", err)
+	}
+	if response.Token == "" {
+		return "", errors.New("minting GitHub App installation access token: GitHub returned no token")
+	}
+
+	s.token, s.expiresAt = response.Token, response.ExpiresAt
+	return s.token, nil
+}
+
+// signJWT returns the short-lived JWT, KubeAid CLI authenticates as the GitHub App with, when
+// minting installation access tokens.
+func (s *GitHubAppTokenSource) signJWT() (string, error) {
+	now := s.now()
+
+	claims := jwt.RegisteredClaims{
+		Issuer:    strconv.FormatInt(s.appID, 10),
+		IssuedAt:  jwt.NewNumericDate(now.Add(-gitHubAppJWTClockSkew)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(gitHubAppJWTLifetime)),
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(s.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("signing GitHub App JWT: %w", err)
+	}
+	return signed, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package forge
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	repourl "github.com/Obmondo/kubeaid-cli/pkg/repository/url"
+)
+
+// fakeGitHubAppServer mints a new installation access token (token-1, token-2, ...) expiring at
+// expiresAt, every time it's asked to. It verifies the JWT against the App's public key.
+func fakeGitHubAppServer(t *testing.T,
+	publicKey *rsa.PublicKey,
+	expiresAt time.Time,
+) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var minted atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method != http.MethodPost) || (r.URL.Path != "/app/installations/42/access_tokens") {
+			http.NotFound(w, r)
+			return
+		}
+
+		var claims jwt.RegisteredClaims
+		_, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "),
+			&claims,
+			func(*jwt.Token) (any, error) { return publicKey, nil },
+			jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+			jwt.WithoutClaimsValidation(),
+		)
+		if err != nil || claims.Issuer != "7" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": "token-%d", "expires_at": %q}`,
+			minted.Add(1), expiresAt.Format(time.RFC3339),
+		)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &minted
+}
+
+func generateGitHubAppPrivateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+	return privateKey, privateKeyPEM
+}
+
+func TestGitHubAppTokenSource(t *testing.T) {
+	privateKey, privateKeyPEM := generateGitHubAppPrivateKey(t)
+
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	server, minted := fakeGitHubAppServer(t, &privateKey.PublicKey, now.Add(time.Hour))
+
+	parsedURL, err := repourl.Parse("https://github.com/Obmondo/kubeaid-config.git")
+	require.NoError(t, err)
+
+	tokenSource, err := NewGitHubAppTokenSource(parsedURL, server.URL, 7, 42, privateKeyPEM, nil)
+	require.NoError(t, err)
+	tokenSource.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	token, err := tokenSource.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// Well before expiry : the cached token is reused.
+	now = now.Add(30 * time.Minute)
+	token, err = tokenSource.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+	assert.Equal(t, int32(1), minted.Load())
+
+	// Within the refresh margin : a new token is minted.
+	now = now.Add(26 * time.Minute)
+	token, err = tokenSource.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, int32(2), minted.Load())
+}
+
+func TestGitHubAppTokenSourceRejectedJWT(t *testing.T) {
+	privateKey, _ := generateGitHubAppPrivateKey(t)
+	_, otherPrivateKeyPEM := generateGitHubAppPrivateKey(t)
+
+	server, _ := fakeGitHubAppServer(t, &privateKey.PublicKey, time.Now().Add(time.Hour))
+
+	parsedURL, err := repourl.Parse("https://github.com/Obmondo/kubeaid-config.git")
+	require.NoError(t, err)
+
+	tokenSource, err := NewGitHubAppTokenSource(parsedURL, server.URL, 7, 42, otherPrivateKeyPEM, nil)
+	require.NoError(t, err)
+
+	_, err = tokenSource.Token(context.Background())
+
+	var apiError *APIError
+	require.ErrorAs(t, err, &apiError)
+	assert.Equal(t, http.StatusUnauthorized, apiError.StatusCode)
+}
+
+func TestNewGitHubAppTokenSourceValidation(t *testing.T) {
+	_, privateKeyPEM := generateGitHubAppPrivateKey(t)
+
+	parsedURL, err := repourl.Parse("https://github.com/Obmondo/kubeaid-config.git")
+	require.NoError(t, err)
+
+	_, err = NewGitHubAppTokenSource(parsedURL, "", 0, 42, privateKeyPEM, nil)
+	require.Error(t, err)
+
+	_, err = NewGitHubAppTokenSource(parsedURL, "", 7, 42, []byte("not a PEM"), nil)
+	require.ErrorContains(t, err, "parsing GitHub App private key")
+
+	tokenSource, err := NewGitHubAppTokenSource(parsedURL, "", 7, 42, privateKeyPEM, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.github.com", tokenSource.baseURL)
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type gitLab struct {
+	*apiClient
+
+	// projectPath is the project's full path (owner/repository), which the API accepts
+	// URL-encoded in place of the numeric project ID.
+	projectPath string
+}
+
+type gitLabMergeRequest struct {
+	IID          int    `json:"iid"`
+	WebURL       string `json:"web_url"`
+	State        string `json:"state"`
+	HeadPipeline *struct {
+		Status string `json:"status"`
+	} `json:"head_pipeline"`
+}
+
+// gitLabApprovals is looked at through approved_by rather than approved : the latter is already
+// true for projects without approval rules, before anybody looked at the merge request.
+type gitLabApprovals struct {
+	ApprovedBy []struct{} `json:"approved_by"`
+}
+
+func (g *gitLab) mergeRequestsPath() string {
+	return "/projects/" + url.PathEscape(g.projectPath) + "/merge_requests"
+}
+
+func (g *gitLab) OpenPullRequest(ctx context.Context, options PullRequestOptions) (*PullRequest, error) {
+	query := url.Values{
+		"state":         {"opened"},
+		"source_branch": {options.Head},
+		"target_branch": {options.Base},
+	}
+
+	var existing []gitLabMergeRequest
+	if err := g.do(ctx, http.MethodGet, g.mergeRequestsPath()+"?"+query.Encode(), nil, &existing); err != nil {
+		return nil, fmt.Errorf("listing open merge requests: %w", err)
+	}
+	if len(existing) > 0 {
+		return g.GetPullRequest(ctx, existing[0].IID)
+	}
+
+	var created gitLabMergeRequest
+	if err := g.do(ctx, http.MethodPost, g.mergeRequestsPath(), map[string]any{
+		"title":                options.Title,
+		"description":          options.Body,
+		"source_branch":        options.Head,
+		"target_branch":        options.Base,
+		"remove_source_branch": true,
+	}, &created); err != nil {
+		return nil, fmt.Errorf("creating merge request: %w", err)
+	}
+	return g.GetPullRequest(ctx, created.IID)
+}
+
+func (g *gitLab) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	mergeRequestPath := fmt.Sprintf("%s/%d", g.mergeRequestsPath(), number)
+
+	var mr gitLabMergeRequest
+	if err := g.do(ctx, http.MethodGet, mergeRequestPath, nil, &mr); err != nil {
+		return nil, fmt.Errorf("getting merge request !%d: %w", number, err)
+	}
+
+	var approvals gitLabApprovals
+	if err := g.do(ctx, http.MethodGet, mergeRequestPath+"/approvals", nil, &approvals); err != nil {
+		return nil, fmt.Errorf("getting approvals of merge request !%d: %w", number, err)
+	}
+
+	pullRequest := &PullRequest{
+		Number: mr.IID,
+		URL:    mr.WebURL,
+		State:  StateOpen,
+		Review: ReviewStatePending,
+		Checks: CheckStateNone,
+	}
+
+	switch mr.State {
+	case "merged":
+		pullRequest.State = StateMerged
+
+	case "closed", "locked":
+		pullRequest.State = StateClosed
+	}
+
+	if len(approvals.ApprovedBy) > 0 {
+		pullRequest.Review = ReviewStateApproved
+	}
+
+	if mr.HeadPipeline != nil {
+		switch mr.HeadPipeline.Status {
+		case "success":
+			pullRequest.Checks = CheckStateSuccess
+
+		case "failed", "canceled":
+			pullRequest.Checks = CheckStateFailure
+
+		default:
+			pullRequest.Checks = CheckStatePending
+		}
+	}
+
+	return pullRequest, nil
+}
@@ -26,6 +26,7 @@ func TestAllocateStoragePlan(t *testing.T) {
 		disks      func() []*storageplan.Disk
 		osDiskSize int
 		minZFSSize int
+		layout     storageplan.StorageLayout
 		wantErr    bool
 		wantErrSub string
 		assertPlan func(t *testing.T, plan *storageplan.StoragePlan, disks []*storageplan.Disk)
@@ -159,13 +160,95 @@ func TestAllocateStoragePlan(t *testing.T) {
 				assert.Equal(t, []string{"sdb", "sdc"}, diskNames(plan.OS))
 			},
 		},
+		{
+			name:     "spans a raidz1 ZFS pool across 3 disks",
+			serverID: "srv",
+			disks: func() []*storageplan.Disk {
+				return []*storageplan.Disk{
+					testDisk("sda", constants.DiskTypeHDD, 500),
+					testDisk("sdb", constants.DiskTypeHDD, 500),
+					testDisk("sdc", constants.DiskTypeHDD, 500),
+				}
+			},
+			osDiskSize: 50,
+			minZFSSize: 100,
+			layout:     storageplan.StorageLayout{ZFSPoolType: constants.ZFSPoolTypeRAIDZ1},
+			assertPlan: func(t *testing.T, plan *storageplan.StoragePlan, _ []*storageplan.Disk) {
+				assert.Equal(t, []string{"sda", "sdb", "sdc"}, diskNames(plan.ZFS))
+				assert.Equal(t, 3, plan.Layout.ZFSPoolDisks)
+			},
+		},
+		{
+			name:     "returns ZFS error when raidz2 doesn't get 4 disks",
+			serverID: "srv",
+			disks: func() []*storageplan.Disk {
+				return []*storageplan.Disk{
+					testDisk("sda", constants.DiskTypeHDD, 500),
+					testDisk("sdb", constants.DiskTypeHDD, 500),
+					testDisk("sdc", constants.DiskTypeHDD, 500),
+				}
+			},
+			osDiskSize: 50,
+			minZFSSize: 100,
+			layout:     storageplan.StorageLayout{ZFSPoolType: constants.ZFSPoolTypeRAIDZ2},
+			wantErr:    true,
+			wantErrSub: "raidz2 ZFS pool",
+		},
+		{
+			name:     "puts a single disk ZFS pool on 1 disk",
+			serverID: "srv",
+			disks: func() []*storageplan.Disk {
+				return []*storageplan.Disk{
+					testDisk("sda", constants.DiskTypeHDD, 500),
+					testDisk("sdb", constants.DiskTypeHDD, 500),
+				}
+			},
+			osDiskSize: 50,
+			minZFSSize: 100,
+			layout:     storageplan.StorageLayout{ZFSPoolType: constants.ZFSPoolTypeSingle},
+			assertPlan: func(t *testing.T, plan *storageplan.StoragePlan, _ []*storageplan.Disk) {
+				assert.Equal(t, []string{"sda"}, diskNames(plan.ZFS))
+			},
+		},
+		{
+			name:     "leaves remaining space unallocated when CEPH is excluded",
+			serverID: "srv",
+			disks: func() []*storageplan.Disk {
+				return []*storageplan.Disk{
+					testDisk("sda", constants.DiskTypeHDD, 300),
+					testDisk("sdb", constants.DiskTypeHDD, 300),
+				}
+			},
+			osDiskSize: 50,
+			minZFSSize: 100,
+			layout:     storageplan.StorageLayout{ExcludeCEPH: true},
+			assertPlan: func(t *testing.T, plan *storageplan.StoragePlan, _ []*storageplan.Disk) {
+				assert.Empty(t, plan.CEPH)
+				assert.Equal(t, 150, plan.Disks[0].Unallocated())
+			},
+		},
+		{
+			name:     "rejects an invalid layout",
+			serverID: "srv",
+			disks: func() []*storageplan.Disk {
+				return []*storageplan.Disk{
+					testDisk("sda", constants.DiskTypeHDD, 500),
+					testDisk("sdb", constants.DiskTypeHDD, 500),
+				}
+			},
+			osDiskSize: 50,
+			minZFSSize: 100,
+			layout:     storageplan.StorageLayout{ZFSPoolType: constants.ZFSPoolTypeMirror, ZFSPoolDisks: 1},
+			wantErr:    true,
+			wantErrSub: "invalid storage layout",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			disks := tc.disks()
 
-			plan, err := allocateStoragePlan(tc.serverID, disks, tc.osDiskSize, tc.minZFSSize)
+			plan, err := allocateStoragePlan(tc.serverID, disks, tc.osDiskSize, tc.minZFSSize, tc.layout)
 			if tc.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tc.wantErrSub)
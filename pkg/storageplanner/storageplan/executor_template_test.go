@@ -61,3 +61,100 @@ func TestStoragePlanExecutorTemplate_BlankDiskGuard(t *testing.T) {
 	assert.Contains(t, script, `echo ",220G,L" | sfdisk -N 5 /dev/sda`,
 		"OS/ZFS disk should get a 220G ZFS partition")
 }
+
+// TestStoragePlanExecutorTemplate_Layout checks the executor script follows the plan's storage
+// layout : the ZFS pool's vdev type and disks, and the datasets with their properties. And that a
+// plan carrying no layout still gets the default mirror and datasets.
+func TestStoragePlanExecutorTemplate_Layout(t *testing.T) {
+	mk := func(name, diskType string) *Disk {
+		d := &Disk{Name: name, Type: diskType, PartitionTableType: "gpt"}
+		d.Allocations.ZFS = 220
+		return d
+	}
+	render := func(plan *StoragePlan) string {
+		return string(templateUtils.ParseAndExecuteTemplate(
+			context.Background(),
+			&templates,
+			constants.TemplateNameStoragePlanExecutor,
+			&StoragePlanExecutorTemplateValues{StoragePlan: plan},
+		))
+	}
+
+	t.Run("raidz1 pool with custom datasets", func(t *testing.T) {
+		disks := []*Disk{mk("nvme0n1", constants.DiskTypeNVMe), mk("sda", constants.DiskTypeHDD), mk("sdb", constants.DiskTypeHDD)}
+		script := render(&StoragePlan{
+			Disks: disks,
+			ZFS:   disks,
+			Layout: StorageLayout{
+				ZFSPoolType:  constants.ZFSPoolTypeRAIDZ1,
+				ZFSPoolDisks: 3,
+				ZFSDatasets: []ZFSDataset{
+					{Name: "data", Mountpoint: "/srv/data", Compression: "zstd", Quota: "100G"},
+				},
+			},
+		})
+
+		assert.Contains(t, script, "zpool create primary raidz1 \\\n    /dev/nvme0n1p5 \\\n    /dev/sda5 \\\n    /dev/sdb5\n")
+		assert.Contains(t, script,
+			"zfs create -p -o mountpoint=/srv/data -o compression=zstd -o quota=100G primary/data")
+		assert.NotContains(t, script, "primary/containerd")
+	})
+
+	t.Run("single disk pool carries no vdev type", func(t *testing.T) {
+		disks := []*Disk{mk("sda", constants.DiskTypeHDD)}
+		script := render(&StoragePlan{
+			Disks:  disks,
+			ZFS:    disks,
+			Layout: StorageLayout{ZFSPoolType: constants.ZFSPoolTypeSingle},
+		})
+
+		assert.Contains(t, script, "zpool create primary \\\n    /dev/sda5\n")
+	})
+
+	t.Run("plan without a layout gets the defaults", func(t *testing.T) {
+		disks := []*Disk{mk("sda", constants.DiskTypeHDD), mk("sdb", constants.DiskTypeHDD)}
+		script := render(&StoragePlan{Disks: disks, ZFS: disks})
+
+		assert.Contains(t, script, "zpool create primary mirror \\\n    /dev/sda5 \\\n    /dev/sdb5\n")
+		for _, dataset := range DefaultZFSDatasets() {
+			assert.Contains(t, script,
+				"zfs create -p -o mountpoint="+dataset.Mountpoint+" primary/"+dataset.Name)
+		}
+	})
+}
+
+// TestStoragePlanExecutorTemplate_StableDevicePaths checks the executor script addresses disks and
+// the ZFS pool's partitions by their /dev/disk/by-id path, and wipes the signatures the preflight
+// was allowed to, before partitioning.
+func TestStoragePlanExecutorTemplate_StableDevicePaths(t *testing.T) {
+	sda := &Disk{Name: "sda", WWN: "0x5000c500a1b2c3d4", Type: constants.DiskTypeHDD, PartitionTableType: "gpt"}
+	sda.Allocations.ZFS = 220
+	nvme := &Disk{Name: "nvme0n1", WWN: "eui.0025388b91b2c3d4", Type: constants.DiskTypeNVMe, PartitionTableType: "gpt"}
+	nvme.Allocations.ZFS = 220
+
+	plan := &StoragePlan{Disks: []*Disk{sda, nvme}, ZFS: []*Disk{sda, nvme}}
+
+	script := string(templateUtils.ParseAndExecuteTemplate(
+		context.Background(),
+		&templates,
+		constants.TemplateNameStoragePlanExecutor,
+		&StoragePlanExecutorTemplateValues{
+			StoragePlan: plan,
+			Wipes: Wipes{
+				"sda": {{Device: "/dev/disk/by-id/wwn-0x5000c500a1b2c3d4-part5", Partition: 5, Type: "zfs_member", Label: "tank"}},
+			},
+		},
+	))
+
+	assert.Contains(t, script,
+		"wipefs --all --force /dev/disk/by-id/wwn-0x5000c500a1b2c3d4-part5\n")
+	assert.Contains(t, script,
+		`echo ",220G,L" | sfdisk -N 5 /dev/disk/by-id/wwn-0x5000c500a1b2c3d4 --force --no-reread`)
+	assert.Contains(t, script, "partprobe /dev/disk/by-id/nvme-eui.0025388b91b2c3d4\n")
+	assert.Contains(t, script, "zpool create primary mirror \\\n"+
+		"    /dev/disk/by-id/wwn-0x5000c500a1b2c3d4-part5 \\\n"+
+		"    /dev/disk/by-id/nvme-eui.0025388b91b2c3d4-part5\n")
+
+	assert.NotContains(t, script, "/dev/sda")
+	assert.NotContains(t, script, "wipefs --all --force /dev/disk/by-id/nvme")
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package storageplan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/tree"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor"
+)
+
+type (
+	// Signature is a signature (filesystem, partition table, RAID / LVM / ZFS member etc.) wipefs
+	// finds on a disk, or on one of its partitions.
+	Signature struct {
+		// Stable path of the disk, or the partition, carrying the signature.
+		Device string
+
+		// Partition number. 0 when the signature is on the disk itself.
+		Partition int
+
+		Type,
+		Label string
+	}
+
+	// Wipes are the signatures the storage plan executor wipes before partitioning, keyed by disk
+	// name.
+	Wipes map[string][]Signature
+
+	wipefsOutput struct {
+		Signatures []struct {
+			Device string `json:"device"`
+			Type   string `json:"type"`
+			Label  string `json:"label"`
+		} `json:"signatures"`
+	}
+)
+
+// Preflight looks for data on the disks the storage plan is about to partition, using wipefs, and
+// refuses to go ahead when a disk carries a signature the storage plan executor doesn't leave
+// there itself : a filesystem, an LVM physical volume, an mdraid member or a foreign ZFS pool.
+// Unless the disk's WWN is in allowedWipeWWNs : then those signatures get returned, for the storage
+// plan executor to wipe.
+func Preflight(ctx context.Context,
+	s *StoragePlan,
+	commandExecutor commandexecutor.CommandExecutor,
+	allowedWipeWWNs []string,
+) (Wipes, error) {
+	wipes := Wipes{}
+
+	var errs []error
+	for _, disk := range s.Disks {
+		signatures, err := getSignatures(ctx, disk, commandExecutor)
+		if err != nil {
+			return nil, err
+		}
+
+		var unexpected []Signature
+		for _, signature := range signatures {
+			if !isExpectedSignature(disk, signature) {
+				unexpected = append(unexpected, signature)
+			}
+		}
+		if len(unexpected) == 0 {
+			continue
+		}
+
+		if (disk.WWN != "") && slices.Contains(allowedWipeWWNs, disk.WWN) {
+			wipes[disk.Name] = unexpected
+			continue
+		}
+
+		descriptions := make([]string, 0, len(unexpected))
+		for _, signature := range unexpected {
+			descriptions = append(descriptions, describeSignature(signature))
+		}
+		errs = append(errs, fmt.Errorf(
+			"disk %s (WWN %s) carries %s : refusing to partition it, unless its WWN is allowed to be wiped",
+			disk.Name, displayWWN(disk.WWN), strings.Join(descriptions, ", "),
+		))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return wipes, nil
+}
+
+// Returns the signatures wipefs finds on the given disk and its partitions.
+func getSignatures(ctx context.Context,
+	disk *Disk,
+	commandExecutor commandexecutor.CommandExecutor,
+) ([]Signature, error) {
+	output, err := commandExecutor.Execute(ctx, "lsblk -lnp -o NAME "+disk.DevicePath())
+	if err != nil {
+		return nil, fmt.Errorf("failed listing partitions of disk %s: %w", disk.Name, err)
+	}
+
+	var signatures []Signature
+	for device := range strings.FieldsSeq(output) {
+		partition, ok := partitionNumber(disk, device)
+		if !ok {
+			continue
+		}
+
+		output, err := commandExecutor.Execute(ctx, "wipefs --no-act --json "+device)
+		if err != nil {
+			return nil, fmt.Errorf("failed looking for signatures on %s: %w", device, err)
+		}
+		if strings.TrimSpace(output) == "" {
+			continue
+		}
+
+		var parsedOutput wipefsOutput
+		if err := json.Unmarshal([]byte(output), &parsedOutput); err != nil {
+			return nil, fmt.Errorf("failed unmarshalling wipefs output for %s: %w", device, err)
+		}
+
+		stablePath := disk.DevicePath()
+		if partition > 0 {
+			stablePath = disk.PartitionPath(partition)
+		}
+		for _, signature := range parsedOutput.Signatures {
+			signatures = append(signatures, Signature{
+				Device:    stablePath,
+				Partition: partition,
+				Type:      signature.Type,
+				Label:     signature.Label,
+			})
+		}
+	}
+	return signatures, nil
+}
+
+// Returns the partition number of the given device (like /dev/sda5, /dev/nvme0n1p5 or
+// /dev/mapper/mpatha-part5) of the given disk, or 0 for the disk itself. And false, when the device
+// isn't the disk or one of its partitions.
+func partitionNumber(disk *Disk, device string) (int, bool) {
+	suffix, found := strings.CutPrefix(path.Base(device), disk.Name)
+	if !found {
+		return 0, false
+	}
+	if suffix == "" {
+		return 0, true
+	}
+
+	suffix = strings.TrimPrefix(strings.TrimPrefix(suffix, "-part"), "p")
+
+	partition, err := strconv.Atoi(suffix)
+	if err != nil || partition <= 0 {
+		return 0, false
+	}
+	return partition, true
+}
+
+// Reports whether the storage plan executor itself leaves the given signature on the given disk :
+// so it's on the disk because the disk got partitioned by it (or the OS installer) before.
+func isExpectedSignature(disk *Disk, signature Signature) bool {
+	switch {
+	// The partition table.
+	case signature.Partition == 0:
+		return slices.Contains([]string{"gpt", "PMBR", "dos"}, signature.Type)
+
+	// The OS volume, on partitions 1 - 4 of an OS disk.
+	case signature.Partition <= 4:
+		return disk.Allocations.OS > 0
+
+	// The ZFS pool, on partition 5.
+	case signature.Partition == 5:
+		return (signature.Type == "zfs_member") && (signature.Label == constants.ZFSPoolName)
+
+	// A Rook Ceph OSD, on partition 6.
+	case signature.Partition == 6:
+		return signature.Type == "ceph_bluestore"
+
+	default:
+		return false
+	}
+}
+
+func describeSignature(signature Signature) string {
+	var description string
+	switch signature.Type {
+	case "LVM2_member":
+		description = "an LVM physical volume"
+
+	case "linux_raid_member":
+		description = "an mdraid member"
+
+	case "zfs_member":
+		description = fmt.Sprintf("a member of ZFS pool %q", signature.Label)
+
+	case "gpt", "PMBR", "dos":
+		description = fmt.Sprintf("a %s partition table", signature.Type)
+
+	default:
+		description = fmt.Sprintf("a %s filesystem", signature.Type)
+		if signature.Label != "" {
+			description += fmt.Sprintf(" labelled %q", signature.Label)
+		}
+	}
+	return fmt.Sprintf("%s on %s", description, signature.Device)
+}
+
+// Devices returns the devices the storage plan executor runs `wipefs --all` on, before partitioning
+// the given disk : the partitions first, then the disk itself. The disk itself is never wiped when
+// it's an OS disk, since that would take the OS volume with it.
+func (w Wipes) Devices(disk *Disk) []string {
+	var partitions, devices []string
+	for _, signature := range w[disk.Name] {
+		switch {
+		case signature.Partition > 0:
+			if !slices.Contains(partitions, signature.Device) {
+				partitions = append(partitions, signature.Device)
+			}
+
+		case disk.Allocations.OS == 0:
+			if !slices.Contains(devices, signature.Device) {
+				devices = append(devices, signature.Device)
+			}
+		}
+	}
+	return append(partitions, devices...)
+}
+
+// Returns the UI tree listing what the storage plan executor is about to do to each disk.
+func getDestructiveActionsUITree(s *StoragePlan, wipes Wipes) *tree.Tree {
+	layout := planLayout(s)
+
+	t := tree.Root("Destructive actions, per disk")
+	for _, disk := range s.Disks {
+		diskTree := tree.Root(fmt.Sprintf("%s (%s)", disk.Name, disk.DevicePath()))
+
+		for _, signature := range wipes[disk.Name] {
+			if slices.Contains(wipes.Devices(disk), signature.Device) {
+				diskTree = diskTree.Child("wipe " + describeSignature(signature))
+			}
+		}
+
+		if disk.PartitionTableType == "" || slices.Contains(wipes.Devices(disk), disk.DevicePath()) {
+			diskTree = diskTree.Child("create a GPT partition table")
+		}
+		if disk.Allocations.OS == 0 {
+			diskTree = diskTree.Child("create placeholder partitions 1 - 3")
+		}
+		diskTree = diskTree.Child("recreate partition 4")
+
+		if disk.Allocations.ZFS > 0 {
+			diskTree = diskTree.Child(fmt.Sprintf("create partition 5 : %d GB, for the ZFS pool (%s)",
+				disk.Allocations.ZFS, layout.ZFSPoolType,
+			))
+		} else {
+			diskTree = diskTree.Child("create placeholder partition 5")
+		}
+
+		if disk.Allocations.CEPH > 0 {
+			diskTree = diskTree.Child(fmt.Sprintf("create partition 6 : %d GB, for Rook Ceph", disk.Allocations.CEPH))
+		} else {
+			diskTree = diskTree.Child("create partition 6 : the rest of the disk, left unused")
+		}
+
+		t = t.Child(diskTree)
+	}
+	return t
+}
+
+// PrintDestructiveActions prints what the storage plan executor is about to do to each disk.
+func PrintDestructiveActions(s *StoragePlan, wipes Wipes) {
+	fmt.Println(getDestructiveActionsUITree(s, wipes).String())
+}
+
+func displayWWN(wwn string) string {
+	if wwn == "" {
+		return "(none)"
+	}
+	return wwn
+}
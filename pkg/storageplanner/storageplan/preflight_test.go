@@ -0,0 +1,200 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package storageplan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor/fake"
+)
+
+// newPreflightTestPlan returns a storage plan across an OS disk (sda) and a data disk (sdb).
+func newPreflightTestPlan() *StoragePlan {
+	osDisk := &Disk{Name: "sda", WWN: "0xa", Type: constants.DiskTypeHDD, PartitionTableType: "gpt"}
+	osDisk.Allocations.OS = 50
+	osDisk.Allocations.ZFS = 220
+
+	dataDisk := &Disk{Name: "sdb", WWN: "0xb", Type: constants.DiskTypeHDD, PartitionTableType: "gpt"}
+	dataDisk.Allocations.ZFS = 220
+	dataDisk.Allocations.CEPH = 1000
+
+	return &StoragePlan{
+		ServerID: "srv",
+		Disks:    []*Disk{osDisk, dataDisk},
+		ZFS:      []*Disk{osDisk, dataDisk},
+		CEPH:     []*Disk{dataDisk},
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	t.Parallel()
+
+	const (
+		gptSignature   = `{"signatures": [{"device":"sda","offset":"0x200","type":"gpt","uuid":null,"label":null}]}`
+		raidSignature  = `{"signatures": [{"device":"sda1","offset":"0x1000","type":"linux_raid_member","uuid":"x","label":"rescue:0"}]}`
+		zfsPrimary     = `{"signatures": [{"device":"sda5","offset":"0x3f000","type":"zfs_member","uuid":"x","label":"primary"}]}`
+		zfsForeign     = `{"signatures": [{"device":"sdb5","offset":"0x3f000","type":"zfs_member","uuid":"x","label":"tank"}]}`
+		ext4Signature  = `{"signatures": [{"device":"sdb1","offset":"0x438","type":"ext4","uuid":"x","label":"backups"}]}`
+		lvmSignature   = `{"signatures": [{"device":"sdb6","offset":"0x218","type":"LVM2_member","uuid":"x","label":null}]}`
+		cephSignature  = `{"signatures": [{"device":"sdb6","offset":"0x0","type":"ceph_bluestore","uuid":"x","label":null}]}`
+		osDiskDevices  = "/dev/sda\n/dev/sda1\n/dev/sda5\n"
+		dataDiskDevice = "/dev/sdb\n/dev/sdb1\n/dev/sdb5\n/dev/sdb6\n"
+	)
+
+	tests := []struct {
+		name            string
+		outputs         []string
+		allowedWipeWWNs []string
+		wantWipes       Wipes
+		wantErrSubs     []string
+	}{
+		{
+			name: "re-run on provisioned disks",
+			outputs: []string{
+				osDiskDevices, gptSignature, raidSignature, zfsPrimary,
+				dataDiskDevice, "", "", "", cephSignature,
+			},
+			wantWipes: Wipes{},
+		},
+		{
+			name: "data disk carrying a filesystem, an LVM PV and a foreign ZFS pool",
+			outputs: []string{
+				osDiskDevices, gptSignature, raidSignature, zfsPrimary,
+				dataDiskDevice, "", ext4Signature, zfsForeign, lvmSignature,
+			},
+			wantErrSubs: []string{
+				"disk sdb (WWN 0xb) carries",
+				`a ext4 filesystem labelled "backups" on /dev/disk/by-id/wwn-0xb-part1`,
+				`a member of ZFS pool "tank" on /dev/disk/by-id/wwn-0xb-part5`,
+				"an LVM physical volume on /dev/disk/by-id/wwn-0xb-part6",
+			},
+		},
+		{
+			name: "data disk allowed to be wiped",
+			outputs: []string{
+				osDiskDevices, gptSignature, raidSignature, zfsPrimary,
+				dataDiskDevice, "", ext4Signature, "", "",
+			},
+			allowedWipeWWNs: []string{"0xb"},
+			wantWipes: Wipes{
+				"sdb": {{Device: "/dev/disk/by-id/wwn-0xb-part1", Partition: 1, Type: "ext4", Label: "backups"}},
+			},
+		},
+		{
+			name: "mdraid member on a data disk",
+			outputs: []string{
+				osDiskDevices, gptSignature, raidSignature, zfsPrimary,
+				"/dev/sdb\n/dev/sdb2\n",
+				"", `{"signatures": [{"device":"sdb2","type":"linux_raid_member","label":"old:1"}]}`,
+			},
+			allowedWipeWWNs: []string{"0xa"},
+			wantErrSubs:     []string{"an mdraid member on /dev/disk/by-id/wwn-0xb-part2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			executor := fake.NewExecutor(tc.outputs...)
+			wipes, err := Preflight(context.Background(), newPreflightTestPlan(), executor, tc.allowedWipeWWNs)
+
+			if len(tc.wantErrSubs) > 0 {
+				require.Error(t, err)
+				for _, wantErrSub := range tc.wantErrSubs {
+					assert.Contains(t, err.Error(), wantErrSub)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantWipes, wipes)
+
+			assert.Equal(t, "lsblk -lnp -o NAME /dev/disk/by-id/wwn-0xa", executor.Commands[0])
+			assert.Equal(t, "wipefs --no-act --json /dev/sda", executor.Commands[1])
+		})
+	}
+}
+
+func TestPartitionNumber(t *testing.T) {
+	t.Parallel()
+
+	sda := &Disk{Name: "sda"}
+	nvme := &Disk{Name: "nvme0n1", Type: constants.DiskTypeNVMe}
+
+	for _, tc := range []struct {
+		disk          *Disk
+		device        string
+		wantPartition int
+		wantOK        bool
+	}{
+		{sda, "/dev/sda", 0, true},
+		{sda, "/dev/sda12", 12, true},
+		{sda, "/dev/sdb1", 0, false},
+		{nvme, "/dev/nvme0n1p5", 5, true},
+		{nvme, "/dev/nvme0n1", 0, true},
+		{&Disk{Name: "mpatha", Multipath: true}, "/dev/mapper/mpatha-part6", 6, true},
+		{sda, "/dev/sdaa1", 0, false},
+	} {
+		partition, ok := partitionNumber(tc.disk, tc.device)
+		assert.Equal(t, tc.wantPartition, partition, tc.device)
+		assert.Equal(t, tc.wantOK, ok, tc.device)
+	}
+}
+
+func TestWipesDevices(t *testing.T) {
+	t.Parallel()
+
+	plan := newPreflightTestPlan()
+	osDisk, dataDisk := plan.Disks[0], plan.Disks[1]
+
+	wipes := Wipes{
+		"sda": {
+			{Device: "/dev/disk/by-id/wwn-0xa", Type: "xfs"},
+			{Device: "/dev/disk/by-id/wwn-0xa-part6", Partition: 6, Type: "ext4"},
+		},
+		"sdb": {
+			{Device: "/dev/disk/by-id/wwn-0xb", Type: "LVM2_member"},
+			{Device: "/dev/disk/by-id/wwn-0xb-part1", Partition: 1, Type: "ext4"},
+			{Device: "/dev/disk/by-id/wwn-0xb-part1", Partition: 1, Type: "LVM2_member"},
+		},
+	}
+
+	// The OS disk itself never gets wiped.
+	assert.Equal(t, []string{"/dev/disk/by-id/wwn-0xa-part6"}, wipes.Devices(osDisk))
+	assert.Equal(t,
+		[]string{"/dev/disk/by-id/wwn-0xb-part1", "/dev/disk/by-id/wwn-0xb"},
+		wipes.Devices(dataDisk),
+	)
+	assert.Empty(t, Wipes(nil).Devices(dataDisk))
+}
+
+func TestGetDestructiveActionsUITree(t *testing.T) {
+	t.Parallel()
+
+	plan := newPreflightTestPlan()
+	plan.Disks[1].PartitionTableType = ""
+
+	output := getDestructiveActionsUITree(plan, Wipes{
+		"sdb": {{Device: "/dev/disk/by-id/wwn-0xb-part1", Partition: 1, Type: "ext4"}},
+	}).String()
+
+	for _, want := range []string{
+		"sda (/dev/disk/by-id/wwn-0xa)",
+		"create partition 5 : 220 GB, for the ZFS pool (mirror)",
+		"create partition 6 : the rest of the disk, left unused",
+		"sdb (/dev/disk/by-id/wwn-0xb)",
+		"wipe a ext4 filesystem on /dev/disk/by-id/wwn-0xb-part1",
+		"create a GPT partition table",
+		"create placeholder partitions 1 - 3",
+		"create partition 6 : 1000 GB, for Rook Ceph",
+	} {
+		assert.Contains(t, output, want)
+	}
+	assert.NotContains(t, output, "placeholder partition 5")
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package storageplan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor"
+)
+
+// Record writes the storage plan, as JSON, to constants.StoragePlanRecordPath on the server the
+// command executor runs commands on.
+func Record(ctx context.Context, s *StoragePlan, commandExecutor commandexecutor.CommandExecutor) error {
+	storagePlanAsJSON, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed marshalling storage plan: %w", err)
+	}
+
+	command := fmt.Sprintf("mkdir -p %s && printf '%%s' %s > %s",
+		path.Dir(constants.StoragePlanRecordPath),
+		shellQuote(string(storagePlanAsJSON)),
+		constants.StoragePlanRecordPath,
+	)
+	if _, err := commandExecutor.Execute(ctx, command); err != nil {
+		return fmt.Errorf("failed writing %s: %w", constants.StoragePlanRecordPath, err)
+	}
+	return nil
+}
+
+// LoadRecorded returns the storage plan Record wrote on the server the command executor runs
+// commands on. Or nil, when there's none : the server got provisioned by a kubeaid-storagectl
+// predating Record.
+func LoadRecorded(ctx context.Context,
+	commandExecutor commandexecutor.CommandExecutor,
+) (*StoragePlan, error) {
+	output, err := commandExecutor.Execute(ctx,
+		fmt.Sprintf("cat %s 2>/dev/null || true", constants.StoragePlanRecordPath),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading %s: %w", constants.StoragePlanRecordPath, err)
+	}
+	if strings.TrimSpace(output) == "" {
+		return nil, nil
+	}
+
+	storagePlan := &StoragePlan{}
+	if err := json.Unmarshal([]byte(output), storagePlan); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling %s: %w", constants.StoragePlanRecordPath, err)
+	}
+	return storagePlan, nil
+}
+
+// shellQuote single-quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package storageplan
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor/fake"
+)
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	plan := &StoragePlan{
+		ServerID: "srv",
+		Disks:    []*Disk{{Name: "sda", WWN: "0xa", Size: 300}},
+		Layout:   StorageLayout{ZFSDatasets: []ZFSDataset{{Name: "o'brien", Mountpoint: "/srv"}}},
+	}
+
+	executor := fake.NewExecutor("")
+	require.NoError(t, Record(context.Background(), plan, executor))
+
+	require.Len(t, executor.Commands, 1)
+	assert.Contains(t, executor.Commands[0], "mkdir -p /var/lib/kubeaid-storagectl && printf '%s' '")
+	assert.Contains(t, executor.Commands[0], `"o'\''brien"`)
+	assert.Contains(t, executor.Commands[0], "> /var/lib/kubeaid-storagectl/storage-plan.json")
+}
+
+func TestLoadRecorded(t *testing.T) {
+	t.Parallel()
+
+	plan := &StoragePlan{
+		ServerID: "srv",
+		Disks:    []*Disk{{Name: "sda", WWN: "0xa", Size: 300}},
+	}
+	planAsJSON, err := json.Marshal(plan)
+	require.NoError(t, err)
+
+	recorded, err := LoadRecorded(context.Background(), fake.NewExecutor(string(planAsJSON)))
+	require.NoError(t, err)
+	assert.Equal(t, plan, recorded)
+
+	recorded, err = LoadRecorded(context.Background(), fake.NewExecutor("\n"))
+	require.NoError(t, err)
+	assert.Nil(t, recorded)
+
+	_, err = LoadRecorded(context.Background(), fake.NewExecutor("not json"))
+	assert.Error(t, err)
+}
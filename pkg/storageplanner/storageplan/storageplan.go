@@ -13,6 +13,7 @@ import (
 
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/storagetypes"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor"
 	templateUtils "github.com/Obmondo/kubeaid-cli/pkg/utils/templates"
 )
@@ -24,13 +25,32 @@ type (
 	StoragePlan    = storagetypes.StoragePlan
 	Disk           = storagetypes.Disk
 	PriorityScores = storagetypes.PriorityScores
+	StorageLayout  = storagetypes.StorageLayout
+	ZFSDataset     = storagetypes.ZFSDataset
+	DiskSelection  = storagetypes.DiskSelection
 )
 
 // NewDisk is re-exported so callers of this package are unchanged.
 var NewDisk = storagetypes.NewDisk
 
+// Re-exported alongside StorageLayout.
+var (
+	ParseZFSDataset    = storagetypes.ParseZFSDataset
+	ZFSPoolParityDisks = storagetypes.ZFSPoolParityDisks
+	DefaultZFSDatasets = storagetypes.DefaultZFSDatasets
+)
+
 type StoragePlanExecutorTemplateValues struct {
 	StoragePlan *StoragePlan
+
+	// Signatures the preflight found on disks whose WWN is allowed to be wiped.
+	Wipes Wipes
+}
+
+// Layout returns the storage plan's layout, with its defaults filled in. So a plan which doesn't
+// carry one still gets the ZFS mirror and the default datasets, instead of a striped pool.
+func (v *StoragePlanExecutorTemplateValues) Layout() StorageLayout {
+	return planLayout(v.StoragePlan)
 }
 
 // Returns the UI tree, which can be used to pretty print the storage-plan.
@@ -52,7 +72,7 @@ func getUITree(s *StoragePlan) *tree.Tree {
 			diskTree = diskTree.Child(fmt.Sprintf("OS   : %d GB", disk.Allocations.OS))
 		}
 		if disk.Allocations.ZFS > 0 {
-			diskTree = diskTree.Child(fmt.Sprintf("ZFS  : %d GB", disk.Allocations.ZFS))
+			diskTree = diskTree.Child(fmt.Sprintf("ZFS  : %d GB (%s)", disk.Allocations.ZFS, planLayout(s).ZFSPoolType))
 		}
 		if disk.Allocations.CEPH > 0 {
 			diskTree = diskTree.Child(fmt.Sprintf("CEPH : %d GB", disk.Allocations.CEPH))
@@ -70,10 +90,25 @@ func PrettyPrint(s *StoragePlan) {
 var templates embed.FS
 
 // Executes the storage plan, by running necessary shell commands.
-func Execute(ctx context.Context, s *StoragePlan, commandExecutor commandexecutor.CommandExecutor) {
+// Disks carrying data the storage plan executor didn't put there get refused, unless their WWN is
+// in allowedWipeWWNs : then that data gets wiped.
+func Execute(ctx context.Context,
+	s *StoragePlan,
+	commandExecutor commandexecutor.CommandExecutor,
+	allowedWipeWWNs []string,
+) {
+	// Make sure we aren't about to partition a disk with data on it.
+	wipes, err := Preflight(ctx, s, commandExecutor, allowedWipeWWNs)
+	assert.AssertErrNil(ctx, err, "Storage plan preflight failed")
+
+	PrintDestructiveActions(s, wipes)
+
 	// Generate the shell commands to execute the storage plan.
 
-	storagePlanExecutorTemplateValues := &StoragePlanExecutorTemplateValues{StoragePlan: s}
+	storagePlanExecutorTemplateValues := &StoragePlanExecutorTemplateValues{
+		StoragePlan: s,
+		Wipes:       wipes,
+	}
 
 	storagePlanExecutorAsBytes := templateUtils.ParseAndExecuteTemplate(ctx,
 		&templates, constants.TemplateNameStoragePlanExecutor, storagePlanExecutorTemplateValues)
@@ -81,4 +116,9 @@ func Execute(ctx context.Context, s *StoragePlan, commandExecutor commandexecuto
 	// Run those shell commands.
 	commandExecutor.MustExecute(ctx, string(storagePlanExecutorAsBytes))
 	slog.InfoContext(ctx, "Executed storage plan")
+
+	// Record the executed storage plan, for `kubeaid-storagectl plan verify` to compare the node
+	// against later.
+	err = Record(ctx, s, commandExecutor)
+	assert.AssertErrNil(ctx, err, "Failed recording the executed storage plan")
 }
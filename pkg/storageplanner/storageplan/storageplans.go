@@ -16,6 +16,8 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
 )
@@ -36,18 +38,31 @@ const (
 	// storageBoxTitle is embedded in the box's top border.
 	storageBoxTitle = "Storage layout · KubeAid recommended"
 
+	// storageBoxCustomTitle replaces storageBoxTitle when a node group
+	// declares its own layout (see StorageLayout.IsDefault).
+	storageBoxCustomTitle = "Storage layout · custom"
+
 	// storageBoxWarning is printed directly below the box rather than
 	// inside it: the line is wider than any boxed content, so framing
 	// it would force the whole box uncomfortably wide.
 	storageBoxWarning = "Warning: KubeAid recommends this layout — " +
 		"changing it destroys data + RAID/mirror redundancy"
 
+	// storageBoxCustomWarning replaces storageBoxWarning along with
+	// storageBoxCustomTitle.
+	storageBoxCustomWarning = "Warning: changing this layout later destroys data + RAID/mirror redundancy"
+
 	// storageBoxZFSNote states why the ZFS volumes carry no sizes:
 	// they have no quota, so any figure would be a guess. Printed
 	// below the box, under storageBoxWarning.
 	storageBoxZFSNote = "Note: these volumes share the ZFS pool with " +
 		"no quota — each grows into free space as needed."
 
+	// storageBoxZFSQuotaNote replaces storageBoxZFSNote when some
+	// volumes carry a quota, shown in their note column.
+	storageBoxZFSQuotaNote = "Note: volumes without a quota share the ZFS pool's " +
+		"free space — each grows into it as needed."
+
 	// Body-line indentation for the three nesting levels. The frame
 	// adds one more leading space, so the operator sees 2 / 4 / 8
 	// columns of indent for group headers / rows / sub-volume rows.
@@ -89,6 +104,16 @@ func (s StoragePlans) render() string {
 		return ""
 	}
 
+	title, warning := storageBoxTitle, storageBoxWarning
+	if !s.allDefaultLayouts() {
+		title, warning = storageBoxCustomTitle, storageBoxCustomWarning
+	}
+
+	zfsNote := storageBoxZFSNote
+	if s.anyZFSQuota() {
+		zfsNote = storageBoxZFSQuotaNote
+	}
+
 	border := lipgloss.RoundedBorder()
 
 	// Pass 1 — size the shared label and size columns from the data
@@ -107,7 +132,7 @@ func (s StoragePlans) render() string {
 	// summary against that final width, so they are deferred to pass 3
 	// and here only widen the box to fit their content.
 	bodies := make([]string, len(items))
-	innerWidth := utf8.RuneCountInString(storageBoxTitle) + 3 // top border must fit the title.
+	innerWidth := utf8.RuneCountInString(title) + 3 // top border must fit the title.
 	for i, it := range items {
 		switch it.kind {
 		case itemRow:
@@ -130,7 +155,7 @@ func (s StoragePlans) render() string {
 
 	// Pass 3 — frame each line within the now-final inner width.
 	var b strings.Builder
-	b.WriteString(topBorder(border, innerWidth))
+	b.WriteString(topBorder(border, title, innerWidth))
 	for i, it := range items {
 		b.WriteString("\n")
 
@@ -162,12 +187,50 @@ func (s StoragePlans) render() string {
 	warningStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("3"))
 	noteStyle := lipgloss.NewStyle().Faint(true)
 	b.WriteString("\n")
-	b.WriteString(warningStyle.Render(storageBoxWarning))
+	b.WriteString(warningStyle.Render(warning))
 	b.WriteString("\n")
-	b.WriteString(noteStyle.Render(storageBoxZFSNote))
+	b.WriteString(noteStyle.Render(zfsNote))
 	return b.String()
 }
 
+// allDefaultLayouts reports whether every node group runs the layout
+// KubeAid recommends, which the box's title and warning then say.
+func (s StoragePlans) allDefaultLayouts() bool {
+	for _, plans := range s {
+		if (len(plans) > 0) && !planLayout(plans[0]).IsDefault() {
+			return false
+		}
+	}
+	return true
+}
+
+// anyZFSQuota reports whether some node group caps a ZFS volume with a
+// quota.
+func (s StoragePlans) anyZFSQuota() bool {
+	for _, plans := range s {
+		if len(plans) == 0 {
+			continue
+		}
+		for _, dataset := range planLayout(plans[0]).ZFSDatasets {
+			if dataset.Quota != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// planLayout returns the plan's layout with its defaults filled in. A
+// plan from the storage planner carries it normalized already; this
+// covers hand-built ones, whose zero Layout means the default one.
+func planLayout(plan *StoragePlan) StorageLayout {
+	layout, err := plan.Layout.Normalized()
+	if err != nil {
+		return plan.Layout
+	}
+	return layout
+}
+
 type itemKind int
 
 const (
@@ -220,6 +283,7 @@ func (s StoragePlans) boxItems() []boxItem {
 // so the operator can match it against their config.
 func groupItems(name string, plans []*StoragePlan) []boxItem {
 	disks := plans[0].Disks
+	layout := planLayout(plans[0])
 
 	header := "NodeGroup: " + name
 	if name == controlPlaneGroup {
@@ -256,7 +320,18 @@ func groupItems(name string, plans []*StoragePlan) []boxItem {
 			note:   "ext4 · RAID-1",
 		},
 	}
-	items = append(items, zfsRows(disks)...)
+	items = append(items, zfsRows(disks, layout)...)
+
+	// Rook Ceph excluded by the layout — said explicitly, so the
+	// operator doesn't take the missing OSDs for small disks.
+	if layout.ExcludeCEPH {
+		return append(items, boxItem{
+			kind:   itemRow,
+			indent: indentRow,
+			label:  "Rook Ceph",
+			note:   "excluded · leftover space stays unallocated",
+		})
+	}
 
 	// Rook Ceph OSDs — cephRows returns nil when no disk has CEPH space
 	// (small SKUs fully consumed by OS + ZFS), so the section vanishes.
@@ -265,15 +340,16 @@ func groupItems(name string, plans []*StoragePlan) []boxItem {
 }
 
 // zfsRows renders the ZFS pool header row and the mount points its
-// volumes provide. The pool is a mirror, so its capacity is one
-// disk's ZFS allocation — a real partition size, so it is shown. The
-// mount points carry no size: the volumes have no ZFS quota, so any
-// figure would be a guess.
-func zfsRows(disks []*Disk) []boxItem {
-	// The ZFS pool mirrors only the disks that carry a ZFS allocation —
-	// a strict subset on a >2-disk server. Both the mirrored capacity
-	// and the "mirror · …" disk shorthand must be derived from that
-	// subset, not from every disk on the server.
+// volumes provide. The pool's usable capacity is one disk's ZFS
+// allocation times its data (non-parity) disks — a real partition size,
+// so it is shown. A mount point shows its volume's properties (quota,
+// compression, recordsize) when it sets any; it carries no size, since
+// a volume without a quota can grow into all of the pool.
+func zfsRows(disks []*Disk, layout StorageLayout) []boxItem {
+	// The ZFS pool spans only the disks that carry a ZFS allocation —
+	// a strict subset on a server with more disks than the pool needs.
+	// Both the capacity and the "mirror · …" disk shorthand must be
+	// derived from that subset, not from every disk on the server.
 	zfsDisks := make([]*Disk, 0, len(disks))
 	zfsTotal := 0
 	for _, d := range disks {
@@ -283,28 +359,48 @@ func zfsRows(disks []*Disk) []boxItem {
 		zfsDisks = append(zfsDisks, d)
 		zfsTotal += d.Allocations.ZFS
 	}
-	capacity := mirroredSize(zfsTotal, len(zfsDisks))
 
-	mountPoint := func(path string) boxItem {
-		return boxItem{
+	dataDisks := len(zfsDisks) - ZFSPoolParityDisks(layout.ZFSPoolType, len(zfsDisks))
+	capacity := mirroredSize(zfsTotal, len(zfsDisks)) * max(dataDisks, 0)
+
+	note := layout.ZFSPoolType + " · " + formatDiskShorthand(zfsDisks)
+	if layout.ZFSPoolType == constants.ZFSPoolTypeSingle {
+		note = "single disk · " + formatDiskShorthand(zfsDisks) + " · no redundancy"
+	}
+
+	rows := []boxItem{{
+		kind:   itemRow,
+		indent: indentRow,
+		label:  `ZFS pool "primary"`,
+		size:   formatDiskSize(capacity),
+		note:   note,
+	}}
+	for _, dataset := range layout.ZFSDatasets {
+		rows = append(rows, boxItem{
 			kind:   itemRow,
 			indent: indentSubVolume,
-			label:  "● " + path,
-		}
+			label:  "● " + dataset.Mountpoint,
+			note:   formatZFSDatasetProperties(dataset),
+		})
 	}
+	return rows
+}
 
-	return []boxItem{
-		{
-			kind:   itemRow,
-			indent: indentRow,
-			label:  `ZFS pool "primary"`,
-			size:   formatDiskSize(capacity),
-			note:   "mirror · " + formatDiskShorthand(zfsDisks),
-		},
-		mountPoint("/var/lib/containerd"),
-		mountPoint("/var/log/pods"),
-		mountPoint("/var/lib/kubelet/pods"),
+// formatZFSDatasetProperties renders the properties a ZFS volume sets
+// besides its mountpoint — "quota 100G · zstd · 1M records" — or ""
+// when it inherits them all from the pool.
+func formatZFSDatasetProperties(dataset ZFSDataset) string {
+	parts := []string{}
+	if dataset.Quota != "" {
+		parts = append(parts, "quota "+dataset.Quota)
+	}
+	if dataset.Compression != "" {
+		parts = append(parts, dataset.Compression)
 	}
+	if dataset.RecordSize != "" {
+		parts = append(parts, dataset.RecordSize+" records")
+	}
+	return strings.Join(parts, " · ")
 }
 
 // cephRows renders the Rook Ceph OSD section: a header row carrying the
@@ -516,8 +612,8 @@ func headerBody(it boxItem, innerWidth int) string {
 // topBorder renders the box's top edge with the title embedded:
 // "╭─ <title> ─────╮", sized so the whole edge spans innerWidth + 4
 // (the body plus its one-space margins plus the two corners).
-func topBorder(b lipgloss.Border, innerWidth int) string {
-	titled := b.Top + " " + storageBoxTitle + " "
+func topBorder(b lipgloss.Border, title string, innerWidth int) string {
+	titled := b.Top + " " + title + " "
 	fill := max((innerWidth+2)-utf8.RuneCountInString(titled), 0)
 	return b.TopLeft + titled + strings.Repeat(b.Top, fill) + b.TopRight
 }
@@ -563,6 +659,15 @@ func (s *StoragePlans) GetApproval(ctx context.Context) {
 
 	s.PrettyPrint()
 
+	// Printed regardless, so a non-interactive run's log records what got approved.
+	approved, answered, err := answers.Parsed.Confirm(answers.DecisionApproveStoragePlans)
+	if err != nil || answered {
+		bar.Resume()
+		assert.AssertErrNil(ctx, err, "Can't decide whether to proceed with the storage plans")
+		assert.Assert(ctx, approved, "Storage plans not approved (answers file)")
+		return
+	}
+
 	for {
 		fmt.Print(lipgloss.NewStyle().Render("Proceed with the above storage plans? (yes/no): "))
 
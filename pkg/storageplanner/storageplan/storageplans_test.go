@@ -258,6 +258,44 @@ func TestStoragePlansRenderOmitsCephWhenUnallocated(t *testing.T) {
 	assert.Contains(t, got, `ZFS pool "primary"`)
 }
 
+func TestStoragePlansRenderCustomLayout(t *testing.T) {
+	// A node group with its own layout : Rook Ceph excluded, and a
+	// dataset with a quota. The box says it isn't the recommended one.
+	custom := mkRenderPlan("200", "NVMe", 474, 0)
+	custom.Layout = StorageLayout{
+		ExcludeCEPH: true,
+		ZFSDatasets: []ZFSDataset{
+			{Name: "containerd", Mountpoint: "/var/lib/containerd", Quota: "100G"},
+		},
+	}
+	plans := StoragePlans{
+		"control-plane": {mkRenderPlan("100", "NVMe", 474, 204)},
+		"workers":       {custom},
+	}
+
+	got := plans.render()
+	t.Logf("rendered storage layout:\n%s", got)
+
+	assert.Contains(t, got, storageBoxCustomTitle)
+	assert.Contains(t, got, storageBoxCustomWarning)
+	assert.Contains(t, got, storageBoxZFSQuotaNote)
+	assert.NotContains(t, got, storageBoxTitle)
+
+	assert.Equal(t, 1, strings.Count(got, "Rook Ceph OSD")) // control-plane only
+	assert.Contains(t, got, "excluded · leftover space stays unallocated")
+	assert.Contains(t, got, "quota 100G")
+	assert.Equal(t, 2, strings.Count(got, "● /var/lib/containerd"))
+	assert.Equal(t, 1, strings.Count(got, "● /var/log/pods"))
+
+	lines := strings.Split(got, "\n")
+	boxLines := lines[:len(lines)-2]
+	width := utf8.RuneCountInString(boxLines[0])
+	for i, line := range boxLines {
+		assert.Equal(t, width, utf8.RuneCountInString(line),
+			"box line %d has a mismatched width: %q", i, line)
+	}
+}
+
 func TestStoragePlansRenderEmpty(t *testing.T) {
 	assert.Empty(t, StoragePlans{}.render(),
 		"no node groups should render nothing, not an empty box")
@@ -513,11 +551,12 @@ func TestZFSRows(t *testing.T) {
 		t.Fatalf("zfsRows produced no %q row", label)
 		return boxItem{}
 	}
+	defaults := planLayout(&StoragePlan{})
 
 	t.Run("pool keeps its real size; mount points listed beneath it", func(t *testing.T) {
 		// Mirror: each disk carries the full pool allocation; capacity
 		// is one disk's worth — a real partition size, so it shows.
-		rows := zfsRows([]*Disk{mk("NVMe", 220), mk("NVMe", 220)})
+		rows := zfsRows([]*Disk{mk("NVMe", 220), mk("NVMe", 220)}, defaults)
 
 		pool := find(rows, `ZFS pool "primary"`)
 		assert.Equal(t, "220 GB", pool.size)
@@ -537,14 +576,46 @@ func TestZFSRows(t *testing.T) {
 	})
 
 	t.Run("pool size tracks the operator's bareMetal.zfs.size", func(t *testing.T) {
-		rows := zfsRows([]*Disk{mk("NVMe", 500), mk("NVMe", 500)})
+		rows := zfsRows([]*Disk{mk("NVMe", 500), mk("NVMe", 500)}, defaults)
 		assert.Equal(t, "500 GB", find(rows, `ZFS pool "primary"`).size)
 	})
 
 	t.Run("mixed disk types render shorthand in the pool note", func(t *testing.T) {
-		rows := zfsRows([]*Disk{mk("NVMe", 220), mk("HDD", 220)})
+		rows := zfsRows([]*Disk{mk("NVMe", 220), mk("HDD", 220)}, defaults)
 		assert.Equal(t, "mirror · NVMe + HDD", find(rows, `ZFS pool "primary"`).note)
 	})
+
+	t.Run("raidz pool capacity excludes its parity disks", func(t *testing.T) {
+		layout := planLayout(&StoragePlan{Layout: StorageLayout{ZFSPoolType: constants.ZFSPoolTypeRAIDZ1, ZFSPoolDisks: 3}})
+
+		rows := zfsRows([]*Disk{mk("HDD", 200), mk("HDD", 200), mk("HDD", 200)}, layout)
+		pool := find(rows, `ZFS pool "primary"`)
+		assert.Equal(t, "400 GB", pool.size)
+		assert.Equal(t, "raidz1 · 3 HDD", pool.note)
+	})
+
+	t.Run("single disk pool is flagged as having no redundancy", func(t *testing.T) {
+		layout := planLayout(&StoragePlan{Layout: StorageLayout{ZFSPoolType: constants.ZFSPoolTypeSingle}})
+
+		rows := zfsRows([]*Disk{mk("NVMe", 300)}, layout)
+		pool := find(rows, `ZFS pool "primary"`)
+		assert.Equal(t, "300 GB", pool.size)
+		assert.Equal(t, "single disk · NVMe · no redundancy", pool.note)
+	})
+
+	t.Run("custom datasets list their mountpoints and properties", func(t *testing.T) {
+		layout := planLayout(&StoragePlan{Layout: StorageLayout{
+			ZFSDatasets: []ZFSDataset{
+				{Name: "containerd", Mountpoint: "/var/lib/containerd", Compression: "zstd", Quota: "100G"},
+				{Name: "data", Mountpoint: "/srv/data", RecordSize: "1M"},
+			},
+		}})
+
+		rows := zfsRows([]*Disk{mk("NVMe", 220), mk("NVMe", 220)}, layout)
+		require.Len(t, rows, 3)
+		assert.Equal(t, "quota 100G · zstd", find(rows, "● /var/lib/containerd").note)
+		assert.Equal(t, "1M records", find(rows, "● /srv/data").note)
+	})
 }
 
 // TestGroupItemsSizesMirrorByMirrorWidth proves the OS volume and ZFS
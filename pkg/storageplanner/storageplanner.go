@@ -6,7 +6,6 @@ package storageplanner
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
@@ -18,23 +17,28 @@ import (
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor"
 )
 
-// Generates storage plan for the intended server.
+// Generates storage plan for the intended server, laying its disks out as declared by layout.
+// Only the disks the disk selection selects get considered.
 func GenerateStoragePlan(ctx context.Context, serverID string,
 
 	commandExecutor commandexecutor.CommandExecutor,
 
 	osSize,
 	zfsPoolSize int,
+
+	layout storageplan.StorageLayout,
+
+	diskSelection storageplan.DiskSelection,
 ) (*storageplan.StoragePlan, error) {
 	slog.InfoContext(ctx, "Generating storage plan")
 
 	// Get the server's disks.
-	disks, err := getDisks(ctx, commandExecutor)
+	disks, err := getDisks(ctx, commandExecutor, diskSelection)
 	if err != nil {
 		return nil, err
 	}
 
-	return allocateStoragePlan(serverID, disks, osSize, zfsPoolSize)
+	return allocateStoragePlan(serverID, disks, osSize, zfsPoolSize, layout)
 }
 
 func allocateStoragePlan(
@@ -42,34 +46,44 @@ func allocateStoragePlan(
 	disks []*storageplan.Disk,
 	osSize,
 	zfsPoolSize int,
+	layout storageplan.StorageLayout,
 ) (*storageplan.StoragePlan, error) {
-	s := &storageplan.StoragePlan{ServerID: serverID, Disks: disks}
+	layout, err := layout.Normalized()
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage layout: %w", err)
+	}
 
-	osDisks, err := allocateTwoDisks(disks, osSize, func(d *storageplan.Disk) int {
+	s := &storageplan.StoragePlan{ServerID: serverID, Disks: disks, Layout: layout}
+
+	osDisks, err := allocateDisks(disks, 2, osSize, func(d *storageplan.Disk) int {
 		return d.PriorityScores.OS
 	}, func(d *storageplan.Disk) {
 		d.Allocations.OS += osSize
 	})
 	if err != nil {
 		return nil, fmt.Errorf(
-			"kubeaid-cli requires 2 disks per server for the RAID-1 OS volume + ZFS mirror "+
+			"kubeaid-cli requires 2 disks per server for the RAID-1 OS volume "+
 				"but couldn't find 2 suitable for OS installation (%d GB each) — %s. "+
 				"If this is a single-disk Hetzner SKU, order a 2-disk SKU or attach an extra drive via Robot",
 			osSize, describeDisks(disks))
 	}
 	s.OS = osDisks
 
-	zfsDisks, err := allocateTwoDisks(disks, zfsPoolSize, func(d *storageplan.Disk) int {
+	zfsDisks, err := allocateDisks(disks, layout.ZFSPoolDisks, zfsPoolSize, func(d *storageplan.Disk) int {
 		return d.PriorityScores.ZFS
 	}, func(d *storageplan.Disk) {
 		d.Allocations.ZFS += zfsPoolSize
 	})
 	if err != nil {
-		return nil, fmt.Errorf("couldn't find 2 disks suitable for ZFS pool installation (%d GB each) — %s",
-			zfsPoolSize, describeDisks(disks))
+		return nil, fmt.Errorf("couldn't find %d disks suitable for the %s ZFS pool (%d GB each) — %s",
+			layout.ZFSPoolDisks, layout.ZFSPoolType, zfsPoolSize, describeDisks(disks))
 	}
 	s.ZFS = zfsDisks
 
+	if layout.ExcludeCEPH {
+		return s, nil
+	}
+
 	for _, disk := range disks {
 		unallocated := disk.Unallocated()
 		if unallocated < constants.CEPHNodeMinSize {
@@ -91,11 +105,13 @@ func allocateStoragePlan(
 //
 // Empty input ("scanned 0 disks") makes the lsblk-found-nothing case
 // obvious (the typical cause being a freshly-installed server whose
-// only block device is a virtio / loop / unknown-transport device
-// that's filtered out as DiskTypeUnknown — see getDisks).
+// only block device is a loop / usb / unknown-transport device that's
+// filtered out as DiskTypeUnknown — see selectDisks — which the
+// operator can include explicitly).
 func describeDisks(disks []*storageplan.Disk) string {
 	if len(disks) == 0 {
-		return "scanned 0 disks (lsblk found nothing kubeaid-cli recognises as HDD / SSD / NVMe — check the host's block-device inventory)"
+		return "scanned 0 disks (lsblk found nothing kubeaid-cli recognises as HDD / SSD / SAS SSD / NVMe / virtio," +
+			" and nothing got explicitly included — check the host's block-device inventory)"
 	}
 	parts := make([]string, 0, len(disks))
 	for _, d := range disks {
@@ -105,8 +121,11 @@ func describeDisks(disks []*storageplan.Disk) string {
 	return fmt.Sprintf("scanned %d disk(s): %s", len(disks), strings.Join(parts, "; "))
 }
 
-func allocateTwoDisks(
+// allocateDisks allocates allocationSize GB on count disks, picking the ones with the highest
+// priority score (then by name) which still have that much unallocated.
+func allocateDisks(
 	disks []*storageplan.Disk,
+	count,
 	allocationSize int,
 	priorityScore func(*storageplan.Disk) int,
 	allocate func(*storageplan.Disk),
@@ -122,15 +141,15 @@ func allocateTwoDisks(
 
 	targetDisks := []*storageplan.Disk{}
 	for _, disk := range disks {
-		if (len(targetDisks) >= 2) || (disk.Unallocated() < allocationSize) {
+		if (len(targetDisks) >= count) || (disk.Unallocated() < allocationSize) {
 			continue
 		}
 
 		allocate(disk)
 		targetDisks = append(targetDisks, disk)
 	}
-	if len(targetDisks) != 2 {
-		return nil, errors.New("couldn't find 2 suitable disks")
+	if len(targetDisks) != count {
+		return nil, fmt.Errorf("couldn't find %d suitable disks", count)
 	}
 
 	return targetDisks, nil
@@ -143,21 +162,39 @@ type (
 
 	// REFER: util-linux lsblk source.
 	LSBLKOutputRow struct {
-		Name string `json:"name"`
-		WWN  string `json:"wwn"`
-		Size int    `json:"size"`
+		Name   string `json:"name"`
+		WWN    string `json:"wwn"`
+		Serial string `json:"serial"`
+		Size   int    `json:"size"`
 
+		DeviceType         string `json:"type"`
 		RotationalDevice   bool   `json:"rota"`
 		TransportType      string `json:"tran"`
 		PartitionTableType string `json:"pttype"`
+
+		// Colon separated subsystems the device sits on, like block:scsi:virtio:pci.
+		Subsystems string `json:"subsystems"`
+
+		Children []LSBLKOutputRow `json:"children"`
+
+		// Whether the row is a dm-multipath device, standing in for its paths. Not reported by
+		// lsblk : see hardwareDisks.
+		Multipath bool `json:"-"`
 	}
 )
 
 const (
 	TransportTypeSATA = "sata"
+	TransportTypeSAS  = "sas"
 	TransportTypeNVMe = "nvme"
 )
 
+// Device types, as reported by lsblk.
+const (
+	deviceTypeDisk      = "disk"
+	deviceTypeMultipath = "mpath"
+)
+
 // partitionTableTypeGPT is the partition table type assumed for a disk that
 // lsblk reports without one (an empty PTTYPE) — i.e. a brand-new, never-
 // partitioned data disk. GPT is mandatory anyway for the >2 TiB disks these
@@ -167,6 +204,11 @@ const (
 const partitionTableTypeGPT = "gpt"
 
 func (l *LSBLKOutputRow) GetDiskType() string {
+	// A virtio disk's rotational bit and transport type are whatever the hypervisor makes up.
+	if slices.Contains(strings.Split(l.Subsystems, ":"), "virtio") {
+		return constants.DiskTypeVirtio
+	}
+
 	if l.RotationalDevice {
 		return constants.DiskTypeHDD
 	}
@@ -175,6 +217,9 @@ func (l *LSBLKOutputRow) GetDiskType() string {
 	case TransportTypeSATA:
 		return constants.DiskTypeSSD
 
+	case TransportTypeSAS:
+		return constants.DiskTypeSASSSD
+
 	case TransportTypeNVMe:
 		return constants.DiskTypeNVMe
 
@@ -183,10 +228,49 @@ func (l *LSBLKOutputRow) GetDiskType() string {
 	}
 }
 
+// hardwareDisks returns the server's hardware disks, out of lsblk's device tree : the top level
+// devices of type disk, except that the paths of a dm-multipath device get replaced by that
+// multipath device. So a disk reachable via 2 paths doesn't get planned twice.
+func hardwareDisks(devices []LSBLKOutputRow) []LSBLKOutputRow {
+	var disks []LSBLKOutputRow
+	for _, device := range devices {
+		if device.DeviceType != deviceTypeDisk {
+			continue
+		}
+
+		i := slices.IndexFunc(device.Children, func(child LSBLKOutputRow) bool {
+			return child.DeviceType == deviceTypeMultipath
+		})
+		if i < 0 {
+			device.Children = nil
+			disks = append(disks, device)
+			continue
+		}
+		multipath := device.Children[i]
+
+		alreadyFound := slices.ContainsFunc(disks, func(disk LSBLKOutputRow) bool {
+			return disk.Multipath && (disk.Name == multipath.Name)
+		})
+		if alreadyFound {
+			continue
+		}
+
+		// The multipath device gets classified and identified by the first of its paths.
+		device.Name = multipath.Name
+		device.PartitionTableType = multipath.PartitionTableType
+		device.Multipath = true
+		device.Children = nil
+		disks = append(disks, device)
+	}
+	return disks
+}
+
 // Fetches disk details for the intended server, by leveraging the provided shell command executor.
+// Only the disks the given disk selection selects are returned.
 func getDisks(
 	ctx context.Context,
 	commandExecutor commandexecutor.CommandExecutor,
+	diskSelection storageplan.DiskSelection,
 ) ([]*storageplan.Disk, error) {
 	slog.InfoContext(ctx, "Getting the server's disks")
 
@@ -213,7 +297,9 @@ func getDisks(
 
 	// List hardware disks, using lsblk.
 
-	stdout, err = commandExecutor.Execute(ctx, "lsblk -dn -o NAME,TRAN,ROTA,WWN,SIZE,PTTYPE -J --bytes")
+	stdout, err = commandExecutor.Execute(ctx,
+		"lsblk -n -o NAME,TYPE,TRAN,ROTA,WWN,SERIAL,SIZE,PTTYPE,SUBSYSTEMS -J --bytes",
+	)
 	if err != nil {
 		return nil, fmt.Errorf("listing hardware disks: %w", err)
 	}
@@ -229,41 +315,26 @@ func getDisks(
 	// silently dropped one as DiskTypeUnknown because it had a
 	// transport kubeaid-cli doesn't recognise yet, like usb / sas /
 	// virtio under a vendor-customised SKU).
-	if len(lsblkOutput.BlockDevices) == 0 {
+	rows := hardwareDisks(lsblkOutput.BlockDevices)
+	if len(rows) == 0 {
 		slog.WarnContext(ctx, "lsblk returned no block devices",
 			slog.String("raw-output", stdout))
 	}
-	for _, row := range lsblkOutput.BlockDevices {
+	for _, row := range rows {
 		slog.InfoContext(ctx, "lsblk row",
 			slog.String("name", row.Name),
+			slog.Bool("multipath", row.Multipath),
 			slog.String("transport", row.TransportType),
 			slog.Bool("rotational", row.RotationalDevice),
+			slog.String("subsystems", row.Subsystems),
 			slog.Int("size-bytes", row.Size),
 			slog.String("partition-table-type", row.PartitionTableType),
 			slog.String("classified-as", row.GetDiskType()),
 		)
 	}
 
-	// Filter out rows which correspond to unknown disk types.
-	// Capture which names get dropped so the error path can surface
-	// them — a "second disk present in lsblk but classified Unknown"
-	// case is otherwise indistinguishable from "second disk missing".
-	var droppedNames []string
-	lsblkOutput.BlockDevices = slices.DeleteFunc(lsblkOutput.BlockDevices, func(row LSBLKOutputRow) bool {
-		drop := row.GetDiskType() == constants.DiskTypeUnknown
-		if drop {
-			droppedNames = append(droppedNames, fmt.Sprintf(
-				"%s (tran=%q, rota=%t)", row.Name, row.TransportType, row.RotationalDevice))
-		}
-		return drop
-	})
-	if len(droppedNames) > 0 {
-		slog.WarnContext(ctx, "Filtered disks with unrecognised type",
-			slog.String("dropped", strings.Join(droppedNames, "; ")))
-	}
-
-	disks := make([]*storageplan.Disk, len(lsblkOutput.BlockDevices))
-	for i, row := range lsblkOutput.BlockDevices {
+	disks := make([]*storageplan.Disk, len(rows))
+	for i, row := range rows {
 		// A brand-new data disk has no partition table yet, so lsblk reports
 		// an empty PTTYPE. That's the normal state of a disk we're about to
 		// provision for ZFS / CEPH, so default it to GPT instead of failing
@@ -279,8 +350,10 @@ func getDisks(
 		disks[i] = &storageplan.Disk{
 			Name:               row.Name,
 			WWN:                row.WWN,
+			Serial:             row.Serial,
 			Type:               row.GetDiskType(),
 			PartitionTableType: partitionTableType,
+			Multipath:          row.Multipath,
 
 			// 2G is kept aside for the boot and EFI partitions.
 			Size: (row.Size / (1024 * 1024 * 1024)) - 2,
@@ -290,9 +363,14 @@ func getDisks(
 		disks[i].AssignPriorityScores()
 	}
 
+	disks, err = selectDisks(ctx, disks, diskSelection)
+	if err != nil {
+		return nil, err
+	}
+
 	// Log the disk inventory at INFO so it shows up in the bootstrap
 	// log without needing --debug. If allocateStoragePlan later fails
-	// to find 2 suitable disks, the error message itself echoes the
+	// to find enough suitable disks, the error message itself echoes the
 	// same per-disk facts; logging here covers the success-but-still-
 	// curious case ("what did kubeaid-cli actually pick from?") and
 	// gives operators a quick before/after view across multiple bootstrap
@@ -304,6 +382,52 @@ func getDisks(
 	return disks, nil
 }
 
+// selectDisks drops the disks the disk selection doesn't select. Without an include list, that's
+// the excluded ones, and the ones of a type the storage planner doesn't recognise.
+func selectDisks(ctx context.Context,
+	disks []*storageplan.Disk,
+	diskSelection storageplan.DiskSelection,
+) ([]*storageplan.Disk, error) {
+	if err := diskSelection.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid disk selection: %w", err)
+	}
+	if unmatched := diskSelection.Unmatched(disks); len(unmatched) > 0 {
+		return nil, fmt.Errorf("included disk(s) %s not found — %s",
+			strings.Join(unmatched, ", "), describeDisks(disks))
+	}
+
+	// Capture which names get dropped so the error path can surface
+	// them — a "second disk present in lsblk but classified Unknown"
+	// case is otherwise indistinguishable from "second disk missing".
+	var droppedNames []string
+	disks = slices.DeleteFunc(disks, func(disk *storageplan.Disk) bool {
+		var reason string
+		switch {
+		case diskSelection.Excludes(disk):
+			reason = "excluded"
+
+		case len(diskSelection.Include) > 0:
+			if !diskSelection.Includes(disk) {
+				reason = "not included"
+			}
+
+		case disk.Type == constants.DiskTypeUnknown:
+			reason = "unrecognised type"
+		}
+		if reason == "" {
+			return false
+		}
+
+		droppedNames = append(droppedNames, fmt.Sprintf("%s (%s)", disk.Name, reason))
+		return true
+	})
+	if len(droppedNames) > 0 {
+		slog.WarnContext(ctx, "Filtered disks",
+			slog.String("dropped", strings.Join(droppedNames, "; ")))
+	}
+	return disks, nil
+}
+
 // describeDisksShort is the slog-friendly variant of describeDisks —
 // no leading "scanned N: " prefix (the count is already a separate
 // log attribute).
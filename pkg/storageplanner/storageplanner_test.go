@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/storageplanner/storageplan"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor/fake"
 )
 
@@ -27,6 +28,7 @@ func newMock(nicSpeed string, devices []LSBLKOutputRow) *fake.Executor {
 func device(name, tran string, sizeGB int, rota bool) LSBLKOutputRow {
 	return LSBLKOutputRow{
 		Name:               name,
+		DeviceType:         "disk",
 		WWN:                "0x" + name,
 		Size:               (sizeGB + 2) * 1024 * 1024 * 1024, // +2GB for boot/EFI reserve
 		RotationalDevice:   rota,
@@ -55,6 +57,42 @@ func TestGetDiskType(t *testing.T) {
 
 	assert.Equal(t, constants.DiskTypeUnknown,
 		(&LSBLKOutputRow{RotationalDevice: false, TransportType: "usb"}).GetDiskType())
+
+	// SAS : a SAS attached HDD is an HDD.
+	assert.Equal(t, constants.DiskTypeSASSSD,
+		(&LSBLKOutputRow{RotationalDevice: false, TransportType: "sas"}).GetDiskType())
+	assert.Equal(t, constants.DiskTypeHDD,
+		(&LSBLKOutputRow{RotationalDevice: true, TransportType: "sas"}).GetDiskType())
+
+	// virtio, whatever the hypervisor claims about rotation.
+	assert.Equal(t, constants.DiskTypeVirtio,
+		(&LSBLKOutputRow{RotationalDevice: true, Subsystems: "block:virtio:pci"}).GetDiskType())
+	assert.Equal(t, constants.DiskTypeVirtio,
+		(&LSBLKOutputRow{Subsystems: "block:scsi:virtio:pci"}).GetDiskType())
+}
+
+func TestHardwareDisks(t *testing.T) {
+	// sdb and sdc are the 2 paths of multipath device mpatha. sr0 and loop0 aren't disks.
+	sdb, sdc := device("sdb", "sas", 500, true), device("sdc", "sas", 500, true)
+	sdb.Children = []LSBLKOutputRow{{Name: "mpatha", DeviceType: "mpath", PartitionTableType: "dos"}}
+	sdc.Children = sdb.Children
+
+	rows := hardwareDisks([]LSBLKOutputRow{
+		device("sda", "sata", 500, true),
+		sdb, sdc,
+		{Name: "sr0", DeviceType: "rom", TransportType: "sata", RotationalDevice: true},
+		{Name: "loop0", DeviceType: "loop"},
+	})
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, "sda", rows[0].Name)
+	assert.False(t, rows[0].Multipath)
+
+	assert.Equal(t, "mpatha", rows[1].Name)
+	assert.True(t, rows[1].Multipath)
+	assert.Equal(t, "0xsdb", rows[1].WWN)
+	assert.Equal(t, "dos", rows[1].PartitionTableType)
+	assert.Empty(t, rows[1].Children)
 }
 
 func TestGenerateStoragePlan_FourIdenticalHDDs(t *testing.T) {
@@ -62,7 +100,9 @@ func TestGenerateStoragePlan_FourIdenticalHDDs(t *testing.T) {
 		hdd("sda", 500), hdd("sdb", 500), hdd("sdc", 500), hdd("sdd", 500),
 	})
 
-	plan, err := GenerateStoragePlan(context.Background(), "srv1", mock, 50, 100)
+	plan, err := GenerateStoragePlan(context.Background(), "srv1", mock, 50, 100,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
 	require.NoError(t, err)
 
 	assert.Equal(t, "srv1", plan.ServerID)
@@ -87,7 +127,9 @@ func TestGenerateStoragePlan_HDDsForOS_NVMeForZFS(t *testing.T) {
 		hdd("sda", 500), hdd("sdb", 500),
 	})
 
-	plan, err := GenerateStoragePlan(context.Background(), "srv2", mock, 50, 100)
+	plan, err := GenerateStoragePlan(context.Background(), "srv2", mock, 50, 100,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
 	require.NoError(t, err)
 
 	// OS priority: HDD=3 > NVMe=1
@@ -108,7 +150,9 @@ func TestGenerateStoragePlan_HighSpeedNIC_HDDsPreferredForZFS(t *testing.T) {
 		hdd("sdc", 500), hdd("sdd", 500),
 	})
 
-	plan, err := GenerateStoragePlan(context.Background(), "srv8", mock, 50, 100)
+	plan, err := GenerateStoragePlan(context.Background(), "srv8", mock, 50, 100,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
 	require.NoError(t, err)
 
 	// OS priority: HDD=3 > SSD=2
@@ -123,7 +167,9 @@ func TestGenerateStoragePlan_HighSpeedNIC_HDDsPreferredForZFS(t *testing.T) {
 func TestGenerateStoragePlan_NotEnoughDisksForOS(t *testing.T) {
 	mock := newMock("1000\n", []LSBLKOutputRow{hdd("sda", 500)})
 
-	_, err := GenerateStoragePlan(context.Background(), "srv3", mock, 50, 100)
+	_, err := GenerateStoragePlan(context.Background(), "srv3", mock, 50, 100,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
 	require.Error(t, err)
 	assert.True(t, strings.Contains(err.Error(), "OS"))
 }
@@ -132,7 +178,9 @@ func TestGenerateStoragePlan_NotEnoughSpaceForZFS(t *testing.T) {
 	// OS eats 80GB, leaving 20GB per disk — not enough for 100GB ZFS.
 	mock := newMock("1000\n", []LSBLKOutputRow{hdd("sda", 100), hdd("sdb", 100)})
 
-	_, err := GenerateStoragePlan(context.Background(), "srv4", mock, 80, 100)
+	_, err := GenerateStoragePlan(context.Background(), "srv4", mock, 80, 100,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
 	require.Error(t, err)
 	assert.True(t, strings.Contains(err.Error(), "ZFS"))
 }
@@ -141,7 +189,9 @@ func TestGenerateStoragePlan_NoCEPHWhenNoSpaceLeft(t *testing.T) {
 	// 200GB disks, OS=80, ZFS=80 -> 40GB remaining < 50GB CEPH minimum.
 	mock := newMock("1000\n", []LSBLKOutputRow{hdd("sda", 200), hdd("sdb", 200)})
 
-	plan, err := GenerateStoragePlan(context.Background(), "srv5", mock, 80, 80)
+	plan, err := GenerateStoragePlan(context.Background(), "srv5", mock, 80, 80,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
 	require.NoError(t, err)
 	assert.Empty(t, plan.CEPH)
 }
@@ -152,7 +202,9 @@ func TestGenerateStoragePlan_SmallDisksSkipped(t *testing.T) {
 		hdd("sda", 500), hdd("sdb", 10), hdd("sdc", 10),
 	})
 
-	_, err := GenerateStoragePlan(context.Background(), "srv6", mock, 50, 100)
+	_, err := GenerateStoragePlan(context.Background(), "srv6", mock, 50, 100,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
 	require.Error(t, err)
 	assert.True(t, strings.Contains(err.Error(), "OS"))
 }
@@ -164,7 +216,9 @@ func TestGenerateStoragePlan_UnknownDiskTypeFiltered(t *testing.T) {
 		device("sdc", "usb", 500, false), // usb -> unknown -> filtered
 	})
 
-	plan, err := GenerateStoragePlan(context.Background(), "srv7", mock, 50, 100)
+	plan, err := GenerateStoragePlan(context.Background(), "srv7", mock, 50, 100,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
 	require.NoError(t, err)
 	assert.Len(t, plan.Disks, 2)
 }
@@ -186,7 +240,9 @@ func TestGenerateStoragePlan_BlankDataDisksDefaultToGPT(t *testing.T) {
 		hdd("sda", 500), hdd("sdb", 500), sdc, sdd,
 	})
 
-	plan, err := GenerateStoragePlan(context.Background(), "srv-blank", mock, 80, 220)
+	plan, err := GenerateStoragePlan(context.Background(), "srv-blank", mock, 80, 220,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
 	require.NoError(t, err)
 
 	// No disk carries an empty table type, and the two blank disks were
@@ -216,7 +272,9 @@ func TestGenerateStoragePlan_AllDisksBlank(t *testing.T) {
 		devices = append(devices, disk)
 	}
 
-	plan, err := GenerateStoragePlan(context.Background(), "srv-fresh", newMock("1000\n", devices), 80, 220)
+	plan, err := GenerateStoragePlan(context.Background(), "srv-fresh", newMock("1000\n", devices), 80, 220,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
 	require.NoError(t, err)
 	require.Len(t, plan.OS, 2)
 	require.Len(t, plan.ZFS, 2)
@@ -226,3 +284,107 @@ func TestGenerateStoragePlan_AllDisksBlank(t *testing.T) {
 			"disk %s should default to GPT", disk.Name)
 	}
 }
+
+func TestGenerateStoragePlan_SASAndVirtioDisks(t *testing.T) {
+	vda, vdb := device("vda", "", 500, true), device("vdb", "", 500, true)
+	vda.Subsystems, vdb.Subsystems = "block:virtio:pci", "block:virtio:pci"
+
+	plan, err := GenerateStoragePlan(context.Background(), "srv-vm", newMock("1000\n", []LSBLKOutputRow{vda, vdb}),
+		50, 100, storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vda", "vdb"}, diskNames(plan.OS))
+	assert.Equal(t, []string{"vda", "vdb"}, diskNames(plan.ZFS))
+
+	// SAS SSDs are as fast as SATA SSDs : HDDs get the OS, the SSDs ZFS.
+	plan, err = GenerateStoragePlan(context.Background(), "srv-sas", newMock("1000\n", []LSBLKOutputRow{
+		device("sda", "sas", 500, true), device("sdb", "sas", 500, true),
+		device("sdc", "sas", 500, false), device("sdd", "sas", 500, false),
+	}),
+		50, 100, storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sda", "sdb"}, diskNames(plan.OS))
+	assert.Equal(t, []string{"sdc", "sdd"}, diskNames(plan.ZFS))
+	assert.Equal(t, constants.DiskTypeSASSSD, plan.ZFS[0].Type)
+}
+
+func TestGenerateStoragePlan_MultipathDisks(t *testing.T) {
+	// sdc and sdd are the 2 paths of mpatha, sde and sdf the 2 paths of mpathb.
+	paths := make([]LSBLKOutputRow, 0, 4)
+	for i, name := range []string{"sdc", "sdd", "sde", "sdf"} {
+		path := device(name, "sas", 500, false)
+		path.Children = []LSBLKOutputRow{{Name: []string{"mpatha", "mpathb"}[i/2], DeviceType: "mpath"}}
+		paths = append(paths, path)
+	}
+
+	plan, err := GenerateStoragePlan(context.Background(), "srv-mpath",
+		newMock("1000\n", append([]LSBLKOutputRow{hdd("sda", 500), hdd("sdb", 500)}, paths...)),
+		50, 100, storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Disks, 4)
+	assert.Equal(t, []string{"mpatha", "mpathb"}, diskNames(plan.ZFS))
+	assert.True(t, plan.ZFS[0].Multipath)
+	assert.Equal(t, "/dev/mapper/mpatha-part5", plan.ZFS[0].PartitionPath(5))
+}
+
+func TestGenerateStoragePlan_DiskSelection(t *testing.T) {
+	serial := func(row LSBLKOutputRow, serial string) LSBLKOutputRow {
+		row.Serial = serial
+		return row
+	}
+	devices := []LSBLKOutputRow{
+		hdd("sda", 500), hdd("sdb", 500), hdd("sdc", 500),
+		serial(device("sdd", "usb", 500, false), "USB-1234"),
+	}
+
+	tests := []struct {
+		name          string
+		diskSelection storageplan.DiskSelection
+		wantDisks     []string
+		wantErrSub    string
+	}{
+		{
+			name:          "exclude by name and WWN",
+			diskSelection: storageplan.DiskSelection{Exclude: []string{"/dev/sda", "0xsdb"}},
+			wantErrSub:    "OS",
+		},
+		{
+			name:          "exclude one",
+			diskSelection: storageplan.DiskSelection{Exclude: []string{"sdc"}},
+			wantDisks:     []string{"sda", "sdb"},
+		},
+		{
+			name:          "include an unrecognised disk by serial",
+			diskSelection: storageplan.DiskSelection{Include: []string{"sdb", "USB-1234"}},
+			wantDisks:     []string{"sdb", "sdd"},
+		},
+		{
+			name:          "include a disk which isn't there",
+			diskSelection: storageplan.DiskSelection{Include: []string{"sda", "sdz"}},
+			wantErrSub:    "included disk(s) sdz not found",
+		},
+		{
+			name:          "include and exclude the same disk",
+			diskSelection: storageplan.DiskSelection{Include: []string{"sda"}, Exclude: []string{"sda"}},
+			wantErrSub:    "both included and excluded",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := GenerateStoragePlan(context.Background(), "srv-select", newMock("1000\n", devices),
+				50, 100, storageplan.StorageLayout{}, tc.diskSelection,
+			)
+			if tc.wantErrSub != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrSub)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.wantDisks, diskNames(plan.Disks))
+		})
+	}
+}
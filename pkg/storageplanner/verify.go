@@ -0,0 +1,784 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package storageplanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/storageplanner/storageplan"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor"
+)
+
+// Exit codes of `kubeaid-storagectl plan verify`, so a cron job / monitoring check can tell a
+// drifted node apart from one that couldn't be fully looked at.
+const (
+	VerifyExitOK      = 0
+	VerifyExitDrift   = 1
+	VerifyExitUnknown = 2
+)
+
+// Results of a storage verify check.
+const (
+	VerifyStatusOK      = "ok"
+	VerifyStatusDrift   = "drift"
+	VerifyStatusUnknown = "unknown"
+)
+
+// VerifyOutputFormats are the --output values `kubeaid-storagectl plan verify` accepts, besides
+// the default human-readable one.
+var VerifyOutputFormats = []string{"json"}
+
+type (
+	// VerifyReport is the outcome of comparing a server's live storage against its storage plan.
+	VerifyReport struct {
+		Server string        `json:"server"`
+		Status string        `json:"status"`
+		Checks []VerifyCheck `json:"checks"`
+	}
+
+	// VerifyCheck compares one aspect of the server's live storage against the storage plan : a
+	// disk's partitions or WWN, a ZFS vdev, a ZFS dataset, or a disk set aside for Rook Ceph.
+	VerifyCheck struct {
+		Name    string `json:"name"`
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+)
+
+// VerifyStoragePlan regenerates the storage plan of the server the command executor runs commands
+// on, and compares the server's live storage against it : the disks' partition tables, the ZFS
+// pool's health and membership, the ZFS datasets' mountpoints, and whether the disks set aside for
+// Rook Ceph are free for it. The disks' WWNs get compared against the storage plan recorded by
+// `kubeaid-storagectl plan execute`, when there's one.
+//
+// Read-only : it never touches a disk. Whatever it couldn't look at becomes an unknown check.
+func VerifyStoragePlan(ctx context.Context, server string,
+	commandExecutor commandexecutor.CommandExecutor,
+	osSize,
+	zfsPoolSize int,
+	layout storageplan.StorageLayout,
+	diskSelection storageplan.DiskSelection,
+) VerifyReport {
+	plan, err := GenerateStoragePlan(ctx, server, commandExecutor, osSize, zfsPoolSize, layout, diskSelection)
+	if err != nil {
+		return newVerifyReport(server, []VerifyCheck{{
+			Name: "plan", Status: VerifyStatusUnknown,
+			Message: fmt.Sprintf("couldn't regenerate the storage plan : %v", err),
+		}})
+	}
+
+	checks := []VerifyCheck{}
+
+	recordedPlan, err := storageplan.LoadRecorded(ctx, commandExecutor)
+	if err != nil {
+		checks = append(checks, VerifyCheck{Name: "wwn", Status: VerifyStatusUnknown, Message: err.Error()})
+	} else {
+		checks = append(checks, recordedPlanChecks(plan, recordedPlan)...)
+	}
+
+	liveState := getLiveStorageState(ctx, commandExecutor, plan)
+
+	checks = append(checks, partitionChecks(plan, liveState.partitionTables)...)
+	checks = append(checks, zpoolChecks(plan, liveState.zpool, liveState.zpoolError)...)
+	checks = append(checks, datasetChecks(plan, liveState.datasets, liveState.datasetsError)...)
+	checks = append(checks, cephChecks(plan, liveState.blockDevices, liveState.blockDevicesError)...)
+
+	return newVerifyReport(server, checks)
+}
+
+func newVerifyReport(server string, checks []VerifyCheck) VerifyReport {
+	report := VerifyReport{Server: server, Checks: checks}
+
+	report.Status = VerifyStatusOK
+	for _, check := range checks {
+		switch check.Status {
+		case VerifyStatusDrift:
+			report.Status = VerifyStatusDrift
+
+		case VerifyStatusUnknown:
+			if report.Status == VerifyStatusOK {
+				report.Status = VerifyStatusUnknown
+			}
+		}
+	}
+	return report
+}
+
+// ExitCode returns VerifyExitDrift when any check found drift, VerifyExitUnknown when nothing
+// drifted but a check couldn't look, and VerifyExitOK otherwise.
+func (r VerifyReport) ExitCode() int {
+	switch r.Status {
+	case VerifyStatusDrift:
+		return VerifyExitDrift
+
+	case VerifyStatusUnknown:
+		return VerifyExitUnknown
+
+	default:
+		return VerifyExitOK
+	}
+}
+
+// RenderVerifyReport renders the report in the given output format (one of VerifyOutputFormats),
+// or human-readable when empty.
+func RenderVerifyReport(report VerifyReport, outputFormat string) (string, error) {
+	switch outputFormat {
+	case "json":
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed marshalling storage verify report: %w", err)
+		}
+		return string(output) + "\n", nil
+
+	case "":
+		return renderVerifyReport(report), nil
+
+	default:
+		return "", fmt.Errorf("unsupported output format %q", outputFormat)
+	}
+}
+
+// Geometry of the human-readable report's table. Same as kubeaid-cli's tables.
+const (
+	tabwriterMinWidth = 0
+	tabwriterTabWidth = 4
+	tabwriterPadding  = 3
+)
+
+func renderVerifyReport(report VerifyReport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Storage of %s, against its storage plan\n\n", report.Server)
+
+	w := tabwriter.NewWriter(&b, tabwriterMinWidth, tabwriterTabWidth, tabwriterPadding, ' ', 0)
+
+	// Writes to a strings.Builder-backed tabwriter never fail.
+	_, _ = fmt.Fprintln(w, "CHECK\tRESULT\tDETAIL")
+	drifted, unknown := 0, 0
+	for _, check := range report.Checks {
+		switch check.Status {
+		case VerifyStatusDrift:
+			drifted++
+
+		case VerifyStatusUnknown:
+			unknown++
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", check.Name, strings.ToUpper(check.Status), check.Message)
+	}
+
+	// Flush only fails when the underlying writer does, and a strings.Builder never does.
+	_ = w.Flush()
+
+	switch report.Status {
+	case VerifyStatusDrift:
+		fmt.Fprintf(&b, "\nDRIFTED : %d / %d checks found drift\n", drifted, len(report.Checks))
+
+	case VerifyStatusUnknown:
+		fmt.Fprintf(&b, "\nUNKNOWN : %d / %d checks couldn't look\n", unknown, len(report.Checks))
+
+	default:
+		fmt.Fprintf(&b, "\nOK : the storage matches the storage plan, across %d checks\n", len(report.Checks))
+	}
+	return b.String()
+}
+
+// recordedPlanChecks compares the regenerated storage plan against the one recorded at execution :
+// whether every recorded disk is still around with the same WWN, and whether the OS volume, the
+// ZFS pool and Rook Ceph still land on the same disks.
+func recordedPlanChecks(plan, recordedPlan *storageplan.StoragePlan) []VerifyCheck {
+	if recordedPlan == nil {
+		return []VerifyCheck{{
+			Name: "wwn", Status: VerifyStatusUnknown,
+			Message: fmt.Sprintf("no storage plan recorded at %s (executed by an older kubeaid-storagectl)"+
+				" : WWNs not compared", constants.StoragePlanRecordPath),
+		}}
+	}
+
+	checks := []VerifyCheck{}
+
+	for _, recordedDisk := range recordedPlan.Disks {
+		check := VerifyCheck{Name: "wwn/" + recordedDisk.Name}
+
+		disk := findDisk(plan.Disks, recordedDisk.Name)
+		switch {
+		case disk == nil:
+			check.Status = VerifyStatusDrift
+			check.Message = "disk gone"
+
+		case disk.WWN != recordedDisk.WWN:
+			check.Status = VerifyStatusDrift
+			check.Message = fmt.Sprintf("WWN changed from %s to %s : the disk got replaced",
+				displayWWN(recordedDisk.WWN), displayWWN(disk.WWN),
+			)
+
+		default:
+			check.Status = VerifyStatusOK
+			check.Message = displayWWN(disk.WWN)
+		}
+		checks = append(checks, check)
+	}
+
+	roles := []struct {
+		name              string
+		disks, recordedTo []*storageplan.Disk
+	}{
+		{"OS volume", plan.OS, recordedPlan.OS},
+		{"ZFS pool", plan.ZFS, recordedPlan.ZFS},
+		{"Rook Ceph", plan.CEPH, recordedPlan.CEPH},
+	}
+	for _, role := range roles {
+		current, recorded := sortedDiskNames(role.disks), sortedDiskNames(role.recordedTo)
+		if slices.Equal(current, recorded) {
+			continue
+		}
+
+		checks = append(checks, VerifyCheck{
+			Name: "plan", Status: VerifyStatusDrift,
+			Message: fmt.Sprintf("the plan now puts the %s on %s, but put it on %s when executed",
+				role.name, formatDiskNames(current), formatDiskNames(recorded),
+			),
+		})
+	}
+
+	return checks
+}
+
+type (
+	// liveStorageState is what the server's storage looks like, at the moment. The errors are set
+	// when the corresponding part couldn't be looked at.
+	liveStorageState struct {
+		// Partition tables, by disk name. Nil for a disk without one.
+		partitionTables map[string]*sfdiskPartitionTable
+
+		// Nil when the ZFS pool isn't imported.
+		zpool      *zpoolStatus
+		zpoolError string
+
+		// ZFS datasets in the ZFS pool, by name relative to the pool.
+		datasets      map[string]zfsDatasetState
+		datasetsError string
+
+		// Block devices (disks, partitions and what's on them), by path.
+		blockDevices      map[string]lsblkDevice
+		blockDevicesError string
+	}
+
+	// REFER : sfdisk --json output.
+	sfdiskOutput struct {
+		PartitionTable sfdiskPartitionTable `json:"partitiontable"`
+	}
+
+	sfdiskPartitionTable struct {
+		Label      string            `json:"label"`
+		SectorSize int               `json:"sectorsize"`
+		Partitions []sfdiskPartition `json:"partitions"`
+	}
+
+	sfdiskPartition struct {
+		Node string `json:"node"`
+		Size int64  `json:"size"` // Sectors.
+	}
+
+	zpoolStatus struct {
+		State string
+
+		// Vdevs below the pool, in the order zpool status lists them.
+		Vdevs []zpoolVdev
+	}
+
+	zpoolVdev struct {
+		Name,
+		State string
+
+		// 1 for a top-level vdev, 2 for a disk in a top-level mirror / raidz vdev.
+		Depth int
+	}
+
+	zfsDatasetState struct {
+		Mountpoint string
+		Mounted    bool
+	}
+
+	// REFER : lsblk --json output.
+	lsblkDevicesOutput struct {
+		BlockDevices []lsblkDevice `json:"blockdevices"`
+	}
+
+	lsblkDevice struct {
+		Name       string        `json:"name"`
+		FSType     string        `json:"fstype"`
+		Mountpoint string        `json:"mountpoint"`
+		Children   []lsblkDevice `json:"children"`
+	}
+)
+
+func getLiveStorageState(ctx context.Context,
+	commandExecutor commandexecutor.CommandExecutor,
+	plan *storageplan.StoragePlan,
+) liveStorageState {
+	slog.InfoContext(ctx, "Looking at the server's live storage")
+
+	state := liveStorageState{partitionTables: map[string]*sfdiskPartitionTable{}}
+
+	// Partition tables. sfdisk fails on a disk without one.
+	for _, disk := range plan.Disks {
+		output, err := commandExecutor.Execute(ctx,
+			fmt.Sprintf("sfdisk --json %s 2>/dev/null || true", devicePath(disk)),
+		)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed reading partition table",
+				slog.String("disk", disk.Name), slog.Any("error", err),
+			)
+			continue
+		}
+		if strings.TrimSpace(output) == "" {
+			state.partitionTables[disk.Name] = nil
+			continue
+		}
+
+		var parsedOutput sfdiskOutput
+		if err := json.Unmarshal([]byte(output), &parsedOutput); err != nil {
+			slog.WarnContext(ctx, "Failed unmarshalling sfdisk output",
+				slog.String("disk", disk.Name), slog.Any("error", err),
+			)
+			continue
+		}
+		state.partitionTables[disk.Name] = &parsedOutput.PartitionTable
+	}
+
+	// ZFS pool. -P and -L print the vdevs as resolved device paths, like /dev/sda5, whichever
+	// /dev/disk/by-* path the pool got imported with.
+	output, err := commandExecutor.Execute(ctx, "zpool status -P -L "+constants.ZFSPoolName)
+	if err != nil {
+		state.zpoolError = fmt.Sprintf("not imported : %v", err)
+	} else {
+		state.zpool = parseZPoolStatus(output)
+
+		// -L resolves a multipath device's partition to the device mapper node behind it, like
+		// /dev/dm-5. Name it back.
+		for _, disk := range plan.ZFS {
+			if !disk.Multipath {
+				continue
+			}
+
+			path := partitionPath(disk, zfsPartition)
+			output, err := commandExecutor.Execute(ctx, "readlink -f "+path)
+			if err != nil {
+				continue
+			}
+			for i, vdev := range state.zpool.Vdevs {
+				if vdev.Name == strings.TrimSpace(output) {
+					state.zpool.Vdevs[i].Name = path
+				}
+			}
+		}
+	}
+
+	// ZFS datasets.
+	output, err = commandExecutor.Execute(ctx,
+		"zfs list -H -t filesystem -o name,mountpoint,mounted -r "+constants.ZFSPoolName,
+	)
+	if err != nil {
+		state.datasetsError = fmt.Sprintf("couldn't list ZFS datasets : %v", err)
+	} else {
+		state.datasets = parseZFSList(output)
+	}
+
+	// Block devices, with what's on them.
+	output, err = commandExecutor.Execute(ctx, "lsblk --json --paths -o NAME,FSTYPE,MOUNTPOINT")
+	if err != nil {
+		state.blockDevicesError = fmt.Sprintf("couldn't list block devices : %v", err)
+	} else {
+		var parsedOutput lsblkDevicesOutput
+		if err := json.Unmarshal([]byte(output), &parsedOutput); err != nil {
+			state.blockDevicesError = fmt.Sprintf("couldn't unmarshal lsblk output : %v", err)
+		} else {
+			state.blockDevices = map[string]lsblkDevice{}
+			flattenBlockDevices(parsedOutput.BlockDevices, state.blockDevices)
+		}
+	}
+
+	return state
+}
+
+// parseZPoolStatus parses the pool state and the vdev tree out of `zpool status` output.
+func parseZPoolStatus(output string) *zpoolStatus {
+	status := &zpoolStatus{}
+
+	baseIndent := -1
+	for line := range strings.Lines(output) {
+		line = strings.TrimRight(line, "\n")
+		fields := strings.Fields(line)
+
+		switch {
+		case (baseIndent < 0) && (len(fields) == 2) && (fields[0] == "state:"):
+			status.State = fields[1]
+
+		case (baseIndent < 0) && (len(fields) >= 2) && (fields[0] == "NAME") && (fields[1] == "STATE"):
+			baseIndent = indentation(line)
+
+		case baseIndent < 0:
+			continue
+
+		// A blank line ends the vdev tree.
+		case len(fields) == 0:
+			return status
+
+		default:
+			// Tabs and spaces both count as 1 column; zpool status indents each level by 2 spaces.
+			depth := (indentation(line) - baseIndent) / 2
+
+			// The pool's own row.
+			if depth == 0 && len(status.Vdevs) == 0 && fields[0] == constants.ZFSPoolName {
+				continue
+			}
+
+			// Past the pool's own vdevs : logs, cache, spares and the like.
+			if depth == 0 {
+				return status
+			}
+
+			vdev := zpoolVdev{Name: fields[0], Depth: depth}
+			if len(fields) > 1 {
+				vdev.State = fields[1]
+			}
+			status.Vdevs = append(status.Vdevs, vdev)
+		}
+	}
+	return status
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// parseZFSList parses `zfs list -H -o name,mountpoint,mounted` output, keyed by dataset name
+// relative to the pool.
+func parseZFSList(output string) map[string]zfsDatasetState {
+	datasets := map[string]zfsDatasetState{}
+	for line := range strings.Lines(output) {
+		fields := strings.Split(strings.TrimRight(line, "\n"), "\t")
+		if len(fields) != 3 {
+			continue
+		}
+
+		name, found := strings.CutPrefix(fields[0], constants.ZFSPoolName+"/")
+		if !found {
+			continue
+		}
+		datasets[name] = zfsDatasetState{Mountpoint: fields[1], Mounted: (fields[2] == "yes")}
+	}
+	return datasets
+}
+
+func flattenBlockDevices(devices []lsblkDevice, byPath map[string]lsblkDevice) {
+	for _, device := range devices {
+		byPath[device.Name] = device
+		flattenBlockDevices(device.Children, byPath)
+	}
+}
+
+// Every disk in the storage plan gets partitions 1 - 6 : 1 - 3 hold the OS (or are placeholders),
+// 4 is a placeholder (or the extended partition, on an MBR disk), 5 is for the ZFS pool (or a
+// placeholder) and 6 for Rook Ceph. See the storage-plan-executor.sh.tmpl template.
+const (
+	partitionCount = 6
+	zfsPartition   = 5
+	cephPartition  = 6
+)
+
+// devicePath returns the device path of the disk, the kernel knows it by : like /dev/sda or
+// /dev/mapper/mpatha. sfdisk names the partitions after it.
+func devicePath(disk *storageplan.Disk) string {
+	if disk.Multipath {
+		return disk.DevicePath()
+	}
+	return "/dev/" + disk.Name
+}
+
+// partitionPath returns the device path of the disk's given partition, like /dev/sda5,
+// /dev/nvme0n1p5 or /dev/mapper/mpatha-part5.
+func partitionPath(disk *storageplan.Disk, partition int) string {
+	switch {
+	case disk.Multipath:
+		return disk.PartitionPath(partition)
+
+	case disk.Type == constants.DiskTypeNVMe:
+		return fmt.Sprintf("/dev/%sp%d", disk.Name, partition)
+
+	default:
+		return fmt.Sprintf("/dev/%s%d", disk.Name, partition)
+	}
+}
+
+// partitionChecks checks that every disk in the storage plan carries all its partitions, with the
+// ZFS partition sized as allocated.
+func partitionChecks(plan *storageplan.StoragePlan,
+	partitionTables map[string]*sfdiskPartitionTable,
+) []VerifyCheck {
+	checks := []VerifyCheck{}
+	for _, disk := range plan.Disks {
+		check := VerifyCheck{Name: "partitions/" + disk.Name}
+
+		partitionTable, found := partitionTables[disk.Name]
+		switch {
+		case !found:
+			check.Status = VerifyStatusUnknown
+			check.Message = "couldn't read the partition table"
+
+		case partitionTable == nil:
+			check.Status = VerifyStatusDrift
+			check.Message = "no partition table"
+
+		default:
+			check.Status, check.Message = comparePartitions(disk, partitionTable)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func comparePartitions(disk *storageplan.Disk, partitionTable *sfdiskPartitionTable) (string, string) {
+	sizes := map[string]int64{}
+	for _, partition := range partitionTable.Partitions {
+		sizes[partition.Node] = partition.Size
+	}
+
+	missing := []string{}
+	for partition := 1; partition <= partitionCount; partition++ {
+		if _, found := sizes[partitionPath(disk, partition)]; !found {
+			missing = append(missing, fmt.Sprint(partition))
+		}
+	}
+	if len(missing) > 0 {
+		return VerifyStatusDrift, fmt.Sprintf("missing partition(s) %s", strings.Join(missing, ", "))
+	}
+
+	if disk.Allocations.ZFS > 0 {
+		sectorSize := int64(partitionTable.SectorSize)
+		if sectorSize == 0 {
+			sectorSize = 512
+		}
+
+		// The executor sizes the ZFS partition in GiB.
+		zfsPartitionSize := int((sizes[partitionPath(disk, zfsPartition)] * sectorSize) >> 30)
+		if (zfsPartitionSize < disk.Allocations.ZFS-1) || (zfsPartitionSize > disk.Allocations.ZFS+1) {
+			return VerifyStatusDrift, fmt.Sprintf("ZFS partition %s is %d GB, but the plan allocates %d GB",
+				partitionPath(disk, zfsPartition), zfsPartitionSize, disk.Allocations.ZFS,
+			)
+		}
+	}
+
+	return VerifyStatusOK, fmt.Sprintf("partitions 1 - %d present (%s)", partitionCount, partitionTable.Label)
+}
+
+// zpoolChecks checks the ZFS pool's health, its shape against the layout's pool type, and that
+// it's made of exactly the ZFS partitions of the disks the storage plan puts it on.
+func zpoolChecks(plan *storageplan.StoragePlan, zpool *zpoolStatus, zpoolError string) []VerifyCheck {
+	poolCheckName := "zpool/" + constants.ZFSPoolName
+
+	if zpool == nil {
+		status := VerifyStatusDrift
+		if zpoolError == "" {
+			status, zpoolError = VerifyStatusUnknown, "couldn't look at the ZFS pool"
+		}
+		return []VerifyCheck{{Name: poolCheckName, Status: status, Message: zpoolError}}
+	}
+
+	layout := plan.Layout
+	checks := []VerifyCheck{}
+
+	poolCheck := VerifyCheck{Name: poolCheckName, Status: VerifyStatusOK,
+		Message: fmt.Sprintf("%s, %s across %d disk(s)", zpool.State, layout.ZFSPoolType, len(plan.ZFS)),
+	}
+	if zpool.State != "ONLINE" {
+		poolCheck.Status = VerifyStatusDrift
+	}
+	checks = append(checks, poolCheck)
+
+	expectedDevices := map[string]bool{}
+	for _, disk := range plan.ZFS {
+		expectedDevices[partitionPath(disk, zfsPartition)] = true
+	}
+
+	// A single disk pool has the disk as its only top-level vdev. Others have a single mirror /
+	// raidz top-level vdev, with the disks in it.
+	leafDepth := 2
+	if layout.ZFSPoolType == constants.ZFSPoolTypeSingle {
+		leafDepth = 1
+	}
+
+	for _, vdev := range zpool.Vdevs {
+		check := VerifyCheck{Name: "zpool/" + vdev.Name, Status: VerifyStatusOK, Message: vdev.State}
+
+		isLeaf := strings.HasPrefix(vdev.Name, "/")
+		switch {
+		case isLeaf && (vdev.Depth != leafDepth), !isLeaf && !strings.HasPrefix(vdev.Name, layout.ZFSPoolType+"-"):
+			check.Status = VerifyStatusDrift
+			check.Message = fmt.Sprintf("%s, but the layout wants a %s pool", vdev.State, layout.ZFSPoolType)
+
+		case isLeaf && !expectedDevices[vdev.Name]:
+			check.Status = VerifyStatusDrift
+			check.Message = fmt.Sprintf("%s, but the plan doesn't put the ZFS pool on it", vdev.State)
+
+		case vdev.State != "ONLINE":
+			check.Status = VerifyStatusDrift
+		}
+		checks = append(checks, check)
+
+		delete(expectedDevices, vdev.Name)
+	}
+
+	for _, device := range slices.Sorted(maps.Keys(expectedDevices)) {
+		checks = append(checks, VerifyCheck{
+			Name: "zpool/" + device, Status: VerifyStatusDrift, Message: "missing from the ZFS pool",
+		})
+	}
+
+	return checks
+}
+
+// datasetChecks checks that every ZFS dataset in the layout exists, and is mounted at its
+// mountpoint.
+func datasetChecks(plan *storageplan.StoragePlan,
+	datasets map[string]zfsDatasetState, datasetsError string,
+) []VerifyCheck {
+	if datasets == nil {
+		return []VerifyCheck{{Name: "datasets", Status: VerifyStatusUnknown, Message: datasetsError}}
+	}
+
+	checks := []VerifyCheck{}
+	for _, dataset := range plan.Layout.ZFSDatasets {
+		check := VerifyCheck{Name: "dataset/" + dataset.Name, Status: VerifyStatusDrift}
+
+		live, found := datasets[dataset.Name]
+		switch {
+		case !found:
+			check.Message = "missing"
+
+		case live.Mountpoint != dataset.Mountpoint:
+			check.Message = fmt.Sprintf("mountpoint is %s, but the layout wants %s", live.Mountpoint, dataset.Mountpoint)
+
+		case !live.Mounted:
+			check.Message = fmt.Sprintf("not mounted at %s", dataset.Mountpoint)
+
+		default:
+			check.Status = VerifyStatusOK
+			check.Message = "mounted at " + dataset.Mountpoint
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// cephChecks checks that the Rook Ceph partition on every disk the storage plan sets aside for
+// Rook Ceph, is free for it (or already used by it) : not holding some other filesystem.
+func cephChecks(plan *storageplan.StoragePlan,
+	blockDevices map[string]lsblkDevice, blockDevicesError string,
+) []VerifyCheck {
+	if plan.Layout.ExcludeCEPH || (len(plan.CEPH) == 0) {
+		return nil
+	}
+
+	if blockDevices == nil {
+		return []VerifyCheck{{Name: "ceph", Status: VerifyStatusUnknown, Message: blockDevicesError}}
+	}
+
+	checks := []VerifyCheck{}
+	for _, disk := range plan.CEPH {
+		check := VerifyCheck{Name: "ceph/" + disk.Name, Status: VerifyStatusOK}
+
+		path := partitionPath(disk, cephPartition)
+		partition, found := blockDevices[path]
+		switch {
+		case !found:
+			check.Status = VerifyStatusDrift
+			check.Message = fmt.Sprintf("%s missing", path)
+
+		case usedByCeph(partition):
+			check.Message = fmt.Sprintf("%s in use by Rook Ceph", path)
+
+		case (partition.FSType == "") && (partition.Mountpoint == "") && (len(partition.Children) == 0):
+			check.Message = fmt.Sprintf("%s free for Rook Ceph", path)
+
+		default:
+			check.Status = VerifyStatusDrift
+			check.Message = fmt.Sprintf("%s holds %s : not available to Rook Ceph", path, describeBlockDevice(partition))
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// usedByCeph reports whether the partition is a Ceph OSD : in raw mode (a BlueStore label right
+// on the partition), or in LVM mode (an LVM physical volume, carrying a ceph-* volume group).
+func usedByCeph(partition lsblkDevice) bool {
+	if partition.FSType == "ceph_bluestore" {
+		return true
+	}
+
+	if partition.FSType != "LVM2_member" {
+		return false
+	}
+	for _, child := range partition.Children {
+		if strings.Contains(child.Name, "ceph") {
+			return true
+		}
+	}
+	return false
+}
+
+func describeBlockDevice(device lsblkDevice) string {
+	description := "data"
+	if device.FSType != "" {
+		description = "a " + device.FSType + " filesystem"
+	}
+	if device.Mountpoint != "" {
+		description += ", mounted at " + device.Mountpoint
+	}
+	if len(device.Children) > 0 {
+		description += fmt.Sprintf(", with %d device(s) on top", len(device.Children))
+	}
+	return description
+}
+
+func findDisk(disks []*storageplan.Disk, name string) *storageplan.Disk {
+	for _, disk := range disks {
+		if disk.Name == name {
+			return disk
+		}
+	}
+	return nil
+}
+
+func sortedDiskNames(disks []*storageplan.Disk) []string {
+	names := make([]string, 0, len(disks))
+	for _, disk := range disks {
+		names = append(names, disk.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func formatDiskNames(names []string) string {
+	if len(names) == 0 {
+		return "no disk"
+	}
+	return strings.Join(names, ", ")
+}
+
+func displayWWN(wwn string) string {
+	if wwn == "" {
+		return "(none)"
+	}
+	return wwn
+}
@@ -0,0 +1,393 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package storageplanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/storageplanner/storageplan"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/commandexecutor/fake"
+)
+
+const degradedZPoolStatus = `  pool: primary
+ state: DEGRADED
+status: One or more devices are faulted in response to persistent errors.
+config:
+
+	NAME           STATE     READ WRITE CKSUM
+	primary        DEGRADED     0     0     0
+	  mirror-0     DEGRADED     0     0     0
+	    /dev/sda5  ONLINE       0     0     0
+	    /dev/sdb5  FAULTED      0     0     0  too many errors
+	cache
+	  /dev/sdc1    ONLINE       0     0     0
+
+errors: No known data errors
+`
+
+func TestParseZPoolStatus(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		&zpoolStatus{
+			State: "DEGRADED",
+			Vdevs: []zpoolVdev{
+				{Name: "mirror-0", State: "DEGRADED", Depth: 1},
+				{Name: "/dev/sda5", State: "ONLINE", Depth: 2},
+				{Name: "/dev/sdb5", State: "FAULTED", Depth: 2},
+			},
+		},
+		parseZPoolStatus(degradedZPoolStatus),
+	)
+}
+
+func TestParseZFSList(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		map[string]zfsDatasetState{
+			"containerd": {Mountpoint: "/var/lib/containerd", Mounted: true},
+			"pod-logs":   {Mountpoint: "/var/log/pods", Mounted: false},
+		},
+		parseZFSList("primary\t/primary\tyes\n"+
+			"primary/containerd\t/var/lib/containerd\tyes\n"+
+			"primary/pod-logs\t/var/log/pods\tno\n"),
+	)
+}
+
+// newVerifyTestPlan returns the storage plan of a server with 2 identical HDDs : the OS volume and
+// the ZFS mirror across both, and Rook Ceph on what's left.
+func newVerifyTestPlan(t *testing.T) *storageplan.StoragePlan {
+	t.Helper()
+
+	plan, err := allocateStoragePlan("srv",
+		[]*storageplan.Disk{
+			{Name: "sda", WWN: "0xa", Type: constants.DiskTypeHDD, Size: 300},
+			{Name: "sdb", WWN: "0xb", Type: constants.DiskTypeHDD, Size: 300},
+		},
+		50, 100, storageplan.StorageLayout{},
+	)
+	require.NoError(t, err)
+	return plan
+}
+
+// sfdiskPartitionTableOf returns the partition table the storage plan executor leaves on disk,
+// with the ZFS partition sized zfsSize GiB, and only the given partitions.
+func sfdiskPartitionTableOf(disk string, zfsSize int, partitions ...int) *sfdiskPartitionTable {
+	table := &sfdiskPartitionTable{Label: "gpt", SectorSize: 512}
+	for _, partition := range partitions {
+		size := int64(2048)
+		if partition == zfsPartition {
+			size = int64(zfsSize) << 30 / 512
+		}
+		table.Partitions = append(table.Partitions, sfdiskPartition{
+			Node: fmt.Sprintf("/dev/%s%d", disk, partition), Size: size,
+		})
+	}
+	return table
+}
+
+func TestPartitionChecks(t *testing.T) {
+	t.Parallel()
+
+	plan := newVerifyTestPlan(t)
+
+	checks := partitionChecks(plan, map[string]*sfdiskPartitionTable{
+		"sda": sfdiskPartitionTableOf("sda", 100, 1, 2, 3, 4, 5, 6),
+		"sdb": sfdiskPartitionTableOf("sdb", 100, 1, 2, 3, 4),
+	})
+	assert.Equal(t, []VerifyCheck{
+		{Name: "partitions/sda", Status: VerifyStatusOK, Message: "partitions 1 - 6 present (gpt)"},
+		{Name: "partitions/sdb", Status: VerifyStatusDrift, Message: "missing partition(s) 5, 6"},
+	}, checks)
+
+	checks = partitionChecks(plan, map[string]*sfdiskPartitionTable{
+		"sda": sfdiskPartitionTableOf("sda", 50, 1, 2, 3, 4, 5, 6),
+		"sdb": nil,
+	})
+	assert.Equal(t, []VerifyCheck{
+		{
+			Name: "partitions/sda", Status: VerifyStatusDrift,
+			Message: "ZFS partition /dev/sda5 is 50 GB, but the plan allocates 100 GB",
+		},
+		{Name: "partitions/sdb", Status: VerifyStatusDrift, Message: "no partition table"},
+	}, checks)
+
+	checks = partitionChecks(plan, map[string]*sfdiskPartitionTable{})
+	assert.Equal(t, VerifyStatusUnknown, checks[0].Status)
+}
+
+func TestZPoolChecks(t *testing.T) {
+	t.Parallel()
+
+	plan := newVerifyTestPlan(t)
+
+	t.Run("degraded mirror", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []VerifyCheck{
+			{Name: "zpool/primary", Status: VerifyStatusDrift, Message: "DEGRADED, mirror across 2 disk(s)"},
+			{Name: "zpool/mirror-0", Status: VerifyStatusDrift, Message: "DEGRADED"},
+			{Name: "zpool/sda5", Status: VerifyStatusOK, Message: "ONLINE"},
+			{Name: "zpool/sdb5", Status: VerifyStatusDrift, Message: "FAULTED"},
+		}, renameDevices(zpoolChecks(plan, parseZPoolStatus(degradedZPoolStatus), "")))
+	})
+
+	t.Run("disk swapped out of the pool", func(t *testing.T) {
+		t.Parallel()
+
+		checks := zpoolChecks(plan, &zpoolStatus{
+			State: "ONLINE",
+			Vdevs: []zpoolVdev{
+				{Name: "mirror-0", State: "ONLINE", Depth: 1},
+				{Name: "/dev/sda5", State: "ONLINE", Depth: 2},
+				{Name: "/dev/sdc5", State: "ONLINE", Depth: 2},
+			},
+		}, "")
+		assert.Equal(t, []VerifyCheck{
+			{Name: "zpool/primary", Status: VerifyStatusOK, Message: "ONLINE, mirror across 2 disk(s)"},
+			{Name: "zpool/mirror-0", Status: VerifyStatusOK, Message: "ONLINE"},
+			{Name: "zpool/sda5", Status: VerifyStatusOK, Message: "ONLINE"},
+			{Name: "zpool/sdc5", Status: VerifyStatusDrift, Message: "ONLINE, but the plan doesn't put the ZFS pool on it"},
+			{Name: "zpool/sdb5", Status: VerifyStatusDrift, Message: "missing from the ZFS pool"},
+		}, renameDevices(checks))
+	})
+
+	t.Run("pool shaped unlike the layout", func(t *testing.T) {
+		t.Parallel()
+
+		checks := zpoolChecks(plan, &zpoolStatus{
+			State: "ONLINE",
+			Vdevs: []zpoolVdev{
+				{Name: "/dev/sda5", State: "ONLINE", Depth: 1},
+				{Name: "/dev/sdb5", State: "ONLINE", Depth: 1},
+			},
+		}, "")
+		assert.Equal(t, VerifyStatusDrift, checks[1].Status)
+		assert.Equal(t, "ONLINE, but the layout wants a mirror pool", checks[1].Message)
+	})
+
+	t.Run("pool not imported", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []VerifyCheck{
+			{Name: "zpool/primary", Status: VerifyStatusDrift, Message: "not imported : no such pool"},
+		}, zpoolChecks(plan, nil, "not imported : no such pool"))
+	})
+}
+
+// renameDevices shortens the ZFS vdev check names from device paths to device names, for
+// readability.
+func renameDevices(checks []VerifyCheck) []VerifyCheck {
+	for i := range checks {
+		if len(checks[i].Name) > len("zpool//dev/") && checks[i].Name[:len("zpool//dev/")] == "zpool//dev/" {
+			checks[i].Name = "zpool/" + checks[i].Name[len("zpool//dev/"):]
+		}
+	}
+	return checks
+}
+
+func TestDatasetChecks(t *testing.T) {
+	t.Parallel()
+
+	plan := newVerifyTestPlan(t)
+
+	checks := datasetChecks(plan, map[string]zfsDatasetState{
+		"containerd":            {Mountpoint: "/var/lib/containerd", Mounted: true},
+		"pod-logs":              {Mountpoint: "/var/log/pods", Mounted: false},
+		"pod-ephemeral-volumes": {Mountpoint: "/mnt/pods", Mounted: true},
+	}, "")
+	assert.Equal(t, []VerifyCheck{
+		{Name: "dataset/containerd", Status: VerifyStatusOK, Message: "mounted at /var/lib/containerd"},
+		{Name: "dataset/pod-logs", Status: VerifyStatusDrift, Message: "not mounted at /var/log/pods"},
+		{
+			Name: "dataset/pod-ephemeral-volumes", Status: VerifyStatusDrift,
+			Message: "mountpoint is /mnt/pods, but the layout wants /var/lib/kubelet/pods",
+		},
+	}, checks)
+
+	checks = datasetChecks(plan, map[string]zfsDatasetState{}, "")
+	assert.Equal(t, "missing", checks[0].Message)
+}
+
+func TestCephChecks(t *testing.T) {
+	t.Parallel()
+
+	plan := newVerifyTestPlan(t)
+
+	checks := cephChecks(plan, map[string]lsblkDevice{
+		"/dev/sda6": {Name: "/dev/sda6", FSType: "ceph_bluestore"},
+		"/dev/sdb6": {Name: "/dev/sdb6", FSType: "ext4", Mountpoint: "/mnt/scratch"},
+	}, "")
+	assert.Equal(t, []VerifyCheck{
+		{Name: "ceph/sda", Status: VerifyStatusOK, Message: "/dev/sda6 in use by Rook Ceph"},
+		{
+			Name: "ceph/sdb", Status: VerifyStatusDrift,
+			Message: "/dev/sdb6 holds a ext4 filesystem, mounted at /mnt/scratch : not available to Rook Ceph",
+		},
+	}, checks)
+
+	checks = cephChecks(plan, map[string]lsblkDevice{
+		"/dev/sda6": {Name: "/dev/sda6"},
+		"/dev/sdb6": {
+			Name: "/dev/sdb6", FSType: "LVM2_member",
+			Children: []lsblkDevice{{Name: "/dev/mapper/ceph--0a1b-osd--block--2c3d"}},
+		},
+	}, "")
+	assert.Equal(t, "/dev/sda6 free for Rook Ceph", checks[0].Message)
+	assert.Equal(t, "/dev/sdb6 in use by Rook Ceph", checks[1].Message)
+
+	plan.Layout.ExcludeCEPH = true
+	assert.Empty(t, cephChecks(plan, nil, ""))
+}
+
+func TestRecordedPlanChecks(t *testing.T) {
+	t.Parallel()
+
+	plan := newVerifyTestPlan(t)
+
+	recordedPlan := newVerifyTestPlan(t)
+	recordedPlan.Disks[1].WWN = "0xold"
+	recordedPlan.Disks = append(recordedPlan.Disks, &storageplan.Disk{Name: "sdc", WWN: "0xc"})
+	recordedPlan.CEPH = recordedPlan.CEPH[:1]
+
+	assert.Equal(t, []VerifyCheck{
+		{Name: "wwn/sda", Status: VerifyStatusOK, Message: "0xa"},
+		{Name: "wwn/sdb", Status: VerifyStatusDrift, Message: "WWN changed from 0xold to 0xb : the disk got replaced"},
+		{Name: "wwn/sdc", Status: VerifyStatusDrift, Message: "disk gone"},
+		{
+			Name: "plan", Status: VerifyStatusDrift,
+			Message: "the plan now puts the Rook Ceph on sda, sdb, but put it on sda when executed",
+		},
+	}, recordedPlanChecks(plan, recordedPlan))
+
+	checks := recordedPlanChecks(plan, nil)
+	require.Len(t, checks, 1)
+	assert.Equal(t, VerifyStatusUnknown, checks[0].Status)
+}
+
+func TestVerifyReportExitCode(t *testing.T) {
+	t.Parallel()
+
+	ok := VerifyCheck{Status: VerifyStatusOK}
+	drift := VerifyCheck{Status: VerifyStatusDrift}
+	unknown := VerifyCheck{Status: VerifyStatusUnknown}
+
+	assert.Equal(t, VerifyExitOK, newVerifyReport("srv", []VerifyCheck{ok, ok}).ExitCode())
+	assert.Equal(t, VerifyExitUnknown, newVerifyReport("srv", []VerifyCheck{ok, unknown}).ExitCode())
+	assert.Equal(t, VerifyExitDrift, newVerifyReport("srv", []VerifyCheck{unknown, drift, ok}).ExitCode())
+}
+
+func TestRenderVerifyReport(t *testing.T) {
+	t.Parallel()
+
+	report := newVerifyReport("srv", []VerifyCheck{
+		{Name: "zpool/primary", Status: VerifyStatusOK, Message: "ONLINE, mirror across 2 disk(s)"},
+		{Name: "ceph/sdb", Status: VerifyStatusDrift, Message: "/dev/sdb6 missing"},
+	})
+
+	output, err := RenderVerifyReport(report, "")
+	require.NoError(t, err)
+	assert.Equal(t,
+		"Storage of srv, against its storage plan\n"+
+			"\n"+
+			"CHECK           RESULT   DETAIL\n"+
+			"zpool/primary   OK       ONLINE, mirror across 2 disk(s)\n"+
+			"ceph/sdb        DRIFT    /dev/sdb6 missing\n"+
+			"\n"+
+			"DRIFTED : 1 / 2 checks found drift\n",
+		output,
+	)
+
+	output, err = RenderVerifyReport(report, "json")
+	require.NoError(t, err)
+
+	var decoded VerifyReport
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded))
+	assert.Equal(t, report, decoded)
+	assert.Contains(t, output, `"status": "drift"`)
+}
+
+// TestVerifyStoragePlan runs the whole verification against a fake server, whose ZFS mirror lost
+// a disk which then got replaced : a new WWN and no partitions on it.
+func TestVerifyStoragePlan(t *testing.T) {
+	t.Parallel()
+
+	recordedPlan := newVerifyTestPlan(t)
+	recordedPlan.Disks[1].WWN = "0xold"
+	recordedPlanAsJSON, err := json.Marshal(recordedPlan)
+	require.NoError(t, err)
+
+	sfdiskOutputOf := func(table *sfdiskPartitionTable) string {
+		output, err := json.Marshal(sfdiskOutput{PartitionTable: *table})
+		require.NoError(t, err)
+		return string(output)
+	}
+
+	lsblkDevices, err := json.Marshal(lsblkDevicesOutput{BlockDevices: []lsblkDevice{
+		{Name: "/dev/sda", Children: []lsblkDevice{{Name: "/dev/sda6", FSType: "ceph_bluestore"}}},
+		{Name: "/dev/sdb"},
+	}})
+	require.NoError(t, err)
+
+	sda, sdb := device("sda", "sata", 300, true), device("sdb", "sata", 300, true)
+	sda.WWN, sdb.WWN = "0xa", "0xb"
+	lsblkDisks, err := json.Marshal(LSBLKOutput{BlockDevices: []LSBLKOutputRow{sda, sdb}})
+	require.NoError(t, err)
+
+	executor := fake.NewExecutor(
+		"1000\n",
+		string(lsblkDisks),
+		string(recordedPlanAsJSON),
+		sfdiskOutputOf(sfdiskPartitionTableOf("sda", 100, 1, 2, 3, 4, 5, 6)),
+		"",
+		degradedZPoolStatus,
+		"primary/containerd\t/var/lib/containerd\tyes\n"+
+			"primary/pod-logs\t/var/log/pods\tyes\n"+
+			"primary/pod-ephemeral-volumes\t/var/lib/kubelet/pods\tyes\n",
+		string(lsblkDevices),
+	)
+
+	report := VerifyStoragePlan(context.Background(), "srv", executor, 50, 100, storageplan.StorageLayout{}, storageplan.DiskSelection{})
+
+	assert.Equal(t, VerifyExitDrift, report.ExitCode())
+
+	drifted := map[string]string{}
+	for _, check := range report.Checks {
+		if check.Status != VerifyStatusOK {
+			drifted[check.Name] = check.Message
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"wwn/sdb":         "WWN changed from 0xold to 0xb : the disk got replaced",
+		"partitions/sdb":  "no partition table",
+		"zpool/primary":   "DEGRADED, mirror across 2 disk(s)",
+		"zpool/mirror-0":  "DEGRADED",
+		"zpool//dev/sdb5": "FAULTED",
+		"ceph/sdb":        "/dev/sdb6 missing",
+	}, drifted)
+
+	assert.Equal(t, "sfdisk --json /dev/sda 2>/dev/null || true", executor.Commands[3])
+	assert.Equal(t, "zpool status -P -L primary", executor.Commands[5])
+}
+
+func TestVerifyStoragePlanUnknownWhenPlanCantBeRegenerated(t *testing.T) {
+	t.Parallel()
+
+	report := VerifyStoragePlan(context.Background(), "srv",
+		fake.NewExecutor("1000\n", `{"blockdevices": []}`), 50, 100,
+		storageplan.StorageLayout{}, storageplan.DiskSelection{},
+	)
+
+	assert.Equal(t, VerifyExitUnknown, report.ExitCode())
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, "plan", report.Checks[0].Name)
+}
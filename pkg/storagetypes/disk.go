@@ -3,15 +3,25 @@
 
 package storagetypes
 
-import "github.com/Obmondo/kubeaid-cli/pkg/constants"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
 
 type (
 	Disk struct {
 		Name,
 		WWN,
+		Serial,
 		Type,
 		PartitionTableType string
 
+		// Whether the disk is a dm-multipath device (like /dev/mapper/mpatha), reachable via multiple
+		// paths (like /dev/sda and /dev/sdb). Its Name is then the multipath map's name.
+		Multipath bool
+
 		Size int // GB.
 
 		// Whether the server to which this disk is attached, has a NIC (Network Interface Card)
@@ -62,16 +72,54 @@ func (d *Disk) Unallocated() int {
 	return (d.Size - d.Allocated())
 }
 
+// DevicePath returns the stable path of the disk, under /dev/disk/by-id, derived from its WWN. Unlike
+// /dev/<name>, it keeps pointing to the same disk across reboots and disk swaps. Falls back to
+// /dev/<name> when the disk reports no WWN.
+func (d *Disk) DevicePath() string {
+	switch {
+	// udev's by-id links of a multipath device's WWN may point to any one of its paths, instead of
+	// the multipath device itself.
+	case d.Multipath:
+		return "/dev/mapper/" + d.Name
+
+	case d.WWN == "":
+		return "/dev/" + d.Name
+
+	// udev names NVMe namespaces by their EUI-64 / NGUID, which lsblk reports as the WWN.
+	case strings.HasPrefix(d.WWN, "eui.") || strings.HasPrefix(d.WWN, "nvme."):
+		return "/dev/disk/by-id/nvme-" + d.WWN
+
+	default:
+		return "/dev/disk/by-id/wwn-" + d.WWN
+	}
+}
+
+// PartitionPath returns the stable path of the disk's nth partition.
+func (d *Disk) PartitionPath(n int) string {
+	switch {
+	case d.Multipath || (d.WWN != ""):
+		return fmt.Sprintf("%s-part%d", d.DevicePath(), n)
+
+	case d.Type == constants.DiskTypeNVMe:
+		return fmt.Sprintf("/dev/%sp%d", d.Name, n)
+
+	default:
+		return fmt.Sprintf("/dev/%s%d", d.Name, n)
+	}
+}
+
 // Assigns priority scores to the disk, for OS and ZFS installations.
 func (d *Disk) AssignPriorityScores() {
 	d.PriorityScores = PriorityScores{}
 
 	d.PriorityScores.OS = (func() int {
 		switch d.Type {
-		case constants.DiskTypeHDD:
+		// All the disks of a virtual machine are alike, so a virtio disk is as good as any other for
+		// the OS.
+		case constants.DiskTypeHDD, constants.DiskTypeVirtio:
 			return 3
 
-		case constants.DiskTypeSSD:
+		case constants.DiskTypeSSD, constants.DiskTypeSASSSD:
 			return 2
 
 		case constants.DiskTypeNVMe:
@@ -88,13 +136,13 @@ func (d *Disk) AssignPriorityScores() {
 		// So Rook CEPH ends up on the faster disks, taking advantage of the higher network bandwidth.
 		case d.WithHighSpeedNIC && (d.Type == constants.DiskTypeNVMe):
 			return 1
-		case d.WithHighSpeedNIC && (d.Type == constants.DiskTypeSSD):
+		case d.WithHighSpeedNIC && (d.Type == constants.DiskTypeSSD || d.Type == constants.DiskTypeSASSSD):
 			return 2
 
 		// Otherwise, ZFS should be on the faster disks.
-		case d.Type == constants.DiskTypeHDD:
+		case d.Type == constants.DiskTypeHDD, d.Type == constants.DiskTypeVirtio:
 			return 3
-		case d.Type == constants.DiskTypeSSD:
+		case d.Type == constants.DiskTypeSSD, d.Type == constants.DiskTypeSASSSD:
 			return 4
 		case d.Type == constants.DiskTypeNVMe:
 			return 5
@@ -85,7 +85,23 @@ func TestAssignPriorityScores(t *testing.T) {
 		{name: "HDD without high-speed NIC", diskType: constants.DiskTypeHDD, wantOS: 3, wantZFS: 3},
 		{name: "SSD without high-speed NIC", diskType: constants.DiskTypeSSD, wantOS: 2, wantZFS: 4},
 		{name: "NVMe without high-speed NIC", diskType: constants.DiskTypeNVMe, wantOS: 1, wantZFS: 5},
+		{name: "SAS SSD without high-speed NIC", diskType: constants.DiskTypeSASSSD, wantOS: 2, wantZFS: 4},
+		{name: "virtio disk without high-speed NIC", diskType: constants.DiskTypeVirtio, wantOS: 3, wantZFS: 3},
 		{name: "unknown disk type without NIC", diskType: constants.DiskTypeUnknown, wantOS: 0, wantZFS: 0},
+		{
+			name:             "SAS SSD with high-speed NIC drops ZFS priority",
+			diskType:         constants.DiskTypeSASSSD,
+			withHighSpeedNIC: true,
+			wantOS:           2,
+			wantZFS:          2,
+		},
+		{
+			name:             "virtio disk with high-speed NIC unaffected",
+			diskType:         constants.DiskTypeVirtio,
+			withHighSpeedNIC: true,
+			wantOS:           3,
+			wantZFS:          3,
+		},
 		{
 			name:             "HDD with high-speed NIC unaffected",
 			diskType:         constants.DiskTypeHDD,
@@ -136,3 +152,49 @@ func TestAssignPriorityScores(t *testing.T) {
 		})
 	}
 }
+
+func TestDiskDevicePaths(t *testing.T) {
+	tests := []struct {
+		name                        string
+		disk                        Disk
+		wantDevicePath, wantPart5At string
+	}{
+		{
+			name:           "SATA disk with a WWN",
+			disk:           Disk{Name: "sda", WWN: "0x5000c500a1b2c3d4", Type: constants.DiskTypeHDD},
+			wantDevicePath: "/dev/disk/by-id/wwn-0x5000c500a1b2c3d4",
+			wantPart5At:    "/dev/disk/by-id/wwn-0x5000c500a1b2c3d4-part5",
+		},
+		{
+			name:           "NVMe namespace with an EUI-64",
+			disk:           Disk{Name: "nvme0n1", WWN: "eui.0025388b91b2c3d4", Type: constants.DiskTypeNVMe},
+			wantDevicePath: "/dev/disk/by-id/nvme-eui.0025388b91b2c3d4",
+			wantPart5At:    "/dev/disk/by-id/nvme-eui.0025388b91b2c3d4-part5",
+		},
+		{
+			name:           "disk without a WWN falls back to its name",
+			disk:           Disk{Name: "sdb", Type: constants.DiskTypeSSD},
+			wantDevicePath: "/dev/sdb",
+			wantPart5At:    "/dev/sdb5",
+		},
+		{
+			name:           "multipath device",
+			disk:           Disk{Name: "mpatha", WWN: "0x5000c500a1b2c3d4", Type: constants.DiskTypeHDD, Multipath: true},
+			wantDevicePath: "/dev/mapper/mpatha",
+			wantPart5At:    "/dev/mapper/mpatha-part5",
+		},
+		{
+			name:           "NVMe namespace without a WWN",
+			disk:           Disk{Name: "nvme1n1", Type: constants.DiskTypeNVMe},
+			wantDevicePath: "/dev/nvme1n1",
+			wantPart5At:    "/dev/nvme1n1p5",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantDevicePath, tc.disk.DevicePath())
+			assert.Equal(t, tc.wantPart5At, tc.disk.PartitionPath(5))
+		})
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package storagetypes
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+type (
+	// StorageLayout declares how the storage planner lays out a server's disks, besides the RAID-1
+	// OS volume across 2 disks : the shape of the ZFS pool, the datasets carved out of it, and
+	// whether the leftover space goes to Rook Ceph.
+	//
+	// The zero value is the layout KubeAid recommends : a 2 disk ZFS mirror, carrying the
+	// DefaultZFSDatasets, with Rook Ceph on whatever's left.
+	StorageLayout struct {
+		// ZFSPoolType is one of constants.ZFSPoolType*. Empty means a mirror.
+		ZFSPoolType string
+
+		// ZFSPoolDisks is how many disks the ZFS pool spans. 0 means the minimum its type needs
+		// (see MinZFSPoolDisks).
+		ZFSPoolDisks int
+
+		// ZFSDatasets get created in the ZFS pool. Empty means DefaultZFSDatasets.
+		ZFSDatasets []ZFSDataset
+
+		// ExcludeCEPH leaves the disk space left over after the OS volume and the ZFS pool
+		// unallocated, instead of handing it to Rook Ceph.
+		ExcludeCEPH bool
+	}
+
+	// ZFSDataset is a ZFS filesystem, created in the ZFS pool (named primary) and mounted on the
+	// node.
+	ZFSDataset struct {
+		// Name, relative to the pool, like containerd or logs/pods.
+		Name string `yaml:"name" validate:"notblank"`
+
+		// Mountpoint is the absolute path the dataset gets mounted at.
+		Mountpoint string `yaml:"mountpoint" validate:"notblank"`
+
+		// Optional ZFS properties. Left unset, the dataset inherits them from the pool.
+
+		// Compression algorithm, like lz4, zstd or off.
+		Compression string `yaml:"compression,omitempty"`
+
+		// RecordSize is the dataset's recordsize, like 128K or 1M.
+		RecordSize string `yaml:"recordSize,omitempty"`
+
+		// Quota caps how much of the pool the dataset can use, like 100G.
+		Quota string `yaml:"quota,omitempty"`
+	}
+)
+
+// DefaultZFSDatasets are the datasets every node gets, unless the layout declares its own : for
+// ContainerD's image store, pod logs and pod ephemeral volumes.
+func DefaultZFSDatasets() []ZFSDataset {
+	return []ZFSDataset{
+		{Name: "containerd", Mountpoint: "/var/lib/containerd"},
+		{Name: "pod-logs", Mountpoint: "/var/log/pods"},
+		{Name: "pod-ephemeral-volumes", Mountpoint: "/var/lib/kubelet/pods"},
+	}
+}
+
+// MinZFSPoolDisks returns how many disks a ZFS pool of the given type needs at least.
+func MinZFSPoolDisks(poolType string) int {
+	switch poolType {
+	case constants.ZFSPoolTypeSingle:
+		return 1
+
+	case constants.ZFSPoolTypeRAIDZ1:
+		return 3
+
+	case constants.ZFSPoolTypeRAIDZ2:
+		return 4
+
+	default:
+		return 2
+	}
+}
+
+// ZFSPoolParityDisks returns how many disks' worth of a ZFS pool of the given type is redundancy :
+// all but one for a mirror, 1 for raidz1, 2 for raidz2 and none for a single disk.
+func ZFSPoolParityDisks(poolType string, disks int) int {
+	switch poolType {
+	case constants.ZFSPoolTypeSingle:
+		return 0
+
+	case constants.ZFSPoolTypeRAIDZ1:
+		return 1
+
+	case constants.ZFSPoolTypeRAIDZ2:
+		return 2
+
+	default:
+		return max(disks-1, 0)
+	}
+}
+
+var (
+	// REFER : https://openzfs.github.io/openzfs-docs/man/master/8/zfs.8.html, on component names.
+	zfsDatasetNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.:-]+(/[A-Za-z0-9_.:-]+)*$`)
+
+	zfsCompressionPattern = regexp.MustCompile(`^(on|off|lz4|lzjb|zle|gzip(-[1-9])?|zstd(-fast)?(-[0-9]+)?)$`)
+	zfsRecordSizePattern  = regexp.MustCompile(`^[0-9]+[KM]$`)
+	zfsQuotaPattern       = regexp.MustCompile(`^(none|[0-9]+(\.[0-9]+)?[KMGTP]?)$`)
+)
+
+// Normalized returns the layout with its defaults filled in, after validating it.
+func (l StorageLayout) Normalized() (StorageLayout, error) {
+	if l.ZFSPoolType == "" {
+		l.ZFSPoolType = constants.ZFSPoolTypeMirror
+	}
+
+	switch l.ZFSPoolType {
+	case constants.ZFSPoolTypeMirror, constants.ZFSPoolTypeRAIDZ1,
+		constants.ZFSPoolTypeRAIDZ2, constants.ZFSPoolTypeSingle:
+
+	default:
+		return l, fmt.Errorf("unknown ZFS pool type %q : must be one of %s, %s, %s or %s",
+			l.ZFSPoolType,
+			constants.ZFSPoolTypeMirror, constants.ZFSPoolTypeRAIDZ1,
+			constants.ZFSPoolTypeRAIDZ2, constants.ZFSPoolTypeSingle,
+		)
+	}
+
+	minDisks := MinZFSPoolDisks(l.ZFSPoolType)
+	switch {
+	case l.ZFSPoolDisks == 0:
+		l.ZFSPoolDisks = minDisks
+
+	case l.ZFSPoolDisks < minDisks:
+		return l, fmt.Errorf("a %s ZFS pool needs at least %d disks, not %d",
+			l.ZFSPoolType, minDisks, l.ZFSPoolDisks,
+		)
+
+	case (l.ZFSPoolType == constants.ZFSPoolTypeSingle) && (l.ZFSPoolDisks != 1):
+		return l, fmt.Errorf("a single disk ZFS pool spans exactly 1 disk, not %d", l.ZFSPoolDisks)
+	}
+
+	if len(l.ZFSDatasets) == 0 {
+		l.ZFSDatasets = DefaultZFSDatasets()
+	}
+
+	names := map[string]bool{}
+	mountpoints := map[string]bool{}
+	for _, dataset := range l.ZFSDatasets {
+		if err := dataset.Validate(); err != nil {
+			return l, err
+		}
+
+		if names[dataset.Name] {
+			return l, fmt.Errorf("ZFS dataset %s declared twice", dataset.Name)
+		}
+		names[dataset.Name] = true
+
+		if mountpoints[dataset.Mountpoint] {
+			return l, fmt.Errorf("ZFS datasets share mountpoint %s", dataset.Mountpoint)
+		}
+		mountpoints[dataset.Mountpoint] = true
+	}
+
+	return l, nil
+}
+
+// IsDefault reports whether the (normalized) layout is the one KubeAid recommends.
+func (l StorageLayout) IsDefault() bool {
+	defaults, _ := StorageLayout{}.Normalized()
+
+	if (l.ZFSPoolType != defaults.ZFSPoolType) || (l.ZFSPoolDisks != defaults.ZFSPoolDisks) ||
+		(l.ExcludeCEPH != defaults.ExcludeCEPH) || (len(l.ZFSDatasets) != len(defaults.ZFSDatasets)) {
+		return false
+	}
+	for i := range l.ZFSDatasets {
+		if l.ZFSDatasets[i] != defaults.ZFSDatasets[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks the dataset's name, mountpoint and properties, before they end up in a zfs
+// create command.
+func (d ZFSDataset) Validate() error {
+	if !zfsDatasetNamePattern.MatchString(d.Name) {
+		return fmt.Errorf("invalid ZFS dataset name %q", d.Name)
+	}
+
+	if !path.IsAbs(d.Mountpoint) || (path.Clean(d.Mountpoint) != d.Mountpoint) ||
+		strings.ContainsAny(d.Mountpoint, " \t\n'\"$`\\") {
+		return fmt.Errorf("ZFS dataset %s : mountpoint %q must be a clean absolute path", d.Name, d.Mountpoint)
+	}
+
+	properties := []struct {
+		name, value string
+		pattern     *regexp.Regexp
+	}{
+		{"compression", d.Compression, zfsCompressionPattern},
+		{"recordsize", d.RecordSize, zfsRecordSizePattern},
+		{"quota", d.Quota, zfsQuotaPattern},
+	}
+	for _, property := range properties {
+		if (property.value != "") && !property.pattern.MatchString(property.value) {
+			return fmt.Errorf("ZFS dataset %s : invalid %s %q", d.Name, property.name, property.value)
+		}
+	}
+
+	return nil
+}
+
+// Properties returns the dataset's ZFS properties, as the name=value pairs zfs create takes :
+// its mountpoint first, then whichever of compression, recordsize and quota are set.
+func (d ZFSDataset) Properties() []string {
+	properties := []string{"mountpoint=" + d.Mountpoint}
+	if d.Compression != "" {
+		properties = append(properties, "compression="+d.Compression)
+	}
+	if d.RecordSize != "" {
+		properties = append(properties, "recordsize="+d.RecordSize)
+	}
+	if d.Quota != "" {
+		properties = append(properties, "quota="+d.Quota)
+	}
+	return properties
+}
+
+// ParseZFSDataset parses a dataset, as given to `kubeaid-storagectl plan --zfs-dataset` :
+// <name>:<mountpoint>, optionally followed by :<property>=<value>,... with compression,
+// recordsize and quota as properties. Like
+// containerd:/var/lib/containerd:compression=zstd,quota=100G.
+func ParseZFSDataset(s string) (ZFSDataset, error) {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) < 2 {
+		return ZFSDataset{}, fmt.Errorf("invalid ZFS dataset %q : expected <name>:<mountpoint>[:<property>=<value>,...]", s)
+	}
+
+	dataset := ZFSDataset{Name: parts[0], Mountpoint: parts[1]}
+
+	if len(parts) == 3 {
+		for property := range strings.SplitSeq(parts[2], ",") {
+			name, value, found := strings.Cut(property, "=")
+			if !found {
+				return ZFSDataset{}, fmt.Errorf("invalid ZFS dataset %q : property %q isn't <property>=<value>", s, property)
+			}
+
+			switch name {
+			case "compression":
+				dataset.Compression = value
+
+			case "recordsize":
+				dataset.RecordSize = value
+
+			case "quota":
+				dataset.Quota = value
+
+			default:
+				return ZFSDataset{}, fmt.Errorf("invalid ZFS dataset %q : unsupported property %q", s, name)
+			}
+		}
+	}
+
+	if err := dataset.Validate(); err != nil {
+		return ZFSDataset{}, err
+	}
+	return dataset, nil
+}
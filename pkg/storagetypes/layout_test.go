@@ -0,0 +1,253 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package storagetypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+func TestStorageLayoutNormalized(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		layout     StorageLayout
+		want       StorageLayout
+		wantErrSub string
+	}{
+		{
+			name:   "zero value defaults to a 2 disk mirror with the default datasets",
+			layout: StorageLayout{},
+			want: StorageLayout{
+				ZFSPoolType:  constants.ZFSPoolTypeMirror,
+				ZFSPoolDisks: 2,
+				ZFSDatasets:  DefaultZFSDatasets(),
+			},
+		},
+		{
+			name:   "raidz2 defaults to 4 disks",
+			layout: StorageLayout{ZFSPoolType: constants.ZFSPoolTypeRAIDZ2},
+			want: StorageLayout{
+				ZFSPoolType:  constants.ZFSPoolTypeRAIDZ2,
+				ZFSPoolDisks: 4,
+				ZFSDatasets:  DefaultZFSDatasets(),
+			},
+		},
+		{
+			name: "keeps custom datasets and Ceph exclusion",
+			layout: StorageLayout{
+				ZFSPoolType:  constants.ZFSPoolTypeMirror,
+				ZFSPoolDisks: 3,
+				ZFSDatasets:  []ZFSDataset{{Name: "data", Mountpoint: "/srv/data", Quota: "50G"}},
+				ExcludeCEPH:  true,
+			},
+			want: StorageLayout{
+				ZFSPoolType:  constants.ZFSPoolTypeMirror,
+				ZFSPoolDisks: 3,
+				ZFSDatasets:  []ZFSDataset{{Name: "data", Mountpoint: "/srv/data", Quota: "50G"}},
+				ExcludeCEPH:  true,
+			},
+		},
+		{
+			name:       "rejects an unknown pool type",
+			layout:     StorageLayout{ZFSPoolType: "raidz3"},
+			wantErrSub: "unknown ZFS pool type",
+		},
+		{
+			name:       "rejects too few disks for raidz1",
+			layout:     StorageLayout{ZFSPoolType: constants.ZFSPoolTypeRAIDZ1, ZFSPoolDisks: 2},
+			wantErrSub: "needs at least 3 disks",
+		},
+		{
+			name:       "rejects a single disk pool across 2 disks",
+			layout:     StorageLayout{ZFSPoolType: constants.ZFSPoolTypeSingle, ZFSPoolDisks: 2},
+			wantErrSub: "exactly 1 disk",
+		},
+		{
+			name: "rejects duplicate dataset names",
+			layout: StorageLayout{ZFSDatasets: []ZFSDataset{
+				{Name: "data", Mountpoint: "/srv/a"},
+				{Name: "data", Mountpoint: "/srv/b"},
+			}},
+			wantErrSub: "declared twice",
+		},
+		{
+			name: "rejects a shared mountpoint",
+			layout: StorageLayout{ZFSDatasets: []ZFSDataset{
+				{Name: "a", Mountpoint: "/srv/data"},
+				{Name: "b", Mountpoint: "/srv/data"},
+			}},
+			wantErrSub: "share mountpoint",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.layout.Normalized()
+			if tc.wantErrSub != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrSub)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStorageLayoutIsDefault(t *testing.T) {
+	t.Parallel()
+
+	defaults, err := StorageLayout{}.Normalized()
+	require.NoError(t, err)
+	assert.True(t, defaults.IsDefault())
+
+	excluded, err := StorageLayout{ExcludeCEPH: true}.Normalized()
+	require.NoError(t, err)
+	assert.False(t, excluded.IsDefault())
+
+	raidz, err := StorageLayout{ZFSPoolType: constants.ZFSPoolTypeRAIDZ1}.Normalized()
+	require.NoError(t, err)
+	assert.False(t, raidz.IsDefault())
+}
+
+func TestZFSDatasetValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		dataset    ZFSDataset
+		wantErrSub string
+	}{
+		{
+			name:    "valid, with properties",
+			dataset: ZFSDataset{Name: "logs/pods", Mountpoint: "/var/log/pods", Compression: "zstd-3", RecordSize: "1M", Quota: "10.5G"},
+		},
+		{
+			name:       "invalid name",
+			dataset:    ZFSDataset{Name: "bad name", Mountpoint: "/srv"},
+			wantErrSub: "invalid ZFS dataset name",
+		},
+		{
+			name:       "relative mountpoint",
+			dataset:    ZFSDataset{Name: "data", Mountpoint: "srv/data"},
+			wantErrSub: "clean absolute path",
+		},
+		{
+			name:       "unclean mountpoint",
+			dataset:    ZFSDataset{Name: "data", Mountpoint: "/srv/../data"},
+			wantErrSub: "clean absolute path",
+		},
+		{
+			name:       "shell special characters in the mountpoint",
+			dataset:    ZFSDataset{Name: "data", Mountpoint: "/srv/$(reboot)"},
+			wantErrSub: "clean absolute path",
+		},
+		{
+			name:       "invalid compression",
+			dataset:    ZFSDataset{Name: "data", Mountpoint: "/srv/data", Compression: "brotli"},
+			wantErrSub: "invalid compression",
+		},
+		{
+			name:       "invalid recordsize",
+			dataset:    ZFSDataset{Name: "data", Mountpoint: "/srv/data", RecordSize: "128"},
+			wantErrSub: "invalid recordsize",
+		},
+		{
+			name:       "invalid quota",
+			dataset:    ZFSDataset{Name: "data", Mountpoint: "/srv/data", Quota: "lots"},
+			wantErrSub: "invalid quota",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.dataset.Validate()
+			if tc.wantErrSub == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErrSub)
+		})
+	}
+}
+
+func TestZFSDatasetProperties(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		[]string{"mountpoint=/var/lib/containerd"},
+		ZFSDataset{Name: "containerd", Mountpoint: "/var/lib/containerd"}.Properties(),
+	)
+	assert.Equal(t,
+		[]string{"mountpoint=/srv/data", "compression=lz4", "recordsize=1M", "quota=100G"},
+		ZFSDataset{
+			Name: "data", Mountpoint: "/srv/data",
+			Compression: "lz4", RecordSize: "1M", Quota: "100G",
+		}.Properties(),
+	)
+}
+
+func TestParseZFSDataset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input      string
+		want       ZFSDataset
+		wantErrSub string
+	}{
+		{
+			input: "containerd:/var/lib/containerd",
+			want:  ZFSDataset{Name: "containerd", Mountpoint: "/var/lib/containerd"},
+		},
+		{
+			input: "data:/srv/data:compression=zstd,recordsize=1M,quota=100G",
+			want: ZFSDataset{
+				Name: "data", Mountpoint: "/srv/data",
+				Compression: "zstd", RecordSize: "1M", Quota: "100G",
+			},
+		},
+		{
+			input:      "containerd",
+			wantErrSub: "expected <name>:<mountpoint>",
+		},
+		{
+			input:      "data:/srv/data:quota",
+			wantErrSub: "isn't <property>=<value>",
+		},
+		{
+			input:      "data:/srv/data:atime=off",
+			wantErrSub: "unsupported property",
+		},
+		{
+			input:      "data:/srv/data:quota=lots",
+			wantErrSub: "invalid quota",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseZFSDataset(tc.input)
+			if tc.wantErrSub != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrSub)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package storagetypes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DiskSelection overrides which of a server's disks the storage planner considers, when its
+// heuristics get it wrong : like an unrecognised disk type which should be used, or a disk which
+// should be left alone. Disks are referred to by name (like sda or /dev/sda), WWN or serial.
+//
+// The zero value considers every disk of a recognised type.
+type DiskSelection struct {
+	// Include, when not empty, makes the storage planner consider only these disks. Even the ones
+	// of a type it doesn't recognise.
+	Include []string `yaml:"include,omitempty" validate:"omitempty,dive,notblank"`
+
+	// Exclude makes the storage planner leave these disks alone.
+	Exclude []string `yaml:"exclude,omitempty" validate:"omitempty,dive,notblank"`
+}
+
+// Validate makes sure no disk is both included and excluded.
+func (s DiskSelection) Validate() error {
+	for _, disk := range s.Include {
+		if slices.Contains(s.Exclude, disk) {
+			return fmt.Errorf("disk %q is both included and excluded", disk)
+		}
+	}
+	return nil
+}
+
+// Includes reports whether the disk is explicitly included.
+func (s DiskSelection) Includes(disk *Disk) bool {
+	return slices.ContainsFunc(s.Include, func(reference string) bool {
+		return refersTo(reference, disk)
+	})
+}
+
+// Excludes reports whether the disk is explicitly excluded.
+func (s DiskSelection) Excludes(disk *Disk) bool {
+	return slices.ContainsFunc(s.Exclude, func(reference string) bool {
+		return refersTo(reference, disk)
+	})
+}
+
+// Unmatched returns the included disks, none of the given disks are referred to by : most likely
+// typos.
+func (s DiskSelection) Unmatched(disks []*Disk) []string {
+	var unmatched []string
+	for _, reference := range s.Include {
+		if !slices.ContainsFunc(disks, func(disk *Disk) bool { return refersTo(reference, disk) }) {
+			unmatched = append(unmatched, reference)
+		}
+	}
+	return unmatched
+}
+
+// Reports whether the reference (a name, WWN or serial) refers to the disk.
+func refersTo(reference string, disk *Disk) bool {
+	name := strings.TrimPrefix(strings.TrimPrefix(reference, "/dev/"), "mapper/")
+
+	return (name == disk.Name) ||
+		((disk.WWN != "") && (reference == disk.WWN)) ||
+		((disk.Serial != "") && (reference == disk.Serial))
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package storagetypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiskSelection(t *testing.T) {
+	t.Parallel()
+
+	sda := &Disk{Name: "sda", WWN: "0x5000c500a1b2c3d4", Serial: "ZA1B2C3D"}
+	mpatha := &Disk{Name: "mpatha", Multipath: true}
+	noWWN := &Disk{Name: "sdb"}
+
+	selection := DiskSelection{
+		Include: []string{"/dev/sda", "/dev/mapper/mpatha", "sdz"},
+		Exclude: []string{"0x5000c500a1b2c3d4", "ZA1B2C3D", ""},
+	}
+
+	assert.True(t, selection.Includes(sda))
+	assert.True(t, selection.Includes(mpatha))
+	assert.False(t, selection.Includes(noWWN))
+
+	assert.True(t, selection.Excludes(sda))
+	assert.False(t, selection.Excludes(mpatha))
+
+	// A disk without a WWN or serial isn't referred to by an empty one.
+	assert.False(t, selection.Excludes(noWWN))
+
+	assert.Equal(t, []string{"sdz"}, selection.Unmatched([]*Disk{sda, mpatha, noWWN}))
+}
+
+func TestDiskSelectionValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DiskSelection{Include: []string{"sda"}, Exclude: []string{"sdb"}}.Validate())
+	assert.ErrorContains(t,
+		DiskSelection{Include: []string{"sda", "sdb"}, Exclude: []string{"sdb"}}.Validate(),
+		`disk "sdb" is both included and excluded`,
+	)
+}
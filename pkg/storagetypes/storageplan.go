@@ -18,14 +18,17 @@ type StoragePlan struct {
 	// 2 disks across which the OS will get installed, with RAID 1 enabled.
 	OS,
 
-	// 2 disks across which the ZFS pool runs, as a ZFS mirror
-	// (two-disk RAID-1 semantics — the executor template does
-	// `zpool create primary mirror …`; raidz-1 needs ≥ 3 disks
-	// anyway). We carve out ZFS volumes for ContainerD's image
-	// store, pod logs, and pod ephemeral volumes; the remainder
-	// backs the OpenEBS ZFS LocalPV provisioner CSI driver.
+	// Disks across which the ZFS pool runs, shaped as Layout.ZFSPoolType
+	// (by default a mirror across 2 disks). We carve the
+	// Layout.ZFSDatasets out of it (by default for ContainerD's image
+	// store, pod logs, and pod ephemeral volumes); the remainder backs
+	// the OpenEBS ZFS LocalPV provisioner CSI driver.
 	ZFS,
 
-	// Disks across which the CEPH cluster will be running.
+	// Disks across which the CEPH cluster will be running. Empty when
+	// Layout.ExcludeCEPH.
 	CEPH []*Disk
+
+	// Layout the plan got allocated for, normalized.
+	Layout StorageLayout
 }
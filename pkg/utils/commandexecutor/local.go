@@ -51,7 +51,9 @@ func (l *LocalCommandExecutor) Execute(ctx context.Context, command string) (str
 				fmt.Println(line)
 			}
 
-			stdoutOutputBuilder.WriteString(line)
+			// go-cmd strips the line breaks; put them back, so multi-line output (like
+			// zpool status) stays parseable line by line.
+			stdoutOutputBuilder.WriteString(line + "\n")
 		}
 		return nil
 	})
@@ -62,7 +64,7 @@ func (l *LocalCommandExecutor) Execute(ctx context.Context, command string) (str
 				fmt.Println(line)
 			}
 
-			stderrOutputBuilder.WriteString(line)
+			stderrOutputBuilder.WriteString(line + "\n")
 		}
 		return nil
 	})
@@ -0,0 +1,162 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Package events streams what a long-running command is doing as newline-delimited JSON, for
+external tools (dashboards, CI) to render progress without scraping the logs.
+
+Nothing gets emitted until a sink gets added via AddSink : emitting is then a no-op, so callers
+never need to check whether event streaming is enabled.
+*/
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Type names an event. The values are part of the stream's contract : never rename one.
+type Type string
+
+const (
+	// TypePhaseStarted / TypePhaseFinished bracket a major step (a section in the progress UI),
+	// like "Provisioning main cluster".
+	TypePhaseStarted  Type = "phase_started"
+	TypePhaseFinished Type = "phase_finished"
+
+	// TypeSubstep is a finished piece of work, within the current phase.
+	TypeSubstep Type = "substep"
+
+	// TypeMachineChanged is a Cluster API Machine showing up, changing phase, or becoming Ready.
+	TypeMachineChanged Type = "machine_changed"
+
+	// TypeArgoCDAppStatus is the observed sync and health status of an ArgoCD App.
+	TypeArgoCDAppStatus Type = "argocd_app_status"
+
+	// TypeWaitingForApproval is the run blocking on the operator : an approval prompt, or a
+	// kubeaid-config PR to merge.
+	TypeWaitingForApproval Type = "waiting_for_approval"
+
+	// TypeError is an error getting logged. The run exits right after most of them.
+	TypeError Type = "error"
+)
+
+// Event is a single line of the stream. Fields not relevant to the event's type are omitted.
+type Event struct {
+	Type    Type      `json:"type"`
+	Time    time.Time `json:"time"`
+	Cluster string    `json:"cluster,omitempty"`
+
+	// Phase is the phase the event happened in.
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	Machine  *Machine   `json:"machine,omitempty"`
+	App      *ArgoCDApp `json:"app,omitempty"`
+	Approval *Approval  `json:"approval,omitempty"`
+
+	// Attributes carries the structured log attributes of a TypeError event.
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Machine is the state of a Cluster API Machine, for a TypeMachineChanged event.
+type Machine struct {
+	Name string `json:"name"`
+
+	Phase         string `json:"phase"`
+	PreviousPhase string `json:"previousPhase,omitempty"`
+
+	Ready bool `json:"ready"`
+}
+
+// ArgoCDApp is the state of an ArgoCD App, for a TypeArgoCDAppStatus event.
+type ArgoCDApp struct {
+	Name         string `json:"name"`
+	SyncStatus   string `json:"syncStatus"`
+	HealthStatus string `json:"healthStatus"`
+}
+
+// Approval is what the run is waiting on, for a TypeWaitingForApproval event.
+type Approval struct {
+	// Decision is the answers file key, which would have answered the approval prompt upfront.
+	// Empty when waiting for a PR merge.
+	Decision string `json:"decision,omitempty"`
+
+	// PullRequestURL is the kubeaid-config PR waiting to be merged.
+	PullRequestURL string `json:"pullRequestURL,omitempty"`
+}
+
+var (
+	mu          sync.Mutex
+	sinks       []io.Writer
+	clusterName string
+)
+
+// Indirected so tests get deterministic timestamps.
+var now = time.Now
+
+// AddSink makes every subsequent event get written to w, one JSON object per line, until the
+// returned remove func gets called.
+func AddSink(w io.Writer) (remove func()) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	sink := &sinkWriter{w}
+	sinks = append(sinks, sink)
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		sinks = slices.DeleteFunc(sinks, func(s io.Writer) bool { return s == sink })
+	}
+}
+
+// sinkWriter gives each added sink its own identity, so the same writer can be added (and
+// removed) more than once.
+type sinkWriter struct {
+	io.Writer
+}
+
+// Enabled reports whether any sink has been added.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return len(sinks) > 0
+}
+
+// SetClusterName sets the cluster name, every subsequent event gets stamped with.
+func SetClusterName(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	clusterName = name
+}
+
+// Emit stamps the event with the current time and the cluster name, and writes it to every sink.
+// A sink failing to be written to doesn't fail the run : the stream is best effort.
+func Emit(event Event) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(sinks) == 0 {
+		return
+	}
+
+	event.Time = now().UTC()
+	event.Cluster = clusterName
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	for _, sink := range sinks {
+		_, _ = sink.Write(line)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Mutates the package level sinks, clusterName and now — sequential only.
+func TestEmit(t *testing.T) {
+	savedNow := now
+	t.Cleanup(func() {
+		now = savedNow
+		clusterName = ""
+	})
+	now = func() time.Time {
+		return time.Date(2026, 10, 17, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	}
+
+	// No sink yet : nothing to write to, and nothing breaks.
+	assert.False(t, Enabled())
+	Emit(Event{Type: TypeSubstep, Message: "Cloned kubeaid-config repo"})
+
+	var stdout, eventsFile bytes.Buffer
+	removeStdout := AddSink(&stdout)
+	removeEventsFile := AddSink(&eventsFile)
+	assert.True(t, Enabled())
+
+	SetClusterName("demo")
+
+	Emit(Event{Type: TypePhaseStarted, Phase: "Provisioning main cluster"})
+	Emit(Event{
+		Type:  TypeMachineChanged,
+		Phase: "Provisioning main cluster",
+		Machine: &Machine{
+			Name:          "demo-control-plane-x7k2p",
+			Phase:         "Running",
+			PreviousPhase: "Provisioning",
+		},
+	})
+
+	expected := `{"type":"phase_started","time":"2026-10-17T10:30:00Z","cluster":"demo","phase":"Provisioning main cluster"}
+{"type":"machine_changed","time":"2026-10-17T10:30:00Z","cluster":"demo","phase":"Provisioning main cluster","machine":{"name":"demo-control-plane-x7k2p","phase":"Running","previousPhase":"Provisioning","ready":false}}
+`
+	assert.Equal(t, expected, stdout.String())
+	assert.Equal(t, expected, eventsFile.String())
+
+	// A removed sink stops receiving events.
+	removeEventsFile()
+	Emit(Event{Type: TypeError, Message: "Failed provisioning main cluster"})
+	assert.Contains(t, stdout.String(), `"type":"error"`)
+	assert.Equal(t, expected, eventsFile.String())
+
+	removeStdout()
+	assert.False(t, Enabled())
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	repourl "github.com/Obmondo/kubeaid-cli/pkg/repository/url"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
 )
 
@@ -26,24 +27,55 @@ const (
 	// is explicitly set.
 	gitAuthModeAgent gitAuthMode = iota
 
-	// gitAuthModePrivateKeyFile: read an unencrypted private key
-	// from disk and use it directly. Selected only when the
-	// operator gave a non-empty PrivateKeyFilePath AND did not opt
-	// into the agent.
+	// gitAuthModePrivateKeyFile: use the private key read from
+	// disk (unlocked, when passphrase protected) directly.
+	// Selected only when the operator gave a non-empty
+	// PrivateKeyFilePath AND did not opt into the agent.
 	gitAuthModePrivateKeyFile
+
+	// gitAuthModeHTTPSToken: HTTP basic auth with the access token
+	// from secrets.yaml or $KUBEAID_CLI_GIT_TOKEN — anonymous when
+	// there's neither. Default for HTTP(S) kubeaid-config URLs.
+	gitAuthModeHTTPSToken
+
+	// gitAuthModeGitHubApp: HTTP basic auth with GitHub App
+	// installation access tokens, minted and refreshed on demand.
+	gitAuthModeGitHubApp
+
+	// gitAuthModeCredentialHelper: HTTP basic auth with whatever
+	// `git credential fill` returns for the host.
+	gitAuthModeCredentialHelper
 )
 
 // gitAuthModeFor selects the auth path based on the operator's git
-// config. Pure function — split out from GetGitAuthMethod so the
-// routing logic can be unit-tested without touching the filesystem,
-// the SSH agent, or known-hosts files.
+// config and the kubeaid-config repository URL. Pure function — split
+// out from GetGitAuthMethod so the routing logic can be unit-tested
+// without touching the filesystem, the SSH agent, or known-hosts files.
+//
+// An HTTP(S) kubeaid-config URL routes to one of the HTTPS modes,
+// whatever SSH settings are around: the SSH ones can't authenticate
+// an HTTPS remote anyway. The credential helper wins over the GitHub
+// App, since it's the operator's explicit "let git handle it".
 //
 // The SSHKeyPairConfig is non-nil whenever ANY of its fields are set
 // (including UseSSHAgent=true alone), so a nil-only check would mis-
 // route YubiKey/agent operators into the file path with an empty path.
 // The full predicate guards against that: pointer non-nil, UseSSHAgent
 // false, AND PrivateKeyFilePath non-empty.
-func gitAuthModeFor(gitConfig config.GitConfig) gitAuthMode {
+func gitAuthModeFor(gitConfig config.GitConfig, kubeaidConfigURL string) gitAuthMode {
+	if repourl.UsingHTTPBasedProtocol(kubeaidConfigURL) {
+		switch {
+		case gitConfig.HTTPS != nil && gitConfig.HTTPS.UseCredentialHelper:
+			return gitAuthModeCredentialHelper
+
+		case gitConfig.HTTPS != nil && gitConfig.HTTPS.GitHubApp != nil:
+			return gitAuthModeGitHubApp
+
+		default:
+			return gitAuthModeHTTPSToken
+		}
+	}
+
 	if gitConfig.SSHKeyPairConfig != nil &&
 		!gitConfig.UseSSHAgent &&
 		gitConfig.PrivateKeyFilePath != "" {
@@ -53,29 +85,45 @@ func gitAuthModeFor(gitConfig config.GitConfig) gitAuthMode {
 }
 
 // Returns the Git authentication method which KubeAid CLI will use to interact with the
-// KubeAid Config and / or KubeAid repositories.
+// KubeAid Config and / or KubeAid repositories. Nil means anonymous access.
 //
-// The operator-supplied private key (file path) is expected to be
-// UNENCRYPTED — we pass an empty passphrase to gossh.NewPublicKeysFromFile.
-// Encrypted keys (BEGIN OPENSSH ENCRYPTED ...) fail at construction
-// time. Operators who need a passphrased key should use the SSH agent
-// path instead, which holds the decrypted material in memory.
-//
-// TODO : Support passphrased private keys by prompting the operator
-// at runtime via golang.org/x/term.ReadPassword (no echo) and passing
-// the captured passphrase to gossh.NewPublicKeysFromFile. Setup-time
-// validation in pkg/config/prompt/prompt_helper.go:validateSSHPrivateKey
-// already accepts encrypted keys ("the passphrase will be supplied
-// later") but the runtime never wires up the prompt — encrypted keys
-// pass setup and then fail at first git op. Adding the prompt here
-// closes that gap without storing the passphrase in general.yaml.
-//
-//nolint:godox
+// The operator-supplied private key may be passphrase protected : it got
+// unlocked while hydrating the git config (see pkg/utils/sshkey), so the
+// hydrated PrivateKey is used here instead of re-reading the file.
 func GetGitAuthMethod(ctx context.Context) transport.AuthMethod {
 	slog.InfoContext(ctx, "Determining Git auth method")
 
 	gitConfig := config.ParsedGeneralConfig.Git
+	kubeaidConfigFork := config.ParsedGeneralConfig.Forks.KubeaidConfigFork
+
+	var authMethod transport.AuthMethod
+	switch authMode := gitAuthModeFor(gitConfig, kubeaidConfigFork.URL); authMode {
+	case gitAuthModePrivateKeyFile, gitAuthModeAgent:
+		authMethod = getSSHAuthMethod(ctx, gitConfig, authMode)
+
+	// HTTPS remotes don't need the known hosts file : the Git server gets authenticated by its
+	// TLS certificate instead.
+	case gitAuthModeHTTPSToken:
+		authMethod = getHTTPSTokenAuthMethod(ctx)
+
+	case gitAuthModeGitHubApp:
+		authMethod = getGitHubAppAuthMethod(ctx, kubeaidConfigFork.ParsedURL)
 
+	case gitAuthModeCredentialHelper:
+		authMethod = newCredentialHelperAuth()
+
+		slog.InfoContext(ctx, "Using git credential helper")
+	}
+
+	return authMethod
+}
+
+// Returns the SSH private key or SSH agent backed auth method, verifying the Git server's host key
+// against the known hosts file.
+func getSSHAuthMethod(ctx context.Context,
+	gitConfig config.GitConfig,
+	authMode gitAuthMode,
+) transport.AuthMethod {
 	// Create the known hosts file, which contains
 	// known hosts of common Git repo hosting providers (like Azure DevOps, GitLab etc.), and
 	// any extra known hosts specified by the user.
@@ -84,32 +132,27 @@ func GetGitAuthMethod(ctx context.Context) transport.AuthMethod {
 	knownHostsCallback, err := gossh.NewKnownHostsCallback(constants.OutputPathKnownHostsFile)
 	assert.AssertErrNil(ctx, err, "Failed creating known hosts callback")
 
-	var authMethod transport.AuthMethod
-	switch gitAuthModeFor(gitConfig) {
-	case gitAuthModePrivateKeyFile:
-		publicKeysAuthMethod, err := gossh.NewPublicKeysFromFile(
+	if authMode == gitAuthModePrivateKeyFile {
+		publicKeysAuthMethod, err := gossh.NewPublicKeys(
 			gitConfig.SSHUsername,
-			gitConfig.PrivateKeyFilePath,
+			[]byte(gitConfig.PrivateKey),
 			"",
 		)
 		assert.AssertErrNil(ctx, err, "Failed generating SSH public key from SSH private key")
 
 		publicKeysAuthMethod.HostKeyCallback = knownHostsCallback
 
-		authMethod = publicKeysAuthMethod
-
 		slog.InfoContext(ctx, "Using SSH private key")
 
-	case gitAuthModeAgent:
-		sshAgentAuthMethod, err := gossh.NewSSHAgentAuth(gitConfig.SSHUsername)
-		assert.AssertErrNil(ctx, err, "SSH agent failed")
+		return publicKeysAuthMethod
+	}
 
-		sshAgentAuthMethod.HostKeyCallback = knownHostsCallback
+	sshAgentAuthMethod, err := gossh.NewSSHAgentAuth(gitConfig.SSHUsername)
+	assert.AssertErrNil(ctx, err, "SSH agent failed")
 
-		authMethod = sshAgentAuthMethod
+	sshAgentAuthMethod.HostKeyCallback = knownHostsCallback
 
-		slog.InfoContext(ctx, "Using SSH agent")
-	}
+	slog.InfoContext(ctx, "Using SSH agent")
 
-	return authMethod
+	return sshAgentAuthMethod
 }
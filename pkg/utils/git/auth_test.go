@@ -92,9 +92,68 @@ func TestGitAuthModeFor(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := gitAuthModeFor(tc.gitCfg)
+			got := gitAuthModeFor(tc.gitCfg, "git@github.com:Obmondo/kubeaid-config.git")
 			assert.Equal(t, tc.want, got,
 				"got %d (%s) want %d", got, tc.wantStr, tc.want)
 		})
 	}
 }
+
+// TestGitAuthModeForHTTPS pins the HTTPS routing: an HTTP(S) kubeaid-config
+// URL never routes to an SSH mode, even with SSH settings around.
+func TestGitAuthModeForHTTPS(t *testing.T) {
+	const kubeaidConfigURL = "https://github.com/Obmondo/kubeaid-config.git"
+
+	gitHubApp := &config.GitHubAppConfig{AppID: 7, InstallationID: 42}
+
+	tests := []struct {
+		name   string
+		gitCfg config.GitConfig
+		want   gitAuthMode
+	}{
+		{
+			name:   "no https block: token",
+			gitCfg: config.GitConfig{},
+			want:   gitAuthModeHTTPSToken,
+		},
+		{
+			name: "SSH private key configured: still token",
+			gitCfg: config.GitConfig{
+				SSHKeyPairConfig: &config.SSHKeyPairConfig{
+					PrivateKeyFilePath: "/home/op/.ssh/id_ed25519_kubeaid",
+				},
+			},
+			want: gitAuthModeHTTPSToken,
+		},
+		{
+			name:   "GitHub App",
+			gitCfg: config.GitConfig{HTTPS: &config.GitHTTPSConfig{GitHubApp: gitHubApp}},
+			want:   gitAuthModeGitHubApp,
+		},
+		{
+			name:   "credential helper",
+			gitCfg: config.GitConfig{HTTPS: &config.GitHTTPSConfig{UseCredentialHelper: true}},
+			want:   gitAuthModeCredentialHelper,
+		},
+		{
+			name: "credential helper wins over GitHub App",
+			gitCfg: config.GitConfig{HTTPS: &config.GitHTTPSConfig{
+				UseCredentialHelper: true,
+				GitHubApp:           gitHubApp,
+			}},
+			want: gitAuthModeCredentialHelper,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, gitAuthModeFor(tc.gitCfg, kubeaidConfigURL))
+		})
+	}
+
+	// The https block is ignored for SSH remotes.
+	assert.Equal(t, gitAuthModeAgent, gitAuthModeFor(
+		config.GitConfig{HTTPS: &config.GitHTTPSConfig{UseCredentialHelper: true}},
+		"git@github.com:Obmondo/kubeaid-config.git",
+	))
+}
@@ -61,11 +61,10 @@ func CloneRepo(ctx context.Context, url string, authMethod transport.AuthMethod,
 		slog.String("repo", url), slog.String("path", path),
 	})
 
-	// For HTTPs URLs, no auth is needed (public repos only).
-	// If the clone fails, the repo is likely private and requires an SSH URL.
-	if repourl.UsingHTTPBasedProtocol(url) {
-		authMethod = nil
-	}
+	// Drop the auth method when it can't authenticate to this repository : like SSH credentials
+	// for an HTTPS URL, or HTTPS credentials for a host other than the KubeAid Config
+	// repository's. The repository then gets accessed anonymously.
+	authMethod = authMethodForURL(url, config.ParsedGeneralConfig.Forks.KubeaidConfigFork.URL, authMethod)
 
 	// When the repo is already cloned.
 	if _, err := os.ReadDir(path); err == nil {
@@ -124,7 +123,7 @@ func CloneRepo(ctx context.Context, url string, authMethod transport.AuthMethod,
 	if repourl.UsingHTTPBasedProtocol(url) &&
 		(errors.Is(err, transport.ErrAuthenticationRequired) || errors.Is(err, transport.ErrAuthorizationFailed)) {
 		slog.ErrorContext(ctx,
-			"HTTPS clone failed: private repo detected, switch to SSH URL",
+			"HTTPS clone failed: private repo detected, provide a Git access token (git.token in secrets.yaml) or switch to an SSH URL",
 			slog.String("url", url),
 		)
 		// os.Exit skips the deferred releaseTouch, so erase the
@@ -0,0 +1,124 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+)
+
+type (
+	// credentialFillFunc returns the credentials for the given protocol and host. Implemented by
+	// gitCredentialFill, faked by tests.
+	credentialFillFunc func(ctx context.Context, protocol, host string) (username, password string, err error)
+
+	// credentialHelperAuth authenticates HTTPS git operations with the credentials the operator's
+	// git credential helper returns. They're asked for once per host, and cached for the rest of
+	// the run.
+	credentialHelperAuth struct {
+		fill credentialFillFunc
+
+		mu          sync.Mutex
+		credentials map[string]*githttp.BasicAuth
+	}
+)
+
+var _ githttp.AuthMethod = (*credentialHelperAuth)(nil)
+
+func newCredentialHelperAuth() *credentialHelperAuth {
+	return &credentialHelperAuth{
+		fill:        gitCredentialFill,
+		credentials: map[string]*githttp.BasicAuth{},
+	}
+}
+
+func (a *credentialHelperAuth) Name() string {
+	return "git-credential-helper"
+}
+
+func (a *credentialHelperAuth) String() string {
+	return a.Name()
+}
+
+// SetAuth can't return an error. When the credential helper fails, the request goes out
+// unauthenticated, and the git operation fails with transport.ErrAuthenticationRequired : the
+// reason gets logged here.
+func (a *credentialHelperAuth) SetAuth(r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	host := r.URL.Host
+
+	credentials, found := a.credentials[host]
+	if !found {
+		username, password, err := a.fill(r.Context(), r.URL.Scheme, host)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Git credential helper failed",
+				slog.String("host", host), logger.Error(err),
+			)
+			return
+		}
+
+		credentials = &githttp.BasicAuth{Username: username, Password: password}
+		a.credentials[host] = credentials
+	}
+
+	credentials.SetAuth(r)
+}
+
+// gitCredentialFill runs `git credential fill`, which asks the credential helpers configured for
+// the host (git config credential.helper) for the credentials. Terminal prompts are disabled :
+// KubeAid CLI runs unattended in CI, where a prompt would hang forever.
+func gitCredentialFill(ctx context.Context, protocol, host string) (username, password string, err error) {
+	command := exec.CommandContext(ctx, "git", "credential", "fill")
+	command.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	command.Stdin = strings.NewReader(fmt.Sprintf("protocol=%s\nhost=%s\n\n", protocol, host))
+
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+
+	output, err := command.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("git credential fill: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	username, password = parseCredentialFillOutput(output)
+	if password == "" {
+		return "", "", fmt.Errorf("git credential fill returned no password for %s", host)
+	}
+	return username, password, nil
+}
+
+// parseCredentialFillOutput extracts the username and password from the key=value lines
+// `git credential fill` prints.
+func parseCredentialFillOutput(output []byte) (username, password string) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "username":
+			username = value
+
+		case "password":
+			password = value
+		}
+	}
+	return username, password
+}
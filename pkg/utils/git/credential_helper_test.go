@@ -0,0 +1,54 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCredentialFillOutput(t *testing.T) {
+	output := []byte("protocol=https\nhost=github.com\nusername=kubeaid-bot\npassword=s3cr=t\n")
+
+	username, password := parseCredentialFillOutput(output)
+	assert.Equal(t, "kubeaid-bot", username)
+	assert.Equal(t, "s3cr=t", password)
+}
+
+func TestCredentialHelperAuthSetAuth(t *testing.T) {
+	var filled []string
+	authMethod := newCredentialHelperAuth()
+	authMethod.fill = func(_ context.Context, protocol, host string) (string, string, error) {
+		filled = append(filled, protocol+"://"+host)
+		if host == "broken.example.com" {
+			return "", "", errors.New("no credentials")
+		}
+		return "kubeaid-bot", "password-for-" + host, nil
+	}
+
+	for range 2 {
+		request := httptest.NewRequest(http.MethodGet, "https://github.com/Obmondo/kubeaid-config.git/info/refs", nil)
+		authMethod.SetAuth(request)
+
+		username, password, ok := request.BasicAuth()
+		require.True(t, ok)
+		assert.Equal(t, "kubeaid-bot", username)
+		assert.Equal(t, "password-for-github.com", password)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "https://broken.example.com/obmondo/kubeaid-config.git/info/refs", nil)
+	authMethod.SetAuth(request)
+
+	_, _, ok := request.BasicAuth()
+	assert.False(t, ok)
+
+	// The credential helper is asked once per host, and failures aren't cached.
+	assert.Equal(t, []string{"https://github.com", "https://broken.example.com"}, filled)
+}
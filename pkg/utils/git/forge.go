@@ -0,0 +1,197 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/repository/forge"
+	repourl "github.com/Obmondo/kubeaid-cli/pkg/repository/url"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+)
+
+// prPollInterval is how often the forge gets asked whether the PR is merged yet. Polling the
+// forge API (instead of git fetching) costs no YubiKey touch, so this can be frequent.
+var prPollInterval = 15 * time.Second
+
+// maxListedChangedFiles caps the changed-files list in the PR body. A first render of a
+// cluster's kubeaid-config touches a few hundred files; nobody reads past the first screen.
+const maxListedChangedFiles = 50
+
+// forgeForRepo returns a client for the forge hosting the given repo's origin, or nil when no
+// forge API token is configured in secrets.yaml (git.forgeToken) : the PR workflow then falls
+// back to printing the compare URL and waiting for the operator to press ENTER.
+func forgeForRepo(ctx context.Context, repo *goGit.Repository) forge.Forge {
+	secretsConfig := config.ParsedSecretsConfig
+	if (secretsConfig == nil) || (secretsConfig.Git == nil) || (secretsConfig.Git.ForgeToken == "") {
+		return nil
+	}
+
+	remote, err := repo.Remote(goGit.DefaultRemoteName)
+	if err != nil || len(remote.Config().URLs) == 0 {
+		slog.WarnContext(ctx, "Can't read origin remote; not using the forge API", logger.Error(err))
+		return nil
+	}
+	parsedURL, err := repourl.Parse(remote.Config().URLs[0])
+	if err != nil {
+		slog.WarnContext(ctx, "Can't parse origin URL; not using the forge API", logger.Error(err))
+		return nil
+	}
+
+	var forgeType, apiURL string
+	if forgeConfig := config.ParsedGeneralConfig.Git.Forge; forgeConfig != nil {
+		forgeType, apiURL = forgeConfig.Type, forgeConfig.APIURL
+	}
+
+	forgeClient, err := forge.New(parsedURL,
+		forgeType, apiURL, secretsConfig.Git.ForgeToken,
+		config.ParsedGeneralConfig.Git.CABundle,
+	)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed constructing forge client; not using the forge API",
+			logger.Error(err),
+		)
+		return nil
+	}
+	return forgeClient
+}
+
+// waitUntilPRMergedViaForge opens a PR from branchToBeMerged into defaultBranchName, using the
+// forge API, and polls it until it gets merged. The PR's review and CI state are surfaced in
+// the progress bar meanwhile.
+//
+// Returns false, when the PR couldn't be opened : the caller then falls back to the manual
+// flow. Panics when the PR gets closed without being merged, or ctx gets cancelled.
+func waitUntilPRMergedViaForge(ctx context.Context,
+	forgeClient forge.Forge,
+	repo *goGit.Repository,
+	defaultBranchName string,
+	commitHash plumbing.Hash,
+	auth transport.AuthMethod,
+	branchToBeMerged string,
+) bool {
+	bar := progress.FromCtx(ctx)
+
+	options, err := buildPullRequestOptions(repo, commitHash, defaultBranchName, branchToBeMerged)
+	assert.AssertErrNil(ctx, err, "Failed building PR title and body")
+
+	pullRequest, err := forgeClient.OpenPullRequest(ctx, options)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed opening PR via the forge API; falling back to manual PR creation",
+			logger.Error(err),
+		)
+		return false
+	}
+	slog.InfoContext(ctx, "Opened PR", slog.String("URL", pullRequest.URL))
+	bar.Substep("Opened PR " + pullRequest.URL)
+	emitWaitingForPRMerge(ctx, pullRequest.URL)
+
+	caption := describePullRequest(pullRequest)
+	releaseCaption := bar.InProgress(caption)
+
+	for {
+		switch pullRequest.State {
+		case forge.StateMerged:
+			releaseCaption()
+			slog.InfoContext(ctx, "Confirmed PR merged", slog.String("URL", pullRequest.URL))
+
+			// The forge is the source of truth here : a squash or rebase merge lands the changes
+			// under a different commit hash, so there's no commit-presence check to do. We still
+			// fetch the default branch, so the local ref is up to date for whatever comes next.
+			fetchDefaultBranch(ctx, repo, defaultBranchName, auth)
+			return true
+
+		case forge.StateClosed:
+			releaseCaption()
+			assert.AssertErrNil(ctx,
+				fmt.Errorf("PR %s got closed without being merged", pullRequest.URL),
+				"Stopped waiting for PR merge",
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			releaseCaption()
+			assert.AssertErrNil(ctx, ctx.Err(), "Stopped waiting for PR merge")
+
+		case <-time.After(prPollInterval):
+		}
+
+		latest, err := forgeClient.GetPullRequest(ctx, pullRequest.Number)
+		if err != nil {
+			// Transient forge hiccups shouldn't abort a bootstrap : keep the last known state
+			// and ask again on the next tick.
+			slog.WarnContext(ctx, "Failed polling PR state", logger.Error(err))
+			continue
+		}
+		pullRequest = latest
+
+		if newCaption := describePullRequest(pullRequest); newCaption != caption {
+			releaseCaption()
+			caption = newCaption
+			releaseCaption = bar.InProgress(caption)
+		}
+	}
+}
+
+// describePullRequest renders the progress bar caption shown while waiting for the PR merge.
+func describePullRequest(pullRequest *forge.PullRequest) string {
+	return fmt.Sprintf("Waiting for PR #%d to be merged  •  review: %s  •  CI: %s",
+		pullRequest.Number, pullRequest.Review, pullRequest.Checks,
+	)
+}
+
+// buildPullRequestOptions generates the PR title (the commit's subject line) and body (listing
+// the files the commit changes).
+func buildPullRequestOptions(repo *goGit.Repository,
+	commitHash plumbing.Hash,
+	baseBranch, headBranch string,
+) (forge.PullRequestOptions, error) {
+	commit, err := repo.CommitObject(commitHash)
+	if err != nil {
+		return forge.PullRequestOptions{}, fmt.Errorf("getting commit %s: %w", commitHash, err)
+	}
+
+	title, _, _ := strings.Cut(commit.Message, "\n")
+
+	fileStats, err := commit.Stats()
+	if err != nil {
+		return forge.PullRequestOptions{}, fmt.Errorf("getting stats of commit %s: %w", commitHash, err)
+	}
+	changedFiles := make([]string, 0, len(fileStats))
+	for _, fileStat := range fileStats {
+		changedFiles = append(changedFiles, fileStat.Name)
+	}
+	sort.Strings(changedFiles)
+
+	var body strings.Builder
+	body.WriteString("Opened by KubeAid CLI, which resumes once this gets merged.\n\n")
+	fmt.Fprintf(&body, "### Changed files (%d)\n\n", len(changedFiles))
+	for i, changedFile := range changedFiles {
+		if i == maxListedChangedFiles {
+			fmt.Fprintf(&body, "- … and %d more\n", len(changedFiles)-maxListedChangedFiles)
+			break
+		}
+		fmt.Fprintf(&body, "- `%s`\n", changedFile)
+	}
+
+	return forge.PullRequestOptions{
+		Title: strings.TrimSpace(title),
+		Body:  body.String(),
+		Base:  baseBranch,
+		Head:  headBranch,
+	}, nil
+}
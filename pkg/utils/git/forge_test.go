@@ -0,0 +1,162 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/repository/forge"
+)
+
+// fakeForge replays getResponses, one per GetPullRequest call, and records what got opened.
+type fakeForge struct {
+	openResponse *forge.PullRequest
+	openErr      error
+	opened       *forge.PullRequestOptions
+
+	getResponses []*forge.PullRequest
+	getErrs      []error
+	getCalls     int
+}
+
+func (f *fakeForge) OpenPullRequest(_ context.Context, options forge.PullRequestOptions) (*forge.PullRequest, error) {
+	f.opened = &options
+	return f.openResponse, f.openErr
+}
+
+func (f *fakeForge) GetPullRequest(_ context.Context, _ int) (*forge.PullRequest, error) {
+	i := f.getCalls
+	f.getCalls++
+
+	var err error
+	if i < len(f.getErrs) {
+		err = f.getErrs[i]
+	}
+	return f.getResponses[i], err
+}
+
+// commitFiles writes the given files into the repo's worktree and commits them.
+func commitFiles(t *testing.T, repo *goGit.Repository, message string, files ...string) plumbing.Hash {
+	t.Helper()
+
+	workTree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	for _, file := range files {
+		path := filepath.Join(workTree.Filesystem.Root(), file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(message), 0o600))
+		_, err = workTree.Add(file)
+		require.NoError(t, err)
+	}
+
+	hash, err := workTree.Commit(message, &goGit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	return hash
+}
+
+func TestBuildPullRequestOptions(t *testing.T) {
+	repo := newTestRepo(t)
+	commitFiles(t, repo, "initial", "README.md")
+	commitHash := commitFiles(t, repo,
+		"(cluster/staging) : created / updated KubeAid config files\n\nCo-Authored-By: Obmondo <info@obmondo.com>",
+		"k8s/staging/argocd-apps/values-velero.yaml", "k8s/staging/argocd-apps/Chart.yaml",
+	)
+
+	options, err := buildPullRequestOptions(repo, commitHash, "main", "kubeaid-staging")
+	require.NoError(t, err)
+
+	assert.Equal(t, "(cluster/staging) : created / updated KubeAid config files", options.Title)
+	assert.Equal(t, "main", options.Base)
+	assert.Equal(t, "kubeaid-staging", options.Head)
+	assert.Contains(t, options.Body, "### Changed files (2)\n\n"+
+		"- `k8s/staging/argocd-apps/Chart.yaml`\n"+
+		"- `k8s/staging/argocd-apps/values-velero.yaml`\n",
+	)
+}
+
+// Mutates prPollInterval, config.ParsedGeneralConfig — sequential only.
+func TestWaitUntilPRMergedViaForge(t *testing.T) {
+	savedPollInterval := prPollInterval
+	savedGeneralConfig := config.ParsedGeneralConfig
+	t.Cleanup(func() {
+		prPollInterval = savedPollInterval
+		config.ParsedGeneralConfig = savedGeneralConfig
+	})
+	prPollInterval = time.Millisecond
+	config.ParsedGeneralConfig = &config.GeneralConfig{}
+
+	// The upstream repo lives on local disk, so fetching the default branch after the merge
+	// works without any network.
+	upstreamDir := t.TempDir()
+	upstream, err := goGit.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	commitFiles(t, upstream, "initial", "README.md")
+	upstreamHead, err := upstream.Head()
+	require.NoError(t, err)
+	defaultBranchName := upstreamHead.Name().Short()
+
+	t.Run("falls back to the manual flow when the PR can't be opened", func(t *testing.T) {
+		repo, err := goGit.PlainClone(t.TempDir(), false, &goGit.CloneOptions{URL: upstreamDir})
+		require.NoError(t, err)
+		commitHash := commitFiles(t, repo, "change", "k8s/staging/file.yaml")
+
+		forgeClient := &fakeForge{openErr: errors.New("HTTP 403")}
+
+		merged := waitUntilPRMergedViaForge(context.Background(),
+			forgeClient, repo, defaultBranchName, commitHash, nil, "kubeaid-staging",
+		)
+		assert.False(t, merged)
+	})
+
+	t.Run("polls through errors and state changes until merged", func(t *testing.T) {
+		repo, err := goGit.PlainClone(t.TempDir(), false, &goGit.CloneOptions{URL: upstreamDir})
+		require.NoError(t, err)
+		commitHash := commitFiles(t, repo, "change", "k8s/staging/file.yaml")
+
+		open := &forge.PullRequest{Number: 4, State: forge.StateOpen, Review: forge.ReviewStatePending}
+		forgeClient := &fakeForge{
+			openResponse: open,
+			getResponses: []*forge.PullRequest{
+				nil,
+				{Number: 4, State: forge.StateOpen, Review: forge.ReviewStateApproved, Checks: forge.CheckStatePending},
+				{Number: 4, State: forge.StateMerged},
+			},
+			getErrs: []error{errors.New("HTTP 502")},
+		}
+
+		merged := waitUntilPRMergedViaForge(context.Background(),
+			forgeClient, repo, defaultBranchName, commitHash, nil, "kubeaid-staging",
+		)
+		assert.True(t, merged)
+		assert.Equal(t, 3, forgeClient.getCalls)
+		assert.Equal(t, "kubeaid-staging", forgeClient.opened.Head)
+		assert.Equal(t, defaultBranchName, forgeClient.opened.Base)
+	})
+}
+
+func TestDescribePullRequest(t *testing.T) {
+	assert.Equal(t,
+		"Waiting for PR #4 to be merged  •  review: changes requested  •  CI: failure",
+		describePullRequest(&forge.PullRequest{
+			Number: 4,
+			Review: forge.ReviewStateChangesRequested,
+			Checks: forge.CheckStateFailure,
+		}),
+	)
+}
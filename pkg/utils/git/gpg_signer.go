@@ -49,14 +49,15 @@ func (s *gpgAgentSigner) Sign(message io.Reader) ([]byte, error) {
 }
 
 // CommitSigner returns a go-git Signer suitable for
-// CommitOptions.Signer when ALL of these hold:
+// CommitOptions.Signer when the operator opted into signing
+// (git config commit.gpgsign == "true") with user.signingkey set.
+// gpg.format picks the signer :
 //
-//   - git config commit.gpgsign == "true" (operator opted in);
-//   - git config user.signingkey is set;
-//   - git config gpg.format is empty or "openpgp" (this signer
-//     doesn't handle ssh-format signatures);
-//   - gpg is on PATH;
-//   - a GPG smartcard is plugged in (`gpg --card-status` succeeds).
+//   - empty or "openpgp" : gpgAgentSigner, which additionally needs
+//     gpg on PATH and a GPG smartcard plugged in (`gpg --card-status`
+//     succeeds);
+//   - "ssh" : sshAgentSigner (see ssh_signer.go), which needs the
+//     signing key loaded in the ssh-agent at $SSH_AUTH_SOCK.
 //
 // Returns nil otherwise — caller passes nil to leave the commit
 // unsigned. With no card we stay unsigned rather than silently fall
@@ -75,19 +76,33 @@ func CommitSigner(ctx context.Context) goGit.Signer {
 	// From here on, the operator has opted into signing — log every
 	// gate failure so they can fix their config without sifting
 	// through debug output.
-	if format := gitConfigGlobal(ctx, "gpg.format"); format != "" && format != "openpgp" {
-		slog.InfoContext(ctx,
-			"commit.gpgsign=true but gpg.format is non-openpgp; this signer only handles openpgp — commits will be unsigned",
-			slog.String("gpg.format", format),
-		)
-		return nil
-	}
 	keyID := gitConfigGlobal(ctx, "user.signingkey")
 	if keyID == "" {
 		slog.InfoContext(ctx,
 			"commit.gpgsign=true but user.signingkey unset; commits will be unsigned")
 		return nil
 	}
+
+	switch format := gitConfigGlobal(ctx, "gpg.format"); format {
+	case "", "openpgp":
+		return gpgCommitSigner(ctx, keyID)
+
+	case "ssh":
+		return sshCommitSigner(ctx, keyID)
+
+	default:
+		slog.InfoContext(ctx,
+			"commit.gpgsign=true but gpg.format is neither openpgp nor ssh; commits will be unsigned",
+			slog.String("gpg.format", format),
+		)
+		return nil
+	}
+}
+
+// gpgCommitSigner returns the gpg-agent backed Signer for
+// gpg.format=openpgp, or nil (logging why) when gpg isn't on PATH or
+// no GPG smartcard is plugged in.
+func gpgCommitSigner(ctx context.Context, keyID string) goGit.Signer {
 	if _, err := exec.LookPath("gpg"); err != nil {
 		slog.WarnContext(ctx,
 			"commit.gpgsign=true but gpg not in PATH; commits will be unsigned",
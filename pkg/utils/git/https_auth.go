@@ -0,0 +1,168 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/repository/forge"
+	repourl "github.com/Obmondo/kubeaid-cli/pkg/repository/url"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+)
+
+// defaultHTTPSUsername is the username sent along with an access token, when the operator didn't
+// set one. GitHub wants exactly this for installation access tokens, and GitHub / GitLab accept
+// any non-empty username for personal access tokens.
+const defaultHTTPSUsername = "x-access-token"
+
+// Returns the HTTP basic auth method, for the access token in secrets.yaml (git.token), or else
+// the KUBEAID_CLI_GIT_TOKEN environment variable. Nil (anonymous access) when there's neither.
+func getHTTPSTokenAuthMethod(ctx context.Context) transport.AuthMethod {
+	username, token := HTTPSTokenCredentials()
+	if token == "" {
+		slog.InfoContext(ctx, "No Git access token provided; accessing the Git server anonymously")
+		return nil
+	}
+
+	slog.InfoContext(ctx, "Using Git access token")
+	return &githttp.BasicAuth{Username: username, Password: token}
+}
+
+// HTTPSTokenCredentials returns the username and access token to authenticate over HTTPS with :
+// the ones in secrets.yaml (git.username / git.token), or else the KUBEAID_CLI_GIT_TOKEN
+// environment variable. The token is empty when there's neither.
+func HTTPSTokenCredentials() (username, token string) {
+	return httpsTokenCredentials(config.ParsedSecretsConfig, os.LookupEnv)
+}
+
+// httpsTokenCredentials returns the username and access token to authenticate over HTTPS with.
+// The access token in secrets.yaml wins over the one in the environment.
+func httpsTokenCredentials(secretsConfig *config.SecretsConfig,
+	lookupEnv func(string) (string, bool),
+) (username, token string) {
+	username = defaultHTTPSUsername
+
+	if (secretsConfig != nil) && (secretsConfig.Git != nil) {
+		if secretsConfig.Git.Username != "" {
+			username = secretsConfig.Git.Username
+		}
+		token = secretsConfig.Git.Token
+	}
+
+	if token == "" {
+		token, _ = lookupEnv(constants.EnvNameGitToken)
+	}
+	return username, token
+}
+
+type (
+	// gitHubAppTokenSource is implemented by forge.GitHubAppTokenSource. Faked by tests.
+	gitHubAppTokenSource interface {
+		Token(ctx context.Context) (string, error)
+	}
+
+	// gitHubAppAuth authenticates HTTPS git operations as a GitHub App installation. It asks the
+	// token source for the installation access token per request, so a long running command (one
+	// waiting for a PR to get merged, for example) carries on past the token's 1 hour lifetime.
+	gitHubAppAuth struct {
+		tokenSource gitHubAppTokenSource
+	}
+)
+
+var _ githttp.AuthMethod = (*gitHubAppAuth)(nil)
+
+func (a *gitHubAppAuth) Name() string {
+	return "github-app"
+}
+
+func (a *gitHubAppAuth) String() string {
+	return a.Name()
+}
+
+// SetAuth can't return an error. When minting the installation access token fails, the request
+// goes out unauthenticated, and the git operation fails with
+// transport.ErrAuthenticationRequired : the reason gets logged here.
+func (a *gitHubAppAuth) SetAuth(r *http.Request) {
+	token, err := a.tokenSource.Token(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed getting GitHub App installation access token",
+			logger.Error(err),
+		)
+		return
+	}
+
+	r.SetBasicAuth(defaultHTTPSUsername, token)
+}
+
+// Returns the GitHub App installation auth method. Mints the first installation access token
+// right away, so a misconfigured GitHub App fails here, instead of at the first git operation.
+func getGitHubAppAuthMethod(ctx context.Context, kubeaidConfigURL *repourl.Parsed) transport.AuthMethod {
+	gitConfig := config.ParsedGeneralConfig.Git
+	gitHubAppConfig := gitConfig.HTTPS.GitHubApp
+
+	var privateKey, apiURL string
+	if config.ParsedSecretsConfig.Git != nil {
+		privateKey = config.ParsedSecretsConfig.Git.GitHubAppPrivateKey
+	}
+	if gitConfig.Forge != nil {
+		apiURL = gitConfig.Forge.APIURL
+	}
+
+	tokenSource, err := forge.NewGitHubAppTokenSource(kubeaidConfigURL,
+		apiURL,
+		gitHubAppConfig.AppID, gitHubAppConfig.InstallationID,
+		[]byte(privateKey),
+		gitConfig.CABundle,
+	)
+	assert.AssertErrNil(ctx, err, "Failed constructing GitHub App token source")
+
+	_, err = tokenSource.Token(ctx)
+	assert.AssertErrNil(ctx, err, "Failed minting GitHub App installation access token",
+		slog.Int64("app-id", gitHubAppConfig.AppID),
+		slog.Int64("installation-id", gitHubAppConfig.InstallationID),
+	)
+
+	slog.InfoContext(ctx, "Using GitHub App installation access token")
+	return &gitHubAppAuth{tokenSource}
+}
+
+// authMethodForURL returns the auth method to use, when talking to the given repository : nil
+// (anonymous access) when authMethod can't authenticate to it.
+//
+// The HTTPS credentials belong to the KubeAid Config repository's host. The KubeAid repository is
+// usually the public github.com/Obmondo/kubeaid : sending it a token minted for another Git
+// server would leak the token, and get the clone rejected. SSH auth methods are host key
+// verified, so they're used for any SSH remote.
+func authMethodForURL(url, kubeaidConfigURL string, authMethod transport.AuthMethod) transport.AuthMethod {
+	_, isHTTPAuthMethod := authMethod.(githttp.AuthMethod)
+	if !repourl.UsingHTTPBasedProtocol(url) {
+		if isHTTPAuthMethod {
+			return nil
+		}
+		return authMethod
+	}
+
+	if !isHTTPAuthMethod {
+		return nil
+	}
+
+	parsedURL, err := repourl.Parse(url)
+	if err != nil {
+		return nil
+	}
+	parsedKubeaidConfigURL, err := repourl.Parse(kubeaidConfigURL)
+	if err != nil || (parsedURL.Host != parsedKubeaidConfigURL.Host) {
+		return nil
+	}
+	return authMethod
+}
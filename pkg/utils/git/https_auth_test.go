@@ -0,0 +1,176 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gossh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+func TestHTTPSTokenCredentials(t *testing.T) {
+	env := func(value string) func(string) (string, bool) {
+		return func(name string) (string, bool) {
+			if (name == constants.EnvNameGitToken) && (value != "") {
+				return value, true
+			}
+			return "", false
+		}
+	}
+
+	tests := []struct {
+		name          string
+		secretsConfig *config.SecretsConfig
+		envToken      string
+		wantUsername  string
+		wantToken     string
+	}{
+		{
+			name:         "nothing provided: anonymous",
+			wantUsername: defaultHTTPSUsername,
+		},
+		{
+			name:          "token from secrets.yaml",
+			secretsConfig: &config.SecretsConfig{Git: &config.GitCredentials{Token: "from-secrets"}},
+			wantUsername:  defaultHTTPSUsername,
+			wantToken:     "from-secrets",
+		},
+		{
+			name:         "token from the environment",
+			envToken:     "from-env",
+			wantUsername: defaultHTTPSUsername,
+			wantToken:    "from-env",
+		},
+		{
+			name: "secrets.yaml wins over the environment",
+			secretsConfig: &config.SecretsConfig{Git: &config.GitCredentials{
+				Token:    "from-secrets",
+				Username: "kubeaid-bot",
+			}},
+			envToken:     "from-env",
+			wantUsername: "kubeaid-bot",
+			wantToken:    "from-secrets",
+		},
+		{
+			name:          "forge token alone isn't used for git",
+			secretsConfig: &config.SecretsConfig{Git: &config.GitCredentials{ForgeToken: "forge"}},
+			wantUsername:  defaultHTTPSUsername,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			username, token := httpsTokenCredentials(tc.secretsConfig, env(tc.envToken))
+			assert.Equal(t, tc.wantUsername, username)
+			assert.Equal(t, tc.wantToken, token)
+		})
+	}
+}
+
+func TestAuthMethodForURL(t *testing.T) {
+	const kubeaidConfigURL = "https://gitea.example.com/obmondo/kubeaid-config.git"
+
+	basicAuth := &githttp.BasicAuth{Username: defaultHTTPSUsername, Password: "token"}
+	sshAuth := &gossh.PublicKeys{User: "git"}
+
+	tests := []struct {
+		name       string
+		url        string
+		authMethod transport.AuthMethod
+		want       transport.AuthMethod
+	}{
+		{
+			name:       "HTTPS credentials for the kubeaid-config host",
+			url:        kubeaidConfigURL,
+			authMethod: basicAuth,
+			want:       basicAuth,
+		},
+		{
+			name:       "HTTPS credentials for another repo on the same host",
+			url:        "https://gitea.example.com/obmondo/kubeaid.git",
+			authMethod: basicAuth,
+			want:       basicAuth,
+		},
+		{
+			name:       "HTTPS credentials aren't sent to another host",
+			url:        "https://github.com/Obmondo/kubeaid.git",
+			authMethod: basicAuth,
+		},
+		{
+			name:       "HTTPS credentials can't authenticate an SSH remote",
+			url:        "git@gitea.example.com:obmondo/kubeaid.git",
+			authMethod: basicAuth,
+		},
+		{
+			name:       "SSH credentials can't authenticate an HTTPS remote",
+			url:        "https://github.com/Obmondo/kubeaid.git",
+			authMethod: sshAuth,
+		},
+		{
+			name:       "SSH credentials for an SSH remote",
+			url:        "git@github.com:Obmondo/kubeaid.git",
+			authMethod: sshAuth,
+			want:       sshAuth,
+		},
+		{
+			name: "anonymous stays anonymous",
+			url:  kubeaidConfigURL,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, authMethodForURL(tc.url, kubeaidConfigURL, tc.authMethod))
+		})
+	}
+}
+
+type fakeGitHubAppTokenSource struct {
+	token string
+	err   error
+}
+
+func (f *fakeGitHubAppTokenSource) Token(context.Context) (string, error) {
+	return f.token, f.err
+}
+
+func TestGitHubAppAuthSetAuth(t *testing.T) {
+	tokenSource := &fakeGitHubAppTokenSource{token: "token-1"}
+	authMethod := &gitHubAppAuth{tokenSource}
+
+	request := httptest.NewRequest(http.MethodGet, "https://github.com/Obmondo/kubeaid-config.git/info/refs", nil)
+	authMethod.SetAuth(request)
+
+	username, password, ok := request.BasicAuth()
+	require.True(t, ok)
+	assert.Equal(t, defaultHTTPSUsername, username)
+	assert.Equal(t, "token-1", password)
+
+	// A refreshed token is picked up by the next request.
+	tokenSource.token = "token-2"
+	request = httptest.NewRequest(http.MethodGet, "https://github.com/Obmondo/kubeaid-config.git/info/refs", nil)
+	authMethod.SetAuth(request)
+
+	_, password, _ = request.BasicAuth()
+	assert.Equal(t, "token-2", password)
+
+	// Failing to mint a token leaves the request unauthenticated.
+	tokenSource.err = errors.New("boom")
+	request = httptest.NewRequest(http.MethodGet, "https://github.com/Obmondo/kubeaid-config.git/info/refs", nil)
+	authMethod.SetAuth(request)
+
+	_, _, ok = request.BasicAuth()
+	assert.False(t, ok)
+}
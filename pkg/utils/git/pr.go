@@ -12,6 +12,7 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	goGit "github.com/go-git/go-git/v5"
@@ -20,7 +21,9 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/transport"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/assert"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
 )
 
@@ -63,7 +66,7 @@ func AddCommitAndPushChanges(ctx context.Context,
 	}
 
 	author, attributedMessage := OperatorAttribution(commitMessage)
-	commit, err := commitWithGPGRetry(ctx, workTree, attributedMessage, &goGit.CommitOptions{
+	commit, err := commitWithSigningRetry(ctx, workTree, attributedMessage, &goGit.CommitOptions{
 		Author: author,
 		Signer: CommitSigner(ctx),
 		// AllowEmptyCommits stays false (the default) — the
@@ -97,23 +100,15 @@ func AddCommitAndPushChanges(ctx context.Context,
 		slog.String("commit-hash", commitObject.Hash.String()),
 	)
 
-	// When we didn't push the changes to the default branch, and rather to a feature branch,
-	// log the create-PR URL so the operator has it in their bootstrap log. WaitUntilPRMerged
-	// also surfaces it at the interactive prompt; the slog line here gives a permanent record.
-	if branch != defaultBranchName {
-		slog.InfoContext(ctx, "Create and merge PR please",
-			slog.String("URL", BuildPRCompareURL(repo, defaultBranchName, branch)))
-	}
-
 	return commitObject.Hash
 }
 
-// commitWithGPGRetry wraps workTree.Commit so a transient GPG signing
-// failure — most often "Card error" when the operator's YubiKey is
-// unplugged, locked, or its gpg-agent connection has gone stale —
-// becomes an interactive retry instead of an abort. The operator sees
-// a prompt with the gpg stderr, re-seats / touches the card, and
-// presses ENTER to try again. Any non-signing commit error propagates
+// commitWithSigningRetry wraps workTree.Commit so a transient commit
+// signing failure — most often "Card error" when the operator's
+// YubiKey is unplugged, locked, or its gpg-agent / ssh-agent
+// connection has gone stale — becomes an interactive retry instead of
+// an abort. The operator sees a prompt with the signer's error,
+// re-seats / touches the card, and presses ENTER to try again. Any non-signing commit error propagates
 // straight back to the caller, so genuine configuration mistakes
 // (bad author, dirty index, etc.) still surface via the existing
 // assert path.
@@ -122,7 +117,7 @@ func AddCommitAndPushChanges(ctx context.Context,
 // operator is in control, Ctrl+C aborts. No attempt cap, because the
 // failure mode this exists for ("I knocked the YubiKey out, plug it
 // back in") shouldn't be artificially limited.
-func commitWithGPGRetry(ctx context.Context,
+func commitWithSigningRetry(ctx context.Context,
 	workTree *goGit.Worktree,
 	message string,
 	opts *goGit.CommitOptions,
@@ -136,11 +131,16 @@ func commitWithGPGRetry(ctx context.Context,
 			return hash, nil
 		}
 
-		if !isGPGSigningError(err) {
+		if !isSigningError(err) {
+			return plumbing.ZeroHash, err
+		}
+
+		// Nobody's there to re-seat the card.
+		if answers.Parsed.NonInteractive() {
 			return plumbing.ZeroHash, err
 		}
 
-		slog.WarnContext(ctx, "GPG signing failed; prompting operator to retry",
+		slog.WarnContext(ctx, "Commit signing failed; prompting operator to retry",
 			slog.String("error", err.Error()))
 
 		// Same Pause / save-cursor / Resume / restore-cursor dance as
@@ -148,7 +148,7 @@ func commitWithGPGRetry(ctx context.Context,
 		// and the whole block disappears cleanly on retry success.
 		bar.Pause()
 		fmt.Fprint(os.Stderr, "\033[s")
-		fmt.Fprintln(os.Stderr, renderGPGRetryBox(err))
+		fmt.Fprintln(os.Stderr, renderSigningRetryBox(err))
 		fmt.Fprint(os.Stderr, "> ")
 
 		if readErr := readLineCtx(ctx, stdin); readErr != nil {
@@ -160,28 +160,32 @@ func commitWithGPGRetry(ctx context.Context,
 	}
 }
 
-// isGPGSigningError reports whether err originated in our gpgAgentSigner.
-// We tag every signer failure with the "gpg signing failed" prefix
-// (see gpg_signer.go), so a substring match is a reliable gate without
-// coupling this file to the signer's concrete error type.
-func isGPGSigningError(err error) bool {
-	return err != nil && strings.Contains(err.Error(), "gpg signing failed")
+// isSigningError reports whether err originated in our gpgAgentSigner
+// or sshAgentSigner. We tag every signer failure with the "gpg signing
+// failed" / "ssh signing failed" prefix (see gpg_signer.go and
+// ssh_signer.go), so a substring match is a reliable gate without
+// coupling this file to the signers' concrete error types.
+func isSigningError(err error) bool {
+	return err != nil &&
+		(strings.Contains(err.Error(), "gpg signing failed") ||
+			strings.Contains(err.Error(), "ssh signing failed"))
 }
 
-// renderGPGRetryBox lays the retry prompt out in the same rounded-border
-// style as renderPRMergeBox / the K8s profile picker. The full gpg
-// stderr is included verbatim because the recovery action depends on
-// it: "Card error" → re-seat the card; "No pinentry" → set up
+// renderSigningRetryBox lays the retry prompt out in the same
+// rounded-border style as renderPRMergeBox / the K8s profile picker.
+// The full signer error (gpg stderr, or the ssh-agent's refusal) is
+// included verbatim because the recovery action depends on it:
+// "Card error" → re-seat the card; "No pinentry" → set up
 // pinentry-tty; "Operation cancelled" → don't cancel the PIN prompt
 // this time. Hiding the underlying message would force the operator
 // to dig through the log file.
-func renderGPGRetryBox(err error) string {
+func renderSigningRetryBox(err error) string {
 	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("3"))
 	hintStyle := lipgloss.NewStyle().Faint(true)
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
-		headerStyle.Render("GPG commit signing failed"),
+		headerStyle.Render("Commit signing failed"),
 		err.Error(),
 		"",
 		hintStyle.Render("Re-insert / touch your YubiKey, then press ENTER to retry  •  Ctrl+C to abort"),
@@ -192,11 +196,15 @@ func renderGPGRetryBox(err error) string {
 		Render(content)
 }
 
-// WaitUntilPRMerged blocks until the operator confirms via stdin that
-// they've merged the feature branch into the default branch.
+// WaitUntilPRMerged blocks until the feature branch is merged into the
+// default branch.
+//
+// When a forge API token is configured (secrets.yaml: git.forgeToken),
+// the PR is opened via the forge API and polled until merged — see
+// waitUntilPRMergedViaForge. Otherwise (or when opening the PR fails)
+// the operator confirms the merge via stdin :
 //
-// The operator sees the PR URL (printed by AddCommitAndPushChanges
-// before this is called), goes to their forge, merges, comes back, and
+// The operator sees the PR compare URL, goes to their forge, merges, comes back, and
 // presses ENTER. We then do ONE fetch + ONE commit-presence check to
 // verify the merge actually happened — if it didn't (operator pressed
 // ENTER too early, or merged the wrong branch), we say so and prompt
@@ -209,8 +217,9 @@ func renderGPGRetryBox(err error) string {
 // control of when it fires.
 //
 // SkipPRWorkflow callers never reach this function — they push directly
-// to the default branch. So this function always runs in interactive
-// mode; no headless variant is needed.
+// to the default branch. Non-interactive runs (--non-interactive) have
+// nobody to press ENTER, so without a forge API token they fall back to
+// polling via fetch instead — see waitUntilPRMergedByFetching.
 func WaitUntilPRMerged(ctx context.Context,
 	repo *goGit.Repository,
 	defaultBranchName string,
@@ -218,14 +227,32 @@ func WaitUntilPRMerged(ctx context.Context,
 	auth transport.AuthMethod,
 	branchToBeMerged string,
 ) {
+	if forgeClient := forgeForRepo(ctx, repo); forgeClient != nil {
+		if waitUntilPRMergedViaForge(ctx,
+			forgeClient, repo, defaultBranchName, commitHash, auth, branchToBeMerged,
+		) {
+			return
+		}
+	}
+
 	stdin := bufio.NewReader(os.Stdin)
 	prURL := BuildPRCompareURL(repo, defaultBranchName, branchToBeMerged)
 	bar := progress.FromCtx(ctx)
 
+	// Log the create-PR URL so the operator has it in their bootstrap log. The interactive
+	// prompt below surfaces it too; the slog line gives a permanent record.
+	slog.InfoContext(ctx, "Create and merge PR please", slog.String("URL", prURL))
+
 	slog.InfoContext(ctx, "Waiting for PR merge",
 		slog.String("from-branch", branchToBeMerged),
 		slog.String("to-branch", defaultBranchName),
 	)
+	emitWaitingForPRMerge(ctx, prURL)
+
+	if answers.Parsed.NonInteractive() {
+		waitUntilPRMergedByFetching(ctx, repo, defaultBranchName, commitHash, auth, prURL)
+		return
+	}
 
 	for {
 		// Pause the bar so its 100ms auto-render goroutine can't
@@ -249,36 +276,7 @@ func WaitUntilPRMerged(ctx context.Context,
 		fmt.Fprint(os.Stderr, "\033[u\033[J")
 		bar.Resume()
 
-		releaseFetchTouch := requestTouchIfAuth(ctx,
-			"verify PR merge on "+originShortName(repo), auth,
-		)
-		// Targeted refspec: only fetch the default branch, force-update
-		// it locally. The previous "refs/*:refs/*" form tried to update
-		// every local ref — including refs/heads/<feature-branch>, which
-		// gets auto-deleted on the remote when the operator merges with
-		// "delete branch after merge" enabled. With no '+' prefix, the
-		// can't-update on the now-stale feature-branch ref made go-git
-		// flag the WHOLE fetch as failed with "some refs were not
-		// updated", even though refs/heads/<defaultBranch> updated fine.
-		// Net effect: the operator merges the PR, kubeaid-cli kills
-		// itself one second later. The targeted refspec sidesteps this:
-		// we only need the default branch ref to check commit presence,
-		// not anything else.
-		err := retryGitOperation(ctx, "fetch refs to verify PR merge", func() error {
-			return repo.FetchContext(ctx, &goGit.FetchOptions{
-				Auth: auth,
-				RefSpecs: []goGitConfig.RefSpec{
-					goGitConfig.RefSpec(
-						"+refs/heads/" + defaultBranchName + ":refs/heads/" + defaultBranchName,
-					),
-				},
-				CABundle: config.ParsedGeneralConfig.Git.CABundle,
-			})
-		})
-		releaseFetchTouch()
-		if err != nil && !errors.Is(err, goGit.NoErrAlreadyUpToDate) {
-			assert.AssertErrNil(ctx, err, "Failed determining whether branch is merged or not")
-		}
+		fetchDefaultBranch(ctx, repo, defaultBranchName, auth)
 
 		defaultBranchRef, err := repo.Reference(
 			plumbing.ReferenceName("refs/heads/"+defaultBranchName),
@@ -298,6 +296,99 @@ func WaitUntilPRMerged(ctx context.Context,
 	}
 }
 
+// emitWaitingForPRMerge reports on the JSON event stream, that the run now blocks until the
+// kubeaid-config PR gets merged.
+func emitWaitingForPRMerge(ctx context.Context, prURL string) {
+	events.Emit(events.Event{
+		Type:     events.TypeWaitingForApproval,
+		Phase:    progress.FromCtx(ctx).Phase(),
+		Message:  "Waiting for kubeaid-config PR merge",
+		Approval: &events.Approval{PullRequestURL: prURL},
+	})
+}
+
+// prFetchPollInterval is how often a non-interactive run fetches the default branch, to check
+// whether the PR got merged. Far less frequent than prPollInterval : every fetch is a git
+// operation against the forge, possibly costing a YubiKey touch.
+var prFetchPollInterval = time.Minute
+
+// waitUntilPRMergedByFetching is WaitUntilPRMerged's non-interactive fallback, when there's no
+// forge API to ask : it periodically fetches the default branch until the commit shows up
+// there. Like the ENTER-driven flow, it can't see squash or rebase merges - configure
+// git.forgeToken in secrets.yaml for those. Panics when ctx gets cancelled.
+func waitUntilPRMergedByFetching(ctx context.Context,
+	repo *goGit.Repository,
+	defaultBranchName string,
+	commitHash plumbing.Hash,
+	auth transport.AuthMethod,
+	prURL string,
+) {
+	bar := progress.FromCtx(ctx)
+
+	releaseCaption := bar.InProgress("Waiting for PR to be merged : " + prURL)
+	defer releaseCaption()
+
+	for {
+		select {
+		case <-ctx.Done():
+			assert.AssertErrNil(ctx, ctx.Err(), "Stopped waiting for PR merge")
+
+		case <-time.After(prFetchPollInterval):
+		}
+
+		fetchDefaultBranch(ctx, repo, defaultBranchName, auth)
+
+		defaultBranchRef, err := repo.Reference(
+			plumbing.ReferenceName("refs/heads/"+defaultBranchName),
+			true,
+		)
+		assert.AssertErrNil(ctx, err, "Failed to get default branch ref")
+
+		if isCommitPresentInBranch(repo, commitHash, defaultBranchRef.Hash()) {
+			slog.InfoContext(ctx, "Confirmed PR merged")
+			return
+		}
+	}
+}
+
+// fetchDefaultBranch force-updates the local default branch ref from origin.
+//
+// Targeted refspec: only fetch the default branch, force-update it
+// locally. The previous "refs/*:refs/*" form tried to update every
+// local ref — including refs/heads/<feature-branch>, which gets
+// auto-deleted on the remote when the operator merges with "delete
+// branch after merge" enabled. With no '+' prefix, the can't-update on
+// the now-stale feature-branch ref made go-git flag the WHOLE fetch as
+// failed with "some refs were not updated", even though
+// refs/heads/<defaultBranch> updated fine. Net effect: the operator
+// merges the PR, kubeaid-cli kills itself one second later. The
+// targeted refspec sidesteps this: we only need the default branch
+// ref to check commit presence, not anything else.
+func fetchDefaultBranch(ctx context.Context,
+	repo *goGit.Repository,
+	defaultBranchName string,
+	auth transport.AuthMethod,
+) {
+	releaseFetchTouch := requestTouchIfAuth(ctx,
+		"verify PR merge on "+originShortName(repo), auth,
+	)
+	err := retryGitOperation(ctx, "fetch refs to verify PR merge", func() error {
+		return repo.FetchContext(ctx, &goGit.FetchOptions{
+			Auth: auth,
+			RefSpecs: []goGitConfig.RefSpec{
+				goGitConfig.RefSpec(
+					"+refs/heads/" + defaultBranchName + ":refs/heads/" + defaultBranchName,
+				),
+			},
+			CABundle: config.ParsedGeneralConfig.Git.CABundle,
+		})
+	})
+	releaseFetchTouch()
+	if err != nil && !errors.Is(err, goGit.NoErrAlreadyUpToDate) {
+		assert.AssertErrNil(ctx, err, "Failed determining whether branch is merged or not")
+	}
+}
+
 // readLineCtx reads one line from r, but cancels and returns ctx.Err()
 // if ctx is cancelled before the read completes (e.g., operator hit
 // Ctrl+C). The blocked stdin read goroutine leaks on cancel — fine,
@@ -4,19 +4,26 @@
 package git
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
 )
 
-// TestIsGPGSigningError pins the contract between gpgAgentSigner.Sign
-// (which wraps its failures with the "gpg signing failed" prefix) and
-// commitWithGPGRetry's gate. A typo on either side would silently
-// disable the interactive YubiKey-retry prompt; this test fails loudly
-// instead.
-func TestIsGPGSigningError(t *testing.T) {
+// TestIsSigningError pins the contract between gpgAgentSigner.Sign /
+// sshAgentSigner.Sign (which wrap their failures with the "gpg signing
+// failed" / "ssh signing failed" prefixes) and commitWithSigningRetry's
+// gate. A typo on either side would silently disable the interactive
+// YubiKey-retry prompt; this test fails loudly instead.
+func TestIsSigningError(t *testing.T) {
 	testCases := []struct {
 		name string
 		err  error
@@ -40,6 +47,11 @@ func TestIsGPGSigningError(t *testing.T) {
 				errors.New("gpg signing failed: exit status 2 (gpg stderr: gpg: signing failed: No pinentry)")),
 			want: true,
 		},
+		{
+			name: "ssh signer error from the agent",
+			err:  fmt.Errorf("commit: %w", errors.New("ssh signing failed: agent: failure")),
+			want: true,
+		},
 		{
 			name: "unrelated commit failure",
 			err:  errors.New("worktree: dirty index"),
@@ -54,7 +66,42 @@ func TestIsGPGSigningError(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.want, isGPGSigningError(tc.err))
+			assert.Equal(t, tc.want, isSigningError(tc.err))
 		})
 	}
 }
+
+// Mutates prFetchPollInterval, config.ParsedGeneralConfig — sequential only.
+func TestWaitUntilPRMergedByFetching(t *testing.T) {
+	savedPollInterval := prFetchPollInterval
+	savedGeneralConfig := config.ParsedGeneralConfig
+	t.Cleanup(func() {
+		prFetchPollInterval = savedPollInterval
+		config.ParsedGeneralConfig = savedGeneralConfig
+	})
+	prFetchPollInterval = time.Millisecond
+	config.ParsedGeneralConfig = &config.GeneralConfig{}
+
+	upstreamDir := t.TempDir()
+	upstream, err := goGit.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	commitFiles(t, upstream, "initial", "README.md")
+	upstreamHead, err := upstream.Head()
+	require.NoError(t, err)
+	defaultBranchName := upstreamHead.Name().Short()
+
+	repo, err := goGit.PlainClone(t.TempDir(), false, &goGit.CloneOptions{URL: upstreamDir})
+	require.NoError(t, err)
+
+	// Stands in for the PR getting merged upstream, after the clone.
+	mergedCommitHash := commitFiles(t, upstream, "merged", "k8s/staging/file.yaml")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	waitUntilPRMergedByFetching(ctx, repo, defaultBranchName, mergedCommitHash, nil, "https://forge/compare")
+
+	defaultBranchRef, err := repo.Reference(plumbing.NewBranchReferenceName(defaultBranchName), true)
+	require.NoError(t, err)
+	assert.Equal(t, mergedCommitHash, defaultBranchRef.Hash())
+}
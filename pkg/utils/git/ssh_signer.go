@@ -0,0 +1,261 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	goGit "github.com/go-git/go-git/v5"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+)
+
+// sshsig constants, from OpenSSH's PROTOCOL.sshsig. Git verifies SSH
+// commit signatures with `ssh-keygen -Y verify -n git`, so the
+// namespace is fixed; SHA-512 is what ssh-keygen itself signs with.
+const (
+	sshsigMagic         = "SSHSIG"
+	sshsigVersion       = 1
+	sshsigNamespace     = "git"
+	sshsigHashAlgorithm = "sha512"
+
+	sshsigArmorBegin = "-----BEGIN SSH SIGNATURE-----"
+	sshsigArmorEnd   = "-----END SSH SIGNATURE-----"
+
+	// sshsigArmorLineLength matches ssh-keygen's armored output.
+	sshsigArmorLineLength = 70
+)
+
+// sshAgentSigner implements go-git's Signer interface by asking the
+// operator's ssh-agent ($SSH_AUTH_SOCK, the same agent git auth
+// uses) to sign with the key named by user.signingkey. Like
+// gpgAgentSigner, hardware-backed keys (YubiKey PIV / FIDO sk-keys)
+// work transparently : the agent handles the card, the operator taps
+// when prompted.
+type sshAgentSigner struct {
+	socketPath string
+	publicKey  ssh.PublicKey
+
+	// bar surfaces the "tap your YubiKey" hint while the agent signs.
+	bar *progress.Bar
+}
+
+// sshsigSignedData is the blob the agent actually signs : the commit
+// isn't signed directly, its hash is, wrapped with the namespace so
+// a git signature can't be replayed as, say, a file signature.
+type sshsigSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          string
+}
+
+// sshsigBlob is the signature blob, which gets armored into the
+// commit's gpgsig header.
+type sshsigBlob struct {
+	Version       uint32
+	PublicKey     string
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     string
+}
+
+// Sign satisfies goGit.Signer, returning the armored sshsig signature
+// of the encoded commit object — exactly what `git commit -S` with
+// gpg.format=ssh writes into the commit. Failures are tagged with the
+// "ssh signing failed" prefix, which commitWithSigningRetry gates on.
+func (s *sshAgentSigner) Sign(message io.Reader) ([]byte, error) {
+	hash := sha512.New()
+	if _, err := io.Copy(hash, message); err != nil {
+		return nil, fmt.Errorf("ssh signing failed: reading commit: %w", err)
+	}
+
+	signedData := append([]byte(sshsigMagic), ssh.Marshal(sshsigSignedData{
+		Namespace:     sshsigNamespace,
+		HashAlgorithm: sshsigHashAlgorithm,
+		Hash:          string(hash.Sum(nil)),
+	})...)
+
+	conn, err := net.Dial("unix", s.socketPath) //nolint:gosec // G704: dialing the operator's own SSH agent socket from $SSH_AUTH_SOCK.
+	if err != nil {
+		return nil, fmt.Errorf("ssh signing failed: dialing SSH agent: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	// RSA keys must sign with rsa-sha2-512 : the SHA-1 based ssh-rsa
+	// signature algorithm is refused by ssh-keygen -Y verify.
+	var flags agent.SignatureFlags
+	if s.publicKey.Type() == ssh.KeyAlgoRSA {
+		flags = agent.SignatureFlagRsaSha512
+	}
+
+	releaseTouch := s.bar.RequestYubiKeyTouch("sign commit")
+	signature, err := agent.NewClient(conn).SignWithFlags(s.publicKey, signedData, flags)
+	releaseTouch()
+	if err != nil {
+		return nil, fmt.Errorf("ssh signing failed: %w", err)
+	}
+
+	blob := append([]byte(sshsigMagic), ssh.Marshal(sshsigBlob{
+		Version:       sshsigVersion,
+		PublicKey:     string(s.publicKey.Marshal()),
+		Namespace:     sshsigNamespace,
+		HashAlgorithm: sshsigHashAlgorithm,
+		Signature:     string(ssh.Marshal(signature)),
+	})...)
+	return armorSSHSignature(blob), nil
+}
+
+// armorSSHSignature PEM-style armors the given sshsig blob, the way
+// ssh-keygen -Y sign does.
+func armorSSHSignature(blob []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(blob)
+
+	var armored bytes.Buffer
+	armored.WriteString(sshsigArmorBegin + "\n")
+	for len(encoded) > sshsigArmorLineLength {
+		armored.WriteString(encoded[:sshsigArmorLineLength] + "\n")
+		encoded = encoded[sshsigArmorLineLength:]
+	}
+	armored.WriteString(encoded + "\n")
+	armored.WriteString(sshsigArmorEnd + "\n")
+	return armored.Bytes()
+}
+
+// sshCommitSigner returns the ssh-agent backed Signer for
+// gpg.format=ssh, or nil (logging why) when:
+//
+//   - user.signingkey can't be resolved to an SSH public key;
+//   - $SSH_AUTH_SOCK is unset, or the agent can't be reached;
+//   - the signing key isn't loaded in the agent.
+//
+// Same stance as the GPG path : with the key unavailable we stay
+// unsigned, rather than fall back to some other key.
+func sshCommitSigner(ctx context.Context, signingKey string) goGit.Signer {
+	publicKey, err := parseSSHSigningKey(signingKey)
+	if err != nil {
+		slog.InfoContext(ctx,
+			"commit.gpgsign=true but user.signingkey isn't a usable SSH public key; commits will be unsigned",
+			slog.String("user.signingkey", signingKey),
+			slog.Any("err", err),
+		)
+		return nil
+	}
+
+	socketPath := os.Getenv(constants.EnvNameSSHAuthSock)
+	if socketPath == "" {
+		slog.InfoContext(ctx,
+			"commit.gpgsign=true with gpg.format=ssh but SSH_AUTH_SOCK is unset; commits will be unsigned")
+		return nil
+	}
+
+	loaded, err := sshAgentHasKey(socketPath, publicKey)
+	if err != nil {
+		slog.WarnContext(ctx,
+			"commit.gpgsign=true with gpg.format=ssh but the SSH agent is unreachable; commits will be unsigned",
+			slog.Any("err", err),
+		)
+		return nil
+	}
+	if !loaded {
+		slog.InfoContext(ctx,
+			"commit.gpgsign=true with gpg.format=ssh but user.signingkey isn't loaded in the SSH agent; commits will be unsigned",
+			slog.String("fingerprint", ssh.FingerprintSHA256(publicKey)),
+		)
+		return nil
+	}
+
+	slog.InfoContext(ctx, "SSH-signing kubeaid-cli commits",
+		slog.String("fingerprint", ssh.FingerprintSHA256(publicKey)),
+	)
+	return &sshAgentSigner{
+		socketPath: socketPath,
+		publicKey:  publicKey,
+		bar:        progress.FromCtx(ctx),
+	}
+}
+
+// parseSSHSigningKey resolves user.signingkey to an SSH public key,
+// accepting the same forms git does with gpg.format=ssh :
+//
+//   - `key::<public key>` : the public key literal;
+//   - `ssh-ed25519 AAAA...` : the public key literal, without the
+//     (newer) key:: prefix;
+//   - a path (~ expanded) to the public key file, or to the private
+//     key file, in which case its .pub sibling is read.
+func parseSSHSigningKey(signingKey string) (ssh.PublicKey, error) {
+	if literal, found := strings.CutPrefix(signingKey, "key::"); found {
+		return parseAuthorizedKey([]byte(literal))
+	}
+	if publicKey, err := parseAuthorizedKey([]byte(signingKey)); err == nil {
+		return publicKey, nil
+	}
+
+	keyFilePath := signingKey
+	if relativePath, found := strings.CutPrefix(keyFilePath, "~/"); found {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("resolving home directory: %w", err)
+		}
+		keyFilePath = filepath.Join(homeDir, relativePath)
+	}
+
+	for _, candidatePath := range []string{keyFilePath, keyFilePath + ".pub"} {
+		contents, err := os.ReadFile(candidatePath)
+		if err != nil {
+			continue
+		}
+		if publicKey, err := parseAuthorizedKey(contents); err == nil {
+			return publicKey, nil
+		}
+	}
+	return nil, fmt.Errorf("no SSH public key found at %s or %s.pub", keyFilePath, keyFilePath)
+}
+
+// parseAuthorizedKey parses an SSH public key in authorized_keys
+// format.
+func parseAuthorizedKey(contents []byte) (ssh.PublicKey, error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey(contents)
+	if err != nil {
+		return nil, fmt.Errorf("parsing SSH public key: %w", err)
+	}
+	return publicKey, nil
+}
+
+// sshAgentHasKey reports whether the SSH agent behind socketPath
+// holds the given public key.
+func sshAgentHasKey(socketPath string, publicKey ssh.PublicKey) (bool, error) {
+	conn, err := net.Dial("unix", socketPath) //nolint:gosec // G704: dialing the operator's own SSH agent socket from $SSH_AUTH_SOCK.
+	if err != nil {
+		return false, fmt.Errorf("dialing SSH agent: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	identities, err := agent.NewClient(conn).List()
+	if err != nil {
+		return false, fmt.Errorf("listing SSH agent identities: %w", err)
+	}
+
+	wanted := publicKey.Marshal()
+	for _, identity := range identities {
+		if bytes.Equal(identity.Marshal(), wanted) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+)
+
+// serveTestAgent serves an in-memory SSH agent holding the given
+// private keys, returning its socket path.
+func serveTestAgent(t *testing.T, privateKeys ...any) string {
+	t.Helper()
+
+	keyring := agent.NewKeyring()
+	for _, privateKey := range privateKeys {
+		require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: privateKey}))
+	}
+
+	// Not t.TempDir() : unix socket paths are capped at ~100 bytes.
+	socketDir, err := os.MkdirTemp("", "agent")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(socketDir) })
+
+	socketPath := filepath.Join(socketDir, "agent.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = agent.ServeAgent(keyring, conn)
+			}()
+		}
+	}()
+	return socketPath
+}
+
+// decodeSSHSignature un-armors the given sshsig signature.
+func decodeSSHSignature(t *testing.T, armored []byte) sshsigBlob {
+	t.Helper()
+
+	encoded := strings.TrimSpace(string(armored))
+	encoded = strings.TrimPrefix(encoded, sshsigArmorBegin)
+	encoded = strings.TrimSuffix(encoded, sshsigArmorEnd)
+	blob, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\n", ""))
+	require.NoError(t, err)
+
+	body, found := bytes.CutPrefix(blob, []byte(sshsigMagic))
+	require.True(t, found)
+
+	var parsed sshsigBlob
+	require.NoError(t, ssh.Unmarshal(body, &parsed))
+	return parsed
+}
+
+// verifySSHSignature checks the armored sshsig signature over message,
+// the way `ssh-keygen -Y verify -n git` does, and returns the
+// signature.
+func verifySSHSignature(t *testing.T, publicKey ssh.PublicKey, message, armored []byte) *ssh.Signature {
+	t.Helper()
+
+	parsed := decodeSSHSignature(t, armored)
+	assert.Equal(t, uint32(sshsigVersion), parsed.Version)
+	assert.Equal(t, sshsigNamespace, parsed.Namespace)
+	assert.Equal(t, sshsigHashAlgorithm, parsed.HashAlgorithm)
+	assert.Equal(t, string(publicKey.Marshal()), parsed.PublicKey)
+
+	var signature ssh.Signature
+	require.NoError(t, ssh.Unmarshal([]byte(parsed.Signature), &signature))
+
+	hash := sha512.Sum512(message)
+	signedData := append([]byte(sshsigMagic), ssh.Marshal(sshsigSignedData{
+		Namespace:     sshsigNamespace,
+		HashAlgorithm: sshsigHashAlgorithm,
+		Hash:          string(hash[:]),
+	})...)
+	require.NoError(t, publicKey.Verify(signedData, &signature))
+	return &signature
+}
+
+func TestSSHAgentSignerSign(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	socketPath := serveTestAgent(t, ed25519Key, rsaKey)
+	message := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nchore : init\n")
+
+	for name, privateKey := range map[string]any{"ed25519": ed25519Key, "rsa": rsaKey} {
+		t.Run(name, func(t *testing.T) {
+			sshSigner, err := ssh.NewSignerFromKey(privateKey)
+			require.NoError(t, err)
+			publicKey := sshSigner.PublicKey()
+
+			signer := &sshAgentSigner{socketPath: socketPath, publicKey: publicKey, bar: progress.FromCtx(t.Context())}
+			armored, err := signer.Sign(bytes.NewReader(message))
+			require.NoError(t, err)
+
+			signature := verifySSHSignature(t, publicKey, message, armored)
+			if name == "rsa" {
+				assert.Equal(t, ssh.KeyAlgoRSASHA512, signature.Format)
+			}
+
+			// ssh-keygen is the reference implementation git itself verifies with.
+			if _, err := exec.LookPath("ssh-keygen"); err != nil {
+				return
+			}
+			signatureFile := filepath.Join(t.TempDir(), "commit.sig")
+			require.NoError(t, os.WriteFile(signatureFile, armored, 0o600))
+
+			cmd := exec.Command("ssh-keygen", "-Y", "check-novalidate", "-n", "git", "-s", signatureFile)
+			cmd.Stdin = bytes.NewReader(message)
+			output, err := cmd.CombinedOutput()
+			require.NoError(t, err, string(output))
+		})
+	}
+}
+
+func TestSSHAgentSignerSignWithoutKey(t *testing.T) {
+	_, loadedKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	socketPath := serveTestAgent(t, loadedKey)
+
+	publicKey, err := ssh.NewPublicKey(otherPublicKey)
+	require.NoError(t, err)
+
+	loaded, err := sshAgentHasKey(socketPath, publicKey)
+	require.NoError(t, err)
+	assert.False(t, loaded)
+
+	// A key the agent doesn't hold makes the retry prompt show up, not an abort.
+	signer := &sshAgentSigner{socketPath: socketPath, publicKey: publicKey, bar: progress.FromCtx(t.Context())}
+	_, err = signer.Sign(strings.NewReader("commit"))
+	require.Error(t, err)
+	assert.True(t, isSigningError(err))
+}
+
+func TestParseSSHSigningKey(t *testing.T) {
+	rawPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	publicKey, err := ssh.NewPublicKey(rawPublicKey)
+	require.NoError(t, err)
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))) + " operator@laptop"
+
+	keyDir := t.TempDir()
+	privateKeyFilePath := filepath.Join(keyDir, "id_ed25519")
+	require.NoError(t, os.WriteFile(privateKeyFilePath, []byte("not a public key"), 0o600))
+	require.NoError(t, os.WriteFile(privateKeyFilePath+".pub", []byte(authorizedKey+"\n"), 0o600))
+
+	testCases := []struct {
+		name       string
+		signingKey string
+		wantErr    bool
+	}{
+		{
+			name:       "key:: literal",
+			signingKey: "key::" + authorizedKey,
+		},
+		{
+			name:       "bare literal",
+			signingKey: authorizedKey,
+		},
+		{
+			name:       "public key file",
+			signingKey: privateKeyFilePath + ".pub",
+		},
+		{
+			name:       "private key file with a .pub sibling",
+			signingKey: privateKeyFilePath,
+		},
+		{
+			name:       "GPG key ID",
+			signingKey: "0xDEADBEEFCAFEBABE",
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := parseSSHSigningKey(tc.signingKey)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, publicKey.Marshal(), parsed.Marshal())
+		})
+	}
+}
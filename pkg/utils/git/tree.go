@@ -0,0 +1,65 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ReadBranchFiles returns the contents of every file under dir, as committed at the tip of the
+// given local branch, keyed by the file's path relative to the repository root. The worktree
+// isn't looked at, so uncommitted changes don't show up.
+//
+// A branch without commits yet (freshly initialized repository) or a dir missing from it, both
+// yield no files.
+func ReadBranchFiles(repo *goGit.Repository, branch, dir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolving branch %s: %w", branch, err)
+	}
+
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("getting tip commit of branch %s: %w", branch, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("getting tree of commit %s: %w", commit.Hash, err)
+	}
+
+	if dir != "" {
+		tree, err = tree.Tree(dir)
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting tree of dir %s: %w", dir, err)
+		}
+	}
+
+	err = tree.Files().ForEach(func(file *object.File) error {
+		contents, err := file.Contents()
+		if err != nil {
+			return fmt.Errorf("reading file %s: %w", file.Name, err)
+		}
+		files[path.Join(dir, file.Name)] = []byte(contents)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBranchFiles(t *testing.T) {
+	repoDir := t.TempDir()
+
+	repo, err := goGit.PlainInit(repoDir, false)
+	require.NoError(t, err)
+
+	// No commits yet.
+	files, err := ReadBranchFiles(repo, "master", "k8s/demo")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	workTree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	for filePath, contents := range map[string]string{
+		"README.md": "readme\n",
+		"k8s/demo/argocd-apps/values-cilium.yaml":  "cilium: {}\n",
+		"k8s/demo/kubeaid-cli.general.yaml":        "cluster: {}\n",
+		"k8s/other/argocd-apps/values-cilium.yaml": "cilium: {}\n",
+	} {
+		absolutePath := filepath.Join(repoDir, filePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(absolutePath), 0o750))
+		require.NoError(t, os.WriteFile(absolutePath, []byte(contents), 0o600))
+	}
+	_, err = workTree.Add(".")
+	require.NoError(t, err)
+
+	_, err = workTree.Commit("init", &goGit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	// Uncommitted changes don't show up.
+	require.NoError(t, os.WriteFile(
+		filepath.Join(repoDir, "k8s/demo/kubeaid-cli.general.yaml"), []byte("dirty\n"), 0o600,
+	))
+
+	files, err = ReadBranchFiles(repo, "master", "k8s/demo")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"k8s/demo/argocd-apps/values-cilium.yaml": []byte("cilium: {}\n"),
+		"k8s/demo/kubeaid-cli.general.yaml":       []byte("cluster: {}\n"),
+	}, files)
+
+	files, err = ReadBranchFiles(repo, "master", "k8s/absent")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -42,13 +42,13 @@ func GetRepoDir(parsedURL *repourl.Parsed) string {
 // only path that actually reaches a hardware token.
 //
 // No-op closures returned for:
-//   - HTTPS-routed ops (authMethod nil; CloneRepo nullifies it for
-//     HTTPS URLs in clone.go). The operator's tap can't satisfy a
-//     TLS request, so the prompt would be a lie.
+//   - HTTPS-routed ops (authMethod nil, or an HTTP basic auth
+//     flavour: see https_auth.go). The operator's tap can't satisfy
+//     a TLS request, so the prompt would be a lie.
 //   - File-backed SSH (authMethod is *gossh.PublicKeys, constructed
-//     by gossh.NewPublicKeysFromFile when the operator configured
+//     by gossh.NewPublicKeys when the operator configured
 //     git.privateKeyFilePath). The library signs in-process from the
-//     unencrypted private-key bytes; nothing reaches the smartcard.
+//     unlocked private-key bytes; nothing reaches the smartcard.
 //
 // We can't rely on RequestYubiKeyTouch's own hasYubiKey gate to
 // suppress these cases: that gate fires on "any cardno: identity
@@ -38,6 +38,7 @@ import (
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
 )
@@ -454,8 +455,31 @@ func (m *ArgoCDAppManager) isArgoCDAppHealthy(ctx context.Context, name string)
 		}
 	}
 
-	return argoCDApp.Status.Sync.Status == argoCDV1Aplha1.SyncStatusCodeSynced &&
-		argoCDApp.Status.Health.Status == health.HealthStatusHealthy
+	emitArgoCDAppStatus(ctx, name, argoCDApp)
+
+	return isArgoCDAppStatusHealthy(argoCDApp.Status)
+}
+
+// isArgoCDAppStatusHealthy returns true when the given ArgoCD App status is both Synced and
+// Healthy. Shared with `cluster status`, which reads the App status without an ArgoCD API
+// session.
+func isArgoCDAppStatusHealthy(appStatus argoCDV1Aplha1.ApplicationStatus) bool {
+	return appStatus.Sync.Status == argoCDV1Aplha1.SyncStatusCodeSynced &&
+		appStatus.Health.Status == health.HealthStatusHealthy
+}
+
+// emitArgoCDAppStatus reports the observed sync and health status of the named ArgoCD App, on
+// the JSON event stream.
+func emitArgoCDAppStatus(ctx context.Context, name string, argoCDApp *argoCDV1Aplha1.Application) {
+	events.Emit(events.Event{
+		Type:  events.TypeArgoCDAppStatus,
+		Phase: progress.FromCtx(ctx).Phase(),
+		App: &events.ArgoCDApp{
+			Name:         name,
+			SyncStatus:   string(argoCDApp.Status.Sync.Status),
+			HealthStatus: string(argoCDApp.Status.Health.Status),
+		},
+	})
 }
 
 // syncAllArgoCDApps is the testable implementation of SyncAllArgoCDApps.
@@ -1086,6 +1110,8 @@ func (m *ArgoCDAppManager) isArgoCDAppSynced(ctx context.Context, name string, r
 		}
 	}
 
+	emitArgoCDAppStatus(ctx, name, argoCDApp)
+
 	switch {
 	// Only check that the specified resources are synced.
 	case len(resources) > 0:
@@ -167,6 +167,11 @@ func isCertificateReady(
 		return false, fmt.Sprintf("read error: %v", err)
 	}
 
+	return certificateReadiness(cert)
+}
+
+// certificateReadiness is isCertificateReady, for an already read Certificate.
+func certificateReadiness(cert *unstructured.Unstructured) (bool, string) {
 	conditions, found, err := unstructured.NestedSlice(cert.Object, "status", "conditions")
 	if err != nil || !found {
 		return false, "no status conditions yet"
@@ -0,0 +1,222 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/argoproj/argo-cd/gitops-engine/pkg/health"
+	argoCDV1Aplha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	k8sAPIErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clusterAPIV1Beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+// StatusItem is the health of a single resource, as reported by `cluster status`.
+type StatusItem struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Detail  string `json:"detail,omitempty"`
+	Healthy bool   `json:"healthy"`
+}
+
+// argoCDApplicationListGVK is the GroupVersionKind of ArgoCD's ApplicationList. Read through the
+// unstructured client, so no ArgoCD API session (port-forward + admin login) is needed just to
+// look at the Apps' status.
+var argoCDApplicationListGVK = schema.GroupVersionKind{
+	Group:   "argoproj.io",
+	Version: "v1alpha1",
+	Kind:    "ApplicationList",
+}
+
+// NodeStatuses returns the health of every Node : healthy when Ready.
+func NodeStatuses(ctx context.Context, clusterClient client.Client) ([]StatusItem, error) {
+	nodes := &coreV1.NodeList{}
+	if err := clusterClient.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed listing Nodes: %w", err)
+	}
+
+	items := make([]StatusItem, 0, len(nodes.Items))
+	for i := range nodes.Items {
+		items = append(items, nodeStatusItem(&nodes.Items[i]))
+	}
+	return items, nil
+}
+
+func nodeStatusItem(node *coreV1.Node) StatusItem {
+	status := nodeReadyStatus(node)
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+
+	return StatusItem{
+		Name:    node.Name,
+		Status:  status,
+		Detail:  fmt.Sprintf("%s %s", nodeRoles(node), node.Status.NodeInfo.KubeletVersion),
+		Healthy: nodeReadyStatus(node) == statusReady,
+	}
+}
+
+// CAPIStatuses returns the health of the ClusterAPI Cluster and its Machines, the same snapshot
+// the live provisioning table renders : the Cluster is healthy once Provisioned, a Machine once
+// Running and not failed.
+func CAPIStatuses(ctx context.Context, clusterClient client.Client) ([]StatusItem, error) {
+	rows, _, err := summarizeCAPIStatus(ctx, clusterClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading ClusterAPI status: %w", err)
+	}
+
+	items := make([]StatusItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, capiStatusItem(row))
+	}
+	return items, nil
+}
+
+func capiStatusItem(row capiStatusRow) StatusItem {
+	healthyPhase := string(clusterAPIV1Beta1.MachinePhaseRunning)
+	if strings.HasPrefix(row.Resource, "Cluster/") {
+		healthyPhase = string(clusterAPIV1Beta1.ClusterPhaseProvisioned)
+	}
+
+	return StatusItem{
+		Name:    row.Resource,
+		Status:  row.Phase,
+		Detail:  row.Status,
+		Healthy: (row.Phase == healthyPhase) && !row.Failed,
+	}
+}
+
+// ArgoCDAppStatuses returns the sync and health state of every ArgoCD App : healthy when Synced
+// and Healthy.
+func ArgoCDAppStatuses(ctx context.Context, clusterClient client.Client) ([]StatusItem, error) {
+	apps := &unstructured.UnstructuredList{}
+	apps.SetGroupVersionKind(argoCDApplicationListGVK)
+
+	if err := clusterClient.List(ctx, apps, client.InNamespace(constants.NamespaceArgoCD)); err != nil {
+		return nil, fmt.Errorf("failed listing ArgoCD Apps: %w", err)
+	}
+
+	items := make([]StatusItem, 0, len(apps.Items))
+	for i := range apps.Items {
+		items = append(items, argoCDAppStatusItem(&apps.Items[i]))
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+
+	return items, nil
+}
+
+func argoCDAppStatusItem(app *unstructured.Unstructured) StatusItem {
+	syncStatus, _, _ := unstructured.NestedString(app.Object, "status", "sync", "status")
+	healthStatus, _, _ := unstructured.NestedString(app.Object, "status", "health", "status")
+
+	healthy := isArgoCDAppStatusHealthy(argoCDV1Aplha1.ApplicationStatus{
+		Sync:   argoCDV1Aplha1.SyncStatus{Status: argoCDV1Aplha1.SyncStatusCode(syncStatus)},
+		Health: argoCDV1Aplha1.AppHealthStatus{Status: health.HealthStatusCode(healthStatus)},
+	})
+
+	if syncStatus == "" {
+		syncStatus = statusUnknown
+	}
+	if healthStatus == "" {
+		healthStatus = statusUnknown
+	}
+
+	// The health message says why an App is Degraded / Progressing, and the last operation's
+	// message why its sync failed.
+	detail, _, _ := unstructured.NestedString(app.Object, "status", "health", "message")
+	if phase, _, _ := unstructured.NestedString(app.Object, "status", "operationState", "phase"); (phase == "Failed") || (phase == "Error") {
+		detail, _, _ = unstructured.NestedString(app.Object, "status", "operationState", "message")
+	}
+
+	return StatusItem{
+		Name:    app.GetName(),
+		Status:  syncStatus + " / " + healthStatus,
+		Detail:  truncate(detail),
+		Healthy: healthy,
+	}
+}
+
+// CertificateStatuses returns the readiness of every cert-manager Certificate, with the same
+// failure detail WaitForCertificatesReady reports. No items when cert-manager isn't installed.
+func CertificateStatuses(ctx context.Context, clusterClient client.Client) ([]StatusItem, error) {
+	certs := &unstructured.UnstructuredList{}
+	certs.SetGroupVersionKind(certManagerCertificateGVK.GroupVersion().WithKind("CertificateList"))
+
+	if err := clusterClient.List(ctx, certs); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed listing cert-manager Certificates: %w", err)
+	}
+
+	items := make([]StatusItem, 0, len(certs.Items))
+	for i := range certs.Items {
+		cert := &certs.Items[i]
+
+		ready, detail := certificateReadiness(cert)
+		status := "NotReady"
+		if ready {
+			status = statusReady
+		}
+
+		items = append(items, StatusItem{
+			Name:    cert.GetNamespace() + "/" + cert.GetName(),
+			Status:  status,
+			Detail:  detail,
+			Healthy: ready,
+		})
+	}
+	return items, nil
+}
+
+// SealedSecretsControllerStatus returns the health of the Sealed Secrets controller Deployment :
+// healthy when fully available. Otherwise, the detail carries the same diagnosis the bootstrap
+// prints when the controller won't become healthy.
+func SealedSecretsControllerStatus(ctx context.Context, clusterClient client.Client) (StatusItem, error) {
+	item := StatusItem{
+		Name: constants.NamespaceSealedSecrets + "/" + sealedSecretsControllerDeploymentName,
+	}
+
+	deployment := &appsV1.Deployment{}
+	err := clusterClient.Get(ctx,
+		types.NamespacedName{
+			Namespace: constants.NamespaceSealedSecrets,
+			Name:      sealedSecretsControllerDeploymentName,
+		},
+		deployment,
+	)
+	switch {
+	case k8sAPIErrors.IsNotFound(err):
+		item.Status = "NotFound"
+		return item, nil
+
+	case err != nil:
+		return item, fmt.Errorf("failed getting Sealed Secrets controller Deployment: %w", err)
+	}
+
+	if deploymentFullyAvailable(deployment) {
+		item.Status = "Available"
+		item.Detail = fmt.Sprintf("%d/%d replicas ready",
+			deployment.Status.ReadyReplicas, deploymentDesiredReplicas(deployment),
+		)
+		item.Healthy = true
+		return item, nil
+	}
+
+	item.Status = "Unavailable"
+	item.Detail = diagnoseSealedSecretsController(ctx, clusterClient)
+	return item, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNodeStatusItem(t *testing.T) {
+	t.Parallel()
+
+	newNode := func(ready coreV1.ConditionStatus, unschedulable bool) *coreV1.Node {
+		return &coreV1.Node{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:   "cp-1",
+				Labels: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+			},
+			Spec: coreV1.NodeSpec{Unschedulable: unschedulable},
+			Status: coreV1.NodeStatus{
+				Conditions: []coreV1.NodeCondition{{Type: coreV1.NodeReady, Status: ready}},
+				NodeInfo:   coreV1.NodeSystemInfo{KubeletVersion: "v1.33.1"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		node        *coreV1.Node
+		wantStatus  string
+		wantHealthy bool
+	}{
+		{
+			name:        "ready",
+			node:        newNode(coreV1.ConditionTrue, false),
+			wantStatus:  "Ready",
+			wantHealthy: true,
+		},
+		{
+			name:        "cordoned, but still ready",
+			node:        newNode(coreV1.ConditionTrue, true),
+			wantStatus:  "Ready,SchedulingDisabled",
+			wantHealthy: true,
+		},
+		{
+			name:       "not ready",
+			node:       newNode(coreV1.ConditionFalse, false),
+			wantStatus: "NotReady",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			item := nodeStatusItem(tt.node)
+			assert.Equal(t, "cp-1", item.Name)
+			assert.Equal(t, tt.wantStatus, item.Status)
+			assert.Equal(t, tt.wantHealthy, item.Healthy)
+			assert.Contains(t, item.Detail, "v1.33.1")
+		})
+	}
+}
+
+func TestCAPIStatusItem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		row         capiStatusRow
+		wantHealthy bool
+	}{
+		{
+			name:        "provisioned Cluster",
+			row:         capiStatusRow{Resource: "Cluster/demo", Phase: "Provisioned"},
+			wantHealthy: true,
+		},
+		{
+			name: "provisioning Cluster",
+			row:  capiStatusRow{Resource: "Cluster/demo", Phase: "Provisioning"},
+		},
+		{
+			name:        "running Machine",
+			row:         capiStatusRow{Resource: "Machine/demo-cp-abcde", Phase: "Running", Status: "Ready"},
+			wantHealthy: true,
+		},
+		{
+			name: "failed Machine",
+			row: capiStatusRow{
+				Resource: "Machine/demo-cp-abcde", Phase: "Running", Status: "server deleted", Failed: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			item := capiStatusItem(tt.row)
+			assert.Equal(t, tt.row.Resource, item.Name)
+			assert.Equal(t, tt.row.Phase, item.Status)
+			assert.Equal(t, tt.row.Status, item.Detail)
+			assert.Equal(t, tt.wantHealthy, item.Healthy)
+		})
+	}
+}
+
+func TestArgoCDAppStatusItem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      map[string]any
+		wantStatus  string
+		wantDetail  string
+		wantHealthy bool
+	}{
+		{
+			name: "synced and healthy",
+			status: map[string]any{
+				"sync":   map[string]any{"status": "Synced"},
+				"health": map[string]any{"status": "Healthy"},
+			},
+			wantStatus:  "Synced / Healthy",
+			wantHealthy: true,
+		},
+		{
+			name: "degraded",
+			status: map[string]any{
+				"sync":   map[string]any{"status": "Synced"},
+				"health": map[string]any{"status": "Degraded", "message": "Deployment exceeded its progress deadline"},
+			},
+			wantStatus: "Synced / Degraded",
+			wantDetail: "Deployment exceeded its progress deadline",
+		},
+		{
+			name: "failed sync",
+			status: map[string]any{
+				"sync":   map[string]any{"status": "OutOfSync"},
+				"health": map[string]any{"status": "Healthy"},
+				"operationState": map[string]any{
+					"phase":   "Failed",
+					"message": "one or more objects failed to apply",
+				},
+			},
+			wantStatus: "OutOfSync / Healthy",
+			wantDetail: "one or more objects failed to apply",
+		},
+		{
+			name:       "never reconciled",
+			status:     map[string]any{},
+			wantStatus: "Unknown / Unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := &unstructured.Unstructured{Object: map[string]any{
+				"metadata": map[string]any{"name": "cert-manager"},
+				"status":   tt.status,
+			}}
+
+			item := argoCDAppStatusItem(app)
+			assert.Equal(t, "cert-manager", item.Name)
+			assert.Equal(t, tt.wantStatus, item.Status)
+			assert.Equal(t, tt.wantDetail, item.Detail)
+			assert.Equal(t, tt.wantHealthy, item.Healthy)
+		})
+	}
+}
+
+func TestSealedSecretsControllerStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("healthy", func(t *testing.T) {
+		t.Parallel()
+
+		item, err := SealedSecretsControllerStatus(ctx, newFakeClient(t, healthyDeployment()))
+		require.NoError(t, err)
+		assert.True(t, item.Healthy)
+		assert.Equal(t, "Available", item.Status)
+		assert.Equal(t, "1/1 replicas ready", item.Detail)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		item, err := SealedSecretsControllerStatus(ctx, newFakeClient(t))
+		require.NoError(t, err)
+		assert.False(t, item.Healthy)
+		assert.Equal(t, "NotFound", item.Status)
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		deployment := healthyDeployment()
+		deployment.Status.AvailableReplicas = 0
+		deployment.Status.ReadyReplicas = 0
+
+		item, err := SealedSecretsControllerStatus(ctx, newFakeClient(t, deployment))
+		require.NoError(t, err)
+		assert.False(t, item.Healthy)
+		assert.Equal(t, "Unavailable", item.Status)
+		assert.NotEmpty(t, item.Detail)
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
 )
 
@@ -297,6 +298,77 @@ func summarizeControlPlaneRollout(ctx context.Context, clusterClient client.Clie
 	return []capiStatusRow{row}, settled, nil
 }
 
+// WaitForMachineDeploymentRolloutComplete is WaitForControlPlaneRolloutComplete's counterpart
+// for a node-group : it blocks until the named MachineDeployment has replaced all its
+// old-revision Machines, or until capiWaitTotalTimeout elapses. Returns nil when the
+// MachineDeployment doesn't exist.
+func WaitForMachineDeploymentRolloutComplete(ctx context.Context,
+	clusterClient client.Client,
+	name string,
+) error {
+	return waitForCAPIStableState(ctx,
+		fmt.Sprintf("Waiting for node-group rollout to settle (MachineDeployment %s)", name),
+		fmt.Sprintf("node-group rollout did not settle (MachineDeployment %s still rolling)", name),
+		func(c context.Context) ([]capiStatusRow, bool, error) {
+			return summarizeMachineDeploymentRollout(c, clusterClient, name)
+		},
+		nil,
+	)
+}
+
+// summarizeMachineDeploymentRollout reports whether the MachineDeployment's rolling update (if
+// any) has settled, as one capiStatusRow plus the ready flag for waitForCAPIStableState.
+//
+// settled = ObservedGeneration caught up to Generation, UpdatedReplicas >= Replicas (no
+// old-revision Machines), no unavailable Machines and - when Spec.Replicas is set - Replicas
+// <= desired (no surge). Spec.Replicas is left unset when the cluster-autoscaler owns the
+// node-group's size. Same NotFound / error semantics as summarizeControlPlaneRollout.
+func summarizeMachineDeploymentRollout(ctx context.Context,
+	clusterClient client.Client,
+	name string,
+) ([]capiStatusRow, bool, error) {
+	machineDeployment := &clusterAPIV1Beta1.MachineDeployment{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: GetCapiClusterNamespace(),
+		},
+	}
+	err := GetKubernetesResource(ctx, clusterClient, machineDeployment)
+	switch {
+	case k8sAPIErrors.IsNotFound(err) || meta.IsNoMatchError(err):
+		return nil, true, nil
+
+	case err != nil:
+		return nil, false, err
+	}
+
+	st := machineDeployment.Status
+
+	desiredStr := "?"
+	settled := st.ObservedGeneration >= machineDeployment.Generation &&
+		st.UpdatedReplicas >= st.Replicas &&
+		st.UnavailableReplicas == 0
+	if machineDeployment.Spec.Replicas != nil {
+		desired := *machineDeployment.Spec.Replicas
+		desiredStr = fmt.Sprintf("%d", desired)
+		settled = settled && (st.Replicas <= desired)
+	}
+
+	phase := "RollingUpdate"
+	if settled {
+		phase = "Settled"
+	}
+	row := capiStatusRow{
+		Resource: "MachineDeployment/" + machineDeployment.Name,
+		Phase:    phase,
+		Status: fmt.Sprintf(
+			"replicas=%d/%s updated=%d ready=%d unavailable=%d",
+			st.Replicas, desiredStr, st.UpdatedReplicas, st.ReadyReplicas, st.UnavailableReplicas,
+		),
+	}
+	return []capiStatusRow{row}, settled, nil
+}
+
 // machineProgressPollInterval is how often the post-wait background
 // watcher polls Machine state. Coarser than capiWaitPollInterval (15s)
 // because we're no longer rendering a live table — we're just looking
@@ -370,9 +442,9 @@ func logMachineProgressInBackground(ctx context.Context, mgmtClient client.Clien
 }
 
 // emitMachineDiff compares the previous and current snapshots and
-// emits one slog line per interesting transition. Pure function;
-// extracted so the diff cases can be unit-tested without spinning up
-// a fake-client + goroutine harness.
+// emits one slog line, plus a machine_changed event on the JSON event
+// stream, per interesting transition. Extracted so the diff cases can
+// be unit-tested without spinning up a fake-client + goroutine harness.
 //
 // Three transition classes get logged:
 //
@@ -410,7 +482,20 @@ func emitMachineDiff(ctx context.Context, prev, current map[string]machineSnapsh
 				"Background Machine watcher: Machine reached Ready",
 				slog.String("machine", name),
 			)
+		default:
+			continue
 		}
+
+		events.Emit(events.Event{
+			Type:  events.TypeMachineChanged,
+			Phase: progress.FromCtx(ctx).Phase(),
+			Machine: &events.Machine{
+				Name:          name,
+				Phase:         cur.phase,
+				PreviousPhase: p.phase,
+				Ready:         cur.ready,
+			},
+		})
 	}
 }
 
@@ -4,10 +4,13 @@
 package kubernetes
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -30,6 +33,7 @@ import (
 	"github.com/Obmondo/kubeaid-cli/pkg/config"
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/globals"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
 )
 
 const (
@@ -1819,45 +1823,46 @@ func TestSnapshotMachines(t *testing.T) {
 }
 
 // TestEmitMachineDiff covers the transition classes the background
-// watcher logs. We don't assert on slog output text — slog is global
-// in this codebase and capturing it cleanly per test is more harness
-// than this small helper warrants. Instead each case asserts the
-// helper doesn't panic and the inputs match the contract documented
-// on emitMachineDiff (new Machine / Phase change / Ready flip). The
-// real signal is in the helper's clean structure (a `switch` with
-// three named cases), which this test pins against accidental
-// reordering by exercising each branch.
+// watcher reports (new Machine / Phase change / Ready flip), via the
+// machine_changed events it emits alongside each slog line. Mutates
+// the events package's sinks — sequential only.
 func TestEmitMachineDiff(t *testing.T) {
 	ctx := context.Background()
 	tests := []struct {
 		name    string
 		prev    map[string]machineSnapshot
 		current map[string]machineSnapshot
+		want    []events.Machine
 	}{
 		{
 			name:    "new Machine — no prior snapshot entry",
 			prev:    map[string]machineSnapshot{},
 			current: map[string]machineSnapshot{"cp-2": {phase: "Provisioning", ready: false}},
+			want:    []events.Machine{{Name: "cp-2", Phase: "Provisioning"}},
 		},
 		{
 			name:    "Phase transition — same key, different phase",
 			prev:    map[string]machineSnapshot{"cp-1": {phase: "Provisioning", ready: false}},
 			current: map[string]machineSnapshot{"cp-1": {phase: "Running", ready: false}},
+			want:    []events.Machine{{Name: "cp-1", Phase: "Running", PreviousPhase: "Provisioning"}},
 		},
 		{
 			name:    "Ready flip — same phase, ready went False → True",
 			prev:    map[string]machineSnapshot{"cp-1": {phase: "Running", ready: false}},
 			current: map[string]machineSnapshot{"cp-1": {phase: "Running", ready: true}},
+			want:    []events.Machine{{Name: "cp-1", Phase: "Running", PreviousPhase: "Running", Ready: true}},
 		},
 		{
 			name:    "no change — identical snapshots emit nothing",
 			prev:    map[string]machineSnapshot{"cp-1": {phase: "Running", ready: true}},
 			current: map[string]machineSnapshot{"cp-1": {phase: "Running", ready: true}},
+			want:    []events.Machine{},
 		},
 		{
 			name:    "deletion — Machine missing from current is intentionally not logged",
 			prev:    map[string]machineSnapshot{"worker-deleted": {phase: "Running", ready: true}},
 			current: map[string]machineSnapshot{},
+			want:    []events.Machine{},
 		},
 		{
 			name: "multiple transitions in one tick — every interesting case fires",
@@ -1870,13 +1875,36 @@ func TestEmitMachineDiff(t *testing.T) {
 				"worker-1": {phase: "Running", ready: true},  // Ready flip
 				"worker-2": {phase: "Pending", ready: false}, // new Machine
 			},
+			want: []events.Machine{
+				{Name: "cp-1", Phase: "Running", PreviousPhase: "Provisioning"},
+				{Name: "worker-1", Phase: "Running", PreviousPhase: "Running", Ready: true},
+				{Name: "worker-2", Phase: "Pending"},
+			},
 		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			assert.NotPanics(t, func() {
-				emitMachineDiff(ctx, tc.prev, tc.current)
-			})
+			var stream bytes.Buffer
+			removeSink := events.AddSink(&stream)
+			defer removeSink()
+
+			emitMachineDiff(ctx, tc.prev, tc.current)
+
+			got := []events.Machine{}
+			for _, line := range strings.Split(strings.TrimSpace(stream.String()), "\n") {
+				if line == "" {
+					continue
+				}
+
+				var event events.Event
+				require.NoError(t, json.Unmarshal([]byte(line), &event))
+				require.Equal(t, events.TypeMachineChanged, event.Type)
+				got = append(got, *event.Machine)
+			}
+
+			// Map iteration order isn't stable.
+			slices.SortFunc(got, func(a, b events.Machine) int { return strings.Compare(a.Name, b.Name) })
+			assert.Equal(t, tc.want, got)
 		})
 	}
 }
@@ -2095,3 +2123,110 @@ func TestSummarizeControlPlaneRolloutTransientError(t *testing.T) {
 	assert.False(t, ready)
 	assert.Nil(t, rows)
 }
+
+// makeMD builds a MachineDeployment fixture for the rollout-settled predicate: spec replicas
+// (nil = autoscaler-owned), generation vs observed generation, and the status counters.
+func makeMD(generation int64, specReplicas *int32,
+	observedGen int64, replicas, updated, ready, unavailable int32,
+) *clusterAPIV1Beta1.MachineDeployment {
+	return &clusterAPIV1Beta1.MachineDeployment{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:       testClusterName + "-workers",
+			Namespace:  testCapiClusterNamespace,
+			Generation: generation,
+		},
+		Spec: clusterAPIV1Beta1.MachineDeploymentSpec{
+			Replicas: specReplicas,
+		},
+		Status: clusterAPIV1Beta1.MachineDeploymentStatus{
+			ObservedGeneration:  observedGen,
+			Replicas:            replicas,
+			UpdatedReplicas:     updated,
+			ReadyReplicas:       ready,
+			UnavailableReplicas: unavailable,
+		},
+	}
+}
+
+// TestSummarizeMachineDeploymentRollout exercises the rollout-settled predicate behind
+// WaitForMachineDeploymentRolloutComplete.
+func TestSummarizeMachineDeploymentRollout(t *testing.T) {
+	scheme := newClusterAPITestScheme(t)
+
+	tests := []struct {
+		name             string
+		preExist         []runtime.Object
+		wantReady        bool
+		wantRowCount     int
+		wantPhase        string
+		wantStatusSubstr string
+	}{
+		{
+			name:         "no MachineDeployment -- ready, no rows",
+			wantReady:    true,
+			wantRowCount: 0,
+		},
+		{
+			name:             "fully rolled out -- ready",
+			preExist:         []runtime.Object{makeMD(2, ptr.To(int32(3)), 2, 3, 3, 3, 0)},
+			wantReady:        true,
+			wantRowCount:     1,
+			wantPhase:        "Settled",
+			wantStatusSubstr: "replicas=3/3",
+		},
+		{
+			// The cluster-autoscaler owns the size : no spec replicas to compare against.
+			name:             "autoscaler-owned size, rolled out -- ready",
+			preExist:         []runtime.Object{makeMD(2, nil, 2, 2, 2, 2, 0)},
+			wantReady:        true,
+			wantRowCount:     1,
+			wantPhase:        "Settled",
+			wantStatusSubstr: "replicas=2/?",
+		},
+		{
+			name:             "surge Machine during the roll -- not ready",
+			preExist:         []runtime.Object{makeMD(2, ptr.To(int32(3)), 2, 4, 1, 3, 1)},
+			wantReady:        false,
+			wantRowCount:     1,
+			wantPhase:        "RollingUpdate",
+			wantStatusSubstr: "updated=1",
+		},
+		{
+			name:         "stale observedGeneration -- not ready",
+			preExist:     []runtime.Object{makeMD(3, ptr.To(int32(3)), 2, 3, 3, 3, 0)},
+			wantReady:    false,
+			wantRowCount: 1,
+			wantPhase:    "RollingUpdate",
+		},
+		{
+			name:             "replacement Machine not yet available -- not ready",
+			preExist:         []runtime.Object{makeMD(2, nil, 2, 3, 3, 2, 1)},
+			wantReady:        false,
+			wantRowCount:     1,
+			wantPhase:        "RollingUpdate",
+			wantStatusSubstr: "unavailable=1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := crFake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(tc.preExist...).
+				Build()
+
+			rows, ready, err := summarizeMachineDeploymentRollout(context.Background(),
+				fakeClient, testClusterName+"-workers",
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantReady, ready)
+			assert.Len(t, rows, tc.wantRowCount)
+			if tc.wantRowCount > 0 {
+				assert.Equal(t, tc.wantPhase, rows[0].Phase)
+				if tc.wantStatusSubstr != "" {
+					assert.Contains(t, rows[0].Status, tc.wantStatusSubstr)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli/values"
+)
+
+// HelmValuesValidationArgs points at a rendered values file, and the Helm chart it's meant for.
+type HelmValuesValidationArgs struct {
+	// ChartPath is the local filesystem path to the Helm chart directory.
+	ChartPath string
+	// ValuesFilePath is the local filesystem path to the rendered values file.
+	ValuesFilePath string
+
+	// ReleaseName and Namespace are used for the dry-run render.
+	ReleaseName,
+	Namespace string
+}
+
+// HelmValuesViolation is a single problem found in a rendered values file.
+type HelmValuesViolation struct {
+	ValuesFilePath string
+	// Field is the dotted path to the offending value (e.g. cilium.k8sServicePort). Empty when
+	// the problem isn't tied to a single field, like a failed dry render.
+	Field   string
+	Message string
+}
+
+func (v HelmValuesViolation) String() string {
+	if v.Field == "" {
+		return fmt.Sprintf("%s : %s", filepath.Base(v.ValuesFilePath), v.Message)
+	}
+	return fmt.Sprintf("%s : %s : %s", filepath.Base(v.ValuesFilePath), v.Field, v.Message)
+}
+
+// HelmValuesValidationError is returned by ValidateHelmValues, when at least one of the rendered
+// values files is invalid.
+type HelmValuesValidationError struct {
+	Violations []HelmValuesViolation
+}
+
+func (e *HelmValuesValidationError) Error() string {
+	violations := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		violations = append(violations, violation.String())
+	}
+	return fmt.Sprintf("%d helm values violation(s) :\n%s",
+		len(e.Violations), strings.Join(violations, "\n"),
+	)
+}
+
+// Indirected so validateHelmValuesWithFactory is testable without a chart on disk.
+var renderHelmManifest = HelmRenderManifest
+
+// ValidateHelmValues validates each rendered values file against its Helm chart's
+// values.schema.json (and those of the chart's subcharts), then dry renders the chart with it.
+// Every violation found is collected into a *HelmValuesValidationError, rather than stopping at
+// the first one.
+func ValidateHelmValues(ctx context.Context, args []*HelmValuesValidationArgs) error {
+	// Only LoadChart is used : no REST client needed.
+	return validateHelmValuesWithFactory(ctx, &realHelmFactory{cfg: new(action.Configuration)}, args)
+}
+
+// validateHelmValuesWithFactory is the unit-testable core of ValidateHelmValues.
+func validateHelmValuesWithFactory(ctx context.Context,
+	factory HelmActionFactory,
+	args []*HelmValuesValidationArgs,
+) error {
+	violations := []HelmValuesViolation{}
+	for _, arg := range args {
+		violations = append(violations, validateHelmValuesFile(ctx, factory, arg)...)
+	}
+
+	if len(violations) > 0 {
+		return &HelmValuesValidationError{Violations: violations}
+	}
+	return nil
+}
+
+func validateHelmValuesFile(ctx context.Context,
+	factory HelmActionFactory,
+	args *HelmValuesValidationArgs,
+) []HelmValuesViolation {
+	violation := func(message string) []HelmValuesViolation {
+		return []HelmValuesViolation{{ValuesFilePath: args.ValuesFilePath, Message: message}}
+	}
+
+	vals, err := chartutil.ReadValuesFile(args.ValuesFilePath)
+	if err != nil {
+		return violation(fmt.Sprintf("failed reading values file : %v", err))
+	}
+
+	chrt, err := factory.LoadChart(args.ChartPath)
+	if err != nil {
+		return violation(fmt.Sprintf("failed loading helm chart %q : %v", args.ChartPath, err))
+	}
+
+	// Same as what Helm does before rendering : validate the user supplied values merged onto the
+	// chart's defaults. Otherwise, a required field set only by the chart's values.yaml would get
+	// reported.
+	coalescedValues, err := chartutil.CoalesceValues(chrt, vals)
+	if err != nil {
+		return violation(fmt.Sprintf("failed merging values with the chart defaults : %v", err))
+	}
+
+	if schemaViolations := helmSchemaViolations(chrt, coalescedValues, ""); len(schemaViolations) > 0 {
+		for i := range schemaViolations {
+			schemaViolations[i].ValuesFilePath = args.ValuesFilePath
+		}
+
+		// The dry render would only fail on the same schema violations.
+		return schemaViolations
+	}
+
+	_, err = renderHelmManifest(ctx, &HelmRenderArgs{
+		ChartPath:   args.ChartPath,
+		ReleaseName: args.ReleaseName,
+		Namespace:   args.Namespace,
+		Values:      &values.Options{ValueFiles: []string{args.ValuesFilePath}},
+	})
+	if err != nil {
+		return violation(fmt.Sprintf("dry render failed : %v", err))
+	}
+
+	return nil
+}
+
+// helmSchemaViolations validates the values against the chart's values.schema.json, and
+// recursively, those of its subcharts. Mirrors chartutil.ValidateAgainstSchema, but keeps track
+// of the subchart path, so each violation can be tied to the field in the values file.
+func helmSchemaViolations(chrt *chart.Chart, vals map[string]any, fieldPrefix string) []HelmValuesViolation {
+	violations := []HelmValuesViolation{}
+
+	if chrt.Schema != nil {
+		err := chartutil.ValidateAgainstSingleSchema(vals, chrt.Schema)
+		if err != nil {
+			violations = append(violations, parseHelmSchemaValidationError(err, fieldPrefix)...)
+		}
+	}
+
+	for _, subchart := range chrt.Dependencies() {
+		raw, exists := vals[subchart.Name()]
+		if !exists || raw == nil {
+			continue
+		}
+
+		subchartFieldPrefix := joinHelmValuesField(fieldPrefix, subchart.Name())
+
+		subchartValues, ok := raw.(map[string]any)
+		if !ok {
+			violations = append(violations, HelmValuesViolation{
+				Field:   subchartFieldPrefix,
+				Message: fmt.Sprintf("got %T, want object", raw),
+			})
+			continue
+		}
+
+		violations = append(violations, helmSchemaViolations(subchart, subchartValues, subchartFieldPrefix)...)
+	}
+
+	return violations
+}
+
+// Matches a single violation, in the JSON schema validator's error output.
+// For e.g. : "- at '/nested/a': value must be one of 'x', 'y'".
+var helmSchemaViolationRegex = regexp.MustCompile(`^\s*- at '([^']*)': (.+)$`)
+
+// parseHelmSchemaValidationError breaks down the multi-line error returned by
+// chartutil.ValidateAgainstSingleSchema, into one violation per offending field.
+func parseHelmSchemaValidationError(err error, fieldPrefix string) []HelmValuesViolation {
+	violations := []HelmValuesViolation{}
+	for _, line := range strings.Split(err.Error(), "\n") {
+		matches := helmSchemaViolationRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		// A 'validation failed' line only groups the nested violations that follow it.
+		message := matches[2]
+		if message == "validation failed" {
+			continue
+		}
+
+		field := fieldPrefix
+		for _, segment := range strings.Split(strings.TrimPrefix(matches[1], "/"), "/") {
+			if segment != "" {
+				field = joinHelmValuesField(field, jsonPointerUnescaper.Replace(segment))
+			}
+		}
+
+		violations = append(violations, HelmValuesViolation{Field: field, Message: message})
+	}
+
+	// Not in the expected format (e.g. the schema itself is broken) : report it as is.
+	if len(violations) == 0 {
+		violations = append(violations, HelmValuesViolation{
+			Field:   fieldPrefix,
+			Message: strings.TrimSpace(err.Error()),
+		})
+	}
+
+	return violations
+}
+
+// Undoes the JSON pointer escaping of '~' and '/', in a field name.
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+func joinHelmValuesField(prefix, segment string) string {
+	if prefix == "" {
+		return segment
+	}
+	return prefix + "." + segment
+}
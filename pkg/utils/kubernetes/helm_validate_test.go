@@ -0,0 +1,183 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+)
+
+// Umbrella chart, the way KubeAid wraps an upstream chart : the upstream chart's values live
+// under its name.
+func umbrellaChartWithSchema() *chart.Chart {
+	subchart := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "cilium", Version: "1.0.0"},
+		Values:   map[string]any{"k8sServicePort": 6443},
+		Schema: []byte(`{
+			"type": "object",
+			"properties": {
+				"k8sServicePort": { "type": "integer" },
+				"routingMode": { "enum": ["native", "tunnel"] }
+			}
+		}`),
+	}
+
+	umbrella := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "cilium", Version: "1.0.0"},
+		Values:   map[string]any{},
+	}
+	umbrella.AddDependency(subchart)
+	return umbrella
+}
+
+func writeValuesFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	valuesFilePath := filepath.Join(t.TempDir(), "values-cilium.yaml")
+	require.NoError(t, os.WriteFile(valuesFilePath, []byte(contents), 0o600))
+	return valuesFilePath
+}
+
+func TestHelmSchemaViolations(t *testing.T) {
+	testCases := []struct {
+		name               string
+		values             map[string]any
+		expectedViolations []HelmValuesViolation
+	}{
+		{
+			name:               "valid values",
+			values:             map[string]any{"cilium": map[string]any{"k8sServicePort": 6443, "routingMode": "native"}},
+			expectedViolations: []HelmValuesViolation{},
+		},
+		{
+			name:   "subchart field violations",
+			values: map[string]any{"cilium": map[string]any{"k8sServicePort": "6443", "routingMode": "vxlan"}},
+			expectedViolations: []HelmValuesViolation{
+				{Field: "cilium.k8sServicePort", Message: "got string, want integer"},
+				{Field: "cilium.routingMode", Message: "value must be one of 'native', 'tunnel'"},
+			},
+		},
+		{
+			name:   "subchart values aren't an object",
+			values: map[string]any{"cilium": "enabled"},
+			expectedViolations: []HelmValuesViolation{
+				{Field: "cilium", Message: "got string, want object"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			violations := helmSchemaViolations(umbrellaChartWithSchema(), testCase.values, "")
+			assert.ElementsMatch(t, testCase.expectedViolations, violations)
+		})
+	}
+}
+
+func TestParseHelmSchemaValidationError(t *testing.T) {
+	err := errors.New("- at '': missing property 'name'\n" +
+		"- at '/nested': validation failed\n" +
+		"  - at '/nested/a~1b': value must be one of 'x', 'y'\n")
+
+	assert.Equal(t,
+		[]HelmValuesViolation{
+			{Field: "traefik", Message: "missing property 'name'"},
+			{Field: "traefik.nested.a/b", Message: "value must be one of 'x', 'y'"},
+		},
+		parseHelmSchemaValidationError(err, "traefik"),
+	)
+
+	// Anything else is reported as is.
+	assert.Equal(t,
+		[]HelmValuesViolation{{Message: "invalid schema"}},
+		parseHelmSchemaValidationError(errors.New("invalid schema\n"), ""),
+	)
+}
+
+// Mutates renderHelmManifest — sequential only.
+func TestValidateHelmValuesWithFactory(t *testing.T) {
+	saved := renderHelmManifest
+	t.Cleanup(func() { renderHelmManifest = saved })
+
+	testCases := []struct {
+		name               string
+		valuesFile         string
+		chartErr           error
+		renderErr          error
+		expectRender       bool
+		expectedViolations []string
+	}{
+		{
+			name:         "valid values get dry rendered",
+			valuesFile:   "cilium:\n  k8sServicePort: 6443\n",
+			expectRender: true,
+		},
+		{
+			name:       "schema violation skips the dry render",
+			valuesFile: "cilium:\n  k8sServicePort: \"6443\"\n",
+			expectedViolations: []string{
+				"values-cilium.yaml : cilium.k8sServicePort : got string, want integer",
+			},
+		},
+		{
+			name:         "dry render failure",
+			valuesFile:   "cilium:\n  k8sServicePort: 6443\n",
+			renderErr:    errors.New("nil pointer evaluating .Values.hubble.tls"),
+			expectRender: true,
+			expectedViolations: []string{
+				"values-cilium.yaml : dry render failed : nil pointer evaluating .Values.hubble.tls",
+			},
+		},
+		{
+			name:       "chart fails to load",
+			valuesFile: "cilium: {}\n",
+			chartErr:   errors.New("Chart.yaml file is missing"),
+			expectedViolations: []string{
+				`values-cilium.yaml : failed loading helm chart "argocd-helm-charts/cilium" : Chart.yaml file is missing`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rendered := false
+			renderHelmManifest = func(_ context.Context, args *HelmRenderArgs) (string, error) {
+				rendered = true
+				assert.Equal(t, "cilium", args.ReleaseName)
+				return "", testCase.renderErr
+			}
+
+			factory := &fakeHelmFactory{chartToLoad: umbrellaChartWithSchema(), chartErr: testCase.chartErr}
+
+			err := validateHelmValuesWithFactory(context.Background(), factory, []*HelmValuesValidationArgs{{
+				ChartPath:      "argocd-helm-charts/cilium",
+				ValuesFilePath: writeValuesFile(t, testCase.valuesFile),
+				ReleaseName:    "cilium",
+				Namespace:      "cilium",
+			}})
+			assert.Equal(t, testCase.expectRender, rendered)
+
+			if len(testCase.expectedViolations) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *HelmValuesValidationError
+			require.ErrorAs(t, err, &validationErr)
+
+			violations := []string{}
+			for _, violation := range validationErr.Violations {
+				violations = append(violations, violation.String())
+			}
+			assert.Equal(t, testCase.expectedViolations, violations)
+		})
+	}
+}
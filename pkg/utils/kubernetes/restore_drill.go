@@ -0,0 +1,158 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	veleroV1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	coreV1 "k8s.io/api/core/v1"
+	k8sAPIErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+// ListObjectNames returns the names of every object of the given kind in namespace, sorted.
+func ListObjectNames(ctx context.Context,
+	clusterClient client.Client,
+	gvk schema.GroupVersionKind,
+	namespace string,
+) ([]string, error) {
+	objects := &unstructured.UnstructuredList{}
+	objects.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	if err := clusterClient.List(ctx, objects, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed listing %s in namespace %s: %w", gvk.Kind, namespace, err)
+	}
+
+	names := make([]string, 0, len(objects.Items))
+	for _, object := range objects.Items {
+		names = append(names, object.GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// GetPVCPhases returns the phase of each of the given PersistentVolumeClaims in namespace. ""
+// for one which doesn't exist.
+func GetPVCPhases(ctx context.Context,
+	clusterClient client.Client,
+	namespace string,
+	names []string,
+) (map[string]coreV1.PersistentVolumeClaimPhase, error) {
+	phases := make(map[string]coreV1.PersistentVolumeClaimPhase, len(names))
+	for _, name := range names {
+		pvc := &coreV1.PersistentVolumeClaim{}
+
+		err := clusterClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pvc)
+		switch {
+		case k8sAPIErrors.IsNotFound(err):
+			phases[name] = ""
+
+		case err != nil:
+			return nil, fmt.Errorf("failed getting PVC %s/%s: %w", namespace, name, err)
+
+		default:
+			phases[name] = pvc.Status.Phase
+		}
+	}
+	return phases, nil
+}
+
+// GetPodVolumeRestorePhases returns the phase of each PodVolumeRestore of the given Velero
+// Restore, keyed by <pod>/<volume> : the file-system backed up volumes it restored data into.
+func GetPodVolumeRestorePhases(ctx context.Context,
+	clusterClient client.Client,
+	restoreName string,
+) (map[string]veleroV1.PodVolumeRestorePhase, error) {
+	podVolumeRestores := &veleroV1.PodVolumeRestoreList{}
+
+	err := clusterClient.List(ctx, podVolumeRestores,
+		client.InNamespace(constants.NamespaceVelero),
+		client.MatchingLabels{veleroV1.RestoreNameLabel: restoreName},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing pod volume restores of velero restore %s: %w", restoreName, err)
+	}
+
+	phases := make(map[string]veleroV1.PodVolumeRestorePhase, len(podVolumeRestores.Items))
+	for _, podVolumeRestore := range podVolumeRestores.Items {
+		phases[podVolumeRestore.Spec.Pod.Name+"/"+podVolumeRestore.Spec.Volume] = podVolumeRestore.Status.Phase
+	}
+	return phases, nil
+}
+
+// ReclaimNamespacePersistentVolumes sets the reclaim policy of every PersistentVolume claimed from
+// namespace to Delete, so deleting the namespace's claims deletes them, and their backing disks,
+// too. Restored PersistentVolumes keep the reclaim policy of the backed up ones, which may be
+// Retain. Returns the names of the ones it changed, sorted.
+func ReclaimNamespacePersistentVolumes(ctx context.Context,
+	clusterClient client.Client,
+	namespace string,
+) ([]string, error) {
+	persistentVolumes := &coreV1.PersistentVolumeList{}
+	if err := clusterClient.List(ctx, persistentVolumes); err != nil {
+		return nil, fmt.Errorf("failed listing persistent volumes: %w", err)
+	}
+
+	var names []string
+	for i := range persistentVolumes.Items {
+		persistentVolume := &persistentVolumes.Items[i]
+
+		claimRef := persistentVolume.Spec.ClaimRef
+		if (claimRef == nil) || (claimRef.Namespace != namespace) ||
+			(persistentVolume.Spec.PersistentVolumeReclaimPolicy == coreV1.PersistentVolumeReclaimDelete) {
+			continue
+		}
+
+		patch := client.MergeFrom(persistentVolume.DeepCopy())
+		persistentVolume.Spec.PersistentVolumeReclaimPolicy = coreV1.PersistentVolumeReclaimDelete
+
+		if err := clusterClient.Patch(ctx, persistentVolume, patch); client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("failed setting reclaim policy of persistent volume %s: %w",
+				persistentVolume.Name, err,
+			)
+		}
+		names = append(names, persistentVolume.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// DeleteNamespaceAndWait deletes the given namespace, with everything in it, and waits up to
+// timeout for it to be gone.
+func DeleteNamespaceAndWait(ctx context.Context,
+	clusterClient client.Client,
+	name string,
+	timeout time.Duration,
+) error {
+	namespace := &coreV1.Namespace{
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+	}
+
+	err := clusterClient.Delete(ctx, namespace, client.PropagationPolicy(metaV1.DeletePropagationForeground))
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed deleting namespace %s: %w", name, err)
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, veleroPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			err := clusterClient.Get(ctx, types.NamespacedName{Name: name}, namespace)
+			return k8sAPIErrors.IsNotFound(err), nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("namespace %s still terminating after %s: %w", name, timeout, err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	veleroV1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	coreV1 "k8s.io/api/core/v1"
+	k8sAPIErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	crFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+func TestListObjectNames(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := crFake.NewClientBuilder().
+		WithScheme(newVeleroTestScheme(t)).
+		WithObjects(
+			&coreV1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "shop"}},
+			&coreV1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "api", Namespace: "shop"}},
+			&coreV1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "other", Namespace: "other"}},
+		).
+		Build()
+
+	names, err := ListObjectNames(context.Background(), fakeClient,
+		schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, "shop",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "web"}, names)
+}
+
+func TestGetPVCPhases(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := crFake.NewClientBuilder().
+		WithScheme(newVeleroTestScheme(t)).
+		WithObjects(
+			&coreV1.PersistentVolumeClaim{
+				ObjectMeta: metaV1.ObjectMeta{Name: "data", Namespace: "shop"},
+				Status:     coreV1.PersistentVolumeClaimStatus{Phase: coreV1.ClaimBound},
+			},
+		).
+		Build()
+
+	phases, err := GetPVCPhases(context.Background(), fakeClient, "shop", []string{"data", "gone"})
+	require.NoError(t, err)
+	assert.Equal(t,
+		map[string]coreV1.PersistentVolumeClaimPhase{"data": coreV1.ClaimBound, "gone": ""},
+		phases,
+	)
+}
+
+func TestReclaimNamespacePersistentVolumes(t *testing.T) {
+	t.Parallel()
+
+	persistentVolume := func(name, claimNamespace string, policy coreV1.PersistentVolumeReclaimPolicy) *coreV1.PersistentVolume {
+		return &coreV1.PersistentVolume{
+			ObjectMeta: metaV1.ObjectMeta{Name: name},
+			Spec: coreV1.PersistentVolumeSpec{
+				ClaimRef:                      &coreV1.ObjectReference{Namespace: claimNamespace, Name: "data"},
+				PersistentVolumeReclaimPolicy: policy,
+			},
+		}
+	}
+
+	fakeClient := crFake.NewClientBuilder().
+		WithScheme(newVeleroTestScheme(t)).
+		WithObjects(
+			persistentVolume("pvc-retained", "scratch", coreV1.PersistentVolumeReclaimRetain),
+			persistentVolume("pvc-deleted", "scratch", coreV1.PersistentVolumeReclaimDelete),
+			persistentVolume("pvc-source", "shop", coreV1.PersistentVolumeReclaimRetain),
+			&coreV1.PersistentVolume{ObjectMeta: metaV1.ObjectMeta{Name: "pvc-unclaimed"}},
+		).
+		Build()
+
+	names, err := ReclaimNamespacePersistentVolumes(context.Background(), fakeClient, "scratch")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pvc-retained"}, names)
+
+	reclaimPolicy := func(name string) coreV1.PersistentVolumeReclaimPolicy {
+		persistentVolume := &coreV1.PersistentVolume{}
+		require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Name: name}, persistentVolume))
+		return persistentVolume.Spec.PersistentVolumeReclaimPolicy
+	}
+	assert.Equal(t, coreV1.PersistentVolumeReclaimDelete, reclaimPolicy("pvc-retained"))
+	assert.Equal(t, coreV1.PersistentVolumeReclaimDelete, reclaimPolicy("pvc-deleted"))
+
+	// The source namespace's ones are left alone.
+	assert.Equal(t, coreV1.PersistentVolumeReclaimRetain, reclaimPolicy("pvc-source"))
+}
+
+func TestDeleteNamespaceAndWait(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := crFake.NewClientBuilder().
+		WithScheme(newVeleroTestScheme(t)).
+		WithObjects(&coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "scratch"}}).
+		Build()
+
+	require.NoError(t, DeleteNamespaceAndWait(context.Background(), fakeClient, "scratch", time.Minute))
+
+	err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "scratch"}, &coreV1.Namespace{})
+	assert.True(t, k8sAPIErrors.IsNotFound(err))
+
+	// Already gone.
+	require.NoError(t, DeleteNamespaceAndWait(context.Background(), fakeClient, "scratch", time.Minute))
+}
+
+func TestGetPodVolumeRestorePhases(t *testing.T) {
+	t.Parallel()
+
+	podVolumeRestore := func(name, restoreName, pod, volume string, phase veleroV1.PodVolumeRestorePhase) *veleroV1.PodVolumeRestore {
+		return &veleroV1.PodVolumeRestore{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: constants.NamespaceVelero,
+				Labels:    map[string]string{veleroV1.RestoreNameLabel: restoreName},
+			},
+			Spec: veleroV1.PodVolumeRestoreSpec{
+				Pod:    coreV1.ObjectReference{Name: pod},
+				Volume: volume,
+			},
+			Status: veleroV1.PodVolumeRestoreStatus{Phase: phase},
+		}
+	}
+
+	fakeClient := crFake.NewClientBuilder().
+		WithScheme(newVeleroTestScheme(t)).
+		WithObjects(
+			podVolumeRestore("a", "drill", "db-0", "data", veleroV1.PodVolumeRestorePhaseCompleted),
+			podVolumeRestore("b", "drill", "db-1", "data", veleroV1.PodVolumeRestorePhaseFailed),
+			podVolumeRestore("c", "other", "db-0", "data", veleroV1.PodVolumeRestorePhaseInProgress),
+		).
+		Build()
+
+	phases, err := GetPodVolumeRestorePhases(context.Background(), fakeClient, "drill")
+	require.NoError(t, err)
+	assert.Equal(t,
+		map[string]veleroV1.PodVolumeRestorePhase{
+			"db-0/data": veleroV1.PodVolumeRestorePhaseCompleted,
+			"db-1/data": veleroV1.PodVolumeRestorePhaseFailed,
+		},
+		phases,
+	)
+}
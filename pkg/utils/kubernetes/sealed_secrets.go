@@ -221,12 +221,38 @@ func SealIfPlaintextChanged(ctx context.Context,
 	if err != nil {
 		return err
 	}
+	return sealIfPlaintextChanged(ctx, destinationFilePath, plaintextBytes, certBytes, publicKey)
+}
+
+// SealIfPlaintextChangedWithCert is SealIfPlaintextChanged, sealing with the given controller
+// certificate instead of the one the controller hands out : so it works offline, with the one
+// LoadCachedSealingCert returns.
+func SealIfPlaintextChangedWithCert(ctx context.Context,
+	destinationFilePath string,
+	plaintextBytes, certBytes []byte,
+) error {
+	publicKey, err := parseKeyFn(bytes.NewReader(certBytes))
+	if err != nil {
+		return fmt.Errorf("retrieving sealed secrets controller's public key: %w", err)
+	}
+	return sealIfPlaintextChanged(ctx, destinationFilePath, plaintextBytes, certBytes, publicKey)
+}
+
+func sealIfPlaintextChanged(ctx context.Context,
+	destinationFilePath string,
+	plaintextBytes, certBytes []byte,
+	publicKey *rsa.PublicKey,
+) error {
 	newHash := sha256Hex(plaintextBytes, certBytes)
 
-	if existingHash, err := readKubeaidHashHeader(destinationFilePath); err == nil && existingHash == newHash {
+	if SealedSecretUpToDate(destinationFilePath, plaintextBytes, certBytes) {
 		slog.InfoContext(ctx, "Sealed secret plaintext and controller cert unchanged, skipping re-encryption",
 			slog.String("path", destinationFilePath),
 		)
+
+		// Recorded on a cache hit too, so `secrets diff` knows the fields of files sealed before
+		// it shipped.
+		recordSealedFields(ctx, destinationFilePath, newHash, plaintextBytes)
 		return nil
 	}
 
@@ -238,7 +264,39 @@ func SealIfPlaintextChanged(ctx context.Context,
 	out := make([]byte, 0, len(header)+len(sealedBytes))
 	out = append(out, header...)
 	out = append(out, sealedBytes...)
-	return writeSealedSecretFile(destinationFilePath, out)
+	if err := writeSealedSecretFile(destinationFilePath, out); err != nil {
+		return err
+	}
+
+	recordSealedFields(ctx, destinationFilePath, newHash, plaintextBytes)
+	return nil
+}
+
+// SealedSecretUpToDate reports whether the sealed secret at filePath was sealed from
+// plaintextBytes, using the controller certificate certBytes : i.e. whether
+// SealIfPlaintextChanged would leave it untouched. A missing file, or one without the
+// kubeaid-sha256 header, isn't up to date.
+func SealedSecretUpToDate(filePath string, plaintextBytes, certBytes []byte) bool {
+	existingHash, err := readKubeaidHashHeader(filePath)
+	return (err == nil) && (existingHash == sha256Hex(plaintextBytes, certBytes))
+}
+
+// LoadSealingCert reads the sealed-secrets controller's certificate from the cluster, pointed to
+// by the KUBECONFIG environment variable. Read-only : nothing gets sealed.
+func LoadSealingCert(ctx context.Context) ([]byte, error) {
+	certBytes, _, err := loadSealingCert(ctx)
+	return certBytes, err
+}
+
+// LoadSealingCertFromKubeconfig is LoadSealingCert, against the cluster the given kubeconfig
+// file points to. The KUBECONFIG environment variable is left alone.
+func LoadSealingCertFromKubeconfig(ctx context.Context, kubeconfigPath string) ([]byte, error) {
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath},
+		&clientcmd.ConfigOverrides{},
+	)
+	certBytes, _, err := loadSealingCertUsing(ctx, clientConfig)
+	return certBytes, err
 }
 
 func writeSealedSecretFile(filePath string, data []byte) error {
@@ -280,7 +338,12 @@ func sealPlaintextToBytes(ctx context.Context, plaintextBytes []byte) ([]byte, e
 // (once for hashing, once for sealing), which doubles the bootstrap-time
 // network calls per sealed secret.
 func loadSealingCert(ctx context.Context) ([]byte, *rsa.PublicKey, error) {
-	kubesealClientConfig := newKubesealClientConfigFn()
+	return loadSealingCertUsing(ctx, newKubesealClientConfigFn())
+}
+
+func loadSealingCertUsing(ctx context.Context,
+	kubesealClientConfig clientcmd.ClientConfig,
+) ([]byte, *rsa.PublicKey, error) {
 	certReader, err := openCertFn(ctx, kubesealClientConfig,
 		constants.NamespaceSealedSecrets, constants.SealedSecretsControllerName, "",
 	)
@@ -298,6 +361,10 @@ func loadSealingCert(ctx context.Context) ([]byte, *rsa.PublicKey, error) {
 	if err != nil {
 		return nil, nil, fmt.Errorf("retrieving sealed secrets controller's public key: %w", err)
 	}
+
+	// For the `secrets` commands, which work offline.
+	cacheSealingCert(ctx, certBytes)
+
 	return certBytes, publicKey, nil
 }
 
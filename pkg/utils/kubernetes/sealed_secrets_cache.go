@@ -0,0 +1,309 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/google/renameio"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+	"github.com/Obmondo/kubeaid-cli/pkg/config/clusterdir"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+)
+
+// Files kept per cluster under constants.OutputSealedSecretsDirectory, so the `secrets` commands
+// can work offline.
+const (
+	// cachedSealingCertFileName is the sealed-secrets controller certificate loadSealingCert last
+	// read.
+	cachedSealingCertFileName = "sealing-cert.pem"
+
+	// sealedFieldsFileName maps the kubeaid-sha256 hash of every plaintext kubeaid-cli sealed to
+	// an HMAC of each of its fields (see SealedSecretFields). Like secrets.yaml, it stays local.
+	sealedFieldsFileName = "sealed-field-macs.json"
+)
+
+// sealedFieldsKeyFileName is the random key SealedSecretFields HMACs field values with. Kept under
+// the cluster's root directory (see clusterdir.Root), away from the outputs directory the field
+// HMACs are in : the HMACs alone can't be brute-forced.
+const sealedFieldsKeyFileName = "sealed-fields.key"
+
+// sealedFieldsKeySize is the size of the HMAC-SHA256 key, in bytes.
+const sealedFieldsKeySize = 32
+
+// sealedFieldRecord is what gets recorded about a plaintext kubeaid-cli sealed.
+type sealedFieldRecord struct {
+	// Path of the Sealed Secret file it got sealed into. The record is pruned, once that file no
+	// longer exists, or got re-sealed from a different plaintext.
+	Path string `json:"path"`
+
+	Fields map[string]string `json:"fields"`
+}
+
+// sealedSecretsCacheDirectory returns the cluster's directory under
+// constants.OutputSealedSecretsDirectory, or "" before a cluster config got parsed : there's then
+// nothing to key the cache by, and nothing gets cached.
+func sealedSecretsCacheDirectory() string {
+	clusterName := config.ParsedGeneralConfig.Cluster.Name
+	if clusterName == "" {
+		return ""
+	}
+	return path.Join(constants.OutputSealedSecretsDirectory, clusterName)
+}
+
+// cacheSealingCert caches certBytes for LoadCachedSealingCert. Best-effort : failing to cache only
+// costs the `secrets` commands their offline mode.
+func cacheSealingCert(ctx context.Context, certBytes []byte) {
+	directory := sealedSecretsCacheDirectory()
+	if directory == "" {
+		return
+	}
+
+	filePath := path.Join(directory, cachedSealingCertFileName)
+	if err := writeCacheFile(filePath, certBytes); err != nil {
+		slog.WarnContext(ctx, "Failed caching sealed-secrets controller certificate",
+			slog.String("path", filePath), logger.Error(err),
+		)
+	}
+}
+
+// LoadCachedSealingCert returns the sealed-secrets controller certificate, as last read from the
+// cluster by a bootstrap / sync / plan : without reaching the cluster.
+func LoadCachedSealingCert() ([]byte, error) {
+	directory := sealedSecretsCacheDirectory()
+	if directory == "" {
+		return nil, errors.New("no cluster config parsed")
+	}
+
+	filePath := path.Join(directory, cachedSealingCertFileName)
+	certBytes, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf(
+			"no cached sealed-secrets controller certificate at %s : run `cluster plan` once, with the cluster reachable",
+			filePath,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading cached sealed-secrets controller certificate: %w", err)
+	}
+	return certBytes, nil
+}
+
+// SealedSecretFields flattens the YAML document(s) in plaintextBytes into their leaf fields, like
+// stringData.password, each mapped to an HMAC-SHA256 of its path and value, keyed with key (see
+// SealedFieldsKey). Enough to tell which fields changed between two plaintexts, without keeping
+// their values around. Fields of a multi-document plaintext get prefixed with their document's
+// index, like 1/stringData.url.
+func SealedSecretFields(key, plaintextBytes []byte) (map[string]string, error) {
+	var documents []any
+
+	decoder := yaml.NewDecoder(bytes.NewReader(plaintextBytes))
+	for {
+		var document any
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing sealed secret plaintext: %w", err)
+		}
+		if document != nil {
+			documents = append(documents, document)
+		}
+	}
+
+	fields := map[string]string{}
+	for i, document := range documents {
+		prefix := ""
+		if len(documents) > 1 {
+			prefix = strconv.Itoa(i) + "/"
+		}
+		flattenSealedSecretFields(fields, key, prefix, document)
+	}
+	return fields, nil
+}
+
+func flattenSealedSecretFields(fields map[string]string, key []byte, fieldPath string, value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		separator := "."
+		if (fieldPath == "") || (fieldPath[len(fieldPath)-1] == '/') {
+			separator = ""
+		}
+		for childKey, child := range value {
+			flattenSealedSecretFields(fields, key, fieldPath+separator+childKey, child)
+		}
+
+	case []any:
+		for i, child := range value {
+			flattenSealedSecretFields(fields, key, fmt.Sprintf("%s[%d]", fieldPath, i), child)
+		}
+
+	default:
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(fieldPath))
+		mac.Write([]byte{0})
+		mac.Write(fmt.Append(nil, value))
+		fields[fieldPath] = hex.EncodeToString(mac.Sum(nil))
+	}
+}
+
+// SealedFieldsKey returns the cluster's key for SealedSecretFields, generating it on first use.
+func SealedFieldsKey() ([]byte, error) {
+	clusterName := config.ParsedGeneralConfig.Cluster.Name
+	if clusterName == "" {
+		return nil, errors.New("no cluster config parsed")
+	}
+
+	root, err := clusterdir.Root(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	filePath := path.Join(root, sealedFieldsKeyFileName)
+
+	key, err := os.ReadFile(filePath)
+	switch {
+	case err == nil:
+		if len(key) != sealedFieldsKeySize {
+			return nil, fmt.Errorf("sealed fields key %s is corrupt : delete it", filePath)
+		}
+		return key, nil
+
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("reading sealed fields key: %w", err)
+	}
+
+	key = make([]byte, sealedFieldsKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generating sealed fields key: %w", err)
+	}
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return nil, fmt.Errorf("creating %s: %w", root, err)
+	}
+	if err := renameio.WriteFile(filePath, key, 0o600); err != nil {
+		return nil, fmt.Errorf("writing sealed fields key: %w", err)
+	}
+	return key, nil
+}
+
+// recordSealedFields records the fields of plaintextBytes, sealed into the file at filePath with
+// the kubeaid-sha256 hash, for RecordedSealedFields. Records of Sealed Secret files which no
+// longer exist, or got re-sealed since, are pruned. Best-effort, like cacheSealingCert.
+func recordSealedFields(ctx context.Context, filePath, hash string, plaintextBytes []byte) {
+	directory := sealedSecretsCacheDirectory()
+	if directory == "" {
+		return
+	}
+	recordsFilePath := path.Join(directory, sealedFieldsFileName)
+
+	err := func() error {
+		key, err := SealedFieldsKey()
+		if err != nil {
+			return err
+		}
+
+		fields, err := SealedSecretFields(key, plaintextBytes)
+		if err != nil {
+			return err
+		}
+
+		records, err := readSealedFieldRecords(recordsFilePath)
+		if err != nil {
+			return err
+		}
+		records[hash] = sealedFieldRecord{Path: filePath, Fields: fields}
+		pruneSealedFieldRecords(records)
+
+		contents, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return fmt.Errorf("JSON encoding sealed fields: %w", err)
+		}
+		return writeCacheFile(recordsFilePath, contents)
+	}()
+	if err != nil {
+		slog.WarnContext(ctx, "Failed recording sealed secret fields",
+			slog.String("path", recordsFilePath), logger.Error(err),
+		)
+	}
+}
+
+// pruneSealedFieldRecords drops the records whose Sealed Secret file no longer carries their
+// kubeaid-sha256 hash : because it got deleted, or re-sealed from a different plaintext.
+func pruneSealedFieldRecords(records map[string]sealedFieldRecord) {
+	for hash, record := range records {
+		if headerHash, err := readKubeaidHashHeader(record.Path); (err != nil) || (headerHash != hash) {
+			delete(records, hash)
+		}
+	}
+}
+
+// RecordedSealedFields returns the fields (see SealedSecretFields) of the plaintext kubeaid-cli
+// sealed into a file with the kubeaid-sha256 hash, and whether any got recorded at all.
+func RecordedSealedFields(hash string) (map[string]string, bool, error) {
+	directory := sealedSecretsCacheDirectory()
+	if directory == "" {
+		return nil, false, nil
+	}
+
+	records, err := readSealedFieldRecords(path.Join(directory, sealedFieldsFileName))
+	if err != nil {
+		return nil, false, err
+	}
+	record, ok := records[hash]
+	return record.Fields, ok, nil
+}
+
+func readSealedFieldRecords(filePath string) (map[string]sealedFieldRecord, error) {
+	records := map[string]sealedFieldRecord{}
+
+	contents, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sealed fields: %w", err)
+	}
+
+	if err := json.Unmarshal(contents, &records); err != nil {
+		return nil, fmt.Errorf("parsing sealed fields %s: %w", filePath, err)
+	}
+	return records, nil
+}
+
+func writeCacheFile(filePath string, contents []byte) error {
+	if err := utils.CreateIntermediateDirsForFile(filePath); err != nil {
+		return err
+	}
+	return renameio.WriteFile(filePath, contents, 0o600)
+}
+
+// KubeaidHashHeader returns the hash in the kubeaid-sha256 header of the sealed secret at
+// filePath, or "" when it has none.
+func KubeaidHashHeader(filePath string) (string, error) {
+	return readKubeaidHashHeader(filePath)
+}
+
+// KubeaidHash returns the kubeaid-sha256 header hash of plaintextBytes, sealed with the
+// controller certificate certBytes.
+func KubeaidHash(plaintextBytes, certBytes []byte) string {
+	return sha256Hex(plaintextBytes, certBytes)
+}
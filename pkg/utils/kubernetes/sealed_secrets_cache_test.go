@@ -0,0 +1,128 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config"
+)
+
+func TestSealedSecretFields(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	fields, err := SealedSecretFields(key, []byte(`
+kind: Secret
+stringData:
+  password: hunter2
+  hosts:
+    - a
+    - b
+`))
+	require.NoError(t, err)
+	assert.ElementsMatch(t,
+		[]string{"kind", "stringData.password", "stringData.hosts[0]", "stringData.hosts[1]"},
+		keysOf(fields),
+	)
+
+	// Values never show up, not even hashed without a key.
+	assert.NotContains(t, fields["stringData.password"], "hunter2")
+	assert.NotEqual(t, sha256Hex([]byte("hunter2")), fields["stringData.password"])
+	assert.NotEqual(t,
+		sha256Hex([]byte("stringData.password"), []byte{0}, []byte("hunter2")),
+		fields["stringData.password"],
+	)
+
+	// The same value under different fields hashes differently.
+	fields, err = SealedSecretFields(key, []byte("a: same\nb: same\n"))
+	require.NoError(t, err)
+	assert.NotEqual(t, fields["a"], fields["b"])
+
+	// And so does the same field, under a different key.
+	otherFields, err := SealedSecretFields([]byte("another key"), []byte("a: same\nb: same\n"))
+	require.NoError(t, err)
+	assert.NotEqual(t, fields["a"], otherFields["a"])
+
+	// Multi-document plaintexts get their fields prefixed with the document's index.
+	fields, err = SealedSecretFields(key, []byte("kind: Secret\n---\nkind: Secret\n"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"0/kind", "1/kind"}, keysOf(fields))
+}
+
+// Mutates ParsedGeneralConfig, the environment and the working directory — sequential only.
+func TestRecordedSealedFields(t *testing.T) {
+	original := config.ParsedGeneralConfig.Cluster.Name
+	t.Cleanup(func() { config.ParsedGeneralConfig.Cluster.Name = original })
+
+	t.Chdir(t.TempDir())
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	config.ParsedGeneralConfig.Cluster.Name = "demo"
+
+	ctx := context.Background()
+	plaintext := []byte("stringData:\n  password: hunter2\n")
+
+	_, recorded, err := RecordedSealedFields("hash")
+	require.NoError(t, err)
+	assert.False(t, recorded)
+
+	_, err = LoadCachedSealingCert()
+	require.Error(t, err)
+
+	sealedFilePath := "sealed-secrets/argocd/repo.yaml"
+	require.NoError(t, writeCacheFile(sealedFilePath, []byte(kubeaidHashHeaderPrefix+"hash\n")))
+
+	recordSealedFields(ctx, sealedFilePath, "hash", plaintext)
+	cacheSealingCert(ctx, []byte("cert"))
+
+	fields, recorded, err := RecordedSealedFields("hash")
+	require.NoError(t, err)
+	assert.True(t, recorded)
+
+	key, err := SealedFieldsKey()
+	require.NoError(t, err)
+	assert.FileExists(t, path.Join(configHome, "kubeaid-cli", "demo", sealedFieldsKeyFileName))
+
+	want, err := SealedSecretFields(key, plaintext)
+	require.NoError(t, err)
+	assert.Equal(t, want, fields)
+
+	certBytes, err := LoadCachedSealingCert()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("cert"), certBytes)
+
+	// Re-sealing the file prunes the record of what it got sealed from before, and deleting a
+	// file prunes its record.
+	require.NoError(t, writeCacheFile(sealedFilePath, []byte(kubeaidHashHeaderPrefix+"newhash\n")))
+	recordSealedFields(ctx, sealedFilePath, "newhash", plaintext)
+
+	_, recorded, err = RecordedSealedFields("hash")
+	require.NoError(t, err)
+	assert.False(t, recorded)
+
+	otherSealedFilePath := "sealed-secrets/argocd/other.yaml"
+	require.NoError(t, writeCacheFile(otherSealedFilePath, []byte(kubeaidHashHeaderPrefix+"otherhash\n")))
+	require.NoError(t, os.Remove(sealedFilePath))
+	recordSealedFields(ctx, otherSealedFilePath, "otherhash", plaintext)
+
+	_, recorded, err = RecordedSealedFields("newhash")
+	require.NoError(t, err)
+	assert.False(t, recorded)
+}
+
+func keysOf(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,415 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	ssCrypto "github.com/bitnami-labs/sealed-secrets/pkg/crypto"
+	"github.com/bitnami-labs/sealed-secrets/pkg/kubeseal"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+const (
+	// sealedSecretsRetiredKeyValue replaces the active label value on a rotated-out key. The
+	// controller only loads keys labelled active, so a retired key drops out of its keyring on the
+	// next restart, while the Secret itself stays around : SealedSecrets from old backups can
+	// still be decrypted with it, by relabelling it active.
+	sealedSecretsRetiredKeyValue = "retired"
+
+	// Same key size, validity and certificate CN the controller uses for the keys it generates
+	// itself.
+	sealedSecretsKeySize     = 4096
+	sealedSecretsKeyValidity = 10 * 365 * 24 * time.Hour
+	sealedSecretsKeyCN       = "sealed-secret"
+
+	// sealedSecretsKeyNamePrefix is the GenerateName of a key Secret, matching the controller's
+	// default --key-prefix.
+	sealedSecretsKeyNamePrefix = "sealed-secrets-key"
+
+	// argoCDRefreshAnnotation asks the ArgoCD application controller to compare an App against
+	// its git revision right away. ArgoCD removes it once the refresh is done.
+	argoCDRefreshAnnotation = "argocd.argoproj.io/refresh"
+)
+
+// sealedSecretListGVK is the GroupVersionKind of the Sealed Secrets controller's
+// SealedSecretList, read through the unstructured client.
+var sealedSecretListGVK = schema.GroupVersionKind{
+	Group:   "bitnami.com",
+	Version: "v1alpha1",
+	Kind:    "SealedSecretList",
+}
+
+// reEncryptFn is the test seam for ResealSealedSecretFile's controller side re-encryption.
+var reEncryptFn = func(ctx context.Context, clientConfig kubeseal.ClientConfig,
+	in io.Reader, out io.Writer,
+) error {
+	return kubeseal.ReEncryptSealedSecret(ctx, clientConfig,
+		constants.NamespaceSealedSecrets, constants.SealedSecretsControllerName,
+		"yaml", in, out, scheme.Codecs,
+	)
+}
+
+// CreateSealedSecretsKey generates a new sealing key pair, and stores it as an active key Secret
+// in the sealed-secrets namespace. The controller only picks it up once restarted (see
+// RestartSealedSecretsController) : being the newest key then, it's the one everything gets
+// sealed with from there on. Returns the key Secret's name, and the key's public half.
+func CreateSealedSecretsKey(ctx context.Context, c client.Client) (string, *rsa.PublicKey, error) {
+	privateKey, cert, err := ssCrypto.GeneratePrivateKeyAndCert(sealedSecretsKeySize,
+		sealedSecretsKeyValidity, sealedSecretsKeyCN,
+	)
+	if err != nil {
+		return "", nil, fmt.Errorf("generating sealed-secrets key pair: %w", err)
+	}
+
+	secret := &coreV1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{
+			GenerateName: sealedSecretsKeyNamePrefix,
+			Namespace:    constants.NamespaceSealedSecrets,
+			Labels: map[string]string{
+				sealedSecretsActiveKeyLabel: sealedSecretsActiveKeyValue,
+			},
+		},
+		Type: coreV1.SecretTypeTLS,
+		Data: map[string][]byte{
+			coreV1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+			}),
+			coreV1.TLSCertKey: pem.EncodeToMemory(&pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: cert.Raw,
+			}),
+		},
+	}
+	if err := c.Create(ctx, secret); err != nil {
+		return "", nil, fmt.Errorf("creating sealed-secrets key Secret: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Created sealed-secrets key", slog.String("name", secret.Name))
+	return secret.Name, &privateKey.PublicKey, nil
+}
+
+// RestartSealedSecretsController rolls the controller Deployment, the same way
+// `kubectl rollout restart` does, and waits for the rollout to complete. The controller reads its
+// keyring only on startup.
+func RestartSealedSecretsController(ctx context.Context, c client.Client) error {
+	deployment := &appsV1.Deployment{}
+	deploymentKey := types.NamespacedName{
+		Namespace: constants.NamespaceSealedSecrets,
+		Name:      sealedSecretsControllerDeploymentName,
+	}
+	if err := c.Get(ctx, deploymentKey, deployment); err != nil {
+		return fmt.Errorf("reading sealed-secrets controller Deployment: %w", err)
+	}
+
+	patch := client.MergeFrom(deployment.DeepCopy())
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
+	if err := c.Patch(ctx, deployment, patch); err != nil {
+		return fmt.Errorf("restarting sealed-secrets controller Deployment: %w", err)
+	}
+	restartedGeneration := deployment.Generation
+
+	err := wait.PollUntilContextTimeout(ctx, healthPollInterval, healthPollTimeoutForTest, true,
+		func(ctx context.Context) (bool, error) {
+			if err := c.Get(ctx, deploymentKey, deployment); err != nil {
+				return false, fmt.Errorf("reading sealed-secrets controller Deployment: %w", err)
+			}
+			return (deployment.Status.ObservedGeneration >= restartedGeneration) &&
+				deploymentRolledOut(deployment), nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("waiting for sealed-secrets controller restart:\n%s",
+			diagnoseSealedSecretsController(ctx, c),
+		)
+	}
+	return nil
+}
+
+// deploymentRolledOut reports whether every replica of dep runs its latest pod template, and is
+// available. Unlike deploymentFullyAvailable alone, it doesn't mistake the old pods for the new
+// ones mid-rollout.
+func deploymentRolledOut(dep *appsV1.Deployment) bool {
+	desired := deploymentDesiredReplicas(dep)
+	return deploymentFullyAvailable(dep) &&
+		(dep.Status.UpdatedReplicas >= desired) &&
+		(dep.Status.Replicas == dep.Status.UpdatedReplicas)
+}
+
+// WaitForSealingCert waits until the controller, pointed to by the KUBECONFIG environment
+// variable, hands out the certificate of publicKey : i.e. seals with the key just created. The
+// controller Service can briefly keep routing to a terminating pod right after a restart.
+func WaitForSealingCert(ctx context.Context, publicKey *rsa.PublicKey) ([]byte, error) {
+	var certBytes []byte
+	err := wait.PollUntilContextTimeout(ctx, healthPollInterval, healthPollTimeoutForTest, true,
+		func(ctx context.Context) (bool, error) {
+			servedCertBytes, servedPublicKey, err := loadSealingCert(ctx)
+			if err != nil {
+				slog.DebugContext(ctx, "Sealing cert not readable yet", slog.String("error", err.Error()))
+				return false, nil
+			}
+			certBytes = servedCertBytes
+			return servedPublicKey.Equal(publicKey), nil
+		},
+	)
+	if err != nil {
+		return nil, errors.New("sealed-secrets controller isn't sealing with the new key")
+	}
+	return certBytes, nil
+}
+
+/*
+ResealSealedSecretFile re-seals the SealedSecret at filePath with the controller's newest key.
+
+When plaintextBytes is known, and the file's kubeaid-sha256 header proves it was sealed from exactly
+those bytes with previousCertBytes, the plaintext gets sealed afresh : the header then gets
+recomputed against the new certificate, so the next bootstrap keeps treating the file as up to
+date.
+
+Otherwise (a hand-added SealedSecret, or one rendered from values which aren't available outside a
+bootstrap), the controller re-encrypts it in place, and any header the file carries is kept as is.
+*/
+func ResealSealedSecretFile(ctx context.Context,
+	filePath string,
+	previousCertBytes, plaintextBytes []byte,
+) error {
+	contents, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("reading sealed secret file: %w", err)
+	}
+
+	header, body := splitKubeaidHashHeader(contents)
+
+	if (plaintextBytes != nil) && (header != "") &&
+		(header == kubeaidHashHeaderPrefix+sha256Hex(plaintextBytes, previousCertBytes)) {
+		return SealIfPlaintextChanged(ctx, filePath, plaintextBytes)
+	}
+
+	var reEncrypted bytes.Buffer
+	if err := reEncryptFn(ctx, newKubesealClientConfigFn(), bytes.NewReader(body), &reEncrypted); err != nil {
+		return fmt.Errorf("re-encrypting sealed secret: %w", err)
+	}
+
+	out := make([]byte, 0, len(header)+1+reEncrypted.Len())
+	if header != "" {
+		out = append(out, header+"\n"...)
+	}
+	out = append(out, reEncrypted.Bytes()...)
+	return writeSealedSecretFile(filePath, out)
+}
+
+// splitKubeaidHashHeader splits contents into its leading kubeaid-sha256 header line (without the
+// line break, "" when there's none) and the rest.
+func splitKubeaidHashHeader(contents []byte) (string, []byte) {
+	if !bytes.HasPrefix(contents, []byte(kubeaidHashHeaderPrefix)) {
+		return "", contents
+	}
+
+	header, body, _ := bytes.Cut(contents, []byte("\n"))
+	return string(header), body
+}
+
+// WaitForSealedSecretsSynced asks ArgoCD to refresh every App managing a SealedSecret, and waits
+// until ArgoCD reports each of those SealedSecrets Synced, and the controller has unsealed every
+// SealedSecret's latest generation. On timeout, the error lists what's still pending.
+func WaitForSealedSecretsSynced(ctx context.Context, c client.Client, timeout time.Duration) error {
+	apps := &unstructured.UnstructuredList{}
+	apps.SetGroupVersionKind(argoCDApplicationListGVK)
+	if err := c.List(ctx, apps, client.InNamespace(constants.NamespaceArgoCD)); err != nil {
+		return fmt.Errorf("failed listing ArgoCD Apps: %w", err)
+	}
+
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		if !argoCDAppManagesSealedSecrets(app) {
+			continue
+		}
+
+		patch := client.MergeFrom(app.DeepCopy())
+		annotations := app.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[argoCDRefreshAnnotation] = "normal"
+		app.SetAnnotations(annotations)
+		if err := c.Patch(ctx, app, patch); err != nil {
+			return fmt.Errorf("failed refreshing ArgoCD App %s: %w", app.GetName(), err)
+		}
+	}
+
+	var pending []string
+	err := wait.PollUntilContextTimeout(ctx, healthPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			apps := &unstructured.UnstructuredList{}
+			apps.SetGroupVersionKind(argoCDApplicationListGVK)
+			if err := c.List(ctx, apps, client.InNamespace(constants.NamespaceArgoCD)); err != nil {
+				return false, fmt.Errorf("failed listing ArgoCD Apps: %w", err)
+			}
+
+			sealedSecrets := &unstructured.UnstructuredList{}
+			sealedSecrets.SetGroupVersionKind(sealedSecretListGVK)
+			if err := c.List(ctx, sealedSecrets); err != nil {
+				return false, fmt.Errorf("failed listing SealedSecrets: %w", err)
+			}
+
+			pending = sealedSecretsPendingSync(apps.Items, sealedSecrets.Items)
+			if len(pending) > 0 {
+				slog.InfoContext(ctx, "Waiting for SealedSecrets to sync", slog.Int("pending", len(pending)))
+			}
+			return len(pending) == 0, nil
+		},
+	)
+	if err != nil {
+		if len(pending) == 0 {
+			return err
+		}
+		return fmt.Errorf("SealedSecrets not synced yet :\n  %s", strings.Join(pending, "\n  "))
+	}
+	return nil
+}
+
+// argoCDAppManagesSealedSecrets reports whether app has a SealedSecret among its resources.
+func argoCDAppManagesSealedSecrets(app *unstructured.Unstructured) bool {
+	resources, _, _ := unstructured.NestedSlice(app.Object, "status", "resources")
+	for _, resource := range resources {
+		resource, ok := resource.(map[string]any)
+		if ok && (resource["kind"] == "SealedSecret") {
+			return true
+		}
+	}
+	return false
+}
+
+// sealedSecretsPendingSync returns, sorted, what keeps the SealedSecrets from being synced :
+//
+//   - an App managing SealedSecrets, which ArgoCD hasn't refreshed yet.
+//   - a SealedSecret ArgoCD reports as anything but Synced.
+//   - a SealedSecret whose latest generation the controller hasn't unsealed.
+func sealedSecretsPendingSync(apps, sealedSecrets []unstructured.Unstructured) []string {
+	var pending []string
+
+	for i := range apps {
+		app := &apps[i]
+		if !argoCDAppManagesSealedSecrets(app) {
+			continue
+		}
+
+		if _, refreshing := app.GetAnnotations()[argoCDRefreshAnnotation]; refreshing {
+			pending = append(pending, fmt.Sprintf("App %s : refresh pending", app.GetName()))
+			continue
+		}
+
+		resources, _, _ := unstructured.NestedSlice(app.Object, "status", "resources")
+		for _, resource := range resources {
+			resource, ok := resource.(map[string]any)
+			if !ok || (resource["kind"] != "SealedSecret") {
+				continue
+			}
+
+			if status, _ := resource["status"].(string); status != "Synced" {
+				if status == "" {
+					status = statusUnknown
+				}
+				pending = append(pending, fmt.Sprintf("SealedSecret %s/%s : %s in App %s",
+					resource["namespace"], resource["name"], status, app.GetName(),
+				))
+			}
+		}
+	}
+
+	for i := range sealedSecrets {
+		sealedSecret := &sealedSecrets[i]
+
+		synced, message := sealedSecretUnsealed(sealedSecret)
+		if !synced {
+			pending = append(pending, fmt.Sprintf("SealedSecret %s/%s : not unsealed%s",
+				sealedSecret.GetNamespace(), sealedSecret.GetName(), message,
+			))
+		}
+	}
+
+	sort.Strings(pending)
+	return pending
+}
+
+// sealedSecretUnsealed reports whether the controller has unsealed sealedSecret's latest
+// generation, going by its Synced condition. Otherwise, also returns the condition's message,
+// formatted as a suffix.
+func sealedSecretUnsealed(sealedSecret *unstructured.Unstructured) (bool, string) {
+	observedGeneration, _, _ := unstructured.NestedInt64(sealedSecret.Object, "status", "observedGeneration")
+	if observedGeneration < sealedSecret.GetGeneration() {
+		return false, ""
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(sealedSecret.Object, "status", "conditions")
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]any)
+		if !ok || (condition["type"] != "Synced") {
+			continue
+		}
+
+		if condition["status"] == "True" {
+			return true, ""
+		}
+		if message, _ := condition["message"].(string); message != "" {
+			return false, " (" + truncate(message) + ")"
+		}
+		return false, ""
+	}
+	return false, ""
+}
+
+// RetireSealedSecretsKeys relabels every active sealed-secrets key, other than keepName, as
+// retired (see sealedSecretsRetiredKeyValue). The controller keeps using them until restarted.
+// Returns the names of the retired keys.
+func RetireSealedSecretsKeys(ctx context.Context, c client.Client, keepName string) ([]string, error) {
+	keys, err := listActiveSealedSecretsKeys(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("listing sealed-secrets keys: %w", err)
+	}
+
+	var retired []string
+	for i := range keys {
+		key := &keys[i]
+		if key.Name == keepName {
+			continue
+		}
+
+		patch := client.MergeFrom(key.DeepCopy())
+		key.Labels[sealedSecretsActiveKeyLabel] = sealedSecretsRetiredKeyValue
+		if err := c.Patch(ctx, key, patch); err != nil {
+			return retired, fmt.Errorf("retiring sealed-secrets key %s: %w", key.Name, err)
+		}
+
+		slog.InfoContext(ctx, "Retired sealed-secrets key", slog.String("name", key.Name))
+		retired = append(retired, key.Name)
+	}
+	return retired, nil
+}
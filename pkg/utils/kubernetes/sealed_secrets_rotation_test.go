@@ -0,0 +1,247 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"crypto/rsa"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sealedSecretsV1Aplha1 "github.com/bitnami-labs/sealed-secrets/pkg/apis/sealedsecrets/v1alpha1"
+	"github.com/bitnami-labs/sealed-secrets/pkg/kubeseal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+// Mutates the kubeseal seams — sequential only.
+func TestResealSealedSecretFile(t *testing.T) {
+	origNewKubesealClientConfig := newKubesealClientConfigFn
+	origOpenCert := openCertFn
+	origParseKey := parseKeyFn
+	origSeal := sealFn
+	origReEncrypt := reEncryptFn
+	t.Cleanup(func() {
+		newKubesealClientConfigFn = origNewKubesealClientConfig
+		openCertFn = origOpenCert
+		parseKeyFn = origParseKey
+		sealFn = origSeal
+		reEncryptFn = origReEncrypt
+	})
+
+	const (
+		previousCert = "previous-cert"
+		newCert      = "new-cert"
+	)
+	plaintext := []byte("kind: Secret\n")
+
+	newKubesealClientConfigFn = func() clientcmd.ClientConfig { return nil }
+	openCertFn = func(_ context.Context, _ kubeseal.ClientConfig, _, _, _ string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(newCert)), nil
+	}
+	parseKeyFn = func(_ io.Reader) (*rsa.PublicKey, error) { return &rsa.PublicKey{}, nil }
+
+	var sealCalls, reEncryptCalls int
+	sealFn = func(_ kubeseal.ClientConfig, _ string, _ io.Reader, out io.Writer,
+		_ *rsa.PublicKey, _ sealedSecretsV1Aplha1.SealingScope,
+	) error {
+		sealCalls++
+		_, err := out.Write([]byte("sealed-with-new-key\n"))
+		return err
+	}
+	reEncryptFn = func(_ context.Context, _ kubeseal.ClientConfig, in io.Reader, out io.Writer) error {
+		reEncryptCalls++
+		body, err := io.ReadAll(in)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write([]byte("re-encrypted " + string(body)))
+		return err
+	}
+
+	header := func(parts ...[]byte) string {
+		return kubeaidHashHeaderPrefix + sha256Hex(parts...) + "\n"
+	}
+
+	tests := []struct {
+		name string
+
+		existing  string
+		plaintext []byte
+
+		wantSealCalls, wantReEncryptCalls int
+		want                              string
+	}{
+		{
+			name:          "verified plaintext gets sealed afresh, with a recomputed header",
+			existing:      header(plaintext, []byte(previousCert)) + "old-ciphertext\n",
+			plaintext:     plaintext,
+			wantSealCalls: 1,
+			want:          header(plaintext, []byte(newCert)) + "sealed-with-new-key\n",
+		},
+		{
+			name:               "plaintext not matching the header gets re-encrypted, keeping the header",
+			existing:           header([]byte("other plaintext"), []byte(previousCert)) + "old-ciphertext\n",
+			plaintext:          plaintext,
+			wantReEncryptCalls: 1,
+			want: header([]byte("other plaintext"), []byte(previousCert)) +
+				"re-encrypted old-ciphertext\n",
+		},
+		{
+			name:               "unknown plaintext gets re-encrypted, keeping the header",
+			existing:           header(plaintext, []byte(previousCert)) + "old-ciphertext\n",
+			wantReEncryptCalls: 1,
+			want:               header(plaintext, []byte(previousCert)) + "re-encrypted old-ciphertext\n",
+		},
+		{
+			name:               "file without a header gets re-encrypted as is",
+			existing:           "old-ciphertext\n",
+			plaintext:          plaintext,
+			wantReEncryptCalls: 1,
+			want:               "re-encrypted old-ciphertext\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sealCalls, reEncryptCalls = 0, 0
+
+			filePath := filepath.Join(t.TempDir(), "secret.yaml")
+			require.NoError(t, os.WriteFile(filePath, []byte(tc.existing), 0o600))
+
+			err := ResealSealedSecretFile(context.Background(), filePath, []byte(previousCert), tc.plaintext)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantSealCalls, sealCalls, "sealFn call count")
+			assert.Equal(t, tc.wantReEncryptCalls, reEncryptCalls, "reEncryptFn call count")
+
+			got, err := os.ReadFile(filePath)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestSealedSecretsPendingSync(t *testing.T) {
+	t.Parallel()
+
+	app := func(name string, annotations map[string]any, resources ...any) unstructured.Unstructured {
+		metadata := map[string]any{"name": name}
+		if annotations != nil {
+			metadata["annotations"] = annotations
+		}
+		return unstructured.Unstructured{Object: map[string]any{
+			"metadata": metadata,
+			"status":   map[string]any{"resources": resources},
+		}}
+	}
+	resource := func(kind, name, status string) map[string]any {
+		return map[string]any{"kind": kind, "namespace": "argocd", "name": name, "status": status}
+	}
+	sealedSecret := func(name string, generation, observedGeneration int64, synced string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": name, "namespace": "argocd", "generation": generation},
+			"status": map[string]any{
+				"observedGeneration": observedGeneration,
+				"conditions": []any{
+					map[string]any{"type": "Synced", "status": synced, "message": "no key could decrypt secret"},
+				},
+			},
+		}}
+	}
+
+	t.Run("all synced", func(t *testing.T) {
+		t.Parallel()
+
+		pending := sealedSecretsPendingSync(
+			[]unstructured.Unstructured{
+				app("secrets", nil, resource("SealedSecret", "repo", "Synced")),
+				app("traefik", nil, resource("Deployment", "traefik", "OutOfSync")),
+			},
+			[]unstructured.Unstructured{sealedSecret("repo", 2, 2, "True")},
+		)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		t.Parallel()
+
+		pending := sealedSecretsPendingSync(
+			[]unstructured.Unstructured{
+				app("refreshing", map[string]any{argoCDRefreshAnnotation: "normal"},
+					resource("SealedSecret", "a", "Synced"),
+				),
+				app("secrets", nil,
+					resource("SealedSecret", "b", "OutOfSync"),
+					resource("SealedSecret", "c", ""),
+				),
+			},
+			[]unstructured.Unstructured{
+				sealedSecret("stale", 3, 2, "True"),
+				sealedSecret("undecryptable", 1, 1, "False"),
+			},
+		)
+		assert.Equal(t, []string{
+			"App refreshing : refresh pending",
+			"SealedSecret argocd/b : OutOfSync in App secrets",
+			"SealedSecret argocd/c : Unknown in App secrets",
+			"SealedSecret argocd/stale : not unsealed",
+			"SealedSecret argocd/undecryptable : not unsealed (no key could decrypt secret)",
+		}, pending)
+	})
+}
+
+func TestRetireSealedSecretsKeys(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := newFakeClient(t,
+		makeSealedSecretsKey("sealed-secrets-keyold1", nil),
+		makeSealedSecretsKey("sealed-secrets-keyold2", nil),
+		makeSealedSecretsKey("sealed-secrets-keynew", nil),
+	)
+
+	retired, err := RetireSealedSecretsKeys(ctx, c, "sealed-secrets-keynew")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"sealed-secrets-keyold1", "sealed-secrets-keyold2"}, retired)
+
+	active, err := listActiveSealedSecretsKeys(ctx, c)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "sealed-secrets-keynew", active[0].Name)
+
+	// Retired keys stay around, for SealedSecrets from old backups.
+	key := &coreV1.Secret{}
+	require.NoError(t, c.Get(ctx,
+		types.NamespacedName{Namespace: constants.NamespaceSealedSecrets, Name: "sealed-secrets-keyold1"},
+		key,
+	))
+	assert.Equal(t, sealedSecretsRetiredKeyValue, key.Labels[sealedSecretsActiveKeyLabel])
+}
+
+func TestDeploymentRolledOut(t *testing.T) {
+	t.Parallel()
+
+	rolledOut := healthyDeployment()
+	rolledOut.Status.UpdatedReplicas = 1
+	assert.True(t, deploymentRolledOut(rolledOut))
+
+	// The old pod is still the available one, mid-rollout.
+	midRollout := healthyDeployment()
+	midRollout.Status.Replicas = 2
+	midRollout.Status.UpdatedReplicas = 1
+	assert.False(t, deploymentRolledOut(midRollout))
+
+	notUpdated := healthyDeployment()
+	assert.False(t, deploymentRolledOut(notUpdated))
+}
@@ -567,3 +567,21 @@ func TestSealIfPlaintextChanged(t *testing.T) {
 		})
 	}
 }
+
+func TestSealedSecretUpToDate(t *testing.T) {
+	dir := t.TempDir()
+
+	upToDate := filepath.Join(dir, "up-to-date.yaml")
+	require.NoError(t, os.WriteFile(upToDate,
+		[]byte("# kubeaid-sha256: "+sha256Hex([]byte("data1"), []byte("cert-v1"))+"\nsealed\n"), 0o600,
+	))
+
+	noHeader := filepath.Join(dir, "no-header.yaml")
+	require.NoError(t, os.WriteFile(noHeader, []byte("sealed\n"), 0o600))
+
+	assert.True(t, SealedSecretUpToDate(upToDate, []byte("data1"), []byte("cert-v1")))
+	assert.False(t, SealedSecretUpToDate(upToDate, []byte("data2"), []byte("cert-v1")), "plaintext changed")
+	assert.False(t, SealedSecretUpToDate(upToDate, []byte("data1"), []byte("cert-v2")), "controller re-keyed")
+	assert.False(t, SealedSecretUpToDate(noHeader, []byte("data1"), []byte("cert-v1")))
+	assert.False(t, SealedSecretUpToDate(filepath.Join(dir, "absent.yaml"), []byte("data1"), []byte("cert-v1")))
+}
@@ -4,23 +4,115 @@
 package kubernetes
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	veleroV1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"github.com/vmware-tanzu/velero/pkg/util/results"
+	coreV1 "k8s.io/api/core/v1"
+	k8sAPIErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilNet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
 	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
 )
 
+// veleroListPageSize is how many Velero Backups a single List call fetches : clusters with a
+// daily schedule and a long TTL easily keep hundreds around.
+const veleroListPageSize = 100
+
+// veleroPollInterval is how often a Velero Backup / Restore gets polled, while it runs.
+// veleroPollInterval is a var (not a const) so unit tests can shrink it.
+var veleroPollInterval = 5 * time.Second
+
+// veleroWaitTimeout bounds waiting for Velero to be done with a Velero Backup / Restore.
+// veleroWaitTimeout is a var (not a const) so unit tests can shrink it.
+var veleroWaitTimeout = 6 * time.Hour
+
+// veleroRestoreResultsTimeout bounds fetching a Restore's warnings and errors from the backup
+// storage location.
+const veleroRestoreResultsTimeout = time.Minute
+
+// VeleroBackupOptions narrows down what a Velero Backup captures, and how long it's kept.
+// The zero value backs up everything, with Velero's default TTL.
+type VeleroBackupOptions struct {
+	IncludedNamespaces,
+	ExcludedNamespaces []string
+
+	// LabelSelector is a kubectl style label selector, like app=postgres,tier!=cache.
+	LabelSelector string
+
+	// TTL is how long Velero keeps the Backup around. 0 means Velero's default (30 days).
+	TTL time.Duration
+}
+
+// VeleroRestoreOptions customizes a Velero Restore.
+type VeleroRestoreOptions struct {
+	// IncludedNamespaces narrows the Restore down to these backed up namespaces. Empty restores
+	// them all.
+	IncludedNamespaces []string
+
+	// NamespaceMapping restores resources of a backed up namespace (key) into another namespace
+	// (value).
+	NamespaceMapping map[string]string
+
+	// RestorePVs toggles restoring PersistentVolumes from their snapshots. nil means Velero's
+	// default.
+	RestorePVs *bool
+
+	// ExcludedResources leaves these resources (like ingresses.networking.k8s.io) out of the
+	// Restore.
+	ExcludedResources []string
+
+	// ResourceModifier is the name of the ConfigMap, in Velero's namespace, holding the resource
+	// modifier rules Velero patches the restored resources with. See
+	// CreateVeleroResourceModifier.
+	ResourceModifier string
+}
+
+// CreateVeleroClient creates a Kubernetes client, for Velero's resources, from the current
+// kubeconfig : exactly like the velero CLI does.
+func CreateVeleroClient(ctx context.Context) (client.Client, error) {
+	restConfig, err := CreateRESTConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := coreV1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed adding core/v1 scheme: %w", err)
+	}
+	if err := veleroV1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed adding Velero v1 scheme: %w", err)
+	}
+
+	clusterClient, err := newClientFn(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed creating kubernetes client: %w", err)
+	}
+	return clusterClient, nil
+}
+
 // CreateBackup creates a Velero Backup with the given name.
-func CreateBackup(ctx context.Context, name string, clusterClient client.Client) error {
+func CreateBackup(ctx context.Context,
+	name string,
+	clusterClient client.Client,
+	options VeleroBackupOptions,
+) error {
 	ctx = logger.AppendSlogAttributesToCtx(ctx, []slog.Attr{
 		slog.String("backup-name", name),
 	})
@@ -31,7 +123,19 @@ func CreateBackup(ctx context.Context, name string, clusterClient client.Client)
 			Namespace: constants.NamespaceVelero,
 		},
 
-		Spec: veleroV1.BackupSpec{},
+		Spec: veleroV1.BackupSpec{
+			IncludedNamespaces: options.IncludedNamespaces,
+			ExcludedNamespaces: options.ExcludedNamespaces,
+			TTL:                metaV1.Duration{Duration: options.TTL},
+		},
+	}
+
+	if options.LabelSelector != "" {
+		labelSelector, err := metaV1.ParseToLabelSelector(options.LabelSelector)
+		if err != nil {
+			return fmt.Errorf("failed parsing label selector %q: %w", options.LabelSelector, err)
+		}
+		backup.Spec.LabelSelector = labelSelector
 	}
 
 	if err := clusterClient.Create(ctx, &backup, &client.CreateOptions{}); err != nil {
@@ -42,22 +146,40 @@ func CreateBackup(ctx context.Context, name string, clusterClient client.Client)
 	return nil
 }
 
-// GetLatestVeleroBackup identifies and returns the latest / most recent Velero Backup.
-func GetLatestVeleroBackup(ctx context.Context, clusterClient client.Client) (*veleroV1.Backup, error) {
-	// List all Velero Backups.
+// ListVeleroBackups lists every Velero Backup, a page at a time.
+func ListVeleroBackups(ctx context.Context, clusterClient client.Client) ([]veleroV1.Backup, error) {
+	var (
+		veleroBackups []veleroV1.Backup
+		continueToken string
+	)
+	for {
+		veleroBackupList := veleroV1.BackupList{}
 
-	veleroBackupList := veleroV1.BackupList{}
+		err := clusterClient.List(ctx, &veleroBackupList, &client.ListOptions{
+			Namespace: constants.NamespaceVelero,
+			Limit:     veleroListPageSize,
+			Continue:  continueToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed listing velero backups: %w", err)
+		}
+		veleroBackups = append(veleroBackups, veleroBackupList.Items...)
 
-	//nolint:godox
-	// TODO : Consider pagination.
-	err := clusterClient.List(ctx, &veleroBackupList, &client.ListOptions{
-		Namespace: constants.NamespaceVelero,
-	})
+		continueToken = veleroBackupList.Continue
+		if continueToken == "" {
+			return veleroBackups, nil
+		}
+	}
+}
+
+// GetLatestVeleroBackup identifies and returns the latest / most recent Velero Backup.
+func GetLatestVeleroBackup(ctx context.Context, clusterClient client.Client) (*veleroV1.Backup, error) {
+	veleroBackups, err := ListVeleroBackups(ctx, clusterClient)
 	if err != nil {
-		return nil, fmt.Errorf("failed listing velero backups: %w", err)
+		return nil, err
 	}
 
-	if len(veleroBackupList.Items) == 0 {
+	if len(veleroBackups) == 0 {
 		return nil, errors.New("no backups found")
 	}
 
@@ -68,7 +190,7 @@ func GetLatestVeleroBackup(ctx context.Context, clusterClient client.Client) (*v
 		latestVeleroBackup          veleroV1.Backup
 		latestVeleroBackupStartTime = time.Unix(0, 0)
 	)
-	for _, veleroBackup := range veleroBackupList.Items {
+	for _, veleroBackup := range veleroBackups {
 		veleroBackupStartTime := veleroBackup.Status.StartTimestamp.Time
 		if veleroBackupStartTime.After(latestVeleroBackupStartTime) {
 			latestVeleroBackup = veleroBackup
@@ -84,27 +206,381 @@ func GetLatestVeleroBackup(ctx context.Context, clusterClient client.Client) (*v
 	return &latestVeleroBackup, nil
 }
 
+// GetVeleroBackup returns the Velero Backup with the given name.
+func GetVeleroBackup(ctx context.Context, clusterClient client.Client, name string) (*veleroV1.Backup, error) {
+	veleroBackup := &veleroV1.Backup{}
+
+	err := clusterClient.Get(ctx,
+		types.NamespacedName{Namespace: constants.NamespaceVelero, Name: name},
+		veleroBackup,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting velero backup %s: %w", name, err)
+	}
+	return veleroBackup, nil
+}
+
 // RestoreVeleroBackup creates a Velero Restore object for the given Velero Backup.
 func RestoreVeleroBackup(ctx context.Context,
 	clusterClient client.Client,
 	latestVeleroBackup *veleroV1.Backup,
 ) error {
-	veleroRestore := veleroV1.Restore{
+	_, err := CreateVeleroRestore(ctx, clusterClient, latestVeleroBackup.Name, latestVeleroBackup.Name,
+		VeleroRestoreOptions{RestorePVs: aws.Bool(true)},
+	)
+	return err
+}
+
+// CreateVeleroRestore creates a Velero Restore, with the given name, of the given Velero Backup.
+func CreateVeleroRestore(ctx context.Context,
+	clusterClient client.Client,
+	name, backupName string,
+	options VeleroRestoreOptions,
+) (*veleroV1.Restore, error) {
+	veleroRestore := &veleroV1.Restore{
 		ObjectMeta: metaV1.ObjectMeta{
-			Name:      latestVeleroBackup.Name,
-			Namespace: latestVeleroBackup.Namespace,
+			Name:      name,
+			Namespace: constants.NamespaceVelero,
 		},
 
 		Spec: veleroV1.RestoreSpec{
-			BackupName: latestVeleroBackup.Name,
-			RestorePVs: aws.Bool(true),
+			BackupName:         backupName,
+			IncludedNamespaces: options.IncludedNamespaces,
+			NamespaceMapping:   options.NamespaceMapping,
+			RestorePVs:         options.RestorePVs,
+			ExcludedResources:  options.ExcludedResources,
 		},
 	}
+	if len(options.ResourceModifier) > 0 {
+		veleroRestore.Spec.ResourceModifier = &coreV1.TypedLocalObjectReference{
+			Kind: "ConfigMap",
+			Name: options.ResourceModifier,
+		}
+	}
 
-	if err := clusterClient.Create(ctx, &veleroRestore, &client.CreateOptions{}); err != nil {
-		return fmt.Errorf("failed creating velero restore: %w", err)
+	if err := clusterClient.Create(ctx, veleroRestore, &client.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed creating velero restore: %w", err)
 	}
 
 	slog.InfoContext(ctx, "Created Velero Restore", slog.String("restore-name", veleroRestore.Name))
+	return veleroRestore, nil
+}
+
+// WaitForVeleroBackup waits until Velero is done with the Velero Backup with the given name,
+// calling onUpdate with it on every poll. Errors when the Backup failed : a partially failed one
+// is returned as is, for the caller to report its errors and warnings. Also errors when the
+// Backup doesn't exist (anymore), or Velero isn't done with it within veleroWaitTimeout.
+func WaitForVeleroBackup(ctx context.Context,
+	clusterClient client.Client,
+	name string,
+	onUpdate func(*veleroV1.Backup),
+) (*veleroV1.Backup, error) {
+	veleroBackup := &veleroV1.Backup{}
+
+	ctx, cancel := context.WithTimeout(ctx, veleroWaitTimeout)
+	defer cancel()
+
+	err := wait.PollUntilContextCancel(ctx, veleroPollInterval, true,
+		func(ctx context.Context) (bool, error) {
+			err := clusterClient.Get(ctx,
+				types.NamespacedName{Namespace: constants.NamespaceVelero, Name: name},
+				veleroBackup,
+			)
+			if err != nil {
+				if !isTransientAPIError(err) {
+					return false, fmt.Errorf("failed getting velero backup: %w", err)
+				}
+
+				slog.DebugContext(ctx, "Failed getting Velero Backup", logger.Error(err))
+				return false, nil
+			}
+
+			onUpdate(veleroBackup)
+			return VeleroBackupDone(veleroBackup.Status.Phase), nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for velero backup %s: %w", name, err)
+	}
+
+	switch veleroBackup.Status.Phase {
+	case veleroV1.BackupPhaseFailedValidation:
+		return veleroBackup, fmt.Errorf("velero backup %s failed validation: %s",
+			name, strings.Join(veleroBackup.Status.ValidationErrors, "; "),
+		)
+
+	case veleroV1.BackupPhaseFailed:
+		return veleroBackup, fmt.Errorf("velero backup %s failed: %s", name, veleroBackup.Status.FailureReason)
+	}
+	return veleroBackup, nil
+}
+
+// WaitForVeleroRestore is WaitForVeleroBackup's counterpart, for a Velero Restore.
+func WaitForVeleroRestore(ctx context.Context,
+	clusterClient client.Client,
+	name string,
+	onUpdate func(*veleroV1.Restore),
+) (*veleroV1.Restore, error) {
+	veleroRestore := &veleroV1.Restore{}
+
+	ctx, cancel := context.WithTimeout(ctx, veleroWaitTimeout)
+	defer cancel()
+
+	err := wait.PollUntilContextCancel(ctx, veleroPollInterval, true,
+		func(ctx context.Context) (bool, error) {
+			err := clusterClient.Get(ctx,
+				types.NamespacedName{Namespace: constants.NamespaceVelero, Name: name},
+				veleroRestore,
+			)
+			if err != nil {
+				if !isTransientAPIError(err) {
+					return false, fmt.Errorf("failed getting velero restore: %w", err)
+				}
+
+				slog.DebugContext(ctx, "Failed getting Velero Restore", logger.Error(err))
+				return false, nil
+			}
+
+			onUpdate(veleroRestore)
+			return VeleroRestoreDone(veleroRestore.Status.Phase), nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for velero restore %s: %w", name, err)
+	}
+
+	switch veleroRestore.Status.Phase {
+	case veleroV1.RestorePhaseFailedValidation:
+		return veleroRestore, fmt.Errorf("velero restore %s failed validation: %s",
+			name, strings.Join(veleroRestore.Status.ValidationErrors, "; "),
+		)
+
+	case veleroV1.RestorePhaseFailed:
+		return veleroRestore, fmt.Errorf("velero restore %s failed: %s", name, veleroRestore.Status.FailureReason)
+	}
+	return veleroRestore, nil
+}
+
+// isTransientAPIError reports whether err, returned by the Kubernetes API, is worth retrying the
+// request for : unlike, say, the resource not existing.
+func isTransientAPIError(err error) bool {
+	return k8sAPIErrors.IsServerTimeout(err) ||
+		k8sAPIErrors.IsTimeout(err) ||
+		k8sAPIErrors.IsTooManyRequests(err) ||
+		k8sAPIErrors.IsServiceUnavailable(err) ||
+		k8sAPIErrors.IsInternalError(err) ||
+		utilNet.IsConnectionRefused(err) ||
+		utilNet.IsConnectionReset(err) ||
+		utilNet.IsProbableEOF(err) ||
+		utilNet.IsTimeout(err)
+}
+
+// VeleroBackupDone reports whether Velero is done with a Velero Backup in the given phase.
+func VeleroBackupDone(phase veleroV1.BackupPhase) bool {
+	switch phase {
+	case veleroV1.BackupPhaseCompleted,
+		veleroV1.BackupPhasePartiallyFailed,
+		veleroV1.BackupPhaseFailed,
+		veleroV1.BackupPhaseFailedValidation:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// VeleroRestoreDone reports whether Velero is done with a Velero Restore in the given phase.
+func VeleroRestoreDone(phase veleroV1.RestorePhase) bool {
+	switch phase {
+	case veleroV1.RestorePhaseCompleted,
+		veleroV1.RestorePhasePartiallyFailed,
+		veleroV1.RestorePhaseFailed,
+		veleroV1.RestorePhaseFailedValidation:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// GetVeleroRestoreResults fetches the warnings and errors of a finished Velero Restore, which
+// Velero keeps in the backup storage location : only their counts make it into its status.
+func GetVeleroRestoreResults(ctx context.Context,
+	clusterClient client.Client,
+	veleroRestore *veleroV1.Restore,
+) (warnings, errs results.Result, err error) {
+	ctx, cancel := context.WithTimeout(ctx, veleroRestoreResultsTimeout)
+	defer cancel()
+
+	downloadURL, err := getVeleroDownloadURL(ctx, clusterClient,
+		veleroRestore.Name, veleroV1.DownloadTargetKindRestoreResults,
+	)
+	if err != nil {
+		return warnings, errs, err
+	}
+
+	contents, err := downloadGzipped(ctx, downloadURL)
+	if err != nil {
+		return warnings, errs, fmt.Errorf("failed downloading velero restore results: %w", err)
+	}
+
+	var resultsByKind map[string]results.Result
+	if err := json.Unmarshal(contents, &resultsByKind); err != nil {
+		return warnings, errs, fmt.Errorf("failed decoding velero restore results: %w", err)
+	}
+	return resultsByKind["warnings"], resultsByKind["errors"], nil
+}
+
+// GetVeleroBackupResourceList fetches the list of resources in a Velero Backup, which Velero keeps
+// in the backup storage location : "namespace/name" (or "name" when cluster scoped) of every
+// backed up object, keyed by its API version and kind, like apps/v1/Deployment.
+func GetVeleroBackupResourceList(ctx context.Context,
+	clusterClient client.Client,
+	backupName string,
+) (map[string][]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, veleroRestoreResultsTimeout)
+	defer cancel()
+
+	downloadURL, err := getVeleroDownloadURL(ctx, clusterClient,
+		backupName, veleroV1.DownloadTargetKindBackupResourceList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := downloadGzipped(ctx, downloadURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed downloading velero backup resource list: %w", err)
+	}
+
+	var resourceList map[string][]string
+	if err := json.Unmarshal(contents, &resourceList); err != nil {
+		return nil, fmt.Errorf("failed decoding velero backup resource list: %w", err)
+	}
+	return resourceList, nil
+}
+
+// DeleteVeleroRestore deletes the Velero Restore with the given name, if it still exists. The
+// restored resources stay.
+func DeleteVeleroRestore(ctx context.Context, clusterClient client.Client, name string) error {
+	veleroRestore := &veleroV1.Restore{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.NamespaceVelero,
+		},
+	}
+
+	err := clusterClient.Delete(ctx, veleroRestore)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed deleting velero restore %s: %w", name, err)
+	}
 	return nil
 }
+
+// CreateVeleroResourceModifier creates a ConfigMap, with the given name, in Velero's namespace,
+// holding the given resource modifier rules. A Velero Restore references it through
+// VeleroRestoreOptions.ResourceModifier.
+func CreateVeleroResourceModifier(ctx context.Context,
+	clusterClient client.Client,
+	name, rules string,
+) error {
+	configMap := &coreV1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.NamespaceVelero,
+		},
+
+		// Velero expects exactly one key, holding the rules.
+		Data: map[string]string{
+			"rules.yaml": rules,
+		},
+	}
+
+	if err := clusterClient.Create(ctx, configMap, &client.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed creating velero resource modifier %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteVeleroResourceModifier deletes the ConfigMap, with the given name, created by
+// CreateVeleroResourceModifier. Not finding it isn't an error.
+func DeleteVeleroResourceModifier(ctx context.Context, clusterClient client.Client, name string) error {
+	configMap := &coreV1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.NamespaceVelero,
+		},
+	}
+
+	err := clusterClient.Delete(ctx, configMap)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed deleting velero resource modifier %s: %w", name, err)
+	}
+	return nil
+}
+
+// getVeleroDownloadURL asks Velero, through a DownloadRequest, for a pre-signed URL to the given
+// file in the backup storage location. Velero garbage collects the DownloadRequest itself, once
+// the URL expires.
+func getVeleroDownloadURL(ctx context.Context,
+	clusterClient client.Client,
+	name string,
+	kind veleroV1.DownloadTargetKind,
+) (string, error) {
+	downloadRequest := &veleroV1.DownloadRequest{
+		ObjectMeta: metaV1.ObjectMeta{
+			GenerateName: name + "-",
+			Namespace:    constants.NamespaceVelero,
+		},
+
+		Spec: veleroV1.DownloadRequestSpec{
+			Target: veleroV1.DownloadTarget{Kind: kind, Name: name},
+		},
+	}
+	if err := clusterClient.Create(ctx, downloadRequest, &client.CreateOptions{}); err != nil {
+		return "", fmt.Errorf("failed creating velero download request: %w", err)
+	}
+
+	err := wait.PollUntilContextCancel(ctx, time.Second, true,
+		func(ctx context.Context) (bool, error) {
+			err := clusterClient.Get(ctx, client.ObjectKeyFromObject(downloadRequest), downloadRequest)
+			if err != nil {
+				return false, err
+			}
+			return downloadRequest.Status.DownloadURL != "", nil
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf(
+			"velero didn't process download request %s, is the backup storage location available? : %w",
+			downloadRequest.Name, err,
+		)
+	}
+	return downloadRequest.Status.DownloadURL, nil
+}
+
+// downloadGzipped downloads and decompresses the gzipped file at downloadURL.
+func downloadGzipped(ctx context.Context, downloadURL string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed building request: %w", err)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %s", response.Status)
+	}
+
+	gzipReader, err := gzip.NewReader(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed decompressing: %w", err)
+	}
+	defer gzipReader.Close()
+
+	return io.ReadAll(gzipReader)
+}
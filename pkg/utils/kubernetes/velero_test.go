@@ -4,8 +4,11 @@
 package kubernetes
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -13,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	veleroV1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	coreV1 "k8s.io/api/core/v1"
+	k8sAPIErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -40,6 +44,7 @@ func TestCreateBackup(t *testing.T) {
 	tests := []struct {
 		name            string
 		backupName      string
+		options         VeleroBackupOptions
 		interceptCreate func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error
 		wantErr         bool
 		wantErrSubstr   string
@@ -48,6 +53,23 @@ func TestCreateBackup(t *testing.T) {
 			name:       "creates a Velero Backup with the given name",
 			backupName: "test-backup",
 		},
+		{
+			name:       "creates a Velero Backup with the given options",
+			backupName: "scoped-backup",
+			options: VeleroBackupOptions{
+				IncludedNamespaces: []string{"db"},
+				ExcludedNamespaces: []string{"cache"},
+				LabelSelector:      "app=postgres",
+				TTL:                72 * time.Hour,
+			},
+		},
+		{
+			name:          "invalid label selector returns error",
+			backupName:    "bad-selector",
+			options:       VeleroBackupOptions{LabelSelector: "app in (postgres"},
+			wantErr:       true,
+			wantErrSubstr: "failed parsing label selector",
+		},
 		{
 			name:       "creates a Velero Backup with another name",
 			backupName: "pre-upgrade-backup",
@@ -78,7 +100,7 @@ func TestCreateBackup(t *testing.T) {
 
 			fakeClient := builder.Build()
 
-			err := CreateBackup(context.Background(), tc.backupName, fakeClient)
+			err := CreateBackup(context.Background(), tc.backupName, fakeClient, tc.options)
 			if tc.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tc.wantErrSubstr)
@@ -94,6 +116,15 @@ func TestCreateBackup(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, tc.backupName, backup.Name)
 			assert.Equal(t, constants.NamespaceVelero, backup.Namespace)
+			assert.Equal(t, tc.options.IncludedNamespaces, backup.Spec.IncludedNamespaces)
+			assert.Equal(t, tc.options.ExcludedNamespaces, backup.Spec.ExcludedNamespaces)
+			assert.Equal(t, tc.options.TTL, backup.Spec.TTL.Duration)
+			if tc.options.LabelSelector == "" {
+				assert.Nil(t, backup.Spec.LabelSelector)
+			} else {
+				require.NotNil(t, backup.Spec.LabelSelector)
+				assert.Equal(t, map[string]string{"app": "postgres"}, backup.Spec.LabelSelector.MatchLabels)
+			}
 		})
 	}
 }
@@ -282,3 +313,293 @@ func TestRestoreVeleroBackup(t *testing.T) {
 		})
 	}
 }
+
+func TestListVeleroBackups(t *testing.T) {
+	t.Parallel()
+
+	scheme := newVeleroTestScheme(t)
+
+	// The fake client ignores Limit / Continue, so pages get served by hand.
+	pages := map[string]veleroV1.BackupList{
+		"": {
+			ListMeta: metaV1.ListMeta{Continue: "page-2"},
+			Items:    []veleroV1.Backup{{ObjectMeta: metaV1.ObjectMeta{Name: "a"}}},
+		},
+		"page-2": {
+			Items: []veleroV1.Backup{{ObjectMeta: metaV1.ObjectMeta{Name: "b"}}},
+		},
+	}
+
+	var limits []int64
+	fakeClient := crFake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(_ context.Context, _ client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listOptions := &client.ListOptions{}
+				listOptions.ApplyOptions(opts)
+				limits = append(limits, listOptions.Limit)
+
+				page, ok := pages[listOptions.Continue]
+				require.True(t, ok, "unexpected continue token %q", listOptions.Continue)
+				page.DeepCopyInto(list.(*veleroV1.BackupList))
+				return nil
+			},
+		}).
+		Build()
+
+	backups, err := ListVeleroBackups(context.Background(), fakeClient)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, backup := range backups {
+		names = append(names, backup.Name)
+	}
+	assert.Equal(t, []string{"a", "b"}, names)
+	assert.Equal(t, []int64{veleroListPageSize, veleroListPageSize}, limits)
+}
+
+// Mutates veleroPollInterval — sequential only.
+func TestWaitForVeleroBackup(t *testing.T) {
+	origPollInterval := veleroPollInterval
+	t.Cleanup(func() { veleroPollInterval = origPollInterval })
+	veleroPollInterval = time.Millisecond
+
+	scheme := newVeleroTestScheme(t)
+
+	tests := []struct {
+		name          string
+		phases        []veleroV1.BackupPhase
+		wantErrSubstr string
+	}{
+		{
+			name:   "follows the backup until completed",
+			phases: []veleroV1.BackupPhase{veleroV1.BackupPhaseNew, veleroV1.BackupPhaseInProgress, veleroV1.BackupPhaseCompleted},
+		},
+		{
+			name:   "partially failed backup is returned without error",
+			phases: []veleroV1.BackupPhase{veleroV1.BackupPhaseInProgress, veleroV1.BackupPhasePartiallyFailed},
+		},
+		{
+			name:          "failed backup returns error",
+			phases:        []veleroV1.BackupPhase{veleroV1.BackupPhaseInProgress, veleroV1.BackupPhaseFailed},
+			wantErrSubstr: "failed: disk full",
+		},
+		{
+			name:          "backup failing validation returns error",
+			phases:        []veleroV1.BackupPhase{veleroV1.BackupPhaseFailedValidation},
+			wantErrSubstr: "failed validation: invalid TTL",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			polls := 0
+			fakeClient := crFake.NewClientBuilder().
+				WithScheme(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(_ context.Context, _ client.WithWatch, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+						backup := obj.(*veleroV1.Backup)
+						backup.Name = "backup"
+						backup.Status = veleroV1.BackupStatus{
+							Phase:            tc.phases[min(polls, len(tc.phases)-1)],
+							FailureReason:    "disk full",
+							ValidationErrors: []string{"invalid TTL"},
+						}
+						polls++
+						return nil
+					},
+				}).
+				Build()
+
+			var seen []veleroV1.BackupPhase
+			backup, err := WaitForVeleroBackup(context.Background(), fakeClient, "backup",
+				func(backup *veleroV1.Backup) { seen = append(seen, backup.Status.Phase) },
+			)
+
+			assert.Equal(t, tc.phases, seen)
+			require.NotNil(t, backup)
+			assert.Equal(t, tc.phases[len(tc.phases)-1], backup.Status.Phase)
+
+			if tc.wantErrSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrSubstr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// Mutates veleroPollInterval and veleroWaitTimeout — sequential only.
+func TestWaitForVeleroGivesUp(t *testing.T) {
+	origPollInterval, origWaitTimeout := veleroPollInterval, veleroWaitTimeout
+	t.Cleanup(func() { veleroPollInterval, veleroWaitTimeout = origPollInterval, origWaitTimeout })
+	veleroPollInterval, veleroWaitTimeout = time.Millisecond, 50*time.Millisecond
+
+	scheme := newVeleroTestScheme(t)
+
+	t.Run("backup doesn't exist", func(t *testing.T) {
+		fakeClient := crFake.NewClientBuilder().WithScheme(scheme).Build()
+
+		_, err := WaitForVeleroBackup(context.Background(), fakeClient, "deleted",
+			func(*veleroV1.Backup) { t.Fatal("unexpected update") },
+		)
+		require.Error(t, err)
+		assert.True(t, k8sAPIErrors.IsNotFound(err))
+	})
+
+	t.Run("restore doesn't exist", func(t *testing.T) {
+		fakeClient := crFake.NewClientBuilder().WithScheme(scheme).Build()
+
+		_, err := WaitForVeleroRestore(context.Background(), fakeClient, "deleted",
+			func(*veleroV1.Restore) { t.Fatal("unexpected update") },
+		)
+		require.Error(t, err)
+		assert.True(t, k8sAPIErrors.IsNotFound(err))
+	})
+
+	t.Run("API server keeps timing out", func(t *testing.T) {
+		fakeClient := crFake.NewClientBuilder().
+			WithScheme(scheme).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+					return k8sAPIErrors.NewServerTimeout(veleroV1.Resource("backups"), "get", 1)
+				},
+			}).
+			Build()
+
+		_, err := WaitForVeleroBackup(context.Background(), fakeClient, "backup",
+			func(*veleroV1.Backup) { t.Fatal("unexpected update") },
+		)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestCreateVeleroRestore(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := crFake.NewClientBuilder().WithScheme(newVeleroTestScheme(t)).Build()
+
+	restorePVs := false
+	_, err := CreateVeleroRestore(context.Background(), fakeClient, "my-backup-restore", "my-backup",
+		VeleroRestoreOptions{
+			IncludedNamespaces: []string{"prod"},
+			NamespaceMapping:   map[string]string{"prod": "prod-restored"},
+			RestorePVs:         &restorePVs,
+			ExcludedResources:  []string{"ingresses.networking.k8s.io"},
+			ResourceModifier:   "my-backup-restore",
+		},
+	)
+	require.NoError(t, err)
+
+	restore := &veleroV1.Restore{}
+	err = fakeClient.Get(context.Background(),
+		types.NamespacedName{Name: "my-backup-restore", Namespace: constants.NamespaceVelero},
+		restore,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "my-backup", restore.Spec.BackupName)
+	assert.Equal(t, []string{"prod"}, restore.Spec.IncludedNamespaces)
+	assert.Equal(t, map[string]string{"prod": "prod-restored"}, restore.Spec.NamespaceMapping)
+	require.NotNil(t, restore.Spec.RestorePVs)
+	assert.False(t, *restore.Spec.RestorePVs)
+	assert.Equal(t, []string{"ingresses.networking.k8s.io"}, restore.Spec.ExcludedResources)
+	require.NotNil(t, restore.Spec.ResourceModifier)
+	assert.Equal(t, "ConfigMap", restore.Spec.ResourceModifier.Kind)
+	assert.Equal(t, "my-backup-restore", restore.Spec.ResourceModifier.Name)
+}
+
+func TestGetVeleroRestoreResults(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		gzipWriter := gzip.NewWriter(w)
+		defer gzipWriter.Close()
+
+		_, _ = gzipWriter.Write([]byte(`{
+			"warnings": {"namespaces": {"prod": ["could not restore, Service prod/api already exists"]}},
+			"errors": {"velero": ["error restoring pvc"]}
+		}`))
+	}))
+	t.Cleanup(server.Close)
+
+	// Stands in for Velero, which fills in the DownloadRequest's URL.
+	fakeClient := crFake.NewClientBuilder().
+		WithScheme(newVeleroTestScheme(t)).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, cl client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := cl.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if downloadRequest, ok := obj.(*veleroV1.DownloadRequest); ok {
+					downloadRequest.Status.DownloadURL = server.URL
+				}
+				return nil
+			},
+		}).
+		Build()
+
+	warnings, errs, err := GetVeleroRestoreResults(context.Background(), fakeClient, &veleroV1.Restore{
+		ObjectMeta: metaV1.ObjectMeta{Name: "my-restore", Namespace: constants.NamespaceVelero},
+	})
+	require.NoError(t, err)
+	assert.Equal(t,
+		map[string][]string{"prod": {"could not restore, Service prod/api already exists"}},
+		warnings.Namespaces,
+	)
+	assert.Equal(t, []string{"error restoring pvc"}, errs.Velero)
+}
+
+func TestGetVeleroBackupResourceList(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		gzipWriter := gzip.NewWriter(w)
+		defer gzipWriter.Close()
+
+		_, _ = gzipWriter.Write([]byte(`{
+			"apps/v1/Deployment": ["shop/api"],
+			"v1/Namespace": ["shop"]
+		}`))
+	}))
+	t.Cleanup(server.Close)
+
+	// Stands in for Velero, which fills in the DownloadRequest's URL.
+	fakeClient := crFake.NewClientBuilder().
+		WithScheme(newVeleroTestScheme(t)).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, cl client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := cl.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if downloadRequest, ok := obj.(*veleroV1.DownloadRequest); ok {
+					downloadRequest.Status.DownloadURL = server.URL
+				}
+				return nil
+			},
+		}).
+		Build()
+
+	resourceList, err := GetVeleroBackupResourceList(context.Background(), fakeClient, "my-backup")
+	require.NoError(t, err)
+	assert.Equal(t,
+		map[string][]string{"apps/v1/Deployment": {"shop/api"}, "v1/Namespace": {"shop"}},
+		resourceList,
+	)
+}
+
+func TestDeleteVeleroRestore(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := crFake.NewClientBuilder().
+		WithScheme(newVeleroTestScheme(t)).
+		WithObjects(&veleroV1.Restore{
+			ObjectMeta: metaV1.ObjectMeta{Name: "my-restore", Namespace: constants.NamespaceVelero},
+		}).
+		Build()
+
+	require.NoError(t, DeleteVeleroRestore(context.Background(), fakeClient, "my-restore"))
+
+	// Already gone.
+	require.NoError(t, DeleteVeleroRestore(context.Background(), fakeClient, "my-restore"))
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/progress"
+)
+
+// eventsHandler mirrors ERROR records onto the JSON event stream, as error events carrying the
+// record's attributes. Every failure path (assert.AssertErrNil and friends) ends in an ERROR
+// record, so external tools learn why a run stopped without parsing the logs.
+type eventsHandler struct {
+	attrs []slog.Attr
+	group string
+}
+
+func (h *eventsHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return (level >= slog.LevelError) && events.Enabled()
+}
+
+func (h *eventsHandler) Handle(ctx context.Context, record slog.Record) error {
+	attributes := map[string]string{}
+	for _, attr := range h.attrs {
+		addEventAttribute(attributes, "", attr)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		addEventAttribute(attributes, h.group, attr)
+		return true
+	})
+
+	events.Emit(events.Event{
+		Type:       events.TypeError,
+		Phase:      progress.FromCtx(ctx).Phase(),
+		Message:    record.Message,
+		Attributes: attributes,
+	})
+	return nil
+}
+
+func (h *eventsHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	withAttrs := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	withAttrs = append(withAttrs, h.attrs...)
+	for _, attr := range attrs {
+		withAttrs = append(withAttrs, prefixAttr(h.group, attr))
+	}
+	return &eventsHandler{attrs: withAttrs, group: h.group}
+}
+
+func (h *eventsHandler) WithGroup(name string) slog.Handler {
+	return &eventsHandler{attrs: h.attrs, group: joinAttrKey(h.group, name)}
+}
+
+// addEventAttribute flattens the attribute into attributes, with dotted keys for groups.
+func addEventAttribute(attributes map[string]string, group string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+
+	key := joinAttrKey(group, attr.Key)
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, groupAttr := range attr.Value.Group() {
+			addEventAttribute(attributes, key, groupAttr)
+		}
+		return
+	}
+	attributes[key] = attr.Value.String()
+}
+
+// prefixAttr qualifies the attribute's key with the group it was added under, since WithAttrs
+// attributes are stored flattened.
+func prefixAttr(group string, attr slog.Attr) slog.Attr {
+	return slog.Attr{Key: joinAttrKey(group, attr.Key), Value: attr.Value}
+}
+
+func joinAttrKey(group, key string) string {
+	if group == "" {
+		return key
+	}
+	if key == "" {
+		return group
+	}
+	return group + "." + key
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
+)
+
+// Mutates the events package's sinks — sequential only.
+func TestEventsHandler(t *testing.T) {
+	logger := slog.New(withContextualSlogAttributesHandler(&eventsHandler{}))
+
+	// No sink : not even enabled.
+	assert.False(t, logger.Enabled(context.Background(), slog.LevelError))
+
+	var stream bytes.Buffer
+	t.Cleanup(events.AddSink(&stream))
+
+	ctx := AppendSlogAttributesToCtx(context.Background(), []slog.Attr{
+		slog.String("app-name", "cilium"),
+	})
+
+	// Below ERROR : not an event.
+	logger.InfoContext(ctx, "Syncing ArgoCD application")
+	assert.Empty(t, stream.String())
+
+	logger.ErrorContext(ctx, "Failed syncing ArgoCD App",
+		Error(errors.New("connection refused")),
+		slog.Group("retry", slog.Int("attempt", 3)),
+	)
+
+	var event events.Event
+	require.NoError(t, json.Unmarshal(stream.Bytes(), &event))
+
+	assert.Equal(t, events.TypeError, event.Type)
+	assert.Equal(t, "Failed syncing ArgoCD App", event.Message)
+	assert.Equal(t,
+		map[string]string{
+			"app-name":      "cilium",
+			"error":         "connection refused",
+			"retry.attempt": "3",
+		},
+		event.Attributes,
+	)
+
+	// Attributes and groups added upfront, via the logger.
+	stream.Reset()
+	slog.New(&eventsHandler{}).
+		With(slog.String("cluster-type", "main")).
+		WithGroup("sync").
+		Error("Failed syncing ArgoCD App", slog.String("app-name", "cilium"))
+
+	event = events.Event{}
+	require.NoError(t, json.Unmarshal(stream.Bytes(), &event))
+	assert.Equal(t,
+		map[string]string{
+			"cluster-type":  "main",
+			"sync.app-name": "cilium",
+		},
+		event.Attributes,
+	)
+}
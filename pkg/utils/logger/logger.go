@@ -15,7 +15,8 @@ import (
 
 // Creates the logger.
 // When debug mode is disabled, only ERROR are written to stdout; INFO+ always goes to the
-// log file. When debug mode is enabled, DEBUG+ goes to both.
+// log file. When debug mode is enabled, DEBUG+ goes to both. ERROR records also go to the
+// JSON event stream, when enabled.
 func CreateLogger(isDebugModeEnabled bool, writers []io.Writer) {
 	// writers[0] is the log file, writers[1] is stdout.
 	// The log file always receives INFO and above.
@@ -32,7 +33,7 @@ func CreateLogger(isDebugModeEnabled bool, writers []io.Writer) {
 	})
 
 	logger := slog.New(withContextualSlogAttributesHandler(
-		&multiHandler{handlers: []slog.Handler{fileHandler, stdoutHandler}},
+		&multiHandler{handlers: []slog.Handler{fileHandler, stdoutHandler, &eventsHandler{}}},
 	))
 	slog.SetDefault(logger)
 
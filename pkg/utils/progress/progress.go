@@ -17,6 +17,7 @@ import (
 	"golang.org/x/crypto/ssh/agent"
 
 	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
 )
 
 // pausableWriter wraps an io.Writer with a runtime mute switch. While
@@ -87,9 +88,14 @@ var majorStepHeaderStyle = lipgloss.NewStyle().Bold(true)
 // A zero-value Bar{} (returned by FromCtx for contexts with no bar
 // attached) is silently a no-op — every method nil-guards so test
 // code and library callers don't have to care.
+//
+// Every section transition and substep is mirrored onto the JSON
+// event stream (see package events), so external tools can follow
+// the same progress without parsing the terminal output.
 type Bar struct {
 	bar         *progressbar.ProgressBar
 	writer      *pausableWriter
+	title       string
 	currentDesc string
 	lastSubstep string
 
@@ -115,6 +121,7 @@ func New(description string) *Bar {
 	return &Bar{
 		bar:        bar,
 		writer:     pw,
+		title:      description,
 		hasYubiKey: detectYubiKeyInAgent(),
 	}
 }
@@ -210,10 +217,14 @@ func (b *Bar) Describe(description string) {
 		// Blank line between sections; the underline is the section's
 		// own opener so we don't need a closing rule on the previous.
 		fmt.Fprintln(os.Stderr)
+
+		events.Emit(events.Event{Type: events.TypePhaseFinished, Phase: b.currentDesc})
 	}
 	b.currentDesc = description
 	b.lastSubstep = ""
 
+	events.Emit(events.Event{Type: events.TypePhaseStarted, Phase: description})
+
 	header := majorStepGlyph + description
 	fmt.Fprintln(os.Stderr, majorStepHeaderStyle.Render(header))
 	fmt.Fprintln(os.Stderr, strings.Repeat("─", utf8.RuneCountInString(header)))
@@ -239,6 +250,21 @@ func (b *Bar) Substep(text string) {
 		completedSubstepStyle.Render(substepIndent+substepGlyph+text),
 	)
 	b.lastSubstep = text
+
+	events.Emit(events.Event{Type: events.TypeSubstep, Phase: b.Phase(), Message: text})
+}
+
+// Phase returns the name of the current major-step section, falling
+// back to the bar's header for runs which never open one. Stamped on
+// the events emitted from within the section.
+func (b *Bar) Phase() string {
+	if b == nil {
+		return ""
+	}
+	if b.currentDesc != "" {
+		return b.currentDesc
+	}
+	return b.title
 }
 
 // InProgress emits a transient "  ↻ <text>" sub-step under the
@@ -329,6 +355,9 @@ func (b *Bar) Finish() {
 	if b == nil || b.bar == nil {
 		return
 	}
+	if b.currentDesc != "" {
+		events.Emit(events.Event{Type: events.TypePhaseFinished, Phase: b.currentDesc})
+	}
 	b.currentDesc = ""
 	b.lastSubstep = ""
 	_ = b.bar.Finish()
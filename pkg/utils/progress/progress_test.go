@@ -4,11 +4,16 @@
 package progress
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/events"
 )
 
 // TestNoopBarIsSafe — every method on a zero-value Bar (the
@@ -65,3 +70,37 @@ func TestSubstepTracksLastForTreeRedraw(t *testing.T) {
 	bar.Substep("Created NAT Gateway")
 	assert.Equal(t, "Created NAT Gateway", bar.lastSubstep)
 }
+
+// Mutates the events package's sinks — sequential only.
+func TestBarEmitsEvents(t *testing.T) {
+	var stream bytes.Buffer
+	t.Cleanup(events.AddSink(&stream))
+
+	bar := New("Bootstrapping cluster")
+	bar.Substep("Validated config")
+	bar.Describe("Provisioning main cluster")
+	bar.Substep("Created Hetzner Network")
+	bar.Describe("Syncing ArgoCD applications")
+	bar.Finish()
+
+	type phaseEvent struct {
+		Type    events.Type `json:"type"`
+		Phase   string      `json:"phase"`
+		Message string      `json:"message"`
+	}
+	emitted := []phaseEvent{}
+	for _, line := range strings.Split(strings.TrimSpace(stream.String()), "\n") {
+		var event phaseEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		emitted = append(emitted, event)
+	}
+
+	assert.Equal(t, []phaseEvent{
+		{Type: events.TypeSubstep, Phase: "Bootstrapping cluster", Message: "Validated config"},
+		{Type: events.TypePhaseStarted, Phase: "Provisioning main cluster"},
+		{Type: events.TypeSubstep, Phase: "Provisioning main cluster", Message: "Created Hetzner Network"},
+		{Type: events.TypePhaseFinished, Phase: "Provisioning main cluster"},
+		{Type: events.TypePhaseStarted, Phase: "Syncing ArgoCD applications"},
+		{Type: events.TypePhaseFinished, Phase: "Syncing ArgoCD applications"},
+	}, emitted)
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package sshkey
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path"
+
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/utils/logger"
+)
+
+// ServeAgent serves the unlocked passphrase protected keys over an SSH agent socket, for the
+// consumers which can't be handed a decrypted key in memory : KubeOne reads the SSH private key
+// file named in its manifest itself, and can't unlock it. The socket lives in a private temporary
+// directory, which stop removes.
+//
+// Returns an empty socket path (and a no-op stop), when no key needed unlocking.
+func (u *Unlocker) ServeAgent() (socketPath string, stop func(), err error) {
+	keyring := agent.NewKeyring()
+
+	u.mu.Lock()
+	for _, unlocked := range u.keys {
+		if !unlocked.passphraseProtected {
+			continue
+		}
+		if err := keyring.Add(agent.AddedKey{PrivateKey: unlocked.key}); err != nil {
+			u.mu.Unlock()
+			return "", nil, fmt.Errorf("adding unlocked SSH private key to the SSH agent: %w", err)
+		}
+	}
+	u.mu.Unlock()
+
+	if keys, _ := keyring.List(); len(keys) == 0 {
+		return "", func() {}, nil
+	}
+
+	socketDir, err := os.MkdirTemp("", "kubeaid-cli-ssh-agent-")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating SSH agent socket directory: %w", err)
+	}
+	socketPath = path.Join(socketDir, "agent.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		_ = os.RemoveAll(socketDir)
+		return "", nil, fmt.Errorf("listening on SSH agent socket: %w", err)
+	}
+
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			if err != nil {
+				slog.Warn("Failed accepting SSH agent connection", logger.Error(err))
+				continue
+			}
+
+			go func() {
+				defer connection.Close()
+				_ = agent.ServeAgent(keyring, connection)
+			}()
+		}
+	}()
+
+	stop = func() {
+		_ = listener.Close()
+		_ = os.RemoveAll(socketDir)
+	}
+	return socketPath, stop, nil
+}
+
+// ServeAgent serves the keys the Default Unlocker unlocked over an SSH agent socket.
+func ServeAgent() (string, func(), error) {
+	return Default.ServeAgent()
+}
@@ -0,0 +1,247 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sshkey unlocks passphrase protected SSH private keys, for every SSH consumer of a run
+// (git transport, KubeOne host access, rescue-system and NAT gateway SSH) to share : each key's
+// passphrase is asked for once, and the decrypted key is kept in memory for the rest of the run.
+// Nothing decrypted ever gets written to disk.
+package sshkey
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
+
+	"github.com/Obmondo/kubeaid-cli/pkg/config/answers"
+	"github.com/Obmondo/kubeaid-cli/pkg/constants"
+)
+
+// maxPassphrasePromptAttempts is how many times the operator gets asked for a key's passphrase,
+// before giving up.
+const maxPassphrasePromptAttempts = 3
+
+// ErrNoPassphrase is returned when a key is passphrase protected, but there's no way to get the
+// passphrase : none in the environment, and no terminal to ask on.
+var ErrNoPassphrase = errors.New("SSH private key is passphrase protected, but no passphrase is available")
+
+type (
+	// Unlocker parses SSH private keys, unlocking the passphrase protected ones. Safe for
+	// concurrent use.
+	Unlocker struct {
+		// unattendedPassphrase returns the passphrase supplied for unattended runs, if any.
+		unattendedPassphrase func() ([]byte, bool, error)
+
+		// promptPassphrase asks the operator for the given key's passphrase. Nil when there's no
+		// terminal to ask on.
+		promptPassphrase func(keyName string) ([]byte, error)
+
+		mu sync.Mutex
+
+		// keys caches the parsed keys, keyed by the SHA-256 digest of their PEM encoding.
+		keys map[[sha256.Size]byte]*unlockedKey
+	}
+
+	unlockedKey struct {
+		key any
+
+		// passphraseProtected is true when the key had to be unlocked.
+		passphraseProtected bool
+	}
+)
+
+// Default is the Unlocker every SSH consumer of this run shares.
+var Default = NewUnlocker(envPassphrase, terminalPassphrasePrompt())
+
+// NewUnlocker returns an Unlocker, getting passphrases from unattendedPassphrase when it has one,
+// and from promptPassphrase otherwise. promptPassphrase may be nil.
+func NewUnlocker(
+	unattendedPassphrase func() ([]byte, bool, error),
+	promptPassphrase func(keyName string) ([]byte, error),
+) *Unlocker {
+	return &Unlocker{
+		unattendedPassphrase: unattendedPassphrase,
+		promptPassphrase:     promptPassphrase,
+		keys:                 map[[sha256.Size]byte]*unlockedKey{},
+	}
+}
+
+// Unlock parses the given PEM encoded SSH private key, asking for its passphrase when it's
+// protected by one. keyName (usually the key file path) is only used in prompts and errors.
+// Returns the key along with whether it was passphrase protected.
+func (u *Unlocker) Unlock(keyName string, pemBytes []byte) (key any, passphraseProtected bool, err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	digest := sha256.Sum256(pemBytes)
+	if unlocked, ok := u.keys[digest]; ok {
+		return unlocked.key, unlocked.passphraseProtected, nil
+	}
+
+	key, err = ssh.ParseRawPrivateKey(pemBytes)
+	if err == nil {
+		u.keys[digest] = &unlockedKey{key: key}
+		return key, false, nil
+	}
+
+	var missingPassphrase *ssh.PassphraseMissingError
+	if !errors.As(err, &missingPassphrase) {
+		return nil, false, fmt.Errorf("parsing SSH private key %s: %w", keyName, err)
+	}
+
+	if key, err = u.unlock(keyName, pemBytes); err != nil {
+		return nil, true, err
+	}
+
+	u.keys[digest] = &unlockedKey{key: key, passphraseProtected: true}
+	return key, true, nil
+}
+
+// UnencryptedPEM returns the given SSH private key OpenSSH PEM encoded, without a passphrase : for
+// consumers which only take private key bytes (KubeOne's SSH connections), or get it sealed into
+// the cluster. Keys which aren't passphrase protected are returned as is.
+func (u *Unlocker) UnencryptedPEM(keyName string, pemBytes []byte) ([]byte, bool, error) {
+	key, passphraseProtected, err := u.Unlock(keyName, pemBytes)
+	if err != nil || !passphraseProtected {
+		return pemBytes, passphraseProtected, err
+	}
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		return nil, true, fmt.Errorf("encoding unlocked SSH private key %s: %w", keyName, err)
+	}
+	return pem.EncodeToMemory(block), true, nil
+}
+
+// unlock decrypts the given SSH private key, with the passphrase supplied for unattended runs, or
+// else the one the operator types in.
+func (u *Unlocker) unlock(keyName string, pemBytes []byte) (any, error) {
+	passphrase, found, err := u.unattendedPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		key, err := ssh.ParseRawPrivateKeyWithPassphrase(pemBytes, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("unlocking SSH private key %s with the passphrase from $%s / $%s: %w",
+				keyName, constants.EnvNameSSHKeyPassphrase, constants.EnvNameSSHKeyPassphraseFD, err,
+			)
+		}
+		return key, nil
+	}
+
+	if u.promptPassphrase == nil {
+		return nil, fmt.Errorf(
+			"%w : %s. Supply it via $%s or $%s, or load the key into ssh-agent and set useSSHAgent: true",
+			ErrNoPassphrase, keyName, constants.EnvNameSSHKeyPassphrase, constants.EnvNameSSHKeyPassphraseFD,
+		)
+	}
+
+	for attempt := 1; ; attempt++ {
+		passphrase, err := u.promptPassphrase(keyName)
+		if err != nil {
+			return nil, fmt.Errorf("reading passphrase of SSH private key %s: %w", keyName, err)
+		}
+
+		key, err := ssh.ParseRawPrivateKeyWithPassphrase(pemBytes, passphrase)
+		if err == nil {
+			return key, nil
+		}
+		if !errors.Is(err, x509.IncorrectPasswordError) || (attempt == maxPassphrasePromptAttempts) {
+			return nil, fmt.Errorf("unlocking SSH private key %s: %w", keyName, err)
+		}
+		slog.Warn("Wrong passphrase, try again", slog.String("key", keyName))
+	}
+}
+
+// Unlock unlocks the given SSH private key using the Default Unlocker.
+func Unlock(keyName string, pemBytes []byte) (any, bool, error) {
+	return Default.Unlock(keyName, pemBytes)
+}
+
+// UnencryptedPEM returns the given SSH private key without a passphrase, using the Default
+// Unlocker.
+func UnencryptedPEM(keyName string, pemBytes []byte) ([]byte, bool, error) {
+	return Default.UnencryptedPEM(keyName, pemBytes)
+}
+
+var (
+	fdPassphraseOnce  sync.Once
+	fdPassphrase      []byte
+	fdPassphraseError error
+)
+
+// envPassphrase returns the passphrase supplied for unattended runs : $KUBEAID_CLI_SSH_KEY_PASSPHRASE,
+// or else whatever gets written to the file descriptor in $KUBEAID_CLI_SSH_KEY_PASSPHRASE_FD
+// (like `kubeaid-cli ... 3< <(pass show ssh-key)`). The file descriptor can only be read once, so
+// what's read is remembered.
+func envPassphrase() ([]byte, bool, error) {
+	if passphrase, found := os.LookupEnv(constants.EnvNameSSHKeyPassphrase); found {
+		return []byte(passphrase), true, nil
+	}
+
+	fdNumber, found := os.LookupEnv(constants.EnvNameSSHKeyPassphraseFD)
+	if !found {
+		return nil, false, nil
+	}
+
+	fdPassphraseOnce.Do(func() {
+		fd, err := strconv.ParseUint(fdNumber, 10, 0)
+		if err != nil {
+			fdPassphraseError = fmt.Errorf("$%s must be a file descriptor number, not %q",
+				constants.EnvNameSSHKeyPassphraseFD, fdNumber,
+			)
+			return
+		}
+
+		file := os.NewFile(uintptr(fd), "ssh-key-passphrase")
+		defer file.Close()
+
+		fdPassphrase, fdPassphraseError = readPassphrase(file)
+	})
+	return fdPassphrase, fdPassphraseError == nil, fdPassphraseError
+}
+
+// readPassphrase reads the passphrase written to the given reader, dropping the trailing newline.
+func readPassphrase(reader io.Reader) ([]byte, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading SSH private key passphrase: %w", err)
+	}
+	return []byte(strings.TrimRight(string(contents), "\r\n")), nil
+}
+
+// terminalPassphrasePrompt returns the prompt asking for the passphrase on the terminal, without
+// echoing it. Nil when stdin isn't a terminal.
+//
+// Whether the run is non-interactive is only known once the answers file got loaded, so that's
+// checked per prompt.
+func terminalPassphrasePrompt() func(keyName string) ([]byte, error) {
+	stdin := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdin) {
+		return nil
+	}
+
+	return func(keyName string) ([]byte, error) {
+		if answers.Parsed.NonInteractive() {
+			return nil, fmt.Errorf("%w : %s. Running non-interactively, so supply it via $%s or $%s",
+				ErrNoPassphrase, keyName, constants.EnvNameSSHKeyPassphrase, constants.EnvNameSSHKeyPassphraseFD,
+			)
+		}
+
+		fmt.Fprintf(os.Stderr, "Enter passphrase for SSH private key %s: ", keyName)
+		defer fmt.Fprintln(os.Stderr)
+
+		return term.ReadPassword(stdin)
+	}
+}
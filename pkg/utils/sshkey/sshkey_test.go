@@ -0,0 +1,164 @@
+// Copyright 2026 Obmondo
+// SPDX-License-Identifier: Apache-2.0
+
+package sshkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func generateKey(t *testing.T, passphrase string) (ssh.PublicKey, []byte) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var block *pem.Block
+	if passphrase == "" {
+		block, err = ssh.MarshalPrivateKey(privateKey, "")
+	} else {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(privateKey, "", []byte(passphrase))
+	}
+	require.NoError(t, err)
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	require.NoError(t, err)
+	return sshPublicKey, pem.EncodeToMemory(block)
+}
+
+func noUnattendedPassphrase() ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+// prompter answers the passphrase prompts in order, and counts them.
+type prompter struct {
+	answers []string
+	asked   int
+}
+
+func (p *prompter) prompt(string) ([]byte, error) {
+	answer := p.answers[p.asked]
+	p.asked++
+	return []byte(answer), nil
+}
+
+func TestUnlockUnprotectedKey(t *testing.T) {
+	_, privateKey := generateKey(t, "")
+
+	unlocker := NewUnlocker(noUnattendedPassphrase, nil)
+
+	_, passphraseProtected, err := unlocker.Unlock("id_ed25519", privateKey)
+	require.NoError(t, err)
+	assert.False(t, passphraseProtected)
+
+	unencrypted, _, err := unlocker.UnencryptedPEM("id_ed25519", privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, privateKey, unencrypted)
+}
+
+func TestUnlockPromptsOncePerKey(t *testing.T) {
+	publicKey, privateKey := generateKey(t, "correct horse")
+
+	p := &prompter{answers: []string{"wrong", "correct horse"}}
+	unlocker := NewUnlocker(noUnattendedPassphrase, p.prompt)
+
+	for range 2 {
+		unencrypted, passphraseProtected, err := unlocker.UnencryptedPEM("id_ed25519", privateKey)
+		require.NoError(t, err)
+		assert.True(t, passphraseProtected)
+
+		signer, err := ssh.ParsePrivateKey(unencrypted)
+		require.NoError(t, err)
+		assert.Equal(t, publicKey.Marshal(), signer.PublicKey().Marshal())
+	}
+
+	// One wrong attempt, then the right one. The second call is served from the cache.
+	assert.Equal(t, 2, p.asked)
+}
+
+func TestUnlockGivesUpAfterMaxAttempts(t *testing.T) {
+	_, privateKey := generateKey(t, "correct horse")
+
+	p := &prompter{answers: []string{"a", "b", "c"}}
+	unlocker := NewUnlocker(noUnattendedPassphrase, p.prompt)
+
+	_, _, err := unlocker.Unlock("id_ed25519", privateKey)
+	require.Error(t, err)
+	assert.Equal(t, maxPassphrasePromptAttempts, p.asked)
+}
+
+func TestUnlockWithUnattendedPassphrase(t *testing.T) {
+	_, privateKey := generateKey(t, "correct horse")
+
+	unattended := func(passphrase string) func() ([]byte, bool, error) {
+		return func() ([]byte, bool, error) { return []byte(passphrase), true, nil }
+	}
+
+	// The prompt is never reached, even on a wrong passphrase.
+	p := &prompter{}
+
+	_, _, err := NewUnlocker(unattended("wrong"), p.prompt).Unlock("id_ed25519", privateKey)
+	require.ErrorContains(t, err, "KUBEAID_CLI_SSH_KEY_PASSPHRASE")
+
+	_, passphraseProtected, err := NewUnlocker(unattended("correct horse"), p.prompt).Unlock("id_ed25519", privateKey)
+	require.NoError(t, err)
+	assert.True(t, passphraseProtected)
+
+	assert.Zero(t, p.asked)
+}
+
+func TestUnlockWithoutPassphraseSource(t *testing.T) {
+	_, privateKey := generateKey(t, "correct horse")
+
+	_, _, err := NewUnlocker(noUnattendedPassphrase, nil).Unlock("id_ed25519", privateKey)
+	require.ErrorIs(t, err, ErrNoPassphrase)
+}
+
+func TestReadPassphrase(t *testing.T) {
+	passphrase, err := readPassphrase(strings.NewReader("correct horse\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "correct horse", string(passphrase))
+}
+
+func TestServeAgent(t *testing.T) {
+	protectedPublicKey, protectedPrivateKey := generateKey(t, "correct horse")
+	_, unprotectedPrivateKey := generateKey(t, "")
+
+	unlocker := NewUnlocker(noUnattendedPassphrase, (&prompter{answers: []string{"correct horse"}}).prompt)
+
+	// Nothing to serve, when no key needed unlocking.
+	_, _, err := unlocker.Unlock("unprotected", unprotectedPrivateKey)
+	require.NoError(t, err)
+
+	socketPath, stop, err := unlocker.ServeAgent()
+	require.NoError(t, err)
+	stop()
+	assert.Empty(t, socketPath)
+
+	// Only the unlocked key gets served.
+	_, _, err = unlocker.Unlock("protected", protectedPrivateKey)
+	require.NoError(t, err)
+
+	socketPath, stop, err = unlocker.ServeAgent()
+	require.NoError(t, err)
+	defer stop()
+
+	connection, err := net.Dial("unix", socketPath)
+	require.NoError(t, err)
+	defer connection.Close()
+
+	keys, err := agent.NewClient(connection).List()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, protectedPublicKey.Marshal(), keys[0].Marshal())
+}